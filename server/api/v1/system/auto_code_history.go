@@ -5,6 +5,8 @@ import (
 	common "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	request "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -113,3 +115,60 @@ func (a *AutoCodeHistoryApi) GetList(c *gin.Context) {
 		PageSize: info.PageSize,
 	}, "获取成功", c)
 }
+
+// UpdatePreview
+// @Tags      AutoCode
+// @Summary   预览更新已生成模块的变更
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoHistoryUpdate                                  true  "生成历史ID及更新后的结构化信息"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodeUpdate,msg=string}  "返回本次更新的内容及各文件的diff"
+// @Router    /autoCode/updatePreview [post]
+func (a *AutoCodeHistoryApi) UpdatePreview(c *gin.Context) {
+	a.update(c, true)
+}
+
+// Update
+// @Tags      AutoCode
+// @Summary   更新已生成的模块
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoHistoryUpdate                                  true  "生成历史ID及更新后的结构化信息"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodeUpdate,msg=string}  "新增字段、搜索条件及字典合并进已生成的代码 手写代码保持不变"
+// @Router    /autoCode/update [post]
+func (a *AutoCodeHistoryApi) Update(c *gin.Context) {
+	a.update(c, false)
+}
+
+func (a *AutoCodeHistoryApi) update(c *gin.Context, preview bool) {
+	var info request.SysAutoHistoryUpdate
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = utils.Verify(info.AutoCode, utils.AutoCodeVerify)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = info.AutoCode.Pretreatment()
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var result systemRes.AutoCodeUpdate
+	result, err = autoCodeTemplateService.Update(c.Request.Context(), info, preview)
+	if err != nil {
+		global.GVA_LOG.Error("更新模块失败!", zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	if preview {
+		response.OkWithDetailed(result, "预览成功", c)
+		return
+	}
+	response.OkWithDetailed(result, "更新成功", c)
+}
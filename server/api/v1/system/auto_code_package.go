@@ -36,7 +36,7 @@ func (a *AutoCodePackageApi) Create(c *gin.Context) {
 	err := autoCodePackageService.Create(c.Request.Context(), &info)
 	if err != nil {
 		global.GVA_LOG.Error("创建失败!", zap.Error(err))
-		response.FailWithMessage("创建失败", c)
+		response.FailWithMessage("创建失败:"+err.Error(), c)
 		return
 	}
 	response.OkWithMessage("创建成功", c)
@@ -98,3 +98,87 @@ func (a *AutoCodePackageApi) Templates(c *gin.Context) {
 	}
 	response.OkWithDetailed(data, "获取成功", c)
 }
+
+// TemplatePacks
+// @Tags      AutoCodePackage
+// @Summary   获取已注册的模板包
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]response.AutoCodeTemplatePack,msg=string}  "获取成功"
+// @Router    /autoCode/getTemplatePacks [get]
+func (a *AutoCodePackageApi) TemplatePacks(c *gin.Context) {
+	data, err := autoCodePackageService.TemplatePacks(c.Request.Context())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
+	}
+	response.OkWithDetailed(data, "获取成功", c)
+}
+
+// UploadTemplatePack
+// @Tags      AutoCodePackage
+// @Summary   上传模板包
+// @Security  ApiKeyAuth
+// @accept    multipart/form-data
+// @Produce   application/json
+// @Param     file  formData  file                                                    true  "zip格式的模板包"
+// @Success   200   {object}  response.Response{data=map[string]interface{},msg=string}  "上传成功"
+// @Router    /autoCode/uploadTemplatePack [post]
+func (a *AutoCodePackageApi) UploadTemplatePack(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	name, err := autoCodePackageService.UploadTemplatePack(c.Request.Context(), header)
+	if err != nil {
+		global.GVA_LOG.Error("上传失败!", zap.Error(err))
+		response.FailWithMessage("上传失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(gin.H{"name": name}, "上传成功", c)
+}
+
+// DeleteTemplatePack
+// @Tags      AutoCodePackage
+// @Summary   删除模板包
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoCodeTemplatePack                              true  "模板包名称"
+// @Success   200   {object}  response.Response{data=map[string]interface{},msg=string}  "删除成功"
+// @Router    /autoCode/delTemplatePack [post]
+func (a *AutoCodePackageApi) DeleteTemplatePack(c *gin.Context) {
+	var info request.SysAutoCodeTemplatePack
+	_ = c.ShouldBindJSON(&info)
+	err := autoCodePackageService.DeleteTemplatePack(c.Request.Context(), info.Name)
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// SetTemplatePack
+// @Tags      AutoCodePackage
+// @Summary   设置package使用的模板包
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.SysAutoCodePackageTemplatePack                       true  "package ID及模板包名称 模板包为空时使用内置模板"
+// @Success   200   {object}  response.Response{data=map[string]interface{},msg=string}  "设置成功"
+// @Router    /autoCode/setTemplatePack [post]
+func (a *AutoCodePackageApi) SetTemplatePack(c *gin.Context) {
+	var info request.SysAutoCodePackageTemplatePack
+	_ = c.ShouldBindJSON(&info)
+	err := autoCodePackageService.SetTemplatePack(c.Request.Context(), info)
+	if err != nil {
+		global.GVA_LOG.Error("设置失败!", zap.Error(err))
+		response.FailWithMessage("设置失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("设置成功", c)
+}
@@ -5,20 +5,46 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 type AutoCodePluginApi struct{}
 
+// Check
+// @Tags      AutoCodePlugin
+// @Summary   检查插件是否满足安装条件
+// @Security  ApiKeyAuth
+// @accept    multipart/form-data
+// @Produce   application/json
+// @Param     plug  formData  file                                                                   true  "插件压缩包"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodePluginCheck,msg=string}  "检查结果"
+// @Router    /autoCode/checkPlugin [post]
+func (a *AutoCodePluginApi) Check(c *gin.Context) {
+	header, err := c.FormFile("plug")
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var report systemRes.AutoCodePluginCheck
+	report, err = autoCodePluginService.Check(c.Request.Context(), header)
+	if err != nil {
+		global.GVA_LOG.Error("检查插件失败!", zap.Error(err))
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(report, "检查完成", c)
+}
+
 // Install
 // @Tags      AutoCodePlugin
 // @Summary   安装插件
 // @Security  ApiKeyAuth
 // @accept    multipart/form-data
 // @Produce   application/json
-// @Param     plug  formData  file                                              true  "this is a test file"
-// @Success   200   {object}  response.Response{data=[]interface{},msg=string}  "安装插件成功"
+// @Param     plug  formData  file                                                                   true  "插件压缩包"
+// @Success   200   {object}  response.Response{data=systemRes.AutoCodePluginCheck,msg=string}  "安装插件成功"
 // @Router    /autoCode/installPlugin [post]
 func (a *AutoCodePluginApi) Install(c *gin.Context) {
 	header, err := c.FormFile("plug")
@@ -26,28 +52,57 @@ func (a *AutoCodePluginApi) Install(c *gin.Context) {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	web, server, err := autoCodePluginService.Install(header)
-	webStr := "web插件安装成功"
-	serverStr := "server插件安装成功"
-	if web == -1 {
-		webStr = "web端插件未成功安装，请按照文档自行解压安装，如果为纯后端插件请忽略此条提示"
+	var report systemRes.AutoCodePluginCheck
+	report, err = autoCodePluginService.Install(c.Request.Context(), header)
+	if err != nil {
+		global.GVA_LOG.Error("安装插件失败!", zap.Error(err))
+		response.FailWithDetailed(report, err.Error(), c)
+		return
 	}
-	if server == -1 {
-		serverStr = "server端插件未成功安装，请按照文档自行解压安装，如果为纯前端插件请忽略此条提示"
+	response.OkWithDetailed(report, "安装成功, 请在initialize中注册插件后重启服务", c)
+}
+
+// Plugins
+// @Tags      AutoCodePlugin
+// @Summary   获取已安装的插件
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Success   200  {object}  response.Response{data=[]system.SysPlugin,msg=string}  "获取成功"
+// @Router    /autoCode/getPlugins [get]
+func (a *AutoCodePluginApi) Plugins(c *gin.Context) {
+	list, err := autoCodePluginService.Plugins(c.Request.Context())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败", c)
+		return
 	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// Uninstall
+// @Tags      AutoCodePlugin
+// @Summary   卸载插件
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.UninstallPlugin                                     true  "插件名"
+// @Success   200   {object}  response.Response{data=map[string]interface{},msg=string}  "卸载成功"
+// @Router    /autoCode/uninstallPlugin [post]
+func (a *AutoCodePluginApi) Uninstall(c *gin.Context) {
+	var info request.UninstallPlugin
+	err := c.ShouldBindJSON(&info)
 	if err != nil {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	response.OkWithData([]interface{}{
-		gin.H{
-			"code": web,
-			"msg":  webStr,
-		},
-		gin.H{
-			"code": server,
-			"msg":  serverStr,
-		}}, c)
+	err = autoCodePluginService.Uninstall(c.Request.Context(), info.Name)
+	if err != nil {
+		global.GVA_LOG.Error("卸载失败!", zap.Error(err))
+		response.FailWithMessage("卸载失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("卸载成功", c)
 }
 
 // Packaged
@@ -61,13 +116,17 @@ func (a *AutoCodePluginApi) Install(c *gin.Context) {
 // @Router    /autoCode/pubPlug [post]
 func (a *AutoCodePluginApi) Packaged(c *gin.Context) {
 	plugName := c.Query("plugName")
-	zipPath, err := autoCodePluginService.PubPlug(plugName)
+	zipPath, signed, err := autoCodePluginService.PubPlug(plugName)
 	if err != nil {
 		global.GVA_LOG.Error("打包失败!", zap.Error(err))
 		response.FailWithMessage("打包失败"+err.Error(), c)
 		return
 	}
-	response.OkWithMessage(fmt.Sprintf("打包成功,文件路径为:%s", zipPath), c)
+	if !signed {
+		response.OkWithMessage(fmt.Sprintf("打包成功,文件路径为:%s,未配置签名私钥,插件未签名", zipPath), c)
+		return
+	}
+	response.OkWithMessage(fmt.Sprintf("打包成功,文件路径为:%s,已签名", zipPath), c)
 }
 
 // InitMenu
@@ -24,6 +24,13 @@ type ApiGroup struct {
 	SysParamsApi
 	SysVersionApi
 	UserActionLogApi
+	NotificationApi
+	PluginRuntimeApi
+	OpenApiApi
+	RateLimitApi
+	AlertApi
+	WebhookApi
+	DepartmentApi
 }
 
 var (
@@ -48,4 +55,11 @@ var (
 	autoCodeTemplateService = service.ServiceGroupApp.SystemServiceGroup.AutoCodeTemplate
 	sysVersionService       = service.ServiceGroupApp.SystemServiceGroup.SysVersionService
 	userActionLogService    = service.ServiceGroupApp.SystemServiceGroup.UserActionLogService
+	notificationService     = service.ServiceGroupApp.SystemServiceGroup.NotificationService
+	pluginRuntimeService    = service.ServiceGroupApp.SystemServiceGroup.PluginRuntimeService
+	openApiService          = service.ServiceGroupApp.SystemServiceGroup.OpenApiService
+	rateLimitService        = service.ServiceGroupApp.SystemServiceGroup.RateLimitService
+	alertService            = service.ServiceGroupApp.SystemServiceGroup.AlertService
+	webhookService          = service.ServiceGroupApp.SystemServiceGroup.WebhookService
+	departmentService       = service.ServiceGroupApp.SystemServiceGroup.DepartmentService
 )
@@ -0,0 +1,224 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AlertApi struct{}
+
+// GetAlertList 分页获取告警
+// @Tags Alert
+// @Summary 分页获取告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.AlertSearch true "分页获取告警"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /alert/getAlertList [get]
+func (alertApi *AlertApi) GetAlertList(c *gin.Context) {
+	var pageInfo systemReq.AlertSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := alertService.GetAlertList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// AckAlert 确认告警 确认后继续计数但不再通知
+// @Tags Alert
+// @Summary 确认告警 确认后继续计数但不再通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "告警ID"
+// @Success 200 {object} response.Response{msg=string} "确认成功"
+// @Router /alert/ackAlert [post]
+func (alertApi *AlertApi) AckAlert(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = alertService.AckAlert(req.Uint(), utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("确认失败!", zap.Error(err))
+		response.FailWithMessage("确认失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("确认成功", c)
+}
+
+// ResolveAlert 解决告警 再次出现时产生新的告警
+// @Tags Alert
+// @Summary 解决告警 再次出现时产生新的告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "告警ID"
+// @Success 200 {object} response.Response{msg=string} "解决成功"
+// @Router /alert/resolveAlert [post]
+func (alertApi *AlertApi) ResolveAlert(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = alertService.ResolveAlert(req.Uint(), utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("解决失败!", zap.Error(err))
+		response.FailWithMessage("解决失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("解决成功", c)
+}
+
+// CreateAlertChannel 创建告警渠道
+// @Tags Alert
+// @Summary 创建告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysAlertChannel true "创建告警渠道"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /alert/createAlertChannel [post]
+func (alertApi *AlertApi) CreateAlertChannel(c *gin.Context) {
+	var ch system.SysAlertChannel
+	err := c.ShouldBindJSON(&ch)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = alertService.CreateAlertChannel(&ch)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// DeleteAlertChannel 删除告警渠道
+// @Tags Alert
+// @Summary 删除告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "渠道ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /alert/deleteAlertChannel [delete]
+func (alertApi *AlertApi) DeleteAlertChannel(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = alertService.DeleteAlertChannel(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// UpdateAlertChannel 更新告警渠道
+// @Tags Alert
+// @Summary 更新告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysAlertChannel true "更新告警渠道"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /alert/updateAlertChannel [put]
+func (alertApi *AlertApi) UpdateAlertChannel(c *gin.Context) {
+	var ch system.SysAlertChannel
+	err := c.ShouldBindJSON(&ch)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = alertService.UpdateAlertChannel(ch)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// GetAlertChannelList 分页获取告警渠道
+// @Tags Alert
+// @Summary 分页获取告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.AlertChannelSearch true "分页获取告警渠道"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /alert/getAlertChannelList [get]
+func (alertApi *AlertApi) GetAlertChannelList(c *gin.Context) {
+	var pageInfo systemReq.AlertChannelSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := alertService.GetAlertChannelList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// TestAlertChannel 发送测试通知
+// @Tags Alert
+// @Summary 发送测试通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "渠道ID"
+// @Success 200 {object} response.Response{msg=string} "发送成功"
+// @Router /alert/testAlertChannel [post]
+func (alertApi *AlertApi) TestAlertChannel(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = alertService.TestAlertChannel(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("发送失败!", zap.Error(err))
+		response.FailWithMessage("发送失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("发送成功", c)
+}
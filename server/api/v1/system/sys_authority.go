@@ -45,12 +45,6 @@ func (a *AuthorityApi) CreateAuthority(c *gin.Context) {
 		response.FailWithMessage("创建失败"+err.Error(), c)
 		return
 	}
-	err = casbinService.FreshCasbin()
-	if err != nil {
-		global.GVA_LOG.Error("创建成功，权限刷新失败。", zap.Error(err))
-		response.FailWithMessage("创建成功，权限刷新失败。"+err.Error(), c)
-		return
-	}
 	response.OkWithDetailed(systemRes.SysAuthorityResponse{Authority: authBack}, "创建成功", c)
 }
 
@@ -116,7 +110,6 @@ func (a *AuthorityApi) DeleteAuthority(c *gin.Context) {
 		response.FailWithMessage("删除失败"+err.Error(), c)
 		return
 	}
-	_ = casbinService.FreshCasbin()
 	response.OkWithMessage("删除成功", c)
 }
 
@@ -2,7 +2,9 @@ package system
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	commonReq "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
@@ -67,3 +69,163 @@ func (cas *CasbinApi) GetPolicyPathByAuthorityId(c *gin.Context) {
 	paths := casbinService.GetPolicyPathByAuthorityId(casbin.AuthorityId)
 	response.OkWithDetailed(systemRes.PolicyPathResponse{Paths: paths}, "获取成功", c)
 }
+
+// Explain
+// @Tags      Casbin
+// @Summary   权限模拟 判断用户或角色能否访问接口并说明原因
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.CasbinExplain                                               true  "用户id或角色id, 路径, 方法"
+// @Success   200   {object}  response.Response{data=systemRes.CasbinExplainResponse,msg=string}  "返回鉴权结果及匹配的策略"
+// @Router    /casbin/explain [post]
+func (cas *CasbinApi) Explain(c *gin.Context) {
+	var info request.CasbinExplain
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = utils.Verify(info, utils.CasbinExplainVerify)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	res, err := casbinService.Explain(info)
+	if err != nil {
+		global.GVA_LOG.Error("权限模拟失败!", zap.Error(err))
+		response.FailWithMessage("权限模拟失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "获取成功", c)
+}
+
+// GetCasbinMatrix
+// @Tags      Casbin
+// @Summary   获取角色与接口的权限矩阵
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.CasbinMatrix                                               true  "角色id列表, api组"
+// @Success   200   {object}  response.Response{data=systemRes.CasbinMatrixResponse,msg=string}  "返回角色、接口及每个角色对接口的权限来源"
+// @Router    /casbin/getCasbinMatrix [post]
+func (cas *CasbinApi) GetCasbinMatrix(c *gin.Context) {
+	var info request.CasbinMatrix
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	res, err := casbinService.GetCasbinMatrix(info)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(res, "获取成功", c)
+}
+
+// CreateCasbinDeny
+// @Tags      Casbin
+// @Summary   创建显式拒绝规则 角色及其子角色不能访问该接口
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      system.SysCasbinDeny           true  "角色id, 路径, 方法, 备注"
+// @Success   200   {object}  response.Response{msg=string}  "创建成功"
+// @Router    /casbin/createCasbinDeny [post]
+func (cas *CasbinApi) CreateCasbinDeny(c *gin.Context) {
+	var deny system.SysCasbinDeny
+	err := c.ShouldBindJSON(&deny)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = casbinService.CreateCasbinDeny(utils.GetUserAuthorityId(c), deny)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateCasbinDeny
+// @Tags      Casbin
+// @Summary   更新显式拒绝规则
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      system.SysCasbinDeny           true  "规则id, 路径, 方法, 备注"
+// @Success   200   {object}  response.Response{msg=string}  "更新成功"
+// @Router    /casbin/updateCasbinDeny [put]
+func (cas *CasbinApi) UpdateCasbinDeny(c *gin.Context) {
+	var deny system.SysCasbinDeny
+	err := c.ShouldBindJSON(&deny)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = casbinService.UpdateCasbinDeny(utils.GetUserAuthorityId(c), deny)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteCasbinDeny
+// @Tags      Casbin
+// @Summary   删除显式拒绝规则
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      commonReq.GetById              true  "规则id"
+// @Success   200   {object}  response.Response{msg=string}  "删除成功"
+// @Router    /casbin/deleteCasbinDeny [delete]
+func (cas *CasbinApi) DeleteCasbinDeny(c *gin.Context) {
+	var req commonReq.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = casbinService.DeleteCasbinDeny(utils.GetUserAuthorityId(c), req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetCasbinDenyList
+// @Tags      Casbin
+// @Summary   获取角色的显式拒绝规则
+// @Security  ApiKeyAuth
+// @accept    application/json
+// @Produce   application/json
+// @Param     data  body      request.CasbinInReceive                                 true  "权限id"
+// @Success   200   {object}  response.Response{data=[]system.SysCasbinDeny,msg=string}  "获取成功"
+// @Router    /casbin/getCasbinDenyList [post]
+func (cas *CasbinApi) GetCasbinDenyList(c *gin.Context) {
+	var casbin request.CasbinInReceive
+	err := c.ShouldBindJSON(&casbin)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = utils.Verify(casbin, utils.AuthorityIdVerify)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, err := casbinService.GetCasbinDenyList(casbin.AuthorityId)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
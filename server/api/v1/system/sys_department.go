@@ -0,0 +1,104 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type DepartmentApi struct{}
+
+// CreateDepartment 创建部门
+// @Tags Department
+// @Summary 创建部门
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysDepartment true "上级部门ID, 部门名称, 排序"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /department/createDepartment [post]
+func (departmentApi *DepartmentApi) CreateDepartment(c *gin.Context) {
+	var department system.SysDepartment
+	err := c.ShouldBindJSON(&department)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = departmentService.CreateDepartment(department)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// DeleteDepartment 删除部门
+// @Tags Department
+// @Summary 删除部门 存在下级部门或部门下仍有用户时不允许删除
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "部门ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /department/deleteDepartment [delete]
+func (departmentApi *DepartmentApi) DeleteDepartment(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = departmentService.DeleteDepartment(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// UpdateDepartment 更新部门
+// @Tags Department
+// @Summary 更新部门
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysDepartment true "部门ID, 上级部门ID, 部门名称, 排序"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /department/updateDepartment [put]
+func (departmentApi *DepartmentApi) UpdateDepartment(c *gin.Context) {
+	var department system.SysDepartment
+	err := c.ShouldBindJSON(&department)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = departmentService.UpdateDepartment(department)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// GetDepartmentTree 获取部门树
+// @Tags Department
+// @Summary 获取部门树
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]system.SysDepartment,msg=string} "获取成功"
+// @Router /department/getDepartmentTree [get]
+func (departmentApi *DepartmentApi) GetDepartmentTree(c *gin.Context) {
+	list, err := departmentService.GetDepartmentTree()
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(list, "获取成功", c)
+}
@@ -0,0 +1,210 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type NotificationApi struct{}
+
+// CreateNotification 发送通知
+// @Tags Notification
+// @Summary 发送通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.NotificationCreate true "发送通知"
+// @Success 200 {object} response.Response{data=system.SysNotification,msg=string} "发送成功"
+// @Router /notification/createNotification [post]
+func (notificationApi *NotificationApi) CreateNotification(c *gin.Context) {
+	var req systemReq.NotificationCreate
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	notification, err := notificationService.Send(req, utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("发送失败!", zap.Error(err))
+		response.FailWithMessage("发送失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(notification, "发送成功", c)
+}
+
+// DeleteNotification 删除通知
+// @Tags Notification
+// @Summary 删除通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "通知ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /notification/deleteNotification [delete]
+func (notificationApi *NotificationApi) DeleteNotification(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = notificationService.DeleteNotification(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetNotificationList 分页获取已发送的通知
+// @Tags Notification
+// @Summary 分页获取已发送的通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.NotificationSearch true "分页获取已发送的通知"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /notification/getNotificationList [get]
+func (notificationApi *NotificationApi) GetNotificationList(c *gin.Context) {
+	var pageInfo systemReq.NotificationSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := notificationService.GetNotificationList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetMyNotificationList 分页获取当前用户的通知
+// @Tags Notification
+// @Summary 分页获取当前用户的通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.MyNotificationSearch true "分页获取当前用户的通知"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /notification/getMyNotificationList [get]
+func (notificationApi *NotificationApi) GetMyNotificationList(c *gin.Context) {
+	var pageInfo systemReq.MyNotificationSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := notificationService.GetMyNotificationList(utils.GetUserID(c), pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// MarkRead 标记通知为已读
+// @Tags Notification
+// @Summary 标记通知为已读
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.NotificationIds true "接收记录ID列表"
+// @Success 200 {object} response.Response{msg=string} "操作成功"
+// @Router /notification/markRead [put]
+func (notificationApi *NotificationApi) MarkRead(c *gin.Context) {
+	var req systemReq.NotificationIds
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = notificationService.MarkRead(utils.GetUserID(c), req.Ids)
+	if err != nil {
+		global.GVA_LOG.Error("操作失败!", zap.Error(err))
+		response.FailWithMessage("操作失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("操作成功", c)
+}
+
+// MarkAllRead 标记全部通知为已读
+// @Tags Notification
+// @Summary 标记全部通知为已读
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{msg=string} "操作成功"
+// @Router /notification/markAllRead [put]
+func (notificationApi *NotificationApi) MarkAllRead(c *gin.Context) {
+	err := notificationService.MarkAllRead(utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("操作失败!", zap.Error(err))
+		response.FailWithMessage("操作失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("操作成功", c)
+}
+
+// Archive 归档通知
+// @Tags Notification
+// @Summary 归档通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.NotificationIds true "接收记录ID列表"
+// @Success 200 {object} response.Response{msg=string} "归档成功"
+// @Router /notification/archive [put]
+func (notificationApi *NotificationApi) Archive(c *gin.Context) {
+	var req systemReq.NotificationIds
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = notificationService.Archive(utils.GetUserID(c), req.Ids)
+	if err != nil {
+		global.GVA_LOG.Error("归档失败!", zap.Error(err))
+		response.FailWithMessage("归档失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("归档成功", c)
+}
+
+// GetUnreadCount 获取未读通知数量
+// @Tags Notification
+// @Summary 获取未读通知数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.NotificationUnreadCount,msg=string} "获取成功"
+// @Router /notification/getUnreadCount [get]
+func (notificationApi *NotificationApi) GetUnreadCount(c *gin.Context) {
+	count, err := notificationService.GetUnreadCount(utils.GetUserID(c))
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(systemRes.NotificationUnreadCount{Count: count}, "获取成功", c)
+}
@@ -0,0 +1,28 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type OpenApiApi struct{}
+
+// GetDocument 获取 OpenAPI 3.1 文档
+// @Tags OpenApi
+// @Summary 按当前路由表生成 OpenAPI 3.1 文档 包括自动化代码及v2插件注册的路由
+// @Produce application/json
+// @Success 200 {object} map[string]interface{} "OpenAPI文档"
+// @Router /openapi.json [get]
+func (a *OpenApiApi) GetDocument(c *gin.Context) {
+	doc, err := openApiService.Document(c.Request.Context())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
@@ -0,0 +1,120 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PluginRuntimeApi struct{}
+
+// GetPlugins 获取v2插件的运行状态
+// @Tags PluginRuntime
+// @Summary 获取v2插件的运行状态
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]systemRes.PluginRuntime,msg=string} "获取成功"
+// @Router /pluginRuntime/getPlugins [get]
+func (a *PluginRuntimeApi) GetPlugins(c *gin.Context) {
+	response.OkWithDetailed(pluginRuntimeService.Plugins(c.Request.Context()), "获取成功", c)
+}
+
+// EnablePlugin 启用插件
+// @Tags PluginRuntime
+// @Summary 启用插件 未安装时先执行安装
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.PluginRuntime true "插件名"
+// @Success 200 {object} response.Response{msg=string} "启用成功"
+// @Router /pluginRuntime/enable [post]
+func (a *PluginRuntimeApi) EnablePlugin(c *gin.Context) {
+	var info systemReq.PluginRuntime
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = pluginRuntimeService.Enable(c.Request.Context(), info.Name)
+	if err != nil {
+		global.GVA_LOG.Error("启用失败!", zap.Error(err))
+		response.FailWithMessage("启用失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("启用成功", c)
+}
+
+// DisablePlugin 停用插件
+// @Tags PluginRuntime
+// @Summary 停用插件 停用后插件的路由返回错误 菜单对用户隐藏
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.PluginRuntime true "插件名"
+// @Success 200 {object} response.Response{msg=string} "停用成功"
+// @Router /pluginRuntime/disable [post]
+func (a *PluginRuntimeApi) DisablePlugin(c *gin.Context) {
+	var info systemReq.PluginRuntime
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = pluginRuntimeService.Disable(c.Request.Context(), info.Name)
+	if err != nil {
+		global.GVA_LOG.Error("停用失败!", zap.Error(err))
+		response.FailWithMessage("停用失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("停用成功", c)
+}
+
+// UninstallPlugin 卸载插件
+// @Tags PluginRuntime
+// @Summary 执行插件的卸载 清理安装时注册的数据 插件需已停用
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.PluginRuntime true "插件名"
+// @Success 200 {object} response.Response{msg=string} "卸载成功"
+// @Router /pluginRuntime/uninstall [post]
+func (a *PluginRuntimeApi) UninstallPlugin(c *gin.Context) {
+	var info systemReq.PluginRuntime
+	err := c.ShouldBindJSON(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = pluginRuntimeService.Uninstall(c.Request.Context(), info.Name)
+	if err != nil {
+		global.GVA_LOG.Error("卸载失败!", zap.Error(err))
+		response.FailWithMessage("卸载失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("卸载成功", c)
+}
+
+// PluginHealth 插件健康检查
+// @Tags PluginRuntime
+// @Summary 执行插件的健康检查
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Param data query systemReq.PluginRuntime true "插件名"
+// @Success 200 {object} response.Response{msg=string} "插件运行正常"
+// @Router /pluginRuntime/health [get]
+func (a *PluginRuntimeApi) PluginHealth(c *gin.Context) {
+	var info systemReq.PluginRuntime
+	err := c.ShouldBindQuery(&info)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = pluginRuntimeService.Health(c.Request.Context(), info.Name)
+	if err != nil {
+		response.FailWithMessage("健康检查未通过:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("插件运行正常", c)
+}
@@ -0,0 +1,132 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RateLimitApi struct{}
+
+// CreateRateLimitRule 创建限流规则
+// @Tags RateLimit
+// @Summary 创建限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysRateLimitRule true "创建限流规则"
+// @Success 200 {object} response.Response{msg=string} "创建成功"
+// @Router /rateLimit/createRateLimitRule [post]
+func (rateLimitApi *RateLimitApi) CreateRateLimitRule(c *gin.Context) {
+	var rule system.SysRateLimitRule
+	err := c.ShouldBindJSON(&rule)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = rateLimitService.CreateRateLimitRule(&rule)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// DeleteRateLimitRule 删除限流规则
+// @Tags RateLimit
+// @Summary 删除限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "规则ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /rateLimit/deleteRateLimitRule [delete]
+func (rateLimitApi *RateLimitApi) DeleteRateLimitRule(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = rateLimitService.DeleteRateLimitRule(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// UpdateRateLimitRule 更新限流规则
+// @Tags RateLimit
+// @Summary 更新限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysRateLimitRule true "更新限流规则"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /rateLimit/updateRateLimitRule [put]
+func (rateLimitApi *RateLimitApi) UpdateRateLimitRule(c *gin.Context) {
+	var rule system.SysRateLimitRule
+	err := c.ShouldBindJSON(&rule)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = rateLimitService.UpdateRateLimitRule(rule)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// GetRateLimitRuleList 分页获取限流规则
+// @Tags RateLimit
+// @Summary 分页获取限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.RateLimitRuleSearch true "分页获取限流规则"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /rateLimit/getRateLimitRuleList [get]
+func (rateLimitApi *RateLimitApi) GetRateLimitRuleList(c *gin.Context) {
+	var pageInfo systemReq.RateLimitRuleSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := rateLimitService.GetRateLimitRuleList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetRateLimitMetrics 获取限流统计
+// @Tags RateLimit
+// @Summary 获取当前实例各限流规则的判断及拒绝次数
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {object} response.Response{data=systemRes.RateLimitMetrics,msg=string} "获取成功"
+// @Router /rateLimit/getRateLimitMetrics [get]
+func (rateLimitApi *RateLimitApi) GetRateLimitMetrics(c *gin.Context) {
+	var metrics systemRes.RateLimitMetrics = rateLimitService.GetRateLimitMetrics()
+	response.OkWithDetailed(metrics, "获取成功", c)
+}
@@ -0,0 +1,236 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type WebhookApi struct{}
+
+// CreateWebhook 创建webhook订阅
+// @Tags Webhook
+// @Summary 创建webhook订阅 未填写密钥时自动生成 密钥只在创建时返回
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysWebhook true "创建webhook订阅"
+// @Success 200 {object} response.Response{data=system.SysWebhook,msg=string} "创建成功"
+// @Router /webhook/createWebhook [post]
+func (webhookApi *WebhookApi) CreateWebhook(c *gin.Context) {
+	var w system.SysWebhook
+	err := c.ShouldBindJSON(&w)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = webhookService.CreateWebhook(&w)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(w, "创建成功", c)
+}
+
+// DeleteWebhook 删除webhook订阅
+// @Tags Webhook
+// @Summary 删除webhook订阅
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "webhook ID"
+// @Success 200 {object} response.Response{msg=string} "删除成功"
+// @Router /webhook/deleteWebhook [delete]
+func (webhookApi *WebhookApi) DeleteWebhook(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = webhookService.DeleteWebhook(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// UpdateWebhook 更新webhook订阅
+// @Tags Webhook
+// @Summary 更新webhook订阅 未填写密钥时保留原密钥
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysWebhook true "更新webhook订阅"
+// @Success 200 {object} response.Response{msg=string} "更新成功"
+// @Router /webhook/updateWebhook [put]
+func (webhookApi *WebhookApi) UpdateWebhook(c *gin.Context) {
+	var w system.SysWebhook
+	err := c.ShouldBindJSON(&w)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = webhookService.UpdateWebhook(w)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// GetWebhookList 分页获取webhook订阅
+// @Tags Webhook
+// @Summary 分页获取webhook订阅
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.WebhookSearch true "分页获取webhook订阅"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /webhook/getWebhookList [get]
+func (webhookApi *WebhookApi) GetWebhookList(c *gin.Context) {
+	var pageInfo systemReq.WebhookSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := webhookService.GetWebhookList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// GetWebhookEventTypes 获取可订阅的事件类型
+// @Tags Webhook
+// @Summary 获取可订阅的事件类型
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {object} response.Response{data=[]systemRes.WebhookEventType,msg=string} "获取成功"
+// @Router /webhook/getWebhookEventTypes [get]
+func (webhookApi *WebhookApi) GetWebhookEventTypes(c *gin.Context) {
+	var list []systemRes.WebhookEventType = webhookService.GetWebhookEventTypes()
+	response.OkWithDetailed(list, "获取成功", c)
+}
+
+// PingWebhook 发送测试推送
+// @Tags Webhook
+// @Summary 立即向webhook发送一条测试推送 不重试
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "webhook ID"
+// @Success 200 {object} response.Response{data=system.SysWebhookDelivery,msg=string} "推送成功"
+// @Router /webhook/pingWebhook [post]
+func (webhookApi *WebhookApi) PingWebhook(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	delivery, err := webhookService.PingWebhook(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("推送失败!", zap.Error(err))
+		response.FailWithDetailed(delivery, "推送失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(delivery, "推送成功", c)
+}
+
+// GetWebhookDeliveryList 分页获取推送记录
+// @Tags Webhook
+// @Summary 分页获取推送记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.WebhookDeliverySearch true "分页获取推送记录"
+// @Success 200 {object} response.Response{data=response.PageResult,msg=string} "获取成功"
+// @Router /webhook/getWebhookDeliveryList [get]
+func (webhookApi *WebhookApi) GetWebhookDeliveryList(c *gin.Context) {
+	var pageInfo systemReq.WebhookDeliverySearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := webhookService.GetWebhookDeliveryList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// FindWebhookDelivery 获取推送记录详情
+// @Tags Webhook
+// @Summary 获取推送记录详情 包含推送和响应内容
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.GetById true "推送记录ID"
+// @Success 200 {object} response.Response{data=system.SysWebhookDelivery,msg=string} "获取成功"
+// @Router /webhook/findWebhookDelivery [get]
+func (webhookApi *WebhookApi) FindWebhookDelivery(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	delivery, err := webhookService.FindWebhookDelivery(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(delivery, "获取成功", c)
+}
+
+// RedeliverWebhookDelivery 重新推送
+// @Tags Webhook
+// @Summary 以相同的事件内容重新推送 新建一条推送记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "推送记录ID"
+// @Success 200 {object} response.Response{data=system.SysWebhookDelivery,msg=string} "已加入推送队列"
+// @Router /webhook/redeliverWebhookDelivery [post]
+func (webhookApi *WebhookApi) RedeliverWebhookDelivery(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	delivery, err := webhookService.RedeliverWebhookDelivery(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("重新推送失败!", zap.Error(err))
+		response.FailWithMessage("重新推送失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(delivery, "已加入推送队列", c)
+}
@@ -0,0 +1,12 @@
+package config
+
+type ActionLog struct {
+	Enable        bool   `mapstructure:"enable" json:"enable" yaml:"enable"`                         // 是否自动记录用户操作日志
+	Store         string `mapstructure:"store" json:"store" yaml:"store"`                            // 存储方式 elasticsearch | gorm | mongo 为空时开启ES则使用ES 否则使用数据库
+	LogGet        bool   `mapstructure:"log-get" json:"log-get" yaml:"log-get"`                      // 是否记录GET请求
+	QueueSize     int    `mapstructure:"queue-size" json:"queue-size" yaml:"queue-size"`             // 异步队列长度 队列满时写入本地暂存
+	BatchSize     int    `mapstructure:"batch-size" json:"batch-size" yaml:"batch-size"`             // 单次批量写入条数
+	FlushInterval int    `mapstructure:"flush-interval" json:"flush-interval" yaml:"flush-interval"` // 批量写入间隔 (秒)
+	MaxRetries    int    `mapstructure:"max-retries" json:"max-retries" yaml:"max-retries"`          // 写入失败时写入本地暂存 补写连续失败时等待时间翻倍 最多翻倍的次数
+	SpoolDir      string `mapstructure:"spool-dir" json:"spool-dir" yaml:"spool-dir"`                // 本地暂存目录 存储恢复后自动补写
+}
@@ -0,0 +1,11 @@
+package config
+
+type Alert struct {
+	Enable       bool `mapstructure:"enable" json:"enable" yaml:"enable"`                      // 是否开启错误告警
+	MinStatus    int  `mapstructure:"min-status" json:"min-status" yaml:"min-status"`          // 响应状态码大于等于此值时记为错误 默认500 发生panic或 c.Error 记录了错误时同样记为错误
+	Window       int  `mapstructure:"window" json:"window" yaml:"window"`                      // 统计窗口(秒) 默认300
+	Threshold    int  `mapstructure:"threshold" json:"threshold" yaml:"threshold"`             // 同一错误在窗口内达到此次数时发送通知 默认1
+	Cooldown     int  `mapstructure:"cooldown" json:"cooldown" yaml:"cooldown"`                // 同一告警两次通知的最小间隔(秒) 默认600
+	MaxPerHour   int  `mapstructure:"max-per-hour" json:"max-per-hour" yaml:"max-per-hour"`    // 每小时最多发送的通知数 超出后只记录不通知 默认30
+	AllowPrivate bool `mapstructure:"allow-private" json:"allow-private" yaml:"allow-private"` // webhook渠道是否允许推送到回环、链路本地及内网地址 默认不允许
+}
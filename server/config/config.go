@@ -11,6 +11,8 @@ type Server struct {
 	Captcha   Captcha `mapstructure:"captcha" json:"captcha" yaml:"captcha"`
 	// auto
 	AutoCode Autocode `mapstructure:"autocode" json:"autocode" yaml:"autocode"`
+	// 插件
+	Plugin Plugin `mapstructure:"plugin" json:"plugin" yaml:"plugin"`
 	// gorm
 	Mysql  Mysql           `mapstructure:"mysql" json:"mysql" yaml:"mysql"`
 	Mssql  Mssql           `mapstructure:"mssql" json:"mssql" yaml:"mssql"`
@@ -40,4 +42,19 @@ type Server struct {
 
 	// Elasticsearch配置
 	Elasticsearch Elasticsearch `mapstructure:"elasticsearch" json:"elasticsearch" yaml:"elasticsearch"`
+
+	// WebSocket配置
+	Websocket Websocket `mapstructure:"websocket" json:"websocket" yaml:"websocket"`
+
+	// 用户操作日志配置
+	ActionLog ActionLog `mapstructure:"action-log" json:"action-log" yaml:"action-log"`
+
+	// 限流配置
+	RateLimit RateLimit `mapstructure:"rate-limit" json:"rate-limit" yaml:"rate-limit"`
+
+	// 错误告警配置
+	Alert Alert `mapstructure:"alert" json:"alert" yaml:"alert"`
+
+	// webhook事件推送配置
+	Webhook Webhook `mapstructure:"webhook" json:"webhook" yaml:"webhook"`
 }
@@ -1,12 +1,18 @@
 package config
 
 type Elasticsearch struct {
-	Addresses   []string `mapstructure:"addresses" json:"addresses" yaml:"addresses"`                   // Elasticsearch 集群地址
-	Username    string   `mapstructure:"username" json:"username" yaml:"username"`                     // 用户名
-	Password    string   `mapstructure:"password" json:"password" yaml:"password"`                     // 密码
-	Index       string   `mapstructure:"index" json:"index" yaml:"index"`                             // 默认索引
-	MaxRetries  int      `mapstructure:"max-retries" json:"max-retries" yaml:"max-retries"`           // 最大重试次数
-	Timeout     int      `mapstructure:"timeout" json:"timeout" yaml:"timeout"`                       // 请求超时时间 (秒)
-	Compression string   `mapstructure:"compression" json:"compression" yaml:"compression"`           // gzip压缩
-	CertFile    string   `mapstructure:"cert-file" json:"cert-file" yaml:"cert-file"`                 // 证书文件路径
+	Addresses          []string `mapstructure:"addresses" json:"addresses" yaml:"addresses"`                               // Elasticsearch 集群地址
+	Username           string   `mapstructure:"username" json:"username" yaml:"username"`                                  // 用户名
+	Password           string   `mapstructure:"password" json:"password" yaml:"password"`                                  // 密码
+	Index              string   `mapstructure:"index" json:"index" yaml:"index"`                                           // 默认索引
+	MaxRetries         int      `mapstructure:"max-retries" json:"max-retries" yaml:"max-retries"`                         // 最大重试次数
+	Timeout            int      `mapstructure:"timeout" json:"timeout" yaml:"timeout"`                                     // 请求超时时间 (秒)
+	Compression        string   `mapstructure:"compression" json:"compression" yaml:"compression"`                         // gzip压缩
+	CertFile           string   `mapstructure:"cert-file" json:"cert-file" yaml:"cert-file"`                               // 证书文件路径
+	IndexRollover      string   `mapstructure:"index-rollover" json:"index-rollover" yaml:"index-rollover"`                // 时间索引滚动周期 daily | monthly
+	NumberOfShards     int      `mapstructure:"number-of-shards" json:"number-of-shards" yaml:"number-of-shards"`          // 时间索引主分片数
+	NumberOfReplicas   int      `mapstructure:"number-of-replicas" json:"number-of-replicas" yaml:"number-of-replicas"`    // 时间索引副本数
+	RetentionDays      int      `mapstructure:"retention-days" json:"retention-days" yaml:"retention-days"`                // 时间索引保留天数 0为不清理
+	RetentionAction    string   `mapstructure:"retention-action" json:"retention-action" yaml:"retention-action"`          // 过期索引处理方式 delete | snapshot
+	SnapshotRepository string   `mapstructure:"snapshot-repository" json:"snapshot-repository" yaml:"snapshot-repository"` // 快照仓库名称 retention-action为snapshot时必填
 }
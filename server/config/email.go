@@ -9,4 +9,9 @@ type Email struct {
 	Port        int    `mapstructure:"port" json:"port" yaml:"port"`                         // 端口     请前往QQ或者你要发邮件的邮箱查看其smtp协议 大多为 465
 	IsSSL       bool   `mapstructure:"is-ssl" json:"is-ssl" yaml:"is-ssl"`                   // 是否SSL   是否开启SSL
 	IsLoginAuth bool   `mapstructure:"is-loginauth" json:"is-loginauth" yaml:"is-loginauth"` // 是否LoginAuth   是否使用LoginAuth认证方式（适用于IBM、微软邮箱服务器等）
+	// 发送队列
+	Workers       int    `mapstructure:"workers" json:"workers" yaml:"workers"`                      // 并发发送数 每个发送者复用一个smtp连接 默认2
+	MaxAttempts   int    `mapstructure:"max-attempts" json:"max-attempts" yaml:"max-attempts"`       // 最大尝试次数 超过后标记为死信 默认5
+	RetryBackoff  int    `mapstructure:"retry-backoff" json:"retry-backoff" yaml:"retry-backoff"`    // 首次重试间隔(秒) 之后每次翻倍 最长1小时 默认30
+	DefaultLocale string `mapstructure:"default-locale" json:"default-locale" yaml:"default-locale"` // 未指定语言时使用的模板语言 默认zh-CN
 }
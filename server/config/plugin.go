@@ -0,0 +1,17 @@
+package config
+
+// Plugin 插件安装与打包配置
+type Plugin struct {
+	RequireSignature bool        `mapstructure:"require-signature" json:"require-signature" yaml:"require-signature"` // 为true时只允许安装已签名的插件
+	SignKey          string      `mapstructure:"sign-key" json:"sign-key" yaml:"sign-key"`                            // 打包插件时使用的ed25519私钥(base64) 为空时只生成校验文件不签名
+	TrustedKeys      []PluginKey `mapstructure:"trusted-keys" json:"trusted-keys" yaml:"trusted-keys"`                // 受信任的签名公钥
+	MaxFileSize      int64       `mapstructure:"max-file-size" json:"max-file-size" yaml:"max-file-size"`             // 解压时单个文件的最大体积 单位MB 为0时使用默认值
+	MaxTotalSize     int64       `mapstructure:"max-total-size" json:"max-total-size" yaml:"max-total-size"`          // 解压后的最大总体积 单位MB 为0时使用默认值
+	MaxFiles         int         `mapstructure:"max-files" json:"max-files" yaml:"max-files"`                         // 压缩包最多包含的文件数 为0时使用默认值
+}
+
+// PluginKey 受信任的签名公钥
+type PluginKey struct {
+	Name string `mapstructure:"name" json:"name" yaml:"name"` // 公钥名 安装时展示为签名者
+	Key  string `mapstructure:"key" json:"key" yaml:"key"`    // base64编码的ed25519公钥
+}
@@ -0,0 +1,7 @@
+package config
+
+type RateLimit struct {
+	Enable    bool   `mapstructure:"enable" json:"enable" yaml:"enable"`          // 是否开启限流
+	Store     string `mapstructure:"store" json:"store" yaml:"store"`             // 计数存储 memory | redis 为空时开启redis则使用redis 否则使用内存
+	Algorithm string `mapstructure:"algorithm" json:"algorithm" yaml:"algorithm"` // 默认IP限流使用的算法 token_bucket | sliding_window
+}
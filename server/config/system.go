@@ -12,4 +12,5 @@ type System struct {
 	UseMongo          bool   `mapstructure:"use-mongo" json:"use-mongo" yaml:"use-mongo"`                         // 使用mongo
 	UseElasticsearch  bool   `mapstructure:"use-elasticsearch" json:"use-elasticsearch" yaml:"use-elasticsearch"` // 使用elasticsearch
 	UseStrictAuth     bool   `mapstructure:"use-strict-auth" json:"use-strict-auth" yaml:"use-strict-auth"`       // 使用树形角色分配模式
+	UseAllAuthorities bool   `mapstructure:"use-all-authorities" json:"use-all-authorities" yaml:"use-all-authorities"` // 合并用户全部角色的权限 关闭时只使用当前角色
 }
@@ -0,0 +1,10 @@
+package config
+
+type Webhook struct {
+	Enable       bool `mapstructure:"enable" json:"enable" yaml:"enable"`                      // 是否开启webhook事件推送
+	Workers      int  `mapstructure:"workers" json:"workers" yaml:"workers"`                   // 推送协程数 默认2
+	MaxAttempts  int  `mapstructure:"max-attempts" json:"max-attempts" yaml:"max-attempts"`    // 每次推送的最大尝试次数 默认6
+	RetryBackoff int  `mapstructure:"retry-backoff" json:"retry-backoff" yaml:"retry-backoff"` // 首次重试等待时间(秒) 之后每次翻倍 默认10
+	Timeout      int  `mapstructure:"timeout" json:"timeout" yaml:"timeout"`                   // 单次请求超时时间(秒) 默认10
+	AllowPrivate bool `mapstructure:"allow-private" json:"allow-private" yaml:"allow-private"` // 是否允许推送到回环、链路本地及内网地址 默认不允许
+}
@@ -0,0 +1,9 @@
+package config
+
+type Websocket struct {
+	Addr              int    `mapstructure:"addr" json:"addr" yaml:"addr"`                                           // 端口值
+	UseCluster        bool   `mapstructure:"use-cluster" json:"use-cluster" yaml:"use-cluster"`                      // 多实例部署时通过redis发布订阅转发消息
+	ChannelPrefix     string `mapstructure:"channel-prefix" json:"channel-prefix" yaml:"channel-prefix"`             // redis key及频道前缀
+	HeartbeatInterval int    `mapstructure:"heartbeat-interval" json:"heartbeat-interval" yaml:"heartbeat-interval"` // 实例心跳间隔 (秒)
+	InstanceTTL       int    `mapstructure:"instance-ttl" json:"instance-ttl" yaml:"instance-ttl"`                   // 实例心跳超时时间 (秒) 超时后视为实例已下线
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/core/internal"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+)
+
+// RunGen 执行 gen 子命令 按模块描述文件离线生成代码 不连接数据库
+// 用法: gva gen -f module.yaml [-f other.json] [-c config.yaml] [-root 项目根目录] [-check] [-dry-run]
+// -check 只比较生成结果与磁盘文件 存在差异时返回非0 用于CI检查生成代码是否被手动修改或未重新生成
+func RunGen(args []string) int {
+	var (
+		files  specFiles
+		config string
+		root   string
+		check  bool
+		dryRun bool
+	)
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	fs.Var(&files, "f", "模块描述文件(YAML或JSON) 可重复指定")
+	fs.StringVar(&config, "c", "", "配置文件路径")
+	fs.StringVar(&root, "root", "", "项目根目录 默认为server的上级目录")
+	fs.BoolVar(&check, "check", false, "只检查生成结果与磁盘文件是否一致")
+	fs.BoolVar(&dryRun, "dry-run", false, "只校验并列出将要写入的文件")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	files = append(files, fs.Args()...)
+	if len(files) == 0 {
+		fmt.Fprintln(os.Stderr, "请使用 -f 指定模块描述文件")
+		fs.Usage()
+		return 2
+	}
+
+	specs := make([]*request.AutoCodeSpec, 0, len(files))
+	failed := false
+	for _, name := range files {
+		spec, err := loadSpec(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] %v\n", name, err)
+			failed = true
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	if failed {
+		return 1
+	}
+
+	if config != "" {
+		_ = os.Setenv(internal.ConfigEnv, config)
+	}
+	global.GVA_VP = Viper()
+	if root != "" {
+		global.GVA_CONFIG.AutoCode.Root, _ = filepath.Abs(root)
+	}
+
+	ctx := context.Background()
+	for i, spec := range specs {
+		info := spec.AutoCode
+		if err := info.Pretreatment(); err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] %v\n", files[i], err)
+			return 1
+		}
+		codes, err := system.AutoCodeTemplate.Render(ctx, info, spec.Template, spec.TemplatePack)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] %v\n", files[i], err)
+			return 1
+		}
+		paths := make([]string, 0, len(codes))
+		for path := range codes {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			rel, err := filepath.Rel(global.GVA_CONFIG.AutoCode.Root, path)
+			if err != nil {
+				rel = path
+			}
+			switch {
+			case check:
+				old, err := os.ReadFile(path)
+				if err != nil || string(old) != codes[path] {
+					fmt.Printf("[%s] %s 与生成结果不一致\n", files[i], rel)
+					failed = true
+				}
+			case dryRun:
+				fmt.Printf("[%s] %s\n", files[i], rel)
+			default:
+				if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+					fmt.Fprintf(os.Stderr, "[filepath:%s]创建文件夹失败! %v\n", rel, err)
+					return 1
+				}
+				if err = os.WriteFile(path, []byte(codes[path]), 0666); err != nil {
+					fmt.Fprintf(os.Stderr, "[filepath:%s]写入文件失败! %v\n", rel, err)
+					return 1
+				}
+				fmt.Printf("[%s] %s 生成成功!\n", files[i], rel)
+			}
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// loadSpec 读取并校验模块描述文件
+func loadSpec(name string) (*request.AutoCodeSpec, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := request.ParseAutoCodeSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// specFiles 支持重复指定的 -f 参数
+type specFiles []string
+
+func (f *specFiles) String() string {
+	return fmt.Sprint(*f)
+}
+
+func (f *specFiles) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin"
+)
+
+// RunKeygen 执行 keygen 子命令 生成插件签名使用的ed25519密钥对
+// 用法: gva keygen
+// 公钥配置到 plugin.trusted-keys 私钥配置到打包插件所用环境的 plugin.sign-key
+func RunKeygen(_ []string) int {
+	publicKey, privateKey, err := plugin.GenerateKey()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "生成密钥失败: %v\n", err)
+		return 1
+	}
+	fmt.Printf("公钥(配置到 plugin.trusted-keys): %s\n", publicKey)
+	fmt.Printf("私钥(配置到 plugin.sign-key, 请妥善保管): %s\n", privateKey)
+	return 0
+}
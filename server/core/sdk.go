@@ -0,0 +1,127 @@
+package core
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/core/internal"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/initialize"
+	sysModel "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	sysSource "github.com/flipped-aurora/gin-vue-admin/server/source/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/sdk"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+)
+
+// RunSdk 执行 sdk 子命令 按路由表及请求、返回结构体生成前端使用的TypeScript SDK
+// 用法: gva sdk [-c config.yaml] [-o 输出目录] [-db] [-check]
+// 接口描述取自 sys_apis 的初始数据 -db 时连接数据库读取 sys_apis 中的描述
+// -check 只比较生成结果与磁盘文件 存在差异时返回非0 用于CI检查前端SDK是否与后端一致
+func RunSdk(args []string) int {
+	var (
+		config string
+		output string
+		useDB  bool
+		check  bool
+	)
+	fs := flag.NewFlagSet("sdk", flag.ContinueOnError)
+	fs.StringVar(&config, "c", "", "配置文件路径")
+	fs.StringVar(&output, "o", "", "输出目录 默认为 web/src/sdk")
+	fs.BoolVar(&useDB, "db", false, "连接数据库读取接口描述")
+	fs.BoolVar(&check, "check", false, "只检查生成结果与磁盘文件是否一致")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "请在server目录下执行:", err)
+		return 1
+	}
+	module := modfile.ModulePath(data)
+	if config != "" {
+		_ = os.Setenv(internal.ConfigEnv, config)
+	}
+	global.GVA_VP = Viper()
+	global.GVA_LOG = zap.NewNop()
+	if output == "" {
+		output = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Web, "sdk")
+	}
+	output, _ = filepath.Abs(output)
+
+	gin.SetMode(gin.ReleaseMode)
+	routes := initialize.RoutesOnly()
+	descriptions := make(map[string]string)
+	for _, api := range sysSource.Apis() {
+		descriptions[api.Method+" "+api.Path] = api.Description
+	}
+	if useDB {
+		if global.GVA_DB = initialize.Gorm(); global.GVA_DB == nil {
+			fmt.Fprintln(os.Stderr, "连接数据库失败, 请检查配置")
+			return 1
+		}
+		var apis []sysModel.SysApi
+		if err = global.GVA_DB.Find(&apis).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "读取接口描述失败: %v\n", err)
+			return 1
+		}
+		for _, api := range apis {
+			descriptions[api.Method+" "+api.Path] = api.Description
+		}
+	}
+
+	list := make([]sdk.Route, 0, len(routes))
+	prefix := global.GVA_CONFIG.System.RouterPrefix
+	for _, route := range routes {
+		path := strings.TrimPrefix(route.Path, prefix)
+		list = append(list, sdk.Route{Method: route.Method, Path: path, Handler: route.Handler, Description: descriptions[route.Method+" "+path]})
+	}
+	webSrc, err := filepath.Rel(output, filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Web))
+	if err != nil {
+		webSrc = ".."
+	}
+	files, skipped := sdk.NewTypes(".", module).Generate(list, filepath.ToSlash(webSrc))
+	for _, route := range skipped {
+		// 匿名函数注册的路由(如 /health)不生成请求函数
+		if strings.HasPrefix(route.Handler, module) && !strings.Contains(route.Handler, ".func") {
+			fmt.Fprintf(os.Stderr, "跳过 %s %s: 未找到处理函数 %s\n", route.Method, route.Path, route.Handler)
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	failed := false
+	for _, name := range names {
+		path := filepath.Join(output, name)
+		if check {
+			old, err := os.ReadFile(path)
+			if err != nil || string(old) != files[name] {
+				fmt.Printf("%s 与生成结果不一致\n", path)
+				failed = true
+			}
+			continue
+		}
+		if err = os.MkdirAll(output, os.ModePerm); err != nil {
+			fmt.Fprintf(os.Stderr, "创建文件夹失败! %v\n", err)
+			return 1
+		}
+		if err = os.WriteFile(path, []byte(files[name]), 0666); err != nil {
+			fmt.Fprintf(os.Stderr, "[filepath:%s]写入文件失败! %v\n", path, err)
+			return 1
+		}
+		fmt.Printf("%s 生成成功!\n", path)
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/initialize"
@@ -24,12 +25,24 @@ func RunServer() {
 			zap.L().Error(fmt.Sprintf("%+v", err))
 		}
 	}
-	// 从db加载jwt数据
+	// 限流器按是否开启redis选择计数存储 需在redis初始化之后加载
+	initialize.RateLimit()
+	initialize.Alert()   // 定时聚合错误并发送告警
+	initialize.Webhook() // 订阅业务事件并启动webhook推送
+	// 迁移升级前没有哈希的jwt黑名单 使用redis时写入redis 需在redis初始化之后执行
 	if global.GVA_DB != nil {
-		system.LoadAll()
+		system.MigrateJwtBlacklist()
 	}
+	// websocket集群模式依赖redis 需在redis初始化之后启动 返回前已赋值 global.GVA_WS
+	initialize.InitWebsocket()
 
 	Router := initialize.Routers()
+	// 生成OpenAPI文档需要解析源码 启动时在后台预先生成 避免首次请求等待
+	go func() {
+		if _, err := system.OpenApiServiceApp.Document(context.Background()); err != nil {
+			global.GVA_LOG.Error("生成OpenAPI文档失败!", zap.Error(err))
+		}
+	}()
 
 	address := fmt.Sprintf(":%d", global.GVA_CONFIG.System.Addr)
 
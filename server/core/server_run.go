@@ -9,6 +9,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -47,6 +49,11 @@ func initServer(address string, router *gin.Engine, readTimeout, writeTimeout ti
 	<-quit
 	zap.L().Info("关闭WEB服务...")
 
+	// 注销websocket实例 集群模式下由本实例广播其用户下线
+	if global.GVA_WS != nil {
+		global.GVA_WS.Close()
+	}
+
 	// 设置5秒的超时时间
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 
@@ -56,5 +63,8 @@ func initServer(address string, router *gin.Engine, readTimeout, writeTimeout ti
 		zap.L().Fatal("WEB服务关闭异常", zap.Error(err))
 	}
 
+	// 写出队列中剩余的用户操作日志
+	service.ServiceGroupApp.SystemServiceGroup.UserActionLogService.Close()
+
 	zap.L().Info("WEB服务已关闭")
 }
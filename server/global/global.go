@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/qiniu/qmgo"
 
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/socket"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils/timer"
 	"github.com/songzhibin97/gkit/cache/local_cache"
 
@@ -39,6 +40,7 @@ var (
 	GVA_ROUTERS             gin.RoutesInfo
 	GVA_ACTIVE_DBNAME       *string
 	GVA_MCP_SERVER          *server.MCPServer
+	GVA_WS                  *socket.Hub
 	BlackCache              local_cache.Cache
 	lock                    sync.RWMutex
 )
@@ -0,0 +1,23 @@
+package initialize
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"go.uber.org/zap"
+)
+
+// Alert 定时将聚合的请求错误入库并发送告警通知
+func Alert() {
+	if !global.GVA_CONFIG.Alert.Enable {
+		return
+	}
+	flush := func() {
+		if err := system.AlertServiceApp.FlushAlerts(); err != nil {
+			global.GVA_LOG.Error("处理错误告警失败!", zap.Error(err))
+		}
+	}
+	_, err := global.GVA_Timer.AddTaskByFunc("ErrorAlert", "@every 10s", flush, "聚合错误告警")
+	if err != nil {
+		global.GVA_LOG.Error("添加错误告警任务失败!", zap.Error(err))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/model/example"
 	sysModel "github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/announcement/model"
+	emailModel "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
 	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"gorm.io/gorm"
 )
@@ -54,6 +55,18 @@ func (e *ensureTables) MigrateTable(ctx context.Context) (context.Context, error
 		sysModel.JoinTemplate{},
 		sysModel.SysParams{},
 		sysModel.SysVersion{},
+		sysModel.SysNotification{},
+		sysModel.SysNotificationReceipt{},
+		sysModel.UserActionLog{},
+		sysModel.SysPlugin{},
+		sysModel.SysPluginState{},
+		sysModel.SysRateLimitRule{},
+		sysModel.SysAlert{},
+		sysModel.SysAlertChannel{},
+		sysModel.SysWebhook{},
+		sysModel.SysWebhookDelivery{},
+		sysModel.SysDepartment{},
+		sysModel.SysCasbinDeny{},
 		adapter.CasbinRule{},
 
 		example.ExaFile{},
@@ -63,6 +76,8 @@ func (e *ensureTables) MigrateTable(ctx context.Context) (context.Context, error
 		example.ExaAttachmentCategory{},
 
 		model.Info{},
+		emailModel.EmailTemplate{},
+		emailModel.EmailMessage{},
 	}
 	for _, t := range tables {
 		_ = db.AutoMigrate(&t)
@@ -104,6 +119,8 @@ func (e *ensureTables) TableCreated(ctx context.Context) bool {
 		example.ExaAttachmentCategory{},
 
 		model.Info{},
+		emailModel.EmailTemplate{},
+		emailModel.EmailMessage{},
 	}
 	yes := true
 	for _, t := range tables {
@@ -6,6 +6,7 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/example"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -57,6 +58,18 @@ func RegisterTables() {
 		system.JoinTemplate{},
 		system.SysParams{},
 		system.SysVersion{},
+		system.SysNotification{},
+		system.SysNotificationReceipt{},
+		system.UserActionLog{},
+		system.SysPlugin{},
+		system.SysPluginState{},
+		system.SysRateLimitRule{},
+		system.SysAlert{},
+		system.SysAlertChannel{},
+		system.SysWebhook{},
+		system.SysWebhookDelivery{},
+		system.SysDepartment{},
+		system.SysCasbinDeny{},
 
 		example.ExaFile{},
 		example.ExaCustomer{},
@@ -75,5 +88,11 @@ func RegisterTables() {
 		global.GVA_LOG.Error("register biz_table failed", zap.Error(err))
 		os.Exit(0)
 	}
+
+	// 旧版casbin策略转换为角色继承模式 需在加载策略前执行
+	if err = systemService.CasbinServiceApp.MigrateInheritance(db); err != nil {
+		global.GVA_LOG.Error("migrate casbin_rule failed", zap.Error(err))
+		os.Exit(0)
+	}
 	global.GVA_LOG.Info("register table success")
 }
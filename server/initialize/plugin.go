@@ -2,14 +2,27 @@ package initialize
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
 	"github.com/gin-gonic/gin"
 )
 
+// pluginRoutesOnly 只注册插件的路由 不执行v2插件的安装及启用
+var pluginRoutesOnly bool
+
+// RoutesOnly 不连接数据库构建路由表 包含所有插件的路由 供 gva sdk 等离线工具读取
+func RoutesOnly() gin.RoutesInfo {
+	pluginRoutesOnly = true
+	defer func() { pluginRoutesOnly = false }()
+	return Routers().Routes()
+}
+
 func InstallPlugin(PrivateGroup *gin.RouterGroup, PublicRouter *gin.RouterGroup, engine *gin.Engine) {
-	if global.GVA_DB == nil {
+	if global.GVA_DB == nil && !pluginRoutesOnly {
 		global.GVA_LOG.Info("项目暂未初始化，无法安装插件，初始化后重启项目即可完成插件安装")
 		return
 	}
 	bizPluginV1(PrivateGroup, PublicRouter)
+	// 之后注册的v2插件路由均经过该中间件 插件停用后返回错误
+	engine.Use(middleware.PluginGate())
 	bizPluginV2(engine)
 }
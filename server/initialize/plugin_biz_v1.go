@@ -31,6 +31,11 @@ func bizPluginV1(group ...*gin.RouterGroup) {
 		global.GVA_CONFIG.Email.Port,
 		global.GVA_CONFIG.Email.IsSSL,
 		global.GVA_CONFIG.Email.IsLoginAuth,
+	).WithQueue(
+		global.GVA_CONFIG.Email.Workers,
+		global.GVA_CONFIG.Email.MaxAttempts,
+		global.GVA_CONFIG.Email.RetryBackoff,
+		global.GVA_CONFIG.Email.DefaultLocale,
 	))
 	holder(public, private)
 }
@@ -1,18 +1,36 @@
 package initialize
 
 import (
+	"context"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/announcement"
 	fansClub "github.com/flipped-aurora/gin-vue-admin/server/plugin/fans-club"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin/v2"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
+// PluginInitV2 注册v2插件 启停状态由 PluginRuntimeService 管理
 func PluginInitV2(group *gin.Engine, plugins ...plugin.Plugin) {
 	for i := 0; i < len(plugins); i++ {
-		plugins[i].Register(group)
+		if pluginRoutesOnly {
+			plugins[i].Register(group)
+			continue
+		}
+		err := system.PluginRuntimeServiceApp.Register(context.Background(), group, plugins[i])
+		if err != nil {
+			global.GVA_LOG.Error("注册插件失败!", zap.Error(err))
+		}
 	}
 }
 func bizPluginV2(engine *gin.Engine) {
 	PluginInitV2(engine, announcement.Plugin)
 	PluginInitV2(engine, fansClub.Plugin)
+	if global.GVA_REDIS != nil && !pluginRoutesOnly {
+		if err := system.PluginRuntimeServiceApp.Watch(global.GVA_REDIS); err != nil {
+			global.GVA_LOG.Error("订阅插件状态变更失败, 多实例部署时插件启停需重启其他实例后生效!", zap.Error(err))
+		}
+	}
 }
@@ -0,0 +1,22 @@
+package initialize
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"go.uber.org/zap"
+)
+
+// RateLimit 加载限流规则 需在redis初始化之后调用以便选择redis存储
+// 定时重新加载 同步其他实例修改的规则及系统配置中的默认IP限流
+func RateLimit() {
+	load := func() {
+		if err := system.RateLimitServiceApp.LoadRateLimitRules(); err != nil {
+			global.GVA_LOG.Error("加载限流规则失败!", zap.Error(err))
+		}
+	}
+	load()
+	_, err := global.GVA_Timer.AddTaskByFunc("RateLimitRules", "@every 1m", load, "同步限流规则")
+	if err != nil {
+		global.GVA_LOG.Error("添加限流规则同步任务失败!", zap.Error(err))
+	}
+}
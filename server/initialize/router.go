@@ -8,6 +8,8 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
 	"github.com/flipped-aurora/gin-vue-admin/server/router"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/sdk"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -36,6 +38,10 @@ func (fs justFilesFilesystem) Open(name string) (http.File, error) {
 func Routers() *gin.Engine {
 	Router := gin.New()
 	Router.Use(gin.Recovery())
+	if global.GVA_CONFIG.Alert.Enable {
+		Router.Use(middleware.ErrorAlert()) // 错误告警 需在Recovery之后以便记录panic
+	}
+	Router.Use(middleware.RouteProbe()) // 生成 OpenAPI 文档时探测路由的中间件
 	if gin.Mode() == gin.DebugMode {
 		Router.Use(gin.Logger())
 	}
@@ -74,7 +80,13 @@ func Routers() *gin.Engine {
 	PublicGroup := Router.Group(global.GVA_CONFIG.System.RouterPrefix)
 	PrivateGroup := Router.Group(global.GVA_CONFIG.System.RouterPrefix)
 
-	PrivateGroup.Use(middleware.JWTAuth()).Use(middleware.CasbinHandler())
+	// 限流 私有路由组放在JWTAuth之后以便按用户和角色限流
+	PublicGroup.Use(middleware.RateLimit())
+	PrivateGroup.Use(middleware.JWTAuth()).Use(middleware.RateLimit()).Use(middleware.CasbinHandler())
+	if global.GVA_CONFIG.ActionLog.Enable {
+		// 自动记录用户操作日志 依赖JWTAuth解析出的claims
+		PrivateGroup.Use(middleware.UserActionLog())
+	}
 
 	{
 		// 健康监测
@@ -83,8 +95,9 @@ func Routers() *gin.Engine {
 		})
 	}
 	{
-		systemRouter.InitBaseRouter(PublicGroup) // 注册基础功能路由 不做鉴权
-		systemRouter.InitInitRouter(PublicGroup) // 自动初始化相关
+		systemRouter.InitBaseRouter(PublicGroup)    // 注册基础功能路由 不做鉴权
+		systemRouter.InitInitRouter(PublicGroup)    // 自动初始化相关
+		systemRouter.InitOpenApiRouter(PublicGroup) // OpenAPI文档
 	}
 
 	{
@@ -105,6 +118,12 @@ func Routers() *gin.Engine {
 		systemRouter.InitSysExportTemplateRouter(PrivateGroup, PublicGroup) // 导出模板
 		systemRouter.InitSysParamsRouter(PrivateGroup, PublicGroup)         // 参数管理
 		systemRouter.InitUserActionLogRouter(PrivateGroup)                  // 用户操作日志（ES）
+		systemRouter.InitNotificationRouter(PrivateGroup)                   // 站内通知
+		systemRouter.InitPluginRuntimeRouter(PrivateGroup)                  // v2插件启停
+		systemRouter.InitRateLimitRouter(PrivateGroup)                      // 限流规则
+		systemRouter.InitAlertRouter(PrivateGroup)                          // 错误告警
+		systemRouter.InitWebhookRouter(PrivateGroup)                        // webhook事件推送
+		systemRouter.InitDepartmentRouter(PrivateGroup)                     // 部门管理
 		exampleRouter.InitCustomerRouter(PrivateGroup)                      // 客户路由
 		exampleRouter.InitFileUploadAndDownloadRouter(PrivateGroup)         // 文件上传下载功能路由
 		exampleRouter.InitAttachmentCategoryRouterRouter(PrivateGroup)      // 文件上传下载分类
@@ -119,6 +138,15 @@ func Routers() *gin.Engine {
 
 	global.GVA_ROUTERS = Router.Routes()
 
+	// 运行时按路由表生成 OpenAPI 文档 包括自动化代码及插件注册的路由
+	system.OpenApiServiceApp.Register(Router, sdk.Info{
+		Title:       docs.SwaggerInfo.Title,
+		Version:     docs.SwaggerInfo.Version,
+		Description: docs.SwaggerInfo.Description,
+	}, func(method, fullPath string) []string {
+		return middleware.ProbeRoute(Router, method, fullPath)
+	})
+
 	global.GVA_LOG.Info("router register success")
 	return Router
 }
@@ -2,179 +2,43 @@ package initialize
 
 import (
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
-	"github.com/gorilla/websocket"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/socket"
+	"go.uber.org/zap"
 )
 
-// 用户信息结构
-type User struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
-}
-
-// WebSocket 消息结构
-type Message struct {
-	Type    string      `json:"type"`    // message, join, leave, etc.
-	From    User        `json:"from"`    // 发送者信息
-	Content interface{} `json:"content"` // 消息内容
-	Time    int64       `json:"time"`    // 时间戳
-}
-
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
-}
-
-type Client struct {
-	hub    *Hub
-	conn   *websocket.Conn
-	send   chan []byte
-	user   *User
-	userID string
-}
-
-type Hub struct {
-	clients    map[*Client]bool
-	users      map[string]*Client // 用户ID到客户端的映射
-	broadcast  chan []byte
-	register   chan *Client
-	unregister chan *Client
-}
+var jwtService = service.ServiceGroupApp.SystemServiceGroup.JwtService
 
-func NewHub() *Hub {
-	return &Hub{
-		broadcast:  make(chan []byte),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		clients:    make(map[*Client]bool),
-		users:      make(map[string]*Client),
-	}
-}
-
-func (h *Hub) Run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.clients[client] = true
-			if client.userID != "" {
-				h.users[client.userID] = client
-			}
-			log.Printf("用户 %s 连接，当前连接数: %d", client.userID, len(h.clients))
-
-			// 广播用户上线消息
-			joinMsg := Message{
-				Type: "user_join",
-				From: *client.user,
-				Content: map[string]interface{}{
-					"userCount":   len(h.clients),
-					"onlineUsers": h.GetOnlineUsers(),
-				},
-				Time: time.Now().Unix(),
-			}
-			h.BroadcastMessage(joinMsg)
-
-		case client := <-h.unregister:
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				if client.userID != "" {
-					delete(h.users, client.userID)
-				}
-				close(client.send)
-				log.Printf("用户 %s 断开，当前连接数: %d", client.userID, len(h.clients))
-
-				// 广播用户下线消息
-				leaveMsg := Message{
-					Type: "user_leave",
-					From: *client.user,
-					Content: map[string]interface{}{
-						"userCount":   len(h.clients),
-						"onlineUsers": h.GetOnlineUsers(),
-					},
-					Time: time.Now().Unix(),
-				}
-				h.BroadcastMessage(leaveMsg)
-			}
-
-		case message := <-h.broadcast:
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-					if client.userID != "" {
-						delete(h.users, client.userID)
-					}
-				}
-			}
-		}
-	}
-}
-
-// 获取在线用户列表
-func (h *Hub) GetOnlineUsers() []User {
-	users := make([]User, 0, len(h.users))
-	for _, client := range h.users {
-		if client.user != nil {
-			users = append(users, *client.user)
-		}
+// validateToken 使用登录时签发的jwt验证连接 用户ID与 SysUser.ID 一致 便于业务按用户推送消息
+// 与 JWTAuth 一致 已注销(加入黑名单)或已吊销(令牌版本更新)的令牌不能建立连接
+func validateToken(token string) (*socket.User, func() bool, error) {
+	claims, err := utils.NewJWT().ParseToken(token)
+	if err != nil {
+		return nil, nil, err
 	}
-	return users
-}
-
-// 向特定用户发送消息
-func (h *Hub) SendToUser(userID string, message Message) {
-	if client, exists := h.users[userID]; exists {
-		msgBytes, _ := json.Marshal(message)
-		client.send <- msgBytes
+	valid := func() bool {
+		return !jwtService.IsBlacklist(token) && !utils.TokenRevoked(claims)
 	}
-}
-
-// 广播消息
-func (h *Hub) BroadcastMessage(message Message) {
-	msgBytes, _ := json.Marshal(message)
-	for client := range h.clients {
-		select {
-		case client.send <- msgBytes:
-		default:
-			close(client.send)
-			delete(h.clients, client)
-			if client.userID != "" {
-				delete(h.users, client.userID)
-			}
-		}
+	if !valid() {
+		return nil, nil, errors.New("登录状态已失效")
 	}
-}
-
-// Token 验证函数（示例）
-func validateToken(token string) (*User, error) {
-	// 这里应该是你的实际 Token 验证逻辑
-	// 示例：简单的基于 token 前缀的验证
-	if strings.HasPrefix(token, "user_") {
-		return &User{
-			ID:       token,
-			Username: "用户_" + token[5:],
-			Role:     "user",
-		}, nil
-	} else if strings.HasPrefix(token, "admin_") {
-		return &User{
-			ID:       token,
-			Username: "管理员_" + token[6:],
-			Role:     "admin",
-		}, nil
+	user := &socket.User{
+		ID:       strconv.Itoa(int(claims.BaseClaims.ID)),
+		Username: claims.Username,
+		Role:     strconv.Itoa(int(claims.AuthorityId)),
 	}
-	return nil, http.ErrAbortHandler
+	return user, valid, nil
 }
 
-func serveWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
+func serveWs(hub *socket.Hub, w http.ResponseWriter, r *http.Request) {
 	// 从查询参数获取 token
 	token := r.URL.Query().Get("token")
 	if token == "" {
@@ -183,142 +47,18 @@ func serveWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 验证 token
-	user, err := validateToken(token)
+	user, valid, err := validateToken(token)
 	if err != nil {
 		http.Error(w, "Invalid token", http.StatusUnauthorized)
 		return
 	}
 
-	conn, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("WebSocket 升级失败: %v", err)
-		return
-	}
-
-	client := &Client{
-		hub:    hub,
-		conn:   conn,
-		send:   make(chan []byte, 256),
-		user:   user,
-		userID: user.ID,
-	}
-
-	client.hub.register <- client
-
-	go client.writePump()
-	go client.readPump()
-}
-
-func (c *Client) readPump() {
-	defer func() {
-		c.hub.unregister <- c
-		c.conn.Close()
-	}()
-
-	c.conn.SetReadLimit(512)
-	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	c.conn.SetPongHandler(func(string) error {
-		c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
-	})
-
-	for {
-		_, message, err := c.conn.ReadMessage()
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("用户 %s 读取错误: %v", c.userID, err)
-			}
-			break
-		}
-
-		// 解析消息
-		var msg Message
-		if err := json.Unmarshal(message, &msg); err != nil {
-			log.Printf("消息解析错误: %v", err)
-			continue
-		}
-
-		// 设置发送者信息
-		msg.From = *c.user
-		msg.Time = time.Now().Unix()
-
-		log.Printf("收到来自 %s 的消息: %s", c.user.Username, msg.Content)
-
-		// 处理不同类型的消息
-		switch msg.Type {
-		case "chat":
-			// 广播聊天消息
-			msgBytes, _ := json.Marshal(msg)
-			c.hub.broadcast <- msgBytes
-
-		case "private_message":
-			// 私聊消息
-			if targetUserID, ok := msg.Content.(map[string]interface{})["target"].(string); ok {
-				privateMsg := Message{
-					Type: "private_message",
-					From: *c.user,
-					Content: map[string]interface{}{
-						"message": msg.Content.(map[string]interface{})["message"],
-						"from":    c.user.Username,
-					},
-					Time: time.Now().Unix(),
-				}
-				c.hub.SendToUser(targetUserID, privateMsg)
-
-				// 同时给自己也发一份
-				c.hub.SendToUser(c.userID, privateMsg)
-			}
-
-		default:
-			// 广播其他类型消息
-			msgBytes, _ := json.Marshal(msg)
-			c.hub.broadcast <- msgBytes
-		}
-	}
-}
-
-func (c *Client) writePump() {
-	ticker := time.NewTicker(54 * time.Second)
-	defer func() {
-		ticker.Stop()
-		c.conn.Close()
-	}()
-
-	for {
-		select {
-		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
-
-			n := len(c.send)
-			for i := 0; i < n; i++ {
-				w.Write(<-c.send)
-			}
-
-			if err := w.Close(); err != nil {
-				return
-			}
-
-		case <-ticker.C:
-			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
-		}
-	}
+	// 连接期间令牌被注销或用户被冻结、删除时断开连接
+	hub.ServeChecked(w, r, user, valid)
 }
 
 // API: 获取在线用户列表
-func onlineUsersHandler(hub *Hub) http.HandlerFunc {
+func onlineUsersHandler(hub *socket.Hub) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		users := hub.GetOnlineUsers()
 		w.Header().Set("Content-Type", "application/json")
@@ -330,7 +70,7 @@ func onlineUsersHandler(hub *Hub) http.HandlerFunc {
 }
 
 // API: 向特定用户发送消息（管理员功能）
-func sendMessageHandler(hub *Hub) http.HandlerFunc {
+func sendMessageHandler(hub *socket.Hub) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -347,9 +87,9 @@ func sendMessageHandler(hub *Hub) http.HandlerFunc {
 			return
 		}
 
-		msg := Message{
+		msg := socket.Message{
 			Type:    "system_message",
-			From:    User{ID: "system", Username: "系统", Role: "system"},
+			From:    socket.SystemUser,
 			Content: request.Message,
 			Time:    time.Now().Unix(),
 		}
@@ -368,10 +108,28 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(`{"status": "ok", "service": "websocket-server"}`))
 }
 
+// InitWebsocket 创建hub并赋值 global.GVA_WS 后在协程中启动WebSocket服务 需在redis初始化之后、处理请求之前调用
 func InitWebsocket() {
-	hub := NewHub()
+	cfg := global.GVA_CONFIG.Websocket
+	hub := socket.NewHub()
+	if cfg.UseCluster {
+		if global.GVA_REDIS == nil {
+			global.GVA_LOG.Warn("websocket集群模式需要开启redis 已降级为单实例模式")
+		} else if err := hub.EnableCluster(global.GVA_REDIS, socket.ClusterOptions{
+			Prefix:            cfg.ChannelPrefix,
+			HeartbeatInterval: time.Duration(cfg.HeartbeatInterval) * time.Second,
+			InstanceTTL:       time.Duration(cfg.InstanceTTL) * time.Second,
+		}); err != nil {
+			global.GVA_LOG.Error("websocket开启集群模式失败 已降级为单实例模式", zap.Error(err))
+		}
+	}
 	go hub.Run()
+	global.GVA_WS = hub
+	go serveWebsocket(hub, cfg.Addr)
+}
 
+// serveWebsocket 启动WebSocket服务 该函数会阻塞
+func serveWebsocket(hub *socket.Hub, addr int) {
 	fs := http.FileServer(http.Dir("./static"))
 	http.Handle("/", fs)
 
@@ -382,11 +140,13 @@ func InitWebsocket() {
 	http.HandleFunc("/api/online-users", onlineUsersHandler(hub))
 	http.HandleFunc("/api/send-message", sendMessageHandler(hub))
 
-	port := ":8081"
-	log.Printf("WebSocket 服务器启动在 http://localhost%s", port)
-	log.Printf("WebSocket 端点: ws://localhost%s/ws?token=user_123", port)
+	if addr == 0 {
+		addr = 8081
+	}
+	port := fmt.Sprintf(":%d", addr)
+	global.GVA_LOG.Info("WebSocket 服务器启动", zap.String("address", "http://localhost"+port), zap.String("endpoint", "ws://localhost"+port+"/ws?token={x-token}"))
 
 	if err := http.ListenAndServe(port, nil); err != nil {
-		log.Fatal("服务器启动失败: ", err)
+		global.GVA_LOG.Error("WebSocket 服务器启动失败", zap.Error(err))
 	}
 }
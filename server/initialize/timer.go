@@ -24,6 +24,19 @@ func Timer() {
 			fmt.Println("add timer error:", err)
 		}
 
+		// 用户操作日志索引滚动及过期清理
+		if global.GVA_CONFIG.System.UseElasticsearch {
+			_, err = global.GVA_Timer.AddTaskByFunc("UserActionLogIndex", "0 0 0 * * *", func() {
+				err := task.UserActionLogIndex()
+				if err != nil {
+					fmt.Println("timer error:", err)
+				}
+			}, "用户操作日志索引滚动及过期清理", option...)
+			if err != nil {
+				fmt.Println("add timer error:", err)
+			}
+		}
+
 		// 其他定时任务定在这里 参考上方使用方法
 
 		//_, err := global.GVA_Timer.AddTaskByFunc("定时任务标识", "corn表达式", func() {
@@ -0,0 +1,21 @@
+package initialize
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"go.uber.org/zap"
+)
+
+// Webhook 订阅业务事件 为订阅了事件的webhook创建推送记录 并启动推送协程
+func Webhook() {
+	if !global.GVA_CONFIG.Webhook.Enable || global.GVA_DB == nil {
+		return
+	}
+	utils.GlobalSystemEvents.Subscribe(func(ev utils.Event) {
+		if err := system.WebhookServiceApp.DispatchEvent(ev); err != nil {
+			global.GVA_LOG.Error("创建webhook推送失败!", zap.String("event", ev.Type), zap.Error(err))
+		}
+	})
+	system.WebhookServiceApp.StartWebhookDispatcher()
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/core"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/initialize"
@@ -28,6 +30,17 @@ import (
 // @name                        x-token
 // @BasePath                    /
 func main() {
+	// 子命令: gva gen -f module.yaml 离线生成代码; gva keygen 生成插件签名密钥; gva sdk 生成前端TypeScript SDK
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "gen":
+			os.Exit(core.RunGen(os.Args[2:]))
+		case "keygen":
+			os.Exit(core.RunKeygen(os.Args[2:]))
+		case "sdk":
+			os.Exit(core.RunSdk(os.Args[2:]))
+		}
+	}
 	// 初始化系统
 	initializeSystem()
 	// 运行服务器
@@ -53,5 +66,4 @@ func initializeSystem() {
 	if global.GVA_DB != nil {
 		initialize.RegisterTables() // 初始化表
 	}
-	initialize.InitWebsocket()
 }
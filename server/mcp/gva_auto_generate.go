@@ -173,6 +173,7 @@ func (t *AutomationModuleAnalyzer) New() mcp.Tool {
     "isAdd": "是否新增(bool) 固定为false",
     "generateWeb": "是否生成前端(bool)",
     "generateServer": "是否生成后端(bool)",
+    "generateTest": "是否生成测试(bool) 仅package模板",
     "fields": [{
       "fieldName": "字段名(string)必须大写开头",
       "fieldDesc": "字段描述(string)",
@@ -805,6 +806,7 @@ func (t *AutomationModuleAnalyzer) handleAnalyze(ctx context.Context, request mc
     "isAdd": false,
     "generateWeb": true,
     "generateServer": true,
+    "generateTest": false,
     "fields": [{
       "fieldName": "字段名（必须大写开头）",
       "fieldDesc": "字段描述",
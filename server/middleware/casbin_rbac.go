@@ -19,10 +19,15 @@ func CasbinHandler() gin.HandlerFunc {
 		obj := strings.TrimPrefix(path, global.GVA_CONFIG.System.RouterPrefix)
 		// 获取请求方法
 		act := c.Request.Method
-		// 获取用户的角色
-		sub := strconv.Itoa(int(waitUse.AuthorityId))
+		// 获取用户的角色 多角色模式下任一角色有权限即可访问
 		e := utils.GetCasbin() // 判断策略中是否存在
-		success, _ := e.Enforce(sub, obj, act)
+		success := false
+		for _, authorityId := range utils.ClaimsAuthorityIds(waitUse) {
+			sub := strconv.Itoa(int(authorityId))
+			if success, _ = e.Enforce(sub, utils.CasbinDomain, obj, act); success {
+				break
+			}
+		}
 		if !success {
 			response.FailWithDetailed(gin.H{}, "权限不足", c)
 			c.Abort()
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/alert"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorAlert 记录出错的请求 由告警服务按接口和错误特征聚合后定期入库并通知
+// 响应状态码大于等于 alert.min-status、c.Error 记录了错误或发生panic时记为错误 需放在 Recovery 之后
+func ErrorAlert() gin.HandlerFunc {
+	minStatus := system.AlertMinStatus()
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				recordError(c, http.StatusInternalServerError, fmt.Sprint("panic: ", r))
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		message := c.Errors.ByType(gin.ErrorTypePrivate).String()
+		if status < minStatus && message == "" {
+			return
+		}
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		recordError(c, status, message)
+	}
+}
+
+func recordError(c *gin.Context, status int, message string) {
+	route := c.FullPath()
+	if route == "" {
+		route = c.Request.URL.Path
+	}
+	alert.Default().Record(alert.Event{
+		Method:  c.Request.Method,
+		Route:   route,
+		Status:  status,
+		Message: message,
+		IP:      c.ClientIP(),
+		Time:    time.Now(),
+	})
+}
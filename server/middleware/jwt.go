@@ -3,6 +3,7 @@ package middleware
 import (
 	"errors"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/golang-jwt/jwt/v5"
 	"strconv"
@@ -12,6 +13,8 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+var jwtService = service.ServiceGroupApp.SystemServiceGroup.JwtService
+
 func JWTAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 我们这里jwt鉴权取头部信息 x-token 登录时回返回token信息 这里前端需要把token存储到cookie或者本地localStorage中 不过需要跟后端协商过期时间 可以约定刷新令牌或者重新登录
@@ -43,14 +46,13 @@ func JWTAuth() gin.HandlerFunc {
 			return
 		}
 
-		// 已登录用户被管理员禁用 需要使该用户的jwt失效 此处比较消耗性能 如果需要 请自行打开
-		// 用户被删除的逻辑 需要优化 此处比较消耗性能 如果需要 请自行打开
-
-		//if user, err := userService.FindUserByUuid(claims.UUID.String()); err != nil || user.Enable == 2 {
-		//	_ = jwtService.JsonInBlacklist(system.JwtBlacklist{Jwt: token})
-		//	response.FailWithDetailed(gin.H{"reload": true}, err.Error(), c)
-		//	c.Abort()
-		//}
+		// 用户被禁用、删除、修改角色或重置密码后 令牌版本更新 之前签发的jwt失效
+		if utils.TokenRevoked(claims) {
+			response.NoAuth("登录状态已失效，请重新登录", c)
+			utils.ClearToken(c)
+			c.Abort()
+			return
+		}
 		c.Set("claims", claims)
 		if claims.ExpiresAt.Unix()-time.Now().Unix() < claims.BufferTime {
 			dr, _ := utils.ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
@@ -83,6 +85,5 @@ func JWTAuth() gin.HandlerFunc {
 //@return: bool
 
 func isBlacklist(jwt string) bool {
-	_, ok := global.BlackCache.Get(jwt)
-	return ok
+	return jwtService.IsBlacklist(jwt)
 }
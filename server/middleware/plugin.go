@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"github.com/gin-gonic/gin"
+)
+
+var pluginRuntimeService = service.ServiceGroupApp.SystemServiceGroup.PluginRuntimeService
+
+// PluginGate 拦截已停用的v2插件的路由 返回503及插件名
+func PluginGate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name, enabled := pluginRuntimeService.RouteState(c.Request.Method, c.FullPath())
+		if !enabled {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, response.Response{
+				Code: response.ERROR,
+				Data: gin.H{"plugin": name},
+				Msg:  fmt.Sprintf("插件[%s]已停用, 请联系管理员启用", name),
+			})
+			return
+		}
+		c.Next()
+	}
+}
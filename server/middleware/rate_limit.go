@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ratelimit"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RateLimit 按限流规则拦截请求 超出限制时返回429及Retry-After 计数存储出错时放行
+// 需放在 JWTAuth 之后 以便按用户和角色限流 未登录的请求只受IP及接口维度的规则限制
+func RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !global.GVA_CONFIG.RateLimit.Enable {
+			c.Next()
+			return
+		}
+		req := ratelimit.Request{
+			IP:     c.ClientIP(),
+			Method: c.Request.Method,
+			Path:   strings.TrimPrefix(c.FullPath(), global.GVA_CONFIG.System.RouterPrefix),
+		}
+		if claims, exists := c.Get("claims"); exists {
+			waitUse := claims.(*systemReq.CustomClaims)
+			req.UserID, req.AuthorityId = waitUse.BaseClaims.ID, waitUse.AuthorityId
+		}
+		decision, err := ratelimit.Default().Allow(c.Request.Context(), req)
+		if err != nil {
+			global.GVA_LOG.Error("限流计数失败!", zap.Error(err))
+		}
+		if decision.Allowed {
+			c.Next()
+			return
+		}
+		retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, response.Response{
+			Code: response.ERROR,
+			Data: gin.H{"retryAfter": retryAfter, "rule": decision.Rule.Name},
+			Msg:  "请求过于频繁, 请" + strconv.Itoa(retryAfter) + "秒后再试",
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeProbeKey 探测请求的 context key 外部请求无法携带
+type routeProbeKey struct{}
+
+type routeProbe struct {
+	fullPath string
+	names    []string
+}
+
+// RouteProbe 记录探测请求匹配到的处理链后中断 需在所有路由注册前注册到 gin.Engine
+// 路由的中间件不在 gin.RoutesInfo 中 生成 OpenAPI 文档时通过探测判断接口是否需要鉴权
+func RouteProbe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if probe, ok := c.Request.Context().Value(routeProbeKey{}).(*routeProbe); ok {
+			probe.fullPath = c.FullPath()
+			probe.names = c.HandlerNames()
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+var probeParam = regexp.MustCompile(`[:*]\w+`)
+
+// ProbeRoute 返回路由的处理链(中间件及处理函数名) 不执行任何处理函数 路由不存在时返回nil
+func ProbeRoute(engine *gin.Engine, method, fullPath string) []string {
+	probe := &routeProbe{}
+	ctx := context.WithValue(context.Background(), routeProbeKey{}, probe)
+	req, err := http.NewRequestWithContext(ctx, method, probeParam.ReplaceAllString(fullPath, "x"), nil)
+	if err != nil {
+		return nil
+	}
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	if probe.fullPath != fullPath {
+		return nil
+	}
+	return probe.names
+}
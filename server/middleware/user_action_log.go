@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// apiMetaCache 缓存 sys_apis 中路由对应的分组和描述 定期从数据库刷新
+var apiMetaCache = struct {
+	sync.RWMutex
+	items    map[string]system.SysApi
+	loadedAt time.Time
+}{}
+
+const apiMetaTTL = 5 * time.Minute
+
+func lookupApiMeta(path, method string) (system.SysApi, bool) {
+	key := method + " " + path
+	apiMetaCache.RLock()
+	fresh := apiMetaCache.items != nil && time.Since(apiMetaCache.loadedAt) < apiMetaTTL
+	api, ok := apiMetaCache.items[key]
+	apiMetaCache.RUnlock()
+	if fresh {
+		return api, ok
+	}
+
+	apiMetaCache.Lock()
+	defer apiMetaCache.Unlock()
+	if apiMetaCache.items == nil || time.Since(apiMetaCache.loadedAt) >= apiMetaTTL {
+		var apis []system.SysApi
+		if err := global.GVA_DB.Select("path", "method", "api_group", "description").Find(&apis).Error; err != nil {
+			global.GVA_LOG.Error("加载api信息失败", zap.Error(err))
+		} else {
+			items := make(map[string]system.SysApi, len(apis))
+			for _, a := range apis {
+				items[a.Method+" "+a.Path] = a
+			}
+			apiMetaCache.items = items
+		}
+		// 加载失败时同样等待下一个周期 避免每个请求都查询数据库
+		apiMetaCache.loadedAt = time.Now()
+	}
+	api, ok = apiMetaCache.items[key]
+	return api, ok
+}
+
+// actionByMethod 路由未登记在 sys_apis 中时按请求方法推断操作动作
+var actionByMethod = map[string]string{
+	http.MethodGet:    "query",
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+// UserActionLog 自动记录用户操作日志
+// 操作动作和模块取自匹配路由在 sys_apis 中登记的描述和分组 日志异步批量写入用户操作日志存储
+func UserActionLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet && !global.GVA_CONFIG.ActionLog.LogGet {
+			c.Next()
+			return
+		}
+
+		var body []byte
+		if c.Request.Method == http.MethodGet {
+			body = []byte(c.Request.URL.RawQuery)
+		} else if !strings.Contains(c.GetHeader("Content-Type"), "multipart/form-data") {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				global.GVA_LOG.Error("read body from request error:", zap.Error(err))
+			} else {
+				c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+			}
+		} else {
+			body = []byte("[文件]")
+		}
+
+		writer := responseBodyWriter{
+			ResponseWriter: c.Writer,
+			body:           &bytes.Buffer{},
+		}
+		c.Writer = writer
+		now := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		route = strings.TrimPrefix(route, global.GVA_CONFIG.System.RouterPrefix)
+
+		log := system.UserActionLog{
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.Path,
+			IP:         c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+			Status:     c.Writer.Status(),
+			Latency:    time.Since(now).Milliseconds(),
+			Request:    truncateActionLog(body),
+			Response:   truncateActionLog(writer.body.Bytes()),
+			ErrorMsg:   c.Errors.ByType(gin.ErrorTypePrivate).String(),
+			CreateTime: now,
+		}
+
+		if api, ok := lookupApiMeta(route, c.Request.Method); ok {
+			log.Module = api.ApiGroup
+			log.Action = api.Description
+		} else {
+			log.Module = strings.Split(strings.TrimPrefix(route, "/"), "/")[0]
+			log.Action = actionByMethod[c.Request.Method]
+		}
+
+		if claims := actionLogClaims(c); claims != nil {
+			log.UserID = claims.BaseClaims.ID
+			log.Username = claims.Username
+		}
+
+		// 业务失败时响应仍为200 从统一响应体中提取错误信息
+		if log.ErrorMsg == "" {
+			var resp struct {
+				Code *int   `json:"code"`
+				Msg  string `json:"msg"`
+			}
+			if json.Unmarshal(writer.body.Bytes(), &resp) == nil && resp.Code != nil && *resp.Code != 0 {
+				log.ErrorMsg = resp.Msg
+			}
+		}
+
+		service.ServiceGroupApp.SystemServiceGroup.UserActionLogService.Enqueue(log)
+	}
+}
+
+func actionLogClaims(c *gin.Context) *systemReq.CustomClaims {
+	if claims, exists := c.Get("claims"); exists {
+		if cl, ok := claims.(*systemReq.CustomClaims); ok {
+			return cl
+		}
+	}
+	cl, _ := utils.GetClaims(c)
+	return cl
+}
+
+func truncateActionLog(data []byte) string {
+	if len(data) > bufferSize {
+		return "[超出记录长度]"
+	}
+	return string(data)
+}
@@ -13,9 +13,11 @@ type CustomClaims struct {
 }
 
 type BaseClaims struct {
-	UUID        uuid.UUID
-	ID          uint
-	Username    string
-	NickName    string
-	AuthorityId uint
+	UUID         uuid.UUID
+	ID           uint
+	Username     string
+	NickName     string
+	AuthorityId  uint
+	AuthorityIds []uint // 用户的全部角色 多角色模式下鉴权使用
+	TokenVersion int64  // 签发时用户的令牌版本 小于当前版本时令牌失效
 }
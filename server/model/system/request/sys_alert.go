@@ -0,0 +1,24 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+// AlertSearch 告警列表
+type AlertSearch struct {
+	State          string     `json:"state" form:"state"`
+	Route          string     `json:"route" form:"route"`
+	Status         int        `json:"status" form:"status"`
+	StartCreatedAt *time.Time `json:"startCreatedAt" form:"startCreatedAt"`
+	EndCreatedAt   *time.Time `json:"endCreatedAt" form:"endCreatedAt"`
+	request.PageInfo
+}
+
+// AlertChannelSearch 告警渠道列表
+type AlertChannelSearch struct {
+	Name string `json:"name" form:"name"`
+	Type string `json:"type" form:"type"`
+	request.PageInfo
+}
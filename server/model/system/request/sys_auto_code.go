@@ -7,6 +7,7 @@ import (
 	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/pkg/errors"
 	"go/token"
+	"sort"
 	"strings"
 )
 
@@ -31,8 +32,10 @@ type AutoCode struct {
 	TreeJson            string                 `json:"treeJson" example:"展示的树json字段"`       // 展示的树json字段
 	IsAdd               bool                   `json:"isAdd" example:"false"`               // 是否新增
 	Fields              []*AutoCodeField       `json:"fields"`
+	SubTables           []*AutoCodeSubTable    `json:"subTables"`                     // 子表 与主表一对多
 	GenerateWeb         bool                   `json:"generateWeb" example:"true"`    // 是否生成web
 	GenerateServer      bool                   `json:"generateServer" example:"true"` // 是否生成server
+	GenerateTest        bool                   `json:"generateTest" example:"false"`  // 是否生成测试
 	Module              string                 `json:"-"`
 	DictTypes           []string               `json:"-"`
 	PrimaryField        *AutoCodeField         `json:"primaryField"`
@@ -47,6 +50,7 @@ type AutoCode struct {
 	HasSearchTimer      bool                   `json:"-"`
 	HasArray            bool                   `json:"-"`
 	HasExcel            bool                   `json:"-"`
+	HasSubTable         bool                   `json:"-"`
 }
 
 type DataSource struct {
@@ -173,10 +177,14 @@ func (r *AutoCode) Pretreatment() error {
 			r.PrimaryField = r.Fields[i]
 		} // 自定义主键
 	}
+	if err := r.pretreatSubTables(dict); err != nil {
+		return err
+	} // 子表
 	{
 		for key := range dict {
 			r.DictTypes = append(r.DictTypes, key)
 		}
+		sort.Strings(r.DictTypes) // 保证多次生成的结果一致
 	} // DictTypes => 字典
 	{
 		if r.GvaModel {
@@ -248,6 +256,101 @@ type AutoCodeField struct {
 	FieldIndexType  string      `json:"fieldIndexType"`  // 索引类型
 }
 
+// AutoCodeSubTable 主子表中的子表 子表通过外键关联主表ID 与主表在同一事务中保存
+type AutoCodeSubTable struct {
+	StructName       string           `json:"structName" example:"OrderLine"`      // 子表Struct名称
+	TableName        string           `json:"tableName" example:"order_lines"`     // 子表表名
+	Description      string           `json:"description" example:"订单明细"`          // 子表中文名称
+	FieldName        string           `json:"fieldName" example:"Lines"`           // 主表中子表集合的字段名 默认为子表Struct名称+List
+	FieldJson        string           `json:"fieldJson" example:"lines"`           // 主表中子表集合的json名称
+	ForeignKey       string           `json:"foreignKey" example:"OrderID"`        // 外键字段名 默认为主表Struct名称+ID
+	ForeignKeyJson   string           `json:"-"`                                   // 外键json名称
+	ForeignKeyColumn string           `json:"foreignKeyColumn" example:"order_id"` // 外键列名 默认为外键字段名的下划线形式
+	Fields           []*AutoCodeField `json:"fields"`
+}
+
+// subTableFieldTypes 子表支持的字段类型 子表在表格中行内编辑 不支持文件、富文本等复杂类型
+var subTableFieldTypes = map[string]bool{
+	"string": true, "int": true, "bool": true, "float64": true, "time.Time": true, "enum": true,
+}
+
+// pretreatSubTables 子表预处理 补全外键等默认值 并收集子表使用的字典
+func (r *AutoCode) pretreatSubTables(dict map[string]string) error {
+	if len(r.SubTables) == 0 {
+		return nil
+	}
+	if !r.GvaModel {
+		return errors.New("子表仅支持使用gvaModel的主表!")
+	}
+	r.HasSubTable = true
+	for i, sub := range r.SubTables {
+		if sub == nil || sub.StructName == "" || sub.TableName == "" {
+			return errors.Errorf("第%d个子表的structName和tableName不能为空!", i+1)
+		}
+		if sub.StructName == r.StructName {
+			return errors.Errorf("子表[%s]不能与主表同名!", sub.StructName)
+		}
+		if len(sub.Fields) == 0 {
+			return errors.Errorf("子表[%s]至少需要一个字段!", sub.StructName)
+		}
+		if sub.Description == "" {
+			sub.Description = sub.StructName
+		}
+		if sub.FieldName == "" {
+			sub.FieldName = sub.StructName + "List"
+		}
+		if sub.FieldJson == "" {
+			sub.FieldJson = lowerFirst(sub.FieldName)
+		}
+		if sub.ForeignKey == "" {
+			sub.ForeignKey = r.StructName + "ID"
+		}
+		sub.ForeignKeyJson = lowerFirst(sub.ForeignKey)
+		if sub.ForeignKeyColumn == "" {
+			sub.ForeignKeyColumn = snakeCase(sub.ForeignKey)
+		}
+		for _, field := range sub.Fields {
+			if !subTableFieldTypes[field.FieldType] {
+				return errors.Errorf("子表[%s]字段[%s]不支持%s类型!", sub.StructName, field.FieldName, field.FieldType)
+			}
+			if field.FieldName == sub.ForeignKey {
+				return errors.Errorf("子表[%s]字段[%s]与外键重名!", sub.StructName, field.FieldName)
+			}
+			if field.FieldType == "time.Time" {
+				r.HasTimer = true
+			}
+			if field.DictType != "" {
+				dict[field.DictType] = ""
+			}
+		}
+	}
+	return nil
+}
+
+// lowerFirst 首字母小写
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// snakeCase 驼峰转下划线 连续大写视为一个单词 如 OrderID => order_id
+func snakeCase(s string) string {
+	var builder strings.Builder
+	runes := []rune(s)
+	for i, c := range runes {
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 && (runes[i-1] < 'A' || runes[i-1] > 'Z' || (i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z')) {
+				builder.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		builder.WriteRune(c)
+	}
+	return builder.String()
+}
+
 type AutoFunc struct {
 	Package         string `json:"package"`
 	FuncName        string `json:"funcName"`        // 方法名称
@@ -280,7 +383,17 @@ type InitApi struct {
 	APIs     []uint `json:"apis"`
 }
 
+// UninstallPlugin 卸载插件
+type UninstallPlugin struct {
+	Name string `json:"name" example:"插件名"`
+}
+
 type LLMAutoCode struct {
 	Prompt string `json:"prompt" form:"prompt" gorm:"column:prompt;comment:提示语;type:text;"` //提示语
 	Mode   string `json:"mode" form:"mode" gorm:"column:mode;comment:模式;type:text;"`        //模式
 }
+
+// PluginRuntime 启停、卸载v2插件
+type PluginRuntime struct {
+	Name string `json:"name" form:"name" binding:"required"` // 插件名
+}
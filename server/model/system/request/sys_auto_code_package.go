@@ -2,15 +2,17 @@ package request
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	common "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
 )
 
 type SysAutoCodePackageCreate struct {
-	Desc        string `json:"desc" example:"描述"`
-	Label       string `json:"label" example:"展示名"`
-	Template    string `json:"template"  example:"模版"`
-	PackageName string `json:"packageName" example:"包名"`
-	Module      string `json:"-" example:"模块"`
+	Desc         string `json:"desc" example:"描述"`
+	Label        string `json:"label" example:"展示名"`
+	Template     string `json:"template"  example:"模版"`
+	PackageName  string `json:"packageName" example:"包名"`
+	TemplatePack string `json:"templatePack" example:"模板包"`
+	Module       string `json:"-" example:"模块"`
 }
 
 func (r *SysAutoCodePackageCreate) AutoCode() AutoCode {
@@ -22,10 +24,22 @@ func (r *SysAutoCodePackageCreate) AutoCode() AutoCode {
 
 func (r *SysAutoCodePackageCreate) Create() model.SysAutoCodePackage {
 	return model.SysAutoCodePackage{
-		Desc:        r.Desc,
-		Label:       r.Label,
-		Template:    r.Template,
-		PackageName: r.PackageName,
-		Module:      global.GVA_CONFIG.AutoCode.Module,
+		Desc:         r.Desc,
+		Label:        r.Label,
+		Template:     r.Template,
+		PackageName:  r.PackageName,
+		TemplatePack: r.TemplatePack,
+		Module:       global.GVA_CONFIG.AutoCode.Module,
 	}
 }
+
+// SysAutoCodePackageTemplatePack 设置package使用的模板包 为空时使用内置模板
+type SysAutoCodePackageTemplatePack struct {
+	common.GetById
+	TemplatePack string `json:"templatePack" example:"模板包"`
+}
+
+// SysAutoCodeTemplatePack 指定模板包
+type SysAutoCodeTemplatePack struct {
+	Name string `json:"name" example:"模板包名称"`
+}
@@ -0,0 +1,214 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// AutoCodeSpecVersion 当前支持的模块描述文件版本
+const AutoCodeSpecVersion = "v1"
+
+// AutoCodeSpec 模块描述文件 除 version、template 和 templatePack 外字段与 AutoCode 请求一致
+// 用于 gva gen 命令脱离运行中的服务离线生成代码
+type AutoCodeSpec struct {
+	Version      string `json:"version"`      // 描述文件版本
+	Template     string `json:"template"`     // 包模板 package 或 plugin 为空时为 package
+	TemplatePack string `json:"templatePack"` // 模板包 为空时使用内置模板
+	AutoCode
+}
+
+var (
+	autoCodeFieldTypes = map[string]bool{
+		"string": true, "richtext": true, "int": true, "bool": true, "float64": true, "time.Time": true, "enum": true,
+		"picture": true, "pictures": true, "video": true, "file": true, "json": true, "array": true,
+	}
+	autoCodeSearchTypes = map[string]bool{
+		"": true, "=": true, "<>": true, ">": true, "<": true, "LIKE": true, "BETWEEN": true, "NOT BETWEEN": true,
+	}
+	autoCodeIndexTypes = map[string]bool{"": true, "index": true, "uniqueIndex": true}
+	autoCodeEnumRegexp = regexp.MustCompile(`^('[^']+'\s*,\s*)*'[^']+'$`)
+)
+
+// ParseAutoCodeSpec 解析YAML或JSON格式的模块描述文件 未知字段视为错误
+func ParseAutoCodeSpec(data []byte) (*AutoCodeSpec, error) {
+	// YAML 为 JSON 的超集 统一按YAML读取后转为JSON 以复用 AutoCode 的json标签
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "解析模块描述文件失败!")
+	}
+	if raw == nil {
+		return nil, errors.New("模块描述文件为空!")
+	}
+	body, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "解析模块描述文件失败!")
+	}
+	var spec AutoCodeSpec
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&spec); err != nil {
+		return nil, errors.Wrap(err, "解析模块描述文件失败!")
+	}
+	if spec.Template == "" {
+		spec.Template = "package"
+	}
+	return &spec, nil
+}
+
+// Validate 校验模块描述文件 返回全部不合法项
+func (s *AutoCodeSpec) Validate() error {
+	var errs []string
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if s.Version != AutoCodeSpecVersion {
+		add("不支持的描述文件版本[%s], 当前版本为%s", s.Version, AutoCodeSpecVersion)
+	}
+	if s.Template != "package" && s.Template != "plugin" {
+		add("template只能为package或plugin")
+	}
+	if !token.IsIdentifier(s.Package) || token.IsKeyword(s.Package) {
+		add("package[%s]不是合法的go包名", s.Package)
+	}
+	if !token.IsIdentifier(s.StructName) || !token.IsExported(s.StructName) {
+		add("structName[%s]必须为大写开头的go标识符", s.StructName)
+	}
+	if !token.IsIdentifier(s.Abbreviation) {
+		add("abbreviation[%s]不是合法的go标识符", s.Abbreviation)
+	}
+	if s.TableName == "" {
+		add("tableName不能为空")
+	}
+	if s.PackageName == "" {
+		add("packageName不能为空")
+	}
+	if s.HumpPackageName == "" {
+		add("humpPackageName不能为空")
+	}
+	if s.Description == "" {
+		add("description不能为空")
+	}
+	if !s.GenerateServer && !s.GenerateWeb {
+		add("generateServer与generateWeb至少开启一项")
+	}
+	if s.GenerateTest && (s.Template != "package" || !s.GenerateServer || s.OnlyTemplate) {
+		add("generateTest仅支持package模板且需要生成完整的server代码")
+	}
+	if len(s.Fields) == 0 {
+		add("fields不能为空")
+	}
+
+	hasPrimary := validateAutoCodeFields("fields", s.Fields, add)
+	if !s.GvaModel && !s.IsAdd && !hasPrimary && s.PrimaryField == nil {
+		add("未使用gvaModel时需要指定主键字段")
+	}
+	if len(s.SubTables) > 0 && !s.GvaModel {
+		add("子表仅支持使用gvaModel的主表")
+	}
+	if len(s.SubTables) > 0 && s.Template != "package" {
+		add("子表暂仅支持package模板")
+	}
+	for i, sub := range s.SubTables {
+		if sub == nil {
+			add("subTables[%d]为空", i)
+			continue
+		}
+		prefix := fmt.Sprintf("subTables[%d](%s)", i, sub.StructName)
+		if !token.IsIdentifier(sub.StructName) || !token.IsExported(sub.StructName) {
+			add("%s structName必须为大写开头的go标识符", prefix)
+		}
+		if sub.TableName == "" {
+			add("%s tableName不能为空", prefix)
+		}
+		if len(sub.Fields) == 0 {
+			add("%s fields不能为空", prefix)
+		}
+		validateAutoCodeFields(prefix+".fields", sub.Fields, add)
+		for _, field := range sub.Fields {
+			if field != nil && !subTableFieldTypes[field.FieldType] {
+				add("%s 字段%s不支持%s类型", prefix, field.FieldName, field.FieldType)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New("模块描述文件校验失败:\n  " + strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// validateAutoCodeFields 校验字段列表 返回是否包含主键字段
+func validateAutoCodeFields(name string, fields []*AutoCodeField, add func(format string, args ...interface{})) (hasPrimary bool) {
+	names := make(map[string]bool, len(fields))
+	jsons := make(map[string]bool, len(fields))
+	columns := make(map[string]bool, len(fields))
+	for i, field := range fields {
+		if field == nil {
+			add("%s[%d]为空", name, i)
+			continue
+		}
+		prefix := fmt.Sprintf("%s[%d](%s)", name, i, field.FieldName)
+		if !token.IsIdentifier(field.FieldName) || !token.IsExported(field.FieldName) {
+			add("%s fieldName必须为大写开头的go标识符", prefix)
+		} else if names[field.FieldName] {
+			add("%s fieldName重复", prefix)
+		}
+		names[field.FieldName] = true
+		if field.FieldJson == "" {
+			add("%s fieldJson不能为空", prefix)
+		} else if jsons[field.FieldJson] {
+			add("%s fieldJson重复", prefix)
+		}
+		jsons[field.FieldJson] = true
+		if field.ColumnName == "" {
+			add("%s columnName不能为空", prefix)
+		} else if columns[field.ColumnName] {
+			add("%s columnName重复", prefix)
+		}
+		columns[field.ColumnName] = true
+		if !autoCodeFieldTypes[field.FieldType] {
+			add("%s 不支持的fieldType[%s]", prefix, field.FieldType)
+		}
+		if !autoCodeSearchTypes[field.FieldSearchType] {
+			add("%s 不支持的fieldSearchType[%s]", prefix, field.FieldSearchType)
+		} else if msg := autoCodeSearchTypeAllowed(field.FieldType, field.FieldSearchType); msg != "" {
+			add("%s %s", prefix, msg)
+		}
+		if !autoCodeIndexTypes[field.FieldIndexType] {
+			add("%s 不支持的fieldIndexType[%s]", prefix, field.FieldIndexType)
+		}
+		if field.FieldType == "enum" && !autoCodeEnumRegexp.MatchString(field.DataTypeLong) {
+			add("%s 枚举值dataTypeLong格式应为'值1','值2'", prefix)
+		}
+		if field.DataSource != nil && field.DataSource.Table != "" && (field.DataSource.Label == "" || field.DataSource.Value == "") {
+			add("%s dataSource需同时指定label和value", prefix)
+		}
+		if field.PrimaryKey {
+			hasPrimary = true
+		}
+	}
+	return hasPrimary
+}
+
+// autoCodeSearchTypeAllowed 与前端字段配置的搜索条件限制保持一致
+func autoCodeSearchTypeAllowed(fieldType, searchType string) string {
+	switch {
+	case searchType == "":
+		return ""
+	case fieldType == "richtext" && searchType != "LIKE":
+		return "richtext字段只支持LIKE搜索"
+	case fieldType != "string" && fieldType != "richtext" && searchType == "LIKE":
+		return "只有string字段支持LIKE搜索"
+	case (searchType == "BETWEEN" || searchType == "NOT BETWEEN") && fieldType != "int" && fieldType != "time.Time" && fieldType != "float64":
+		return "只有int、float64、time.Time字段支持BETWEEN搜索"
+	}
+	return ""
+}
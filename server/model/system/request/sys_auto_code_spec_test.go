@@ -0,0 +1,93 @@
+package request
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAutoCodeSpec(t *testing.T) {
+	spec, err := ParseAutoCodeSpec([]byte(`
+version: v1
+package: example
+tableName: exa_books
+structName: Book
+packageName: book
+humpPackageName: book
+description: 图书
+abbreviation: book
+gvaModel: true
+generateServer: true
+fields:
+  - fieldName: Title
+    fieldType: string
+    fieldJson: title
+    columnName: title
+    dataTypeLong: "64"
+    fieldSearchType: LIKE
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Template != "package" {
+		t.Errorf("Template = %q, want package", spec.Template)
+	}
+	if len(spec.Fields) != 1 || spec.Fields[0].DataTypeLong != "64" {
+		t.Fatalf("Fields = %+v", spec.Fields)
+	}
+	if err = spec.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	json := `{"version":"v1","package":"example","structName":"Book","tableName":"exa_books"}`
+	if _, err = ParseAutoCodeSpec([]byte(json)); err != nil {
+		t.Fatalf("json spec: %v", err)
+	}
+
+	if _, err = ParseAutoCodeSpec([]byte("version: v1\nstructNmae: Book\n")); err == nil {
+		t.Error("unknown field should be rejected")
+	}
+}
+
+func TestAutoCodeSpecValidate(t *testing.T) {
+	spec := AutoCodeSpec{
+		Version:  AutoCodeSpecVersion,
+		Template: "package",
+		AutoCode: AutoCode{
+			Package:         "example",
+			TableName:       "exa_books",
+			StructName:      "Book",
+			PackageName:     "book",
+			HumpPackageName: "book",
+			Description:     "图书",
+			Abbreviation:    "book",
+			GenerateServer:  true,
+			Fields: []*AutoCodeField{
+				{FieldName: "Title", FieldType: "string", FieldJson: "title", ColumnName: "title", FieldSearchType: "BETWEEN"},
+				{FieldName: "Title", FieldType: "int", FieldJson: "count", ColumnName: "count", FieldSearchType: "LIKE"},
+				{FieldName: "Status", FieldType: "enum", FieldJson: "status", ColumnName: "status", DataTypeLong: "a,b"},
+			},
+		},
+	}
+	err := spec.Validate()
+	if err == nil {
+		t.Fatal("want validation error")
+	}
+	for _, want := range []string{"BETWEEN", "fieldName重复", "LIKE", "枚举值", "主键"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %s", err.Error(), want)
+		}
+	}
+
+	spec.Fields = []*AutoCodeField{
+		{FieldName: "ID", FieldType: "int", FieldJson: "id", ColumnName: "id", PrimaryKey: true, FieldSearchType: "BETWEEN"},
+		{FieldName: "Status", FieldType: "enum", FieldJson: "status", ColumnName: "status", DataTypeLong: "'on', 'off'"},
+	}
+	if err = spec.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	spec.GenerateTest, spec.Template = true, "plugin"
+	if err = spec.Validate(); err == nil || !strings.Contains(err.Error(), "generateTest") {
+		t.Fatalf("generateTest with plugin template should be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package request
+
+import "testing"
+
+func TestAutoCodePretreatSubTables(t *testing.T) {
+	info := AutoCode{
+		Package:    "example",
+		StructName: "Order",
+		GvaModel:   true,
+		Fields:     []*AutoCodeField{{FieldName: "OrderNo", FieldType: "string"}},
+		SubTables: []*AutoCodeSubTable{{
+			StructName: "OrderLine",
+			TableName:  "exa_order_lines",
+			Fields: []*AutoCodeField{
+				{FieldName: "ShipAt", FieldType: "time.Time"},
+				{FieldName: "Unit", FieldType: "string", DictType: "unit"},
+			},
+		}},
+	}
+	if err := info.Pretreatment(); err != nil {
+		t.Fatal(err)
+	}
+	sub := info.SubTables[0]
+	if !info.HasSubTable || !info.HasTimer {
+		t.Errorf("HasSubTable = %v, HasTimer = %v", info.HasSubTable, info.HasTimer)
+	}
+	if sub.FieldName != "OrderLineList" || sub.FieldJson != "orderLineList" {
+		t.Errorf("FieldName = %s, FieldJson = %s", sub.FieldName, sub.FieldJson)
+	}
+	if sub.ForeignKey != "OrderID" || sub.ForeignKeyJson != "orderID" || sub.ForeignKeyColumn != "order_id" {
+		t.Errorf("ForeignKey = %s, ForeignKeyJson = %s, ForeignKeyColumn = %s", sub.ForeignKey, sub.ForeignKeyJson, sub.ForeignKeyColumn)
+	}
+	if len(info.DictTypes) != 1 || info.DictTypes[0] != "unit" {
+		t.Errorf("DictTypes = %v", info.DictTypes)
+	}
+
+	info.SubTables[0].Fields = append(info.SubTables[0].Fields, &AutoCodeField{FieldName: "Photo", FieldType: "picture"})
+	if err := info.Pretreatment(); err == nil {
+		t.Error("picture field in sub table should be rejected")
+	}
+
+	info.GvaModel = false
+	info.SubTables[0].Fields = info.SubTables[0].Fields[:1]
+	if err := info.Pretreatment(); err == nil {
+		t.Error("sub table without gvaModel should be rejected")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"OrderID":     "order_id",
+		"UserOrderID": "user_order_id",
+		"HTTPServer":  "http_server",
+		"id":          "id",
+	} {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
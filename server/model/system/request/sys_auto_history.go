@@ -1,8 +1,12 @@
 package request
 
 import (
+	"encoding/json"
+	"fmt"
+
 	common "github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/pkg/errors"
 )
 
 type SysAutoHistoryCreate struct {
@@ -55,3 +59,96 @@ func (r *SysAutoHistoryRollBack) ApiIds(entity model.SysAutoCodeHistory) common.
 	}
 	return common.IdsReq{Ids: ids}
 }
+
+// SysAutoHistoryUpdate 按新的结构化信息更新已生成的模块
+type SysAutoHistoryUpdate struct {
+	common.GetById
+	AutoCode AutoCode `json:"autoCode"` // 更新后的结构化信息
+}
+
+// Changes 与生成时的结构化信息比较 返回本次更新的内容
+// 只支持新增字段、为已有字段增加或修改搜索条件及字典 其余修改会导致已生成的代码无法安全合并
+func (r *SysAutoHistoryUpdate) Changes(old AutoCode) ([]string, error) {
+	info := r.AutoCode
+	modules := []struct {
+		name          string
+		before, after any
+	}{
+		{"包名", old.Package, info.Package},
+		{"表名", old.TableName, info.TableName},
+		{"业务库", old.BusinessDB, info.BusinessDB},
+		{"结构体名称", old.StructName, info.StructName},
+		{"结构体中文名称", old.Description, info.Description},
+		{"结构体简称", old.Abbreviation, info.Abbreviation},
+		{"文件名称", old.PackageName, info.PackageName},
+		{"go文件名称", old.HumpPackageName, info.HumpPackageName},
+		{"使用gva默认Model", old.GvaModel, info.GvaModel},
+		{"树形结构", old.IsTree, info.IsTree},
+		{"树形展示字段", old.TreeJson, info.TreeJson},
+		{"仅生成模板", old.OnlyTemplate, info.OnlyTemplate},
+		{"生成web", old.GenerateWeb, info.GenerateWeb},
+		{"生成server", old.GenerateServer, info.GenerateServer},
+		{"创建资源标识", old.AutoCreateResource, info.AutoCreateResource},
+	}
+	for _, module := range modules {
+		if module.before != module.after {
+			return nil, errors.Errorf("模块更新不支持修改%s!", module.name)
+		}
+	}
+	before, _ := json.Marshal(old.SubTables)
+	after, _ := json.Marshal(info.SubTables)
+	if string(before) != string(after) {
+		return nil, errors.New("模块更新暂不支持修改子表!")
+	}
+
+	if old.GenerateTest && !info.GenerateTest {
+		return nil, errors.New("模块更新不支持取消生成测试!")
+	}
+
+	fields := make(map[string]*AutoCodeField, len(info.Fields))
+	for _, field := range info.Fields {
+		fields[field.FieldName] = field
+	}
+	var changes []string
+	if !old.GenerateTest && info.GenerateTest {
+		changes = append(changes, "生成测试")
+	}
+	for _, field := range old.Fields {
+		current, ok := fields[field.FieldName]
+		if !ok {
+			return nil, errors.Errorf("模块更新不支持删除字段%s!", field.FieldName)
+		}
+		delete(fields, field.FieldName)
+		if !sameAutoCodeField(*field, *current) {
+			return nil, errors.Errorf("字段%s仅支持修改搜索条件及字典!", field.FieldName)
+		}
+		if field.FieldSearchType != "" && current.FieldSearchType == "" {
+			return nil, errors.Errorf("字段%s不支持取消搜索条件!", field.FieldName)
+		}
+		if field.DictType != "" && current.DictType == "" {
+			return nil, errors.Errorf("字段%s不支持解除字典绑定!", field.FieldName)
+		}
+		if field.FieldSearchType != current.FieldSearchType || field.FieldSearchHide != current.FieldSearchHide {
+			changes = append(changes, fmt.Sprintf("字段%s搜索条件: %s", field.FieldName, current.FieldSearchType))
+		}
+		if field.DictType != current.DictType {
+			changes = append(changes, fmt.Sprintf("字段%s绑定字典: %s", field.FieldName, current.DictType))
+		}
+	}
+	for _, field := range info.Fields {
+		if _, ok := fields[field.FieldName]; ok {
+			changes = append(changes, fmt.Sprintf("新增字段: %s(%s)", field.FieldName, field.FieldDesc))
+		}
+	}
+	return changes, nil
+}
+
+// sameAutoCodeField 忽略搜索条件及字典后字段是否一致
+func sameAutoCodeField(before, after AutoCodeField) bool {
+	before.FieldSearchType, after.FieldSearchType = "", ""
+	before.FieldSearchHide, after.FieldSearchHide = false, false
+	before.DictType, after.DictType = "", ""
+	a, _ := json.Marshal(before)
+	b, _ := json.Marshal(after)
+	return string(a) == string(b)
+}
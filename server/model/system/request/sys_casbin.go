@@ -25,3 +25,17 @@ func DefaultCasbin() []CasbinInfo {
 		{Path: "/sysDictionary/findSysDictionary", Method: "GET"},
 	}
 }
+
+// CasbinExplain 权限模拟 按用户或角色判断能否访问接口
+type CasbinExplain struct {
+	UserId      uint   `json:"userId"`      // 用户id 不为0时判断用户的全部角色
+	AuthorityId uint   `json:"authorityId"` // 角色id 未指定用户时使用
+	Path        string `json:"path"`        // 路径 可带路由前缀
+	Method      string `json:"method"`      // 方法
+}
+
+// CasbinMatrix 角色接口权限矩阵的查询条件
+type CasbinMatrix struct {
+	AuthorityIds []uint `json:"authorityIds"` // 角色id 为空时为全部角色
+	ApiGroup     string `json:"apiGroup"`     // api组
+}
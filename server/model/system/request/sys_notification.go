@@ -0,0 +1,40 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+// NotificationCreate 发送通知
+type NotificationCreate struct {
+	Title      string `json:"title" binding:"required"`      // 通知标题
+	Content    string `json:"content"`                       // 通知内容
+	Type       string `json:"type"`                          // 通知类型
+	Level      string `json:"level"`                         // 通知级别 info|warning|error
+	Link       string `json:"link"`                          // 跳转地址
+	TargetType string `json:"targetType" binding:"required"` // 目标类型 user|authority|department|all
+	TargetIDs  []uint `json:"targetIDs"`                     // 目标ID列表 targetType为all时忽略
+}
+
+// NotificationSearch 管理端通知列表
+type NotificationSearch struct {
+	StartCreatedAt *time.Time `json:"startCreatedAt" form:"startCreatedAt"`
+	EndCreatedAt   *time.Time `json:"endCreatedAt" form:"endCreatedAt"`
+	Title          string     `json:"title" form:"title"`
+	Type           string     `json:"type" form:"type"`
+	TargetType     string     `json:"targetType" form:"targetType"`
+	request.PageInfo
+}
+
+// MyNotificationSearch 当前用户的通知列表
+type MyNotificationSearch struct {
+	Status string `json:"status" form:"status"` // unread|read|archived 为空时返回未归档的全部通知
+	Type   string `json:"type" form:"type"`
+	request.PageInfo
+}
+
+// NotificationIds 通知ID列表
+type NotificationIds struct {
+	Ids []uint `json:"ids" binding:"required"`
+}
@@ -0,0 +1,13 @@
+package request
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+// RateLimitRuleSearch 限流规则列表
+type RateLimitRuleSearch struct {
+	Name  string `json:"name" form:"name"`
+	KeyBy string `json:"keyBy" form:"keyBy"`
+	Path  string `json:"path" form:"path"`
+	request.PageInfo
+}
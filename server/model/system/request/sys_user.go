@@ -16,6 +16,7 @@ type Register struct {
 	AuthorityIds []uint `json:"authorityIds" swaggertype:"string" example:"[]uint 角色id"`
 	Phone        string `json:"phone" example:"电话号码"`
 	Email        string `json:"email" example:"电子邮箱"`
+	DepartmentID uint   `json:"departmentId" swaggertype:"string" example:"int 所属部门id"`
 }
 
 // Login User login structure
@@ -57,6 +58,7 @@ type ChangeUserInfo struct {
 	Email        string                `json:"email"  gorm:"comment:用户邮箱"`                                                           // 用户邮箱
 	HeaderImg    string                `json:"headerImg" gorm:"default:https://qmplusimg.henrongyi.top/gva_header.jpg;comment:用户头像"` // 用户头像
 	Enable       int                   `json:"enable" gorm:"comment:冻结用户"`                                                           //冻结用户
+	DepartmentID uint                  `json:"departmentId" gorm:"comment:所属部门ID"`                                                   // 所属部门ID
 	Authorities  []system.SysAuthority `json:"-" gorm:"many2many:sys_user_authority;"`
 }
 
@@ -0,0 +1,25 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+)
+
+// WebhookSearch webhook订阅列表
+type WebhookSearch struct {
+	Name  string `json:"name" form:"name"`
+	Event string `json:"event" form:"event"` // 订阅了此事件类型的webhook
+	request.PageInfo
+}
+
+// WebhookDeliverySearch 推送记录列表
+type WebhookDeliverySearch struct {
+	WebhookID      uint       `json:"webhookId" form:"webhookId"`
+	EventID        string     `json:"eventId" form:"eventId"`
+	EventType      string     `json:"eventType" form:"eventType"`
+	Status         string     `json:"status" form:"status"`
+	StartCreatedAt *time.Time `json:"startCreatedAt" form:"startCreatedAt"`
+	EndCreatedAt   *time.Time `json:"endCreatedAt" form:"endCreatedAt"`
+	request.PageInfo
+}
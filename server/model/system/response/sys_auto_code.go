@@ -15,3 +15,26 @@ type Column struct {
 	ColumnComment string `json:"columnComment" gorm:"column:column_comment"`
 	PrimaryKey    bool   `json:"primaryKey" gorm:"column:primary_key"`
 }
+
+// AutoCodeUpdate 更新已生成模块的结果
+type AutoCodeUpdate struct {
+	Changes []string             `json:"changes"` // 本次更新的内容
+	Files   []AutoCodeUpdateFile `json:"files"`   // 发生变更的文件
+}
+
+type AutoCodeUpdateFile struct {
+	Path      string   `json:"path"`      // 相对项目根目录的路径
+	Diff      string   `json:"diff"`      // unified diff
+	Conflicts []string `json:"conflicts"` // 已被手动修改而未能自动合并的内容 需手动处理
+}
+
+// AutoCodeTemplatePack 已注册的模板包
+type AutoCodeTemplatePack struct {
+	Name        string   `json:"name"`        // 模板包名称
+	Description string   `json:"description"` // 描述
+	Base        string   `json:"base"`        // 适用的内置模板
+	Files       []string `json:"files"`       // 模板文件
+	Injections  []string `json:"injections"`  // 依赖的AST注入
+	Packages    []string `json:"packages"`    // 使用该模板包的package
+	Error       string   `json:"error"`       // 校验失败的原因 不为空时不可使用
+}
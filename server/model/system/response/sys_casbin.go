@@ -1,9 +1,53 @@
 package response
 
 import (
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 )
 
 type PolicyPathResponse struct {
 	Paths []request.CasbinInfo `json:"paths"`
 }
+
+// 角色对接口的权限来源
+const (
+	CasbinEffectAllow   = "allow"   // 角色自身的允许策略
+	CasbinEffectInherit = "inherit" // 继承自父角色的允许策略
+	CasbinEffectDeny    = "deny"    // 拒绝策略
+	CasbinEffectNone    = ""        // 没有匹配的策略
+)
+
+// CasbinDecision 单个角色的鉴权结果
+type CasbinDecision struct {
+	AuthorityId   uint     `json:"authorityId"`   // 角色id
+	AuthorityName string   `json:"authorityName"` // 角色名
+	Allowed       bool     `json:"allowed"`       // 是否允许访问
+	Effect        string   `json:"effect"`        // 权限来源 allow inherit deny 或空
+	Policy        []string `json:"policy"`        // 决定结果的策略 [角色, 域, 路径, 方法, 效果]
+	Roles         []string `json:"roles"`         // 参与判断的角色 包括继承的父角色
+	Reason        string   `json:"reason"`        // 说明
+}
+
+// CasbinExplainResponse 权限模拟结果
+type CasbinExplainResponse struct {
+	Path        string           `json:"path"`        // 鉴权使用的路径 已去掉路由前缀
+	Method      string           `json:"method"`      // 方法
+	Api         *system.SysApi   `json:"api"`         // 匹配的api 未登记时为空
+	AuthorityId uint             `json:"authorityId"` // 用户当前角色
+	Allowed     bool             `json:"allowed"`     // 是否允许访问 多角色模式下任一角色允许即可
+	Decisions   []CasbinDecision `json:"decisions"`   // 每个角色的鉴权结果
+}
+
+// CasbinMatrixAuthority 权限矩阵中的角色
+type CasbinMatrixAuthority struct {
+	AuthorityId   uint   `json:"authorityId"`   // 角色id
+	AuthorityName string `json:"authorityName"` // 角色名
+	ParentId      uint   `json:"parentId"`      // 父角色id
+}
+
+// CasbinMatrixResponse 角色接口权限矩阵 Cells[i][j] 为第 i 个接口对第 j 个角色的权限来源
+type CasbinMatrixResponse struct {
+	Authorities []CasbinMatrixAuthority `json:"authorities"`
+	Apis        []system.SysApi         `json:"apis"`
+	Cells       [][]string              `json:"cells"`
+}
@@ -0,0 +1,6 @@
+package response
+
+// NotificationUnreadCount 未读通知数量
+type NotificationUnreadCount struct {
+	Count int64 `json:"count"`
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin"
+	interfaces "github.com/flipped-aurora/gin-vue-admin/server/utils/plugin/v2"
+)
+
+// AutoCodePluginCheck 插件安装前的检查结果
+type AutoCodePluginCheck struct {
+	Name      string           `json:"name"`      // 插件名
+	Version   string           `json:"version"`   // 插件版本 缺少描述文件时为空
+	Manifest  *plugin.Manifest `json:"manifest"`  // 插件描述文件
+	Server    bool             `json:"server"`    // 是否包含server端插件
+	Web       bool             `json:"web"`       // 是否包含web端插件
+	Errors    []string         `json:"errors"`    // 不满足的安装条件 存在时不能安装
+	Warnings  []string         `json:"warnings"`  // 不影响安装的提示
+	Conflicts []string         `json:"conflicts"` // 已存在的文件 相对项目根目录 存在时不能安装
+	Signer    string           `json:"signer"`    // 验证通过的签名公钥名 未签名时为空
+	Installed bool             `json:"installed"` // 是否已安装
+}
+
+// PluginRuntime v2插件的运行时状态
+type PluginRuntime struct {
+	Name      string              `json:"name"`      // 插件名
+	Lifecycle bool                `json:"lifecycle"` // 是否实现了生命周期接口 未实现时只能启停路由
+	Enabled   bool                `json:"enabled"`   // 是否启用
+	Installed bool                `json:"installed"` // 是否已执行安装
+	Healthy   bool                `json:"healthy"`   // 健康检查是否通过 停用的插件不检查
+	Health    string              `json:"health"`    // 健康检查失败的原因
+	Message   string              `json:"message"`   // 最近一次启用失败的原因
+	Routes    []string            `json:"routes"`    // 插件注册的路由
+	Menus     []string            `json:"menus"`     // 插件声明的菜单
+	Config    []PluginConfigField `json:"config"`    // 插件的配置项
+}
+
+// PluginConfigField 插件配置项及是否已配置
+type PluginConfigField struct {
+	interfaces.ConfigField
+	Configured bool `json:"configured"` // 配置文件中是否存在该项
+}
@@ -0,0 +1,19 @@
+package response
+
+import "time"
+
+// RateLimitMetric 单条限流规则的统计
+type RateLimitMetric struct {
+	RuleID         uint       `json:"ruleId"`         // 规则ID 0为默认IP限流
+	Name           string     `json:"name"`           // 规则名称
+	Checked        int64      `json:"checked"`        // 判断次数
+	Rejected       int64      `json:"rejected"`       // 拒绝次数
+	LastRejectedAt *time.Time `json:"lastRejectedAt"` // 最近一次拒绝的时间
+}
+
+// RateLimitMetrics 限流统计 为当前实例自启动以来的数据
+type RateLimitMetrics struct {
+	Enable   bool              `json:"enable"`   // 是否开启限流
+	Rejected int64             `json:"rejected"` // 拒绝总数
+	Rules    []RateLimitMetric `json:"rules"`    // 各规则的统计
+}
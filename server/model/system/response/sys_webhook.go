@@ -0,0 +1,7 @@
+package response
+
+// WebhookEventType 可订阅的事件类型
+type WebhookEventType struct {
+	Type        string `json:"type"`        // 事件类型
+	Description string `json:"description"` // 说明
+}
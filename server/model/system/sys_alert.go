@@ -0,0 +1,61 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// 告警状态
+const (
+	AlertStateOpen     = "open"     // 未处理 新的错误会继续通知
+	AlertStateAcked    = "acked"    // 已确认 继续计数但不再通知
+	AlertStateResolved = "resolved" // 已解决 再次出现时产生新的告警
+)
+
+// 告警渠道类型
+const (
+	AlertChannelEmail   = "email"
+	AlertChannelWebhook = "webhook"
+)
+
+// SysAlert 错误告警 同一接口、同一错误特征在解决前计入同一条告警
+type SysAlert struct {
+	global.GVA_MODEL
+	Fingerprint     string     `json:"fingerprint" form:"fingerprint" gorm:"column:fingerprint;size:32;index;comment:错误特征;"` // 错误特征
+	Method          string     `json:"method" form:"method" gorm:"column:method;size:16;comment:请求方法;"`                      // 请求方法
+	Route           string     `json:"route" form:"route" gorm:"column:route;size:255;index;comment:路由;"`                    // 路由
+	Status          int        `json:"status" form:"status" gorm:"column:status;comment:最近一次的响应状态码;"`                        // 最近一次的响应状态码
+	Message         string     `json:"message" gorm:"column:message;type:text;comment:最近一次的错误信息;"`                           // 最近一次的错误信息
+	LastIP          string     `json:"lastIp" gorm:"column:last_ip;size:64;comment:最近一次的请求IP;"`                              // 最近一次的请求IP
+	Count           int64      `json:"count" gorm:"column:count;comment:累计次数;"`                                              // 累计次数
+	FirstSeen       time.Time  `json:"firstSeen" gorm:"column:first_seen;comment:首次出现时间;"`                                   // 首次出现时间
+	LastSeen        time.Time  `json:"lastSeen" gorm:"column:last_seen;comment:最近出现时间;"`                                     // 最近出现时间
+	State           string     `json:"state" form:"state" gorm:"column:state;size:16;index;comment:状态 open|acked|resolved;"` // 状态
+	AckedBy         uint       `json:"ackedBy" gorm:"column:acked_by;comment:确认人;"`                                          // 确认人
+	AckedAt         *time.Time `json:"ackedAt" gorm:"column:acked_at;comment:确认时间;"`                                         // 确认时间
+	ResolvedBy      uint       `json:"resolvedBy" gorm:"column:resolved_by;comment:解决人;"`                                    // 解决人
+	ResolvedAt      *time.Time `json:"resolvedAt" gorm:"column:resolved_at;comment:解决时间;"`                                   // 解决时间
+	NotifyCount     int        `json:"notifyCount" gorm:"column:notify_count;comment:已通知次数;"`                                // 已通知次数
+	NotifiedCount   int64      `json:"notifiedCount" gorm:"column:notified_count;comment:最近一次通知时的累计次数;"`                     // 最近一次通知时的累计次数
+	LastNotifiedAt  *time.Time `json:"lastNotifiedAt" gorm:"column:last_notified_at;comment:最近一次通知时间;"`                      // 最近一次通知时间
+	LastNotifyError string     `json:"lastNotifyError" gorm:"column:last_notify_error;size:500;comment:最近一次通知失败的原因;"`        // 最近一次通知失败的原因
+}
+
+func (SysAlert) TableName() string {
+	return "sys_alerts"
+}
+
+// SysAlertChannel 告警通知渠道
+type SysAlertChannel struct {
+	global.GVA_MODEL
+	Name   string `json:"name" form:"name" gorm:"column:name;size:64;comment:渠道名称;" binding:"required"`                  // 渠道名称
+	Type   string `json:"type" form:"type" gorm:"column:type;size:16;comment:类型 email|webhook;" binding:"required"`      // 类型
+	Target string `json:"target" gorm:"column:target;size:1000;comment:邮件为收件人 多个以英文逗号分隔 webhook为地址;" binding:"required"` // 收件人或webhook地址
+	Format string `json:"format" gorm:"column:format;size:16;comment:webhook消息格式 generic|slack|dingtalk|feishu|wecom;"`  // webhook消息格式
+	Enable bool   `json:"enable" form:"enable" gorm:"column:enable;comment:是否启用;"`                                       // 是否启用
+}
+
+func (SysAlertChannel) TableName() string {
+	return "sys_alert_channels"
+}
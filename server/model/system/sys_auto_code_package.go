@@ -6,11 +6,12 @@ import (
 
 type SysAutoCodePackage struct {
 	global.GVA_MODEL
-	Desc        string `json:"desc" gorm:"comment:描述"`
-	Label       string `json:"label" gorm:"comment:展示名"`
-	Template    string `json:"template"  gorm:"comment:模版"`
-	PackageName string `json:"packageName" gorm:"comment:包名"`
-	Module      string `json:"-" example:"模块"`
+	Desc         string `json:"desc" gorm:"comment:描述"`
+	Label        string `json:"label" gorm:"comment:展示名"`
+	Template     string `json:"template"  gorm:"comment:模版"`
+	PackageName  string `json:"packageName" gorm:"comment:包名"`
+	TemplatePack string `json:"templatePack" gorm:"comment:模板包"`
+	Module       string `json:"-" example:"模块"`
 }
 
 func (s *SysAutoCodePackage) TableName() string {
@@ -0,0 +1,17 @@
+package system
+
+import "github.com/flipped-aurora/gin-vue-admin/server/global"
+
+// SysCasbinDeny 角色的显式拒绝规则 对应 casbin 中 eft 为 deny 的策略 角色及其子角色均不能访问该接口
+// 与编辑角色接口权限时为未勾选的继承接口自动生成的 deny 策略不同 只能在此处增删
+type SysCasbinDeny struct {
+	global.GVA_MODEL
+	AuthorityId uint   `json:"authorityId" form:"authorityId" gorm:"uniqueIndex:idx_casbin_deny;comment:角色ID;" binding:"required"` // 角色ID
+	Path        string `json:"path" gorm:"uniqueIndex:idx_casbin_deny;size:191;comment:路径;" binding:"required"`                    // 路径
+	Method      string `json:"method" gorm:"uniqueIndex:idx_casbin_deny;size:16;comment:方法;" binding:"required"`                   // 方法
+	Remark      string `json:"remark" gorm:"size:255;comment:备注;"`                                                                 // 备注
+}
+
+func (SysCasbinDeny) TableName() string {
+	return "sys_casbin_denies"
+}
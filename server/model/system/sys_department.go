@@ -0,0 +1,18 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysDepartment 部门 用户通过 SysUser.DepartmentID 归属部门 用于按部门发送通知等
+type SysDepartment struct {
+	global.GVA_MODEL
+	ParentID uint            `json:"parentId" form:"parentId" gorm:"index;default:0;comment:上级部门ID 0为顶级部门;"` // 上级部门ID
+	Name     string          `json:"name" form:"name" gorm:"size:64;comment:部门名称;" binding:"required"`       // 部门名称
+	Sort     int             `json:"sort" form:"sort" gorm:"default:0;comment:排序;"`                          // 排序
+	Children []SysDepartment `json:"children" gorm:"-"`
+}
+
+func (SysDepartment) TableName() string {
+	return "sys_departments"
+}
@@ -1,10 +1,14 @@
 package system
 
 import (
+	"time"
+
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 )
 
 type JwtBlacklist struct {
 	global.GVA_MODEL
-	Jwt string `gorm:"type:text;comment:jwt"`
+	Jwt       string     `gorm:"type:text;comment:jwt"`
+	Hash      string     `gorm:"index;size:64;comment:jwt的sha256"` // 按哈希查询 旧数据为空
+	ExpiresAt *time.Time `gorm:"index;comment:jwt的过期时间"`           // 过期后由定时任务清理 旧数据为空
 }
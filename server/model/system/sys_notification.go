@@ -0,0 +1,53 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"gorm.io/datatypes"
+)
+
+// 通知目标类型
+const (
+	NotificationTargetUser       = "user"       // 指定用户
+	NotificationTargetAuthority  = "authority"  // 指定角色下的用户
+	NotificationTargetDepartment = "department" // 指定部门及其下级部门中的用户
+	NotificationTargetAll        = "all"        // 全部启用用户
+)
+
+// NotificationSettingKey 用户通知偏好在 SysUser.OriginSetting 中的键
+// 例: {"notification": {"emailWhenOffline": true}}
+const NotificationSettingKey = "notification"
+
+// SysNotification 站内通知
+type SysNotification struct {
+	global.GVA_MODEL
+	Title      string         `json:"title" form:"title" gorm:"column:title;comment:通知标题;" binding:"required"`                            // 通知标题
+	Content    string         `json:"content" form:"content" gorm:"column:content;type:text;comment:通知内容;"`                               // 通知内容
+	Type       string         `json:"type" form:"type" gorm:"column:type;default:system;comment:通知类型;"`                                   // 通知类型
+	Level      string         `json:"level" form:"level" gorm:"column:level;default:info;comment:通知级别 info|warning|error;"`               // 通知级别
+	Link       string         `json:"link" form:"link" gorm:"column:link;comment:跳转地址;"`                                                  // 跳转地址
+	TargetType string         `json:"targetType" form:"targetType" gorm:"column:target_type;comment:目标类型 user|authority|department|all;"` // 目标类型
+	TargetIDs  datatypes.JSON `json:"targetIDs" form:"targetIDs" gorm:"column:target_ids;comment:目标ID列表;" swaggertype:"array,integer"`    // 目标ID列表
+	SenderID   uint           `json:"senderID" form:"senderID" gorm:"column:sender_id;comment:发送者ID 0为系统;"`                               // 发送者ID
+	Receivers  int            `json:"receivers" form:"receivers" gorm:"column:receivers;comment:接收人数;"`                                   // 接收人数
+}
+
+func (SysNotification) TableName() string {
+	return "sys_notifications"
+}
+
+// SysNotificationReceipt 通知的接收记录 保存每个用户的已读和归档状态
+type SysNotificationReceipt struct {
+	global.GVA_MODEL
+	NotificationID uint            `json:"notificationID" gorm:"index;column:notification_id;comment:通知ID;"`
+	UserID         uint            `json:"userID" gorm:"index;column:user_id;comment:接收用户ID;"`
+	ReadAt         *time.Time      `json:"readAt" gorm:"column:read_at;comment:已读时间;"`
+	ArchivedAt     *time.Time      `json:"archivedAt" gorm:"column:archived_at;comment:归档时间;"`
+	Channel        string          `json:"channel" gorm:"column:channel;comment:投递渠道 websocket|email|none;"`
+	Notification   SysNotification `json:"notification" gorm:"foreignKey:NotificationID"`
+}
+
+func (SysNotificationReceipt) TableName() string {
+	return "sys_notification_receipts"
+}
@@ -0,0 +1,36 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysPlugin 已安装的插件
+type SysPlugin struct {
+	global.GVA_MODEL
+	Name        string   `json:"name" gorm:"uniqueIndex;size:64;comment:插件名"`
+	Version     string   `json:"version" gorm:"comment:插件版本"`
+	Description string   `json:"description" gorm:"comment:描述"`
+	Author      string   `json:"author" gorm:"comment:作者"`
+	Gva         string   `json:"gva" gorm:"comment:兼容的gva版本范围"`
+	Manifest    string   `json:"manifest" gorm:"type:text;comment:插件描述文件"`
+	Files       []string `json:"files" gorm:"serializer:json;type:text;comment:安装的文件"`
+	MenuIDs     []uint   `json:"menuIDs" gorm:"serializer:json;column:menu_ids;comment:安装时创建的菜单"`
+	ApiIDs      []uint   `json:"apiIDs" gorm:"serializer:json;column:api_ids;comment:安装时创建的api"`
+}
+
+func (SysPlugin) TableName() string {
+	return "sys_plugins"
+}
+
+// SysPluginState v2插件的运行时状态
+type SysPluginState struct {
+	global.GVA_MODEL
+	Name      string `json:"name" gorm:"uniqueIndex;size:64;comment:插件名"`
+	Enabled   bool   `json:"enabled" gorm:"comment:是否启用"`
+	Installed bool   `json:"installed" gorm:"comment:是否已执行安装"`
+	Message   string `json:"message" gorm:"type:text;comment:最近一次启用或停用失败的原因"`
+}
+
+func (SysPluginState) TableName() string {
+	return "sys_plugin_states"
+}
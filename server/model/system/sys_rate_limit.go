@@ -0,0 +1,24 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// SysRateLimitRule 限流规则 按 Sort 从小到大依次判断 任一规则拒绝即返回429
+type SysRateLimitRule struct {
+	global.GVA_MODEL
+	Name      string `json:"name" form:"name" gorm:"column:name;comment:规则名称;" binding:"required"`                             // 规则名称
+	KeyBy     string `json:"keyBy" form:"keyBy" gorm:"column:key_by;comment:计数维度 ip|user|authority|route;" binding:"required"` // 计数维度
+	Target    string `json:"target" form:"target" gorm:"column:target;comment:只限制指定的IP/用户ID/角色ID 为空时不限定;"`                     // 限定对象
+	Method    string `json:"method" form:"method" gorm:"column:method;comment:接口方法 为空时匹配全部方法;"`                                // 接口方法
+	Path      string `json:"path" form:"path" gorm:"column:path;comment:接口路径 与api管理中的路径一致 为空时匹配全部接口;"`                         // 接口路径
+	Algorithm string `json:"algorithm" form:"algorithm" gorm:"column:algorithm;comment:限流算法 token_bucket|sliding_window;"`     // 限流算法
+	Limit     int    `json:"limit" form:"limit" gorm:"column:limit_count;comment:窗口内允许的请求次数;" binding:"required,min=1"`        // 请求次数
+	Window    int    `json:"window" form:"window" gorm:"column:window_seconds;comment:时间窗口(秒);" binding:"required,min=1"`      // 时间窗口(秒)
+	Enable    bool   `json:"enable" form:"enable" gorm:"column:enable;comment:是否启用;"`                                          // 是否启用
+	Sort      int    `json:"sort" form:"sort" gorm:"column:sort;comment:判断顺序 从小到大;"`                                           // 判断顺序
+}
+
+func (SysRateLimitRule) TableName() string {
+	return "sys_rate_limit_rules"
+}
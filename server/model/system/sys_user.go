@@ -12,6 +12,7 @@ type Login interface {
 	GetUUID() uuid.UUID
 	GetUserId() uint
 	GetAuthorityId() uint
+	GetAuthorityIds() []uint
 	GetUserInfo() any
 }
 
@@ -27,10 +28,12 @@ type SysUser struct {
 	AuthorityId   uint           `json:"authorityId" gorm:"default:888;comment:用户角色ID"`                                                      // 用户角色ID
 	Authority     SysAuthority   `json:"authority" gorm:"foreignKey:AuthorityId;references:AuthorityId;comment:用户角色"`                        // 用户角色
 	Authorities   []SysAuthority `json:"authorities" gorm:"many2many:sys_user_authority;"`                                                   // 多用户角色
+	DepartmentID  uint           `json:"departmentId" gorm:"index;default:0;comment:所属部门ID 0为未分配"`                                           // 所属部门ID
 	Phone         string         `json:"phone"  gorm:"comment:用户手机号"`                                                                        // 用户手机号
 	Email         string         `json:"email"  gorm:"comment:用户邮箱"`                                                                         // 用户邮箱
 	Enable        int            `json:"enable" gorm:"default:1;comment:用户是否被冻结 1正常 2冻结"`                                                    //用户是否被冻结 1正常 2冻结
 	OriginSetting common.JSONMap `json:"originSetting" form:"originSetting" gorm:"type:text;default:null;column:origin_setting;comment:配置;"` //配置
+	TokenVersion  int64          `json:"-" gorm:"default:0;column:token_version;comment:令牌版本 不使用redis时持久化"`                                  // 令牌版本
 }
 
 func (SysUser) TableName() string {
@@ -57,6 +60,14 @@ func (s *SysUser) GetAuthorityId() uint {
 	return s.AuthorityId
 }
 
+func (s *SysUser) GetAuthorityIds() []uint {
+	ids := make([]uint, 0, len(s.Authorities))
+	for _, authority := range s.Authorities {
+		ids = append(ids, authority.AuthorityId)
+	}
+	return ids
+}
+
 func (s *SysUser) GetUserInfo() any {
 	return *s
 }
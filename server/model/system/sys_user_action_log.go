@@ -1,37 +1,65 @@
 package system
 
-import "time"
+import (
+	"time"
 
-// UserActionLog 用户操作日志（存储在 ES 中）
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/elasticsearch"
+)
+
+// UserActionLog 用户操作日志（按配置存储在 ES、数据库或 MongoDB 中）
 type UserActionLog struct {
-	ID         string    `json:"id"`                       // 日志ID
-	UserID     uint      `json:"user_id"`                  // 用户ID
-	Username   string    `json:"username"`                 // 用户名
-	Action     string    `json:"action"`                   // 操作动作（login, logout, create, update, delete等）
-	Module     string    `json:"module"`                   // 操作模块（user, role, menu等）
-	Method     string    `json:"method"`                   // 请求方法（GET, POST, PUT, DELETE）
-	Path       string    `json:"path"`                     // 请求路径
-	IP         string    `json:"ip"`                       // IP地址
-	UserAgent  string    `json:"user_agent"`               // 用户代理
-	Status     int       `json:"status"`                   // 响应状态码
-	Latency    int64     `json:"latency"`                  // 响应时间（毫秒）
-	Request    string    `json:"request,omitempty"`        // 请求参数
-	Response   string    `json:"response,omitempty"`       // 响应数据
-	ErrorMsg   string    `json:"error_msg,omitempty"`      // 错误信息
-	CreateTime time.Time `json:"create_time"`              // 创建时间
+	ID         string    `json:"id" gorm:"primaryKey;size:36" bson:"_id"`                                      // 日志ID
+	UserID     uint      `json:"user_id" gorm:"index;comment:用户ID" bson:"user_id"`                             // 用户ID
+	Username   string    `json:"username" gorm:"size:191;comment:用户名" bson:"username"`                         // 用户名
+	Action     string    `json:"action" gorm:"size:191;index;comment:操作动作" bson:"action"`                      // 操作动作（login, logout, create, update, delete等）
+	Module     string    `json:"module" gorm:"size:191;index;comment:操作模块" bson:"module"`                      // 操作模块（user, role, menu等）
+	Method     string    `json:"method" gorm:"size:16;comment:请求方法" bson:"method"`                             // 请求方法（GET, POST, PUT, DELETE）
+	Path       string    `json:"path" gorm:"size:255;comment:请求路径" bson:"path"`                                // 请求路径
+	IP         string    `json:"ip" gorm:"size:64;comment:IP地址" bson:"ip"`                                     // IP地址
+	UserAgent  string    `json:"user_agent" gorm:"size:512;comment:用户代理" bson:"user_agent"`                    // 用户代理
+	Status     int       `json:"status" gorm:"comment:响应状态码" bson:"status"`                                    // 响应状态码
+	Latency    int64     `json:"latency" gorm:"comment:响应时间(毫秒)" bson:"latency"`                               // 响应时间（毫秒）
+	Request    string    `json:"request,omitempty" gorm:"type:text;comment:请求参数" bson:"request,omitempty"`     // 请求参数
+	Response   string    `json:"response,omitempty" gorm:"type:text;comment:响应数据" bson:"response,omitempty"`   // 响应数据
+	ErrorMsg   string    `json:"error_msg,omitempty" gorm:"type:text;comment:错误信息" bson:"error_msg,omitempty"` // 错误信息
+	CreateTime time.Time `json:"create_time" gorm:"index;comment:创建时间" bson:"create_time"`                     // 创建时间
+}
+
+// TableName 数据库存储时的表名 MongoDB存储时的集合名
+func (UserActionLog) TableName() string {
+	return "sys_user_action_logs"
 }
 
-// GetESIndexName 获取ES索引名称
+// GetESIndexName 获取ES写入别名 实际数据按周期写入 user_action_logs-日期 索引
 func (UserActionLog) GetESIndexName() string {
 	return "user_action_logs"
 }
 
-// GetESMapping 获取ES映射定义
+// GetESIndexPattern 获取查询用的索引通配模式 覆盖全部周期索引
+func (l UserActionLog) GetESIndexPattern() string {
+	return elasticsearch.IndexPattern(l.GetESIndexName())
+}
+
+// GetESWriteIndex 获取指定时间所在周期的索引名称
+func (l UserActionLog) GetESWriteIndex(t time.Time) string {
+	return elasticsearch.IndexNameFor(l.GetESIndexName(), global.GVA_CONFIG.Elasticsearch.IndexRollover, t)
+}
+
+// GetESMapping 获取ES映射定义 分片及副本数取自配置
 func (UserActionLog) GetESMapping() map[string]interface{} {
+	shards := global.GVA_CONFIG.Elasticsearch.NumberOfShards
+	if shards <= 0 {
+		shards = 3
+	}
+	replicas := global.GVA_CONFIG.Elasticsearch.NumberOfReplicas
+	if replicas < 0 {
+		replicas = 0
+	}
 	return map[string]interface{}{
 		"settings": map[string]interface{}{
-			"number_of_shards":   3,
-			"number_of_replicas": 1,
+			"number_of_shards":   shards,
+			"number_of_replicas": replicas,
 			"refresh_interval":   "5s",
 			"analysis": map[string]interface{}{
 				"analyzer": map[string]interface{}{
@@ -82,11 +110,11 @@ func (UserActionLog) GetESMapping() map[string]interface{} {
 					"type": "long",
 				},
 				"request": map[string]interface{}{
-					"type": "text",
+					"type":  "text",
 					"index": false, // 不索引，只存储
 				},
 				"response": map[string]interface{}{
-					"type": "text",
+					"type":  "text",
 					"index": false, // 不索引，只存储
 				},
 				"error_msg": map[string]interface{}{
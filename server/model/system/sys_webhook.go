@@ -0,0 +1,77 @@
+package system
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// WebhookAllEvents 订阅全部事件
+const WebhookAllEvents = "*"
+
+// webhook推送状态
+const (
+	WebhookDeliveryPending = "pending" // 等待推送 包括等待重试
+	WebhookDeliverySending = "sending" // 推送中
+	WebhookDeliverySuccess = "success" // 推送成功
+	WebhookDeliveryDead    = "dead"    // 死信 超过最大尝试次数 可手动重新推送
+)
+
+// SysWebhook webhook订阅 订阅的事件发生时向地址推送签名后的事件
+type SysWebhook struct {
+	global.GVA_MODEL
+	Name        string   `json:"name" form:"name" gorm:"column:name;size:64;comment:名称;" binding:"required"`                     // 名称
+	URL         string   `json:"url" gorm:"column:url;size:1000;comment:推送地址;" binding:"required"`                               // 推送地址
+	Secret      string   `json:"secret,omitempty" gorm:"column:secret;size:128;comment:签名密钥;"`                                   // 签名密钥 为空时自动生成 只在创建时返回
+	SecretHint  string   `json:"secretHint,omitempty" gorm:"-"`                                                                  // 脱敏后的签名密钥 用于列表展示
+	Events      []string `json:"events" gorm:"column:events;type:text;serializer:json;comment:订阅的事件类型 *为全部;" binding:"required"` // 订阅的事件类型
+	Enable      bool     `json:"enable" form:"enable" gorm:"column:enable;comment:是否启用;"`                                        // 是否启用
+	Description string   `json:"description" gorm:"column:description;size:255;comment:描述;"`                                     // 描述
+}
+
+func (SysWebhook) TableName() string {
+	return "sys_webhooks"
+}
+
+// MaskSecret 清空签名密钥 只保留末4位用于辨认
+func (w *SysWebhook) MaskSecret() {
+	if n := len(w.Secret); n > 4 {
+		w.SecretHint = "****" + w.Secret[n-4:]
+	} else if n > 0 {
+		w.SecretHint = "****"
+	}
+	w.Secret = ""
+}
+
+// Subscribed 是否订阅了事件
+func (w SysWebhook) Subscribed(eventType string) bool {
+	for _, e := range w.Events {
+		if e == WebhookAllEvents || e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// SysWebhookDelivery 一次事件推送 推送完成后保留作为推送记录
+type SysWebhookDelivery struct {
+	global.GVA_MODEL
+	WebhookID      uint       `json:"webhookId" form:"webhookId" gorm:"column:webhook_id;index;comment:webhook订阅ID;"`                   // webhook订阅ID
+	EventID        string     `json:"eventId" form:"eventId" gorm:"column:event_id;size:64;index;comment:事件ID;"`                        // 事件ID 重新推送时不变 接收方可据此去重
+	EventType      string     `json:"eventType" form:"eventType" gorm:"column:event_type;size:64;index;comment:事件类型;"`                  // 事件类型
+	Payload        string     `json:"payload,omitempty" gorm:"column:payload;type:text;comment:推送内容;"`                                  // 推送内容
+	Status         string     `json:"status" form:"status" gorm:"column:status;size:16;index;comment:状态 pending|sending|success|dead;"` // 状态
+	Attempts       int        `json:"attempts" gorm:"column:attempts;comment:已尝试次数;"`                                                   // 已尝试次数
+	MaxAttempts    int        `json:"maxAttempts" gorm:"column:max_attempts;comment:最大尝试次数;"`                                           // 最大尝试次数
+	NextAttemptAt  time.Time  `json:"nextAttemptAt" gorm:"column:next_attempt_at;index;comment:下次尝试时间 推送中时为超时时间;"`                      // 下次尝试时间
+	ResponseStatus int        `json:"responseStatus" gorm:"column:response_status;comment:最近一次响应状态码;"`                                  // 最近一次响应状态码
+	ResponseBody   string     `json:"responseBody,omitempty" gorm:"column:response_body;type:text;comment:最近一次响应内容;"`                   // 最近一次响应内容
+	LastError      string     `json:"lastError" gorm:"column:last_error;size:500;comment:最近一次失败原因;"`                                    // 最近一次失败原因
+	Duration       int64      `json:"duration" gorm:"column:duration;comment:最近一次请求耗时(毫秒);"`                                            // 最近一次请求耗时(毫秒)
+	DeliveredAt    *time.Time `json:"deliveredAt" gorm:"column:delivered_at;comment:推送成功时间;"`                                           // 推送成功时间
+	RedeliveryOf   uint       `json:"redeliveryOf" gorm:"column:redelivery_of;comment:重新推送自哪条记录;"`                                      // 重新推送自哪条记录
+}
+
+func (SysWebhookDelivery) TableName() string {
+	return "sys_webhook_deliveries"
+}
@@ -7,7 +7,16 @@ import (
 )
 
 func Api(ctx context.Context) {
-	entities := []model.SysApi{
+	utils.RegisterApis(apis()...)
+}
+
+// RemoveApi 卸载时删除注册的API
+func RemoveApi(ctx context.Context) error {
+	return utils.UnregisterApis(apis()...)
+}
+
+func apis() []model.SysApi {
+	return []model.SysApi{
 		{
 			Path:        "/info/createInfo",
 			Description: "新建公告",
@@ -45,5 +54,4 @@ func Api(ctx context.Context) {
 			Method:      "GET",
 		},
 	}
-	utils.RegisterApis(entities...)
 }
@@ -7,7 +7,16 @@ import (
 )
 
 func Menu(ctx context.Context) {
-	entities := []model.SysBaseMenu{
+	utils.RegisterMenus(menus()...)
+}
+
+// RemoveMenu 卸载时删除注册的菜单
+func RemoveMenu(ctx context.Context) error {
+	return utils.UnregisterMenus(menus()...)
+}
+
+func menus() []model.SysBaseMenu {
+	return []model.SysBaseMenu{
 		{
 			ParentId:  24,
 			Path:      "anInfo",
@@ -18,5 +27,4 @@ func Menu(ctx context.Context) {
 			Meta:      model.Meta{Title: "公告管理", Icon: "box"},
 		},
 	}
-	utils.RegisterMenus(entities...)
 }
@@ -2,25 +2,59 @@ package announcement
 
 import (
 	"context"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/announcement/initialize"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/announcement/model"
 	interfaces "github.com/flipped-aurora/gin-vue-admin/server/utils/plugin/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
-var _ interfaces.Plugin = (*plugin)(nil)
+var _ interfaces.Lifecycle = (*plugin)(nil)
 
 var Plugin = new(plugin)
 
-type plugin struct{}
+type plugin struct {
+	interfaces.Base
+}
 
-func (p *plugin) Register(group *gin.Engine) {
-	ctx := context.Background()
+func (p *plugin) Name() string {
+	return "announcement"
+}
+
+// Install 首次启用时注册api、菜单及数据表
+func (p *plugin) Install(ctx context.Context) error {
 	// 如果需要配置文件，请到config.Config中填充配置结构，且到下方发放中填入其在config.yaml中的key
 	// initialize.Viper()
 	// 安装插件时候自动注册的api数据请到下方法.Api方法中实现
 	initialize.Api(ctx)
 	// 安装插件时候自动注册的api数据请到下方法.Menu方法中实现
 	initialize.Menu(ctx)
+	return nil
+}
+
+// Enable 启用时同步表结构
+func (p *plugin) Enable(ctx context.Context) error {
 	initialize.Gorm(ctx)
+	return nil
+}
+
+// Uninstall 删除注册的api及菜单 保留公告数据
+func (p *plugin) Uninstall(ctx context.Context) error {
+	if err := initialize.RemoveApi(ctx); err != nil {
+		return err
+	}
+	return initialize.RemoveMenu(ctx)
+}
+
+func (p *plugin) Health(ctx context.Context) error {
+	if !global.GVA_DB.WithContext(ctx).Migrator().HasTable(&model.Info{}) {
+		return errors.New("公告表不存在")
+	}
+	return nil
+}
+
+func (p *plugin) Register(group *gin.Engine) {
 	initialize.Router(group)
 }
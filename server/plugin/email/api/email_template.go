@@ -0,0 +1,137 @@
+package api
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	emailReq "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/request"
+	email_response "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CreateEmailTemplate
+// @Tags      System
+// @Summary   创建邮件模板
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  body      model.EmailTemplate            true  "模板标识, 语言, 标题模板, 正文模板"
+// @Success   200   {object}  response.Response{msg=string}  "创建成功"
+// @Router    /email/createEmailTemplate [post]
+func (s *EmailApi) CreateEmailTemplate(c *gin.Context) {
+	var tpl model.EmailTemplate
+	err := c.ShouldBindJSON(&tpl)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = service.ServiceGroupApp.CreateEmailTemplate(&tpl)
+	if err != nil {
+		global.GVA_LOG.Error("创建失败!", zap.Error(err))
+		response.FailWithMessage("创建失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("创建成功", c)
+}
+
+// UpdateEmailTemplate
+// @Tags      System
+// @Summary   更新邮件模板
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  body      model.EmailTemplate            true  "模板标识, 语言, 标题模板, 正文模板"
+// @Success   200   {object}  response.Response{msg=string}  "更新成功"
+// @Router    /email/updateEmailTemplate [put]
+func (s *EmailApi) UpdateEmailTemplate(c *gin.Context) {
+	var tpl model.EmailTemplate
+	err := c.ShouldBindJSON(&tpl)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = service.ServiceGroupApp.UpdateEmailTemplate(tpl)
+	if err != nil {
+		global.GVA_LOG.Error("更新失败!", zap.Error(err))
+		response.FailWithMessage("更新失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("更新成功", c)
+}
+
+// DeleteEmailTemplate
+// @Tags      System
+// @Summary   删除邮件模板
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  body      request.GetById                true  "模板ID"
+// @Success   200   {object}  response.Response{msg=string}  "删除成功"
+// @Router    /email/deleteEmailTemplate [delete]
+func (s *EmailApi) DeleteEmailTemplate(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = service.ServiceGroupApp.DeleteEmailTemplate(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("删除失败!", zap.Error(err))
+		response.FailWithMessage("删除失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("删除成功", c)
+}
+
+// GetEmailTemplateList
+// @Tags      System
+// @Summary   分页获取邮件模板
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  query     emailReq.EmailTemplateSearch                        true  "页码, 每页大小, 搜索条件"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "获取成功"
+// @Router    /email/getEmailTemplateList [get]
+func (s *EmailApi) GetEmailTemplateList(c *gin.Context) {
+	var pageInfo emailReq.EmailTemplateSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := service.ServiceGroupApp.GetEmailTemplateList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// PreviewEmailTemplate
+// @Tags      System
+// @Summary   预览邮件模板
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  body      emailReq.EmailTemplatePreview                                  true  "模板ID或标题正文, 模板变量"
+// @Success   200   {object}  response.Response{data=email_response.EmailPreview,msg=string}  "渲染成功"
+// @Router    /email/previewEmailTemplate [post]
+func (s *EmailApi) PreviewEmailTemplate(c *gin.Context) {
+	var req emailReq.EmailTemplatePreview
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	subject, body, err := service.ServiceGroupApp.PreviewEmailTemplate(req)
+	if err != nil {
+		response.FailWithMessage("渲染失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(email_response.EmailPreview{Subject: subject, Body: body}, "渲染成功", c)
+}
@@ -2,7 +2,10 @@ package api
 
 import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	emailReq "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/request"
 	email_response "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/service"
 	"github.com/gin-gonic/gin"
@@ -30,24 +33,102 @@ func (s *EmailApi) EmailTest(c *gin.Context) {
 
 // SendEmail
 // @Tags      System
-// @Summary   发送邮件
+// @Summary   发送邮件 加入发送队列后立即返回
 // @Security  ApiKeyAuth
 // @Produce   application/json
-// @Param     data  body      email_response.Email  true  "发送邮件必须的参数"
-// @Success   200   {string}  string                "{"success":true,"data":{},"msg":"发送成功"}"
+// @Param     data  body      emailReq.EmailSend                                          true  "收件人 标题正文或模板及变量 附件"
+// @Success   200   {object}  response.Response{data=email_response.EmailSendResult,msg=string}  "已加入发送队列"
 // @Router    /email/sendEmail [post]
 func (s *EmailApi) SendEmail(c *gin.Context) {
-	var email email_response.Email
-	err := c.ShouldBindJSON(&email)
+	var req emailReq.EmailSend
+	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		response.FailWithMessage(err.Error(), c)
 		return
 	}
-	err = service.ServiceGroupApp.SendEmail(email.To, email.Subject, email.Body)
+	id, err := service.ServiceGroupApp.Send(req)
 	if err != nil {
 		global.GVA_LOG.Error("发送失败!", zap.Error(err))
-		response.FailWithMessage("发送失败", c)
+		response.FailWithMessage("发送失败:"+err.Error(), c)
 		return
 	}
-	response.OkWithMessage("发送成功", c)
+	response.OkWithDetailed(email_response.EmailSendResult{ID: id}, "已加入发送队列", c)
+}
+
+// GetEmailMessageList
+// @Tags      System
+// @Summary   分页获取邮件发送记录
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  query     emailReq.EmailMessageSearch                         true  "页码, 每页大小, 搜索条件"
+// @Success   200   {object}  response.Response{data=response.PageResult,msg=string}  "获取成功"
+// @Router    /email/getEmailMessageList [get]
+func (s *EmailApi) GetEmailMessageList(c *gin.Context) {
+	var pageInfo emailReq.EmailMessageSearch
+	err := c.ShouldBindQuery(&pageInfo)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	list, total, err := service.ServiceGroupApp.GetEmailMessageList(pageInfo)
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(response.PageResult{
+		List:     list,
+		Total:    total,
+		Page:     pageInfo.Page,
+		PageSize: pageInfo.PageSize,
+	}, "获取成功", c)
+}
+
+// FindEmailMessage
+// @Tags      System
+// @Summary   获取邮件发送记录详情
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  query     request.GetById                                       true  "发送记录ID"
+// @Success   200   {object}  response.Response{data=model.EmailMessage,msg=string}  "获取成功"
+// @Router    /email/findEmailMessage [get]
+func (s *EmailApi) FindEmailMessage(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	var message model.EmailMessage
+	message, err = service.ServiceGroupApp.FindEmailMessage(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("获取失败!", zap.Error(err))
+		response.FailWithMessage("获取失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithDetailed(message, "获取成功", c)
+}
+
+// RetryEmailMessage
+// @Tags      System
+// @Summary   重新发送死信邮件
+// @Security  ApiKeyAuth
+// @Produce   application/json
+// @Param     data  body      request.GetById                 true  "发送记录ID"
+// @Success   200   {object}  response.Response{msg=string}  "已重新加入发送队列"
+// @Router    /email/retryEmailMessage [post]
+func (s *EmailApi) RetryEmailMessage(c *gin.Context) {
+	var req request.GetById
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		response.FailWithMessage(err.Error(), c)
+		return
+	}
+	err = service.ServiceGroupApp.RetryEmailMessage(req.Uint())
+	if err != nil {
+		global.GVA_LOG.Error("重试失败!", zap.Error(err))
+		response.FailWithMessage("重试失败:"+err.Error(), c)
+		return
+	}
+	response.OkWithMessage("已重新加入发送队列", c)
 }
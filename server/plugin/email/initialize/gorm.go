@@ -0,0 +1,18 @@
+package initialize
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"go.uber.org/zap"
+)
+
+// Gorm 注册邮件模板及发送队列表
+func Gorm() {
+	err := global.GVA_DB.AutoMigrate(
+		new(model.EmailTemplate),
+		new(model.EmailMessage),
+	)
+	if err != nil {
+		global.GVA_LOG.Error("注册邮件插件表失败!", zap.Error(err))
+	}
+}
@@ -1,8 +1,12 @@
 package email
 
 import (
+	serverGlobal "github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/initialize"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/router"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/service"
+	"github.com/flipped-aurora/gin-vue-admin/server/service/system"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,8 +24,24 @@ func CreateEmailPlug(To, From, Host, Secret, Nickname string, Port int, IsSSL bo
 	return &emailPlugin{}
 }
 
+// WithQueue 设置发送队列 参数小于等于0或为空时使用默认值
+func (p *emailPlugin) WithQueue(workers, maxAttempts, retryBackoff int, defaultLocale string) *emailPlugin {
+	global.GlobalConfig.Workers = workers
+	global.GlobalConfig.MaxAttempts = maxAttempts
+	global.GlobalConfig.RetryBackoff = retryBackoff
+	global.GlobalConfig.DefaultLocale = defaultLocale
+	return p
+}
+
 func (*emailPlugin) Register(group *gin.RouterGroup) {
 	router.RouterGroupApp.InitEmailRouter(group)
+	// 数据库未初始化时无法使用模板和发送队列
+	if serverGlobal.GVA_DB != nil {
+		initialize.Gorm()
+		service.StartQueue()
+		// 站内通知的离线用户及告警通过邮件队列发送
+		system.RegisterEmailSender(service.ServiceGroupApp.SendEmail)
+	}
 }
 
 func (*emailPlugin) RouterPath() string {
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"gorm.io/datatypes"
+)
+
+// 邮件发送状态
+const (
+	EmailStatusPending = "pending" // 等待发送 包括等待重试
+	EmailStatusSending = "sending" // 发送中
+	EmailStatusSent    = "sent"    // 已发送
+	EmailStatusDead    = "dead"    // 死信 超过最大尝试次数或被服务器永久拒绝 可手动重试
+)
+
+// EmailAttachment 邮件附件 Content 在json中为base64
+type EmailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Content     []byte `json:"content,omitempty"`
+}
+
+// EmailMessage 发送队列中的邮件 发送完成后保留作为发送记录
+type EmailMessage struct {
+	global.GVA_MODEL
+	To            string         `json:"to" form:"to" gorm:"column:to_addrs;comment:收件人 多个以英文逗号分隔;"`                                    // 收件人
+	Cc            string         `json:"cc" form:"cc" gorm:"column:cc_addrs;comment:抄送 多个以英文逗号分隔;"`                                     // 抄送
+	Subject       string         `json:"subject" form:"subject" gorm:"column:subject;comment:标题;"`                                      // 标题
+	Body          string         `json:"body,omitempty" form:"body" gorm:"column:body;comment:正文;"`                                     // 正文
+	Template      string         `json:"template" form:"template" gorm:"column:template;size:64;index;comment:使用的模板;"`                  // 使用的模板
+	Locale        string         `json:"locale" form:"locale" gorm:"column:locale;size:16;comment:模板语言;"`                               // 模板语言
+	Attachments   datatypes.JSON `json:"attachments,omitempty" gorm:"column:attachments;comment:附件;" swaggertype:"array,object"`        // 附件
+	Status        string         `json:"status" form:"status" gorm:"column:status;size:16;index;comment:状态 pending|sending|sent|dead;"` // 状态
+	Attempts      int            `json:"attempts" gorm:"column:attempts;comment:已尝试次数;"`                                                // 已尝试次数
+	MaxAttempts   int            `json:"maxAttempts" gorm:"column:max_attempts;comment:最大尝试次数;"`                                        // 最大尝试次数
+	NextAttemptAt time.Time      `json:"nextAttemptAt" gorm:"column:next_attempt_at;index;comment:下次尝试时间 发送中时为超时时间;"`                   // 下次尝试时间
+	LastError     string         `json:"lastError" gorm:"column:last_error;comment:最近一次失败原因;"`                                          // 最近一次失败原因
+	SentAt        *time.Time     `json:"sentAt" gorm:"column:sent_at;comment:发送成功时间;"`                                                  // 发送成功时间
+}
+
+func (EmailMessage) TableName() string {
+	return "email_messages"
+}
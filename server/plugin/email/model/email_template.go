@@ -0,0 +1,19 @@
+package model
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// EmailTemplate 邮件模板 同名模板可按语言提供多个版本 标题使用 text/template 正文使用 html/template
+type EmailTemplate struct {
+	global.GVA_MODEL
+	Name        string `json:"name" form:"name" gorm:"column:name;size:64;uniqueIndex:idx_email_template_locale;comment:模板标识;" binding:"required"` // 模板标识
+	Locale      string `json:"locale" form:"locale" gorm:"column:locale;size:16;uniqueIndex:idx_email_template_locale;comment:语言 为空时为默认版本;"`       // 语言 如 zh-CN en
+	Subject     string `json:"subject" form:"subject" gorm:"column:subject;comment:标题模板;" binding:"required"`                                      // 标题模板
+	Body        string `json:"body" form:"body" gorm:"column:body;comment:正文模板;" binding:"required"`                                               // 正文模板
+	Description string `json:"description" form:"description" gorm:"column:description;comment:说明 可列出模板使用的变量;"`                                    // 说明
+}
+
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
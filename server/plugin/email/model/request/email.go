@@ -0,0 +1,45 @@
+package request
+
+import (
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+)
+
+// EmailSend 发送邮件 指定 Template 时使用模板渲染标题和正文 否则直接使用 Subject 和 Body
+type EmailSend struct {
+	To          []string                `json:"to" binding:"required"` // 收件人
+	Cc          []string                `json:"cc"`                    // 抄送
+	Subject     string                  `json:"subject"`               // 标题
+	Body        string                  `json:"body"`                  // 正文 html
+	Template    string                  `json:"template"`              // 模板标识
+	Locale      string                  `json:"locale"`                // 模板语言 为空时使用配置的默认语言
+	Data        map[string]interface{}  `json:"data"`                  // 模板变量
+	Attachments []model.EmailAttachment `json:"attachments"`           // 附件 content 为base64
+}
+
+// EmailMessageSearch 发送记录查询条件
+type EmailMessageSearch struct {
+	To             string     `json:"to" form:"to"`
+	Status         string     `json:"status" form:"status"`
+	Template       string     `json:"template" form:"template"`
+	StartCreatedAt *time.Time `json:"startCreatedAt" form:"startCreatedAt"`
+	EndCreatedAt   *time.Time `json:"endCreatedAt" form:"endCreatedAt"`
+	request.PageInfo
+}
+
+// EmailTemplateSearch 邮件模板查询条件
+type EmailTemplateSearch struct {
+	Name   string `json:"name" form:"name"`
+	Locale string `json:"locale" form:"locale"`
+	request.PageInfo
+}
+
+// EmailTemplatePreview 预览模板 ID 为0时直接使用请求中的标题和正文
+type EmailTemplatePreview struct {
+	ID      uint                   `json:"ID"`
+	Subject string                 `json:"subject"`
+	Body    string                 `json:"body"`
+	Data    map[string]interface{} `json:"data"`
+}
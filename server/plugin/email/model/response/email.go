@@ -5,3 +5,14 @@ type Email struct {
 	Subject string `json:"subject"` // 邮件标题
 	Body    string `json:"body"`    // 邮件内容
 }
+
+// EmailSendResult 加入发送队列的邮件
+type EmailSendResult struct {
+	ID uint `json:"ID"` // 发送记录ID 可用于查询发送结果
+}
+
+// EmailPreview 模板渲染结果
+type EmailPreview struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
@@ -9,11 +9,21 @@ import (
 type EmailRouter struct{}
 
 func (s *EmailRouter) InitEmailRouter(Router *gin.RouterGroup) {
-	emailRouter := Router.Use(middleware.OperationRecord())
-	EmailApi := api.ApiGroupApp.EmailApi.EmailTest
-	SendEmail := api.ApiGroupApp.EmailApi.SendEmail
+	emailRouter := Router.Group("").Use(middleware.OperationRecord())
+	emailRouterWithoutRecord := Router.Group("")
+	emailApi := api.ApiGroupApp.EmailApi
 	{
-		emailRouter.POST("emailTest", EmailApi)  // 发送测试邮件
-		emailRouter.POST("sendEmail", SendEmail) // 发送邮件
+		emailRouter.POST("emailTest", emailApi.EmailTest)                       // 发送测试邮件
+		emailRouter.POST("sendEmail", emailApi.SendEmail)                       // 发送邮件
+		emailRouter.POST("retryEmailMessage", emailApi.RetryEmailMessage)       // 重新发送死信邮件
+		emailRouter.POST("createEmailTemplate", emailApi.CreateEmailTemplate)   // 创建邮件模板
+		emailRouter.PUT("updateEmailTemplate", emailApi.UpdateEmailTemplate)    // 更新邮件模板
+		emailRouter.DELETE("deleteEmailTemplate", emailApi.DeleteEmailTemplate) // 删除邮件模板
+	}
+	{
+		emailRouterWithoutRecord.GET("getEmailMessageList", emailApi.GetEmailMessageList)    // 分页获取发送记录
+		emailRouterWithoutRecord.GET("findEmailMessage", emailApi.FindEmailMessage)          // 获取发送记录详情
+		emailRouterWithoutRecord.GET("getEmailTemplateList", emailApi.GetEmailTemplateList)  // 分页获取邮件模板
+		emailRouterWithoutRecord.POST("previewEmailTemplate", emailApi.PreviewEmailTemplate) // 预览邮件模板
 	}
 }
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	emailGlobal "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/utils"
+	"gorm.io/gorm"
+)
+
+const defaultLocale = "zh-CN"
+
+func checkTemplate(tpl *model.EmailTemplate) error {
+	tpl.Name = strings.TrimSpace(tpl.Name)
+	tpl.Locale = strings.TrimSpace(tpl.Locale)
+	if err := utils.ParseTemplate(tpl.Subject, tpl.Body); err != nil {
+		return errors.New("模板语法错误:" + err.Error())
+	}
+	var count int64
+	err := global.GVA_DB.Model(&model.EmailTemplate{}).
+		Where("name = ? AND locale = ? AND id <> ?", tpl.Name, tpl.Locale, tpl.ID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("相同标识和语言的模板已存在")
+	}
+	return nil
+}
+
+// findTemplate 按 LocaleCandidates 的顺序查找模板 如 zh-CN 找不到时依次尝试 zh、默认语言和未指定语言的版本
+func findTemplate(name, locale string) (tpl model.EmailTemplate, err error) {
+	fallback := emailGlobal.GlobalConfig.DefaultLocale
+	if fallback == "" {
+		fallback = defaultLocale
+	}
+	candidates := utils.LocaleCandidates(locale, fallback)
+	var list []model.EmailTemplate
+	if err = global.GVA_DB.Where("name = ? AND locale IN ?", name, candidates).Find(&list).Error; err != nil {
+		return
+	}
+	for _, l := range candidates {
+		for _, t := range list {
+			if strings.EqualFold(t.Locale, l) {
+				return t, nil
+			}
+		}
+	}
+	return tpl, errors.New("邮件模板不存在:" + name)
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: CreateEmailTemplate
+//@description: 创建邮件模板
+//@param: tpl *model.EmailTemplate
+//@return: err error
+
+func (e *EmailService) CreateEmailTemplate(tpl *model.EmailTemplate) (err error) {
+	tpl.ID = 0
+	if err = checkTemplate(tpl); err != nil {
+		return err
+	}
+	return global.GVA_DB.Create(tpl).Error
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: UpdateEmailTemplate
+//@description: 更新邮件模板
+//@param: tpl model.EmailTemplate
+//@return: err error
+
+func (e *EmailService) UpdateEmailTemplate(tpl model.EmailTemplate) (err error) {
+	if err = checkTemplate(&tpl); err != nil {
+		return err
+	}
+	return global.GVA_DB.Model(&model.EmailTemplate{}).Where("id = ?", tpl.ID).
+		Select("name", "locale", "subject", "body", "description").Updates(&tpl).Error
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: DeleteEmailTemplate
+//@description: 删除邮件模板 直接删除以便重新创建同名模板
+//@param: id uint
+//@return: err error
+
+func (e *EmailService) DeleteEmailTemplate(id uint) (err error) {
+	return global.GVA_DB.Unscoped().Delete(&model.EmailTemplate{}, "id = ?", id).Error
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: GetEmailTemplateList
+//@description: 分页获取邮件模板
+//@param: info request.EmailTemplateSearch
+//@return: list []model.EmailTemplate, total int64, err error
+
+func (e *EmailService) GetEmailTemplateList(info request.EmailTemplateSearch) (list []model.EmailTemplate, total int64, err error) {
+	db := global.GVA_DB.Model(&model.EmailTemplate{})
+	if info.Name != "" {
+		db = db.Where("name LIKE ?", "%"+info.Name+"%")
+	}
+	if info.Locale != "" {
+		db = db.Where("locale = ?", info.Locale)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Scopes(info.Paginate()).Order("name, locale").Find(&list).Error
+	return list, total, err
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: PreviewEmailTemplate
+//@description: 使用给定变量渲染模板 ID 不为0时使用已保存的模板
+//@param: req request.EmailTemplatePreview
+//@return: subject string, body string, err error
+
+func (e *EmailService) PreviewEmailTemplate(req request.EmailTemplatePreview) (subject, body string, err error) {
+	if req.ID != 0 {
+		var tpl model.EmailTemplate
+		if err = global.GVA_DB.First(&tpl, "id = ?", req.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				err = errors.New("邮件模板不存在")
+			}
+			return
+		}
+		req.Subject, req.Body = tpl.Subject, tpl.Body
+	}
+	return utils.RenderTemplate(req.Subject, req.Body, req.Data)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	emailGlobal "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/jobqueue"
+)
+
+const (
+	defaultQueueWorkers  = 2
+	defaultMaxAttempts   = 5
+	defaultRetryBackoff  = 30 * time.Second
+	queueSendingTimeout  = 5 * time.Minute // 发送中的邮件超过此时间未完成(如进程退出)则重新发送
+	emailAddrSeparator   = ","
+	emailAttachmentLimit = 20 << 20 // 附件总大小上限
+)
+
+var queue = jobqueue.New(jobqueue.Options{
+	Name:  "email",
+	Model: &model.EmailMessage{},
+	Status: jobqueue.Status{
+		Pending: model.EmailStatusPending,
+		Running: model.EmailStatusSending,
+		Done:    model.EmailStatusSent,
+		Dead:    model.EmailStatusDead,
+	},
+	DoneAt: "sent_at",
+	Workers: func() int {
+		if n := emailGlobal.GlobalConfig.Workers; n > 0 {
+			return n
+		}
+		return defaultQueueWorkers
+	},
+	RetryBackoff: func() time.Duration {
+		if b := emailGlobal.GlobalConfig.RetryBackoff; b > 0 {
+			return time.Duration(b) * time.Second
+		}
+		return defaultRetryBackoff
+	},
+	Lease: func() time.Duration { return queueSendingTimeout },
+})
+
+// mailSender 发送邮件 默认为 utils.Sender
+type mailSender interface {
+	Send(msg utils.Message) error
+	Close()
+}
+
+// newMailSender 为每个发送协程创建发送者 测试时替换为不连接smtp服务器的实现
+var newMailSender = func() mailSender { return utils.NewSender() }
+
+// StartQueue 启动发送队列 重复调用只启动一次
+func StartQueue() {
+	queue.Start(func() jobqueue.Worker { return &queueWorker{sender: newMailSender()} })
+}
+
+// queueWorker 发送者 每个协程持有一个smtp连接
+type queueWorker struct {
+	sender mailSender
+}
+
+// Handle 发送已领取的邮件 服务器永久拒绝时不再重试
+func (w *queueWorker) Handle(job jobqueue.Job) (map[string]interface{}, error) {
+	var m model.EmailMessage
+	if err := global.GVA_DB.First(&m, job.ID).Error; err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+	msg := utils.Message{
+		To:      splitAddrs(m.To),
+		Cc:      splitAddrs(m.Cc),
+		Subject: m.Subject,
+		Body:    m.Body,
+	}
+	if len(m.Attachments) > 0 {
+		if err := json.Unmarshal(m.Attachments, &msg.Attachments); err != nil {
+			return nil, jobqueue.Permanent(err)
+		}
+	}
+	if err := w.sender.Send(msg); err != nil {
+		if isPermanent(err) {
+			return nil, jobqueue.Permanent(err)
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// Idle 一个轮询周期内没有邮件 关闭连接 避免长期占用服务器连接
+func (w *queueWorker) Idle() {
+	w.sender.Close()
+}
+
+// enqueue 将邮件加入发送队列
+func enqueue(m *model.EmailMessage) error {
+	m.Status = model.EmailStatusPending
+	m.Attempts = 0
+	m.MaxAttempts = emailGlobal.GlobalConfig.MaxAttempts
+	if m.MaxAttempts <= 0 {
+		m.MaxAttempts = defaultMaxAttempts
+	}
+	m.NextAttemptAt = time.Now()
+	if err := global.GVA_DB.Create(m).Error; err != nil {
+		return err
+	}
+	queue.Notify()
+	return nil
+}
+
+// authReplyCodes 与认证有关的5xx回复 修正账号或授权码后可以发送
+var authReplyCodes = map[int]bool{530: true, 534: true, 535: true, 538: true}
+
+// isPermanent 服务器以5xx拒绝这封邮件时重试没有意义
+// 连接、握手及认证阶段的失败与邮件本身无关 服务器恢复或修正配置后可以发送 按临时失败重试
+func isPermanent(err error) bool {
+	var connErr *utils.ConnectError
+	var reply *textproto.Error
+	if errors.As(err, &connErr) || !errors.As(err, &reply) {
+		return false
+	}
+	return reply.Code >= 500 && !authReplyCodes[reply.Code]
+}
+
+func splitAddrs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, emailAddrSeparator)
+}
+
+func joinAddrs(addrs []string) string {
+	list := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		if a = strings.TrimSpace(a); a != "" {
+			list = append(list, a)
+		}
+	}
+	return strings.Join(list, emailAddrSeparator)
+}
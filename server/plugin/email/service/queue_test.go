@@ -0,0 +1,290 @@
+package service
+
+import (
+	"errors"
+	"net/textproto"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/config"
+	emailGlobal "github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/utils"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// fakeSender 记录发送的邮件 按 Fail 设置的回复码依次失败
+type fakeSender struct {
+	mu   sync.Mutex
+	fail []int
+	sent []utils.Message
+}
+
+func (s *fakeSender) Send(msg utils.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.fail) > 0 {
+		code := s.fail[0]
+		s.fail = s.fail[1:]
+		return &textproto.Error{Code: code, Msg: "rejected"}
+	}
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *fakeSender) Close() {}
+
+// Fail 之后的发送依次以 codes 回复失败
+func (s *fakeSender) Fail(codes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = codes
+}
+
+func (s *fakeSender) Sent() []utils.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]utils.Message(nil), s.sent...)
+}
+
+var (
+	testSender    = &fakeSender{}
+	testQueueOnce sync.Once
+)
+
+// setupQueue 发送队列只能启动一次 各测试共用数据库及发送协程 开始前清空邮件及模板
+func setupQueue(t *testing.T) *fakeSender {
+	testQueueOnce.Do(func() {
+		db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 内存数据库每个连接各自独立 发送协程需使用同一个连接
+		sqlDB, _ := db.DB()
+		sqlDB.SetMaxOpenConns(1)
+		if err = db.AutoMigrate(&model.EmailTemplate{}, &model.EmailMessage{}); err != nil {
+			t.Fatal(err)
+		}
+		global.GVA_DB = db
+		global.GVA_LOG = zap.NewNop()
+		*emailGlobal.GlobalConfig = config.Email{
+			From:          "gva@example.com",
+			Workers:       1,
+			MaxAttempts:   3,
+			RetryBackoff:  10,
+			DefaultLocale: "zh-CN",
+		}
+		newMailSender = func() mailSender { return testSender }
+		StartQueue()
+	})
+	for _, m := range []interface{}{&model.EmailTemplate{}, &model.EmailMessage{}} {
+		if err := global.GVA_DB.Session(&gorm.Session{AllowGlobalUpdate: true}).Unscoped().Delete(m).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	testSender.mu.Lock()
+	testSender.fail, testSender.sent = nil, nil
+	testSender.mu.Unlock()
+	return testSender
+}
+
+func loadMessage(t *testing.T, id uint) model.EmailMessage {
+	var m model.EmailMessage
+	if err := global.GVA_DB.First(&m, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// waitMessage 等待发送协程处理邮件 直到 cond 成立
+func waitMessage(t *testing.T, id uint, cond func(m model.EmailMessage) bool, msg string) model.EmailMessage {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		m := loadMessage(t, id)
+		if cond(m) {
+			return m
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: %+v", msg, m)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// makeDue 跳过重试等待并唤醒发送协程
+func makeDue(t *testing.T, id uint) {
+	err := global.GVA_DB.Model(&model.EmailMessage{}).Where("id = ?", id).Update("next_attempt_at", time.Now()).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue.Notify()
+}
+
+// assertUnchanged 唤醒发送协程后邮件仍未被领取
+func assertUnchanged(t *testing.T, id uint, attempts int, msg string) {
+	t.Helper()
+	queue.Notify()
+	time.Sleep(200 * time.Millisecond)
+	if m := loadMessage(t, id); m.Attempts != attempts {
+		t.Fatalf("%s: %+v", msg, m)
+	}
+}
+
+func TestQueueSendsTemplate(t *testing.T) {
+	sender := setupQueue(t)
+	svc := EmailService{}
+	for _, tpl := range []model.EmailTemplate{
+		{Name: "welcome", Subject: "欢迎 {{.Name}}", Body: "<p>你好 {{.Name}}</p>"},
+		{Name: "welcome", Locale: "en", Subject: "Welcome {{.Name}}", Body: "<p>Hello {{.Name}}</p>"},
+	} {
+		if err := svc.CreateEmailTemplate(&tpl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.CreateEmailTemplate(&model.EmailTemplate{Name: "welcome", Locale: "en", Subject: "x", Body: "x"}); err == nil {
+		t.Fatal("duplicate name and locale should be rejected")
+	}
+	if err := svc.CreateEmailTemplate(&model.EmailTemplate{Name: "broken", Subject: "{{.Name", Body: "x"}); err == nil {
+		t.Fatal("invalid template should be rejected")
+	}
+
+	id, err := svc.Send(request.EmailSend{To: []string{"a@example.com"}, Template: "welcome", Locale: "en-US", Data: map[string]interface{}{"Name": "Tom"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := loadMessage(t, id)
+	if m.Subject != "Welcome Tom" || m.Locale != "en" || m.MaxAttempts != 3 {
+		t.Fatalf("unexpected queued message: %+v", m)
+	}
+	zhID, err := svc.Send(request.EmailSend{To: []string{"b@example.com"}, Template: "welcome", Locale: "fr", Data: map[string]interface{}{"Name": "Tom"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := loadMessage(t, zhID); m.Subject != "欢迎 Tom" {
+		t.Fatalf("unknown locale should fall back to the default template: %q", m.Subject)
+	}
+
+	sent := func(m model.EmailMessage) bool { return m.Status == model.EmailStatusSent }
+	if m = waitMessage(t, id, sent, "message should be sent"); m.SentAt == nil || m.Attempts != 1 {
+		t.Fatalf("unexpected sent message: %+v", m)
+	}
+	waitMessage(t, zhID, sent, "message should be sent")
+	if list := sender.Sent(); len(list) != 2 || list[0].Subject != "Welcome Tom" || list[0].To[0] != "a@example.com" {
+		t.Fatalf("sender received %+v", list)
+	}
+}
+
+func TestQueueRetryAndDeadLetter(t *testing.T) {
+	sender := setupQueue(t)
+	svc := EmailService{}
+	sender.Fail(451, 451, 451)
+	start := time.Now()
+	id, err := svc.Send(request.EmailSend{To: []string{"a@example.com"}, Subject: "retry", Body: "body"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := waitMessage(t, id, func(m model.EmailMessage) bool {
+		return m.Status == model.EmailStatusPending && m.Attempts == 1
+	}, "temporary failure should be retried")
+	if m.LastError == "" {
+		t.Fatalf("last error should be recorded: %+v", m)
+	}
+	if wait := m.NextAttemptAt.Sub(start); wait < 10*time.Second || wait > 11*time.Second {
+		t.Fatalf("first retry after %v, want 10s", wait)
+	}
+	assertUnchanged(t, id, 1, "message should wait for the backoff")
+
+	start = time.Now()
+	makeDue(t, id)
+	m = waitMessage(t, id, func(m model.EmailMessage) bool {
+		return m.Status == model.EmailStatusPending && m.Attempts == 2
+	}, "message should be retried again")
+	if wait := m.NextAttemptAt.Sub(start); wait < 20*time.Second || wait > 21*time.Second {
+		t.Fatalf("second retry after %v, want 20s: %+v", wait, m)
+	}
+
+	makeDue(t, id)
+	if m = waitMessage(t, id, func(m model.EmailMessage) bool {
+		return m.Status == model.EmailStatusDead
+	}, "message should be dead after max attempts"); m.Attempts != 3 {
+		t.Fatalf("unexpected dead message: %+v", m)
+	}
+
+	if err = svc.RetryEmailMessage(id); err != nil {
+		t.Fatal(err)
+	}
+	if m = waitMessage(t, id, func(m model.EmailMessage) bool {
+		return m.Status == model.EmailStatusSent
+	}, "manually retried message should be sent"); m.Attempts != 1 {
+		t.Fatalf("unexpected sent message: %+v", m)
+	}
+	if err = svc.RetryEmailMessage(id); err == nil {
+		t.Fatal("only dead messages can be retried")
+	}
+}
+
+func TestQueuePermanentFailure(t *testing.T) {
+	sender := setupQueue(t)
+	svc := EmailService{}
+	sender.Fail(550)
+	id, err := svc.Send(request.EmailSend{To: []string{"a@example.com"}, Subject: "bounce", Body: "body"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := waitMessage(t, id, func(m model.EmailMessage) bool {
+		return m.Status == model.EmailStatusDead
+	}, "5xx reply should not be retried"); m.Attempts != 1 {
+		t.Fatalf("unexpected dead message: %+v", m)
+	}
+}
+
+func TestQueueRecoversStaleSending(t *testing.T) {
+	setupQueue(t)
+	// 模拟已被领取 直接写入发送中的邮件 不经过 enqueue 唤醒发送协程
+	m := model.EmailMessage{
+		To:            "a@example.com",
+		Subject:       "stale",
+		Body:          "body",
+		Status:        model.EmailStatusSending,
+		Attempts:      1,
+		MaxAttempts:   3,
+		NextAttemptAt: time.Now().Add(queueSendingTimeout),
+	}
+	if err := global.GVA_DB.Create(&m).Error; err != nil {
+		t.Fatal(err)
+	}
+	assertUnchanged(t, m.ID, 1, "message being sent should not be claimed again")
+	// 模拟发送中进程退出 超时后重新发送
+	makeDue(t, m.ID)
+	if m = waitMessage(t, m.ID, func(m model.EmailMessage) bool {
+		return m.Status == model.EmailStatusSent
+	}, "stale message should be sent"); m.Attempts != 2 {
+		t.Fatalf("unexpected sent message: %+v", m)
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&textproto.Error{Code: 550, Msg: "mailbox unavailable"}, true},
+		{&textproto.Error{Code: 451, Msg: "try again later"}, false},
+		{&textproto.Error{Code: 535, Msg: "authentication failed"}, false},
+		{&textproto.Error{Code: 530, Msg: "authentication required"}, false},
+		{&utils.ConnectError{Err: &textproto.Error{Code: 554, Msg: "no service"}}, false},
+		{errors.New("i/o timeout"), false},
+	}
+	for _, c := range cases {
+		if got := isPermanent(c.err); got != c.want {
+			t.Errorf("isPermanent(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
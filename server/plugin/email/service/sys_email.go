@@ -1,6 +1,14 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/utils"
 )
 
@@ -8,7 +16,7 @@ type EmailService struct{}
 
 //@author: [maplepie](https://github.com/maplepie)
 //@function: EmailTest
-//@description: 发送邮件测试
+//@description: 发送邮件测试 不经过发送队列 直接返回发送结果
 //@return: err error
 
 func (e *EmailService) EmailTest() (err error) {
@@ -19,14 +27,139 @@ func (e *EmailService) EmailTest() (err error) {
 }
 
 //@author: [maplepie](https://github.com/maplepie)
-//@function: EmailTest
-//@description: 发送邮件测试
+//@function: SendEmail
+//@description: 将邮件加入发送队列 立即返回 发送结果可在发送记录中查看
 //@return: err error
-//@params to string 	 收件人
+//@params to string 	 收件人 多个以英文逗号分隔
 //@params subject string   标题（主题）
 //@params body  string 	 邮件内容
 
 func (e *EmailService) SendEmail(to, subject, body string) (err error) {
-	err = utils.Email(to, subject, body)
+	_, err = e.Send(request.EmailSend{To: strings.Split(to, ","), Subject: subject, Body: body})
 	return err
 }
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: Send
+//@description: 将邮件加入发送队列 指定模板时按语言查找模板并渲染
+//@param: req request.EmailSend
+//@return: id uint, err error
+
+func (e *EmailService) Send(req request.EmailSend) (id uint, err error) {
+	m := model.EmailMessage{
+		To:      joinAddrs(req.To),
+		Cc:      joinAddrs(req.Cc),
+		Subject: req.Subject,
+		Body:    req.Body,
+	}
+	if m.To == "" {
+		return 0, errors.New("收件人不能为空")
+	}
+	if req.Template != "" {
+		tpl, err := findTemplate(req.Template, req.Locale)
+		if err != nil {
+			return 0, err
+		}
+		m.Subject, m.Body, err = utils.RenderTemplate(tpl.Subject, tpl.Body, req.Data)
+		if err != nil {
+			return 0, errors.New("渲染模板失败:" + err.Error())
+		}
+		m.Template, m.Locale = tpl.Name, tpl.Locale
+	}
+	if m.Subject == "" {
+		return 0, errors.New("标题不能为空")
+	}
+	if len(req.Attachments) > 0 {
+		size := 0
+		for _, a := range req.Attachments {
+			if a.Filename == "" {
+				return 0, errors.New("附件文件名不能为空")
+			}
+			size += len(a.Content)
+		}
+		if size > emailAttachmentLimit {
+			return 0, errors.New("附件总大小不能超过20MB")
+		}
+		if m.Attachments, err = json.Marshal(req.Attachments); err != nil {
+			return 0, err
+		}
+	}
+	if err = enqueue(&m); err != nil {
+		return 0, err
+	}
+	return m.ID, nil
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: GetEmailMessageList
+//@description: 分页获取发送记录 不返回正文和附件
+//@param: info request.EmailMessageSearch
+//@return: list []model.EmailMessage, total int64, err error
+
+func (e *EmailService) GetEmailMessageList(info request.EmailMessageSearch) (list []model.EmailMessage, total int64, err error) {
+	db := global.GVA_DB.Model(&model.EmailMessage{})
+	if info.To != "" {
+		db = db.Where("to_addrs LIKE ?", "%"+info.To+"%")
+	}
+	if info.Status != "" {
+		db = db.Where("status = ?", info.Status)
+	}
+	if info.Template != "" {
+		db = db.Where("template = ?", info.Template)
+	}
+	if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
+		db = db.Where("created_at BETWEEN ? AND ?", info.StartCreatedAt, info.EndCreatedAt)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Omit("body", "attachments").Scopes(info.Paginate()).Order("id desc").Find(&list).Error
+	return list, total, err
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: FindEmailMessage
+//@description: 获取发送记录详情 附件只返回文件名和类型
+//@param: id uint
+//@return: m model.EmailMessage, err error
+
+func (e *EmailService) FindEmailMessage(id uint) (m model.EmailMessage, err error) {
+	if err = global.GVA_DB.First(&m, "id = ?", id).Error; err != nil {
+		return
+	}
+	if len(m.Attachments) > 0 {
+		var attachments []model.EmailAttachment
+		if err = json.Unmarshal(m.Attachments, &attachments); err != nil {
+			return
+		}
+		for i := range attachments {
+			attachments[i].Content = nil
+		}
+		m.Attachments, err = json.Marshal(attachments)
+	}
+	return
+}
+
+//@author: [maplepie](https://github.com/maplepie)
+//@function: RetryEmailMessage
+//@description: 重新发送死信邮件 尝试次数清零
+//@param: id uint
+//@return: err error
+
+func (e *EmailService) RetryEmailMessage(id uint) (err error) {
+	res := global.GVA_DB.Model(&model.EmailMessage{}).
+		Where("id = ? AND status = ?", id, model.EmailStatusDead).
+		Updates(map[string]interface{}{
+			"status":          model.EmailStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("只能重试已转为死信的邮件")
+	}
+	queue.Notify()
+	return nil
+}
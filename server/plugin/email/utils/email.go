@@ -1,14 +1,10 @@
 package utils
 
 import (
-	"crypto/tls"
-	"fmt"
 	"net/smtp"
 	"strings"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
-
-	"github.com/jordan-wright/email"
 )
 
 //@author: [maplepie](https://github.com/maplepie)
@@ -49,42 +45,12 @@ func EmailTest(subject string, body string) error {
 
 //@author: [maplepie](https://github.com/maplepie)
 //@function: send
-//@description: Email发送方法
+//@description: Email发送方法 复用默认发送者的smtp连接
 //@param: subject string, body string
 //@return: error
 
 func send(to []string, subject string, body string) error {
-	from := global.GlobalConfig.From
-	nickname := global.GlobalConfig.Nickname
-	secret := global.GlobalConfig.Secret
-	host := global.GlobalConfig.Host
-	port := global.GlobalConfig.Port
-	isSSL := global.GlobalConfig.IsSSL
-	isLoginAuth := global.GlobalConfig.IsLoginAuth
-
-	var auth smtp.Auth
-	if isLoginAuth {
-		auth = LoginAuth(from, secret)
-	} else {
-		auth = smtp.PlainAuth("", from, secret, host)
-	}
-	e := email.NewEmail()
-	if nickname != "" {
-		e.From = fmt.Sprintf("%s <%s>", nickname, from)
-	} else {
-		e.From = from
-	}
-	e.To = to
-	e.Subject = subject
-	e.HTML = []byte(body)
-	var err error
-	hostAddr := fmt.Sprintf("%s:%d", host, port)
-	if isSSL {
-		err = e.SendWithTLS(hostAddr, auth, &tls.Config{ServerName: host})
-	} else {
-		err = e.Send(hostAddr, auth)
-	}
-	return err
+	return defaultSender.Send(Message{To: to, Subject: subject, Body: body})
 }
 
 // LoginAuth 用于IBM、微软邮箱服务器的LOGIN认证方式
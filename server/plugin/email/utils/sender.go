@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"github.com/jordan-wright/email"
+)
+
+// senderMaxIdle 连接空闲超过此时间后不再复用 避免使用已被服务器关闭的连接
+const senderMaxIdle = 30 * time.Second
+
+// senderDialTimeout 连接smtp服务器的超时时间
+const senderDialTimeout = 10 * time.Second
+
+// senderTimeout 每次发送(包括连接后的握手及登录)的读写超时时间 避免服务器不响应时一直阻塞发送协程
+const senderTimeout = time.Minute
+
+// defaultSender Email、ErrorToEmail 等同步发送方法共用的发送者
+var defaultSender = NewSender()
+
+// Message 待发送的邮件 正文为html
+type Message struct {
+	To          []string
+	Cc          []string
+	Subject     string
+	Body        string
+	Attachments []model.EmailAttachment
+}
+
+// build 生成邮件原文及全部收件地址
+func (m Message) build() (raw []byte, rcpts []string, err error) {
+	cfg := global.GlobalConfig
+	e := email.NewEmail()
+	if cfg.Nickname != "" {
+		e.From = fmt.Sprintf("%s <%s>", cfg.Nickname, cfg.From)
+	} else {
+		e.From = cfg.From
+	}
+	e.To, e.Cc = trimAddrs(m.To), trimAddrs(m.Cc)
+	e.Subject = m.Subject
+	e.HTML = []byte(m.Body)
+	for _, a := range m.Attachments {
+		if _, err = e.Attach(bytes.NewReader(a.Content), a.Filename, a.ContentType); err != nil {
+			return nil, nil, err
+		}
+	}
+	rcpts = append(append(rcpts, e.To...), e.Cc...)
+	if len(rcpts) == 0 {
+		return nil, nil, errors.New("收件人不能为空")
+	}
+	raw, err = e.Bytes()
+	return raw, rcpts, err
+}
+
+func trimAddrs(addrs []string) []string {
+	list := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		if a = strings.TrimSpace(a); a != "" {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+// ConnectError 连接、握手或登录smtp服务器失败 与具体的邮件无关
+// 服务器恢复或修正配置后可以发送 即使服务器回复5xx(如535认证失败)也不应视为邮件被拒绝
+type ConnectError struct {
+	Err error
+}
+
+func (e *ConnectError) Error() string { return e.Err.Error() }
+func (e *ConnectError) Unwrap() error { return e.Err }
+
+// Sender 复用同一个smtp连接发送多封邮件 连接出错或空闲过久时重新连接
+type Sender struct {
+	mu      sync.Mutex
+	client  *smtp.Client
+	conn    net.Conn
+	last    time.Time
+	timeout time.Duration
+}
+
+func NewSender() *Sender {
+	return &Sender{timeout: senderTimeout}
+}
+
+// Send 发送邮件 服务器返回的错误为 *textproto.Error 可按 Code 区分临时失败(4xx)和永久失败(5xx)
+// 连接阶段的错误为 *ConnectError
+func (s *Sender) Send(m Message) error {
+	raw, rcpts, err := m.build()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reused := s.client != nil && time.Since(s.last) < senderMaxIdle
+	if !reused {
+		s.close()
+	}
+	err = s.send(rcpts, raw)
+	var reply *textproto.Error
+	if err != nil && reused && !errors.As(err, &reply) {
+		// 复用的连接可能已被服务器关闭 重新连接后再试一次
+		s.abort()
+		err = s.send(rcpts, raw)
+	}
+	switch {
+	case err == nil:
+		s.last = time.Now()
+	case errors.As(err, &reply) && s.client != nil && s.client.Reset() == nil:
+		// 服务器拒绝了这封邮件 连接仍可继续使用
+		s.last = time.Now()
+	default:
+		s.abort()
+	}
+	return err
+}
+
+// Close 关闭连接
+func (s *Sender) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.close()
+}
+
+func (s *Sender) close() {
+	if s.client != nil {
+		_ = s.conn.SetDeadline(time.Now().Add(senderDialTimeout))
+		_ = s.client.Quit()
+		_ = s.client.Close()
+		s.client, s.conn = nil, nil
+	}
+}
+
+// abort 出错后直接关闭连接 连接状态未知(如读写超时) 不再等待QUIT的回复
+func (s *Sender) abort() {
+	if s.client != nil {
+		_ = s.client.Close()
+		s.client, s.conn = nil, nil
+	}
+}
+
+func (s *Sender) send(rcpts []string, raw []byte) error {
+	if s.client == nil {
+		c, conn, err := dial(s.timeout)
+		if err != nil {
+			return &ConnectError{Err: err}
+		}
+		s.client, s.conn = c, conn
+	}
+	if err := s.conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+	c := s.client
+	if err := c.Mail(global.GlobalConfig.From); err != nil {
+		return err
+	}
+	for _, addr := range rcpts {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(raw); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// dial 按配置连接smtp服务器 is-ssl 为true时直接使用tls 否则服务器支持时使用STARTTLS 服务器支持AUTH时登录
+// 返回的连接用于设置读写超时 STARTTLS 后设置在底层连接上同样生效
+func dial(timeout time.Duration) (*smtp.Client, net.Conn, error) {
+	cfg := global.GlobalConfig
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	dialer := &net.Dialer{Timeout: senderDialTimeout}
+	var conn net.Conn
+	var err error
+	if cfg.IsSSL {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: cfg.Host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if err = c.Hello("localhost"); err != nil {
+		c.Close()
+		return nil, nil, err
+	}
+	if ok, _ := c.Extension("STARTTLS"); ok && !cfg.IsSSL {
+		if err = c.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			c.Close()
+			return nil, nil, err
+		}
+	}
+	if ok, _ := c.Extension("AUTH"); ok && cfg.Secret != "" {
+		var auth smtp.Auth
+		if cfg.IsLoginAuth {
+			auth = LoginAuth(cfg.From, cfg.Secret)
+		} else {
+			auth = smtp.PlainAuth("", cfg.From, cfg.Secret, cfg.Host)
+		}
+		if err = c.Auth(auth); err != nil {
+			c.Close()
+			return nil, nil, err
+		}
+	}
+	return c, conn, nil
+}
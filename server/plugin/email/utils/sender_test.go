@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/model"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/email/utils/smtptest"
+)
+
+func useServer(t *testing.T) *smtptest.Server {
+	srv := smtptest.NewServer()
+	t.Cleanup(srv.Close)
+	old := *global.GlobalConfig
+	*global.GlobalConfig = config.Email{From: "gva@example.com", Host: srv.Host(), Port: srv.Port()}
+	t.Cleanup(func() { *global.GlobalConfig = old })
+	return srv
+}
+
+func TestSenderReusesConnection(t *testing.T) {
+	srv := useServer(t)
+	s := NewSender()
+	defer s.Close()
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		if err := s.Send(Message{To: []string{to}, Subject: "hello", Body: "<p>hi</p>"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	msgs := srv.Messages()
+	if len(msgs) != 2 || msgs[1].To[0] != "b@example.com" || msgs[0].From != "gva@example.com" {
+		t.Fatalf("unexpected messages: %+v", msgs)
+	}
+	if n := srv.Connections(); n != 1 {
+		t.Fatalf("connections = %d, want 1", n)
+	}
+}
+
+func TestSenderRejectionKeepsConnection(t *testing.T) {
+	srv := useServer(t)
+	s := NewSender()
+	defer s.Close()
+	srv.Fail(550)
+	err := s.Send(Message{To: []string{"a@example.com"}, Subject: "hello"})
+	var reply *textproto.Error
+	if !errors.As(err, &reply) || reply.Code != 550 {
+		t.Fatalf("err = %v, want a 550 reply", err)
+	}
+	if err = s.Send(Message{To: []string{"a@example.com"}, Cc: []string{"c@example.com"}, Subject: "again"}); err != nil {
+		t.Fatal(err)
+	}
+	msgs := srv.Messages()
+	if len(msgs) != 1 || len(msgs[0].To) != 2 {
+		t.Fatalf("unexpected messages: %+v", msgs)
+	}
+	if n := srv.Connections(); n != 1 {
+		t.Fatalf("connections = %d, want 1", n)
+	}
+}
+
+func TestSenderTimeout(t *testing.T) {
+	srv := useServer(t)
+	s := NewSender()
+	s.timeout = 200 * time.Millisecond
+	defer s.Close()
+	srv.Stall(1)
+	start := time.Now()
+	err := s.Send(Message{To: []string{"a@example.com"}, Subject: "stalled"})
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("err = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Send blocked for %v", elapsed)
+	}
+	if err = s.Send(Message{To: []string{"b@example.com"}, Subject: "again"}); err != nil {
+		t.Fatal(err)
+	}
+	if msgs, n := srv.Messages(), srv.Connections(); len(msgs) != 1 || n != 2 {
+		t.Fatalf("messages = %+v, connections = %d, want a new connection after the timeout", msgs, n)
+	}
+}
+
+func TestSenderConnectError(t *testing.T) {
+	srv := useServer(t)
+	srv.Close()
+	err := NewSender().Send(Message{To: []string{"a@example.com"}, Subject: "hello"})
+	var connErr *ConnectError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("err = %v, want a ConnectError", err)
+	}
+}
+
+func TestSenderAttachments(t *testing.T) {
+	srv := useServer(t)
+	s := NewSender()
+	defer s.Close()
+	err := s.Send(Message{
+		To:          []string{"a@example.com"},
+		Subject:     "report",
+		Body:        "<p>see attachment</p>",
+		Attachments: []model.EmailAttachment{{Filename: "report.csv", ContentType: "text/csv", Content: []byte("a,b\n1,2\n")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := srv.Messages()[0].Data
+	if !bytes.Contains(data, []byte(`filename="report.csv"`)) || !bytes.Contains(data, []byte("Content-Type: text/csv")) {
+		t.Fatalf("attachment missing from message:\n%s", data)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	subject, body, err := RenderTemplate("Hi {{.Name}}\r\nBcc: x@example.com", "<p>{{.Name}}</p>", map[string]interface{}{"Name": "<b>Tom</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Hi <b>Tom</b> Bcc: x@example.com" {
+		t.Errorf("subject = %q", subject)
+	}
+	if body != "<p>&lt;b&gt;Tom&lt;/b&gt;</p>" {
+		t.Errorf("body = %q", body)
+	}
+	got := LocaleCandidates("en-US", "zh-CN")
+	want := []string{"en-US", "en", "zh-CN", "zh", ""}
+	if len(got) != len(want) {
+		t.Fatalf("candidates = %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("candidates = %q", got)
+		}
+	}
+}
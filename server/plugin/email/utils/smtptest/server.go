@@ -0,0 +1,211 @@
+// Package smtptest 提供用于测试的本地smtp服务 无需真实邮箱即可验证邮件发送流程
+package smtptest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message 服务收到的邮件
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Server 本地smtp服务 只实现发送邮件所需的命令 不支持认证和加密
+type Server struct {
+	ln       net.Listener
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	messages []Message
+	conns    int
+	fails    []int
+	stalls   int
+}
+
+// NewServer 在随机端口启动服务 与 httptest.NewServer 一样 监听失败时panic
+func NewServer() *Server {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("smtptest: failed to listen: %v", err))
+	}
+	s := &Server{ln: ln}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// Host 服务地址
+func (s *Server) Host() string {
+	return s.ln.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Port 服务端口
+func (s *Server) Port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+// Messages 已收到的邮件
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Connections 已建立的连接数
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+// Fail 接下来的邮件依次以给定的状态码拒绝 如 451 为临时失败 550 为永久失败
+func (s *Server) Fail(codes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fails = append(s.fails, codes...)
+}
+
+// Stall 接下来的 n 封邮件在收到内容后不再回复 直到客户端关闭连接 用于模拟无响应的服务器
+func (s *Server) Stall(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stalls += n
+}
+
+// Close 停止服务并关闭全部连接
+func (s *Server) Close() {
+	s.ln.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	var conns sync.WaitGroup
+	var mu sync.Mutex
+	open := map[net.Conn]struct{}{}
+	defer func() {
+		mu.Lock()
+		for c := range open {
+			c.Close()
+		}
+		mu.Unlock()
+		conns.Wait()
+	}()
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		mu.Lock()
+		open[c] = struct{}{}
+		mu.Unlock()
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			s.handle(c)
+			mu.Lock()
+			delete(open, c)
+			mu.Unlock()
+		}()
+	}
+}
+
+func (s *Server) handle(c net.Conn) {
+	defer c.Close()
+	r := textproto.NewReader(bufio.NewReader(c))
+	reply := func(code int, msg string) {
+		fmt.Fprintf(c, "%d %s\r\n", code, msg)
+	}
+	reply(220, "smtptest ESMTP")
+	var msg Message
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "EHLO":
+			fmt.Fprintf(c, "250-smtptest\r\n250 8BITMIME\r\n")
+		case "HELO", "NOOP":
+			reply(250, "OK")
+		case "MAIL":
+			msg = Message{From: address(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			msg.To = append(msg.To, address(arg))
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "End data with <CR><LF>.<CR><LF>")
+			data, err := r.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = data
+			if s.nextStall() {
+				_, _ = io.Copy(io.Discard, c)
+				return
+			}
+			if code := s.nextFail(); code != 0 {
+				reply(code, "rejected by smtptest "+strconv.Itoa(code))
+			} else {
+				s.mu.Lock()
+				s.messages = append(s.messages, msg)
+				s.mu.Unlock()
+				reply(250, "OK")
+			}
+			msg = Message{}
+		case "RSET":
+			msg = Message{}
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+func (s *Server) nextStall() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stalls == 0 {
+		return false
+	}
+	s.stalls--
+	return true
+}
+
+func (s *Server) nextFail() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.fails) == 0 {
+		return 0
+	}
+	code := s.fails[0]
+	s.fails = s.fails[1:]
+	return code
+}
+
+// address 取出 FROM:<a@b.com> BODY=8BITMIME 中的地址
+func address(arg string) string {
+	if i := strings.IndexByte(arg, '<'); i >= 0 {
+		if j := strings.IndexByte(arg[i:], '>'); j > 0 {
+			return arg[i+1 : i+j]
+		}
+	}
+	_, addr, _ := strings.Cut(arg, ":")
+	return strings.TrimSpace(addr)
+}
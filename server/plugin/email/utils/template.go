@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"bytes"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+)
+
+// ParseTemplate 校验模板语法
+func ParseTemplate(subject, body string) error {
+	if _, err := textTemplate.New("subject").Parse(subject); err != nil {
+		return err
+	}
+	_, err := htmlTemplate.New("body").Parse(body)
+	return err
+}
+
+// RenderTemplate 渲染邮件模板 标题使用 text/template 正文使用 html/template 变量会按html转义
+func RenderTemplate(subject, body string, data interface{}) (string, string, error) {
+	st, err := textTemplate.New("subject").Parse(subject)
+	if err != nil {
+		return "", "", err
+	}
+	bt, err := htmlTemplate.New("body").Parse(body)
+	if err != nil {
+		return "", "", err
+	}
+	var s, b bytes.Buffer
+	if err = st.Execute(&s, data); err != nil {
+		return "", "", err
+	}
+	if err = bt.Execute(&b, data); err != nil {
+		return "", "", err
+	}
+	// 标题中不允许换行 避免注入邮件头
+	return strings.Join(strings.Fields(s.String()), " "), b.String(), nil
+}
+
+// LocaleCandidates 查找模板时依次尝试的语言 如 zh-CN 依次为 zh-CN、zh、默认语言、空(默认版本)
+func LocaleCandidates(locale, defaultLocale string) []string {
+	var list []string
+	add := func(l string) {
+		for _, v := range list {
+			if strings.EqualFold(v, l) {
+				return
+			}
+		}
+		list = append(list, l)
+	}
+	for _, l := range []string{locale, defaultLocale} {
+		if l == "" {
+			continue
+		}
+		add(l)
+		if i := strings.IndexAny(l, "-_"); i > 0 {
+			add(l[:i])
+		}
+	}
+	add("")
+	return list
+}
@@ -8,7 +8,16 @@ import (
 
 // Api 初始化API注册
 func Api(ctx context.Context) {
-	entities := []model.SysApi{
+	utils.RegisterApis(apis()...)
+}
+
+// RemoveApi 卸载时删除注册的API
+func RemoveApi(ctx context.Context) error {
+	return utils.UnregisterApis(apis()...)
+}
+
+func apis() []model.SysApi {
+	return []model.SysApi{
 		{
 			Path:        "/fansClub/createFansClub",
 			Description: "创建粉丝团",
@@ -112,5 +121,4 @@ func Api(ctx context.Context) {
 			Method:      "POST",
 		},
 	}
-	utils.RegisterApis(entities...)
 }
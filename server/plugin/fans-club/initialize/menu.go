@@ -9,7 +9,16 @@ import (
 
 // Menu 初始化菜单注册
 func Menu(ctx context.Context) {
-	entities := []model.SysBaseMenu{
+	utils.RegisterMenus(menus()...)
+}
+
+// RemoveMenu 卸载时删除注册的菜单
+func RemoveMenu(ctx context.Context) error {
+	return utils.UnregisterMenus(menus()...)
+}
+
+func menus() []model.SysBaseMenu {
+	return []model.SysBaseMenu{
 		{
 			ParentId:  24,
 			Path:      "fansClub",
@@ -61,5 +70,4 @@ func Menu(ctx context.Context) {
 			},
 		},
 	}
-	utils.RegisterMenus(entities...)
 }
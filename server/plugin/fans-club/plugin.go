@@ -2,26 +2,67 @@ package fans_club
 
 import (
 	"context"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/plugin/fans-club/initialize"
+	"github.com/flipped-aurora/gin-vue-admin/server/plugin/fans-club/model"
+	interfaces "github.com/flipped-aurora/gin-vue-admin/server/utils/plugin/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 )
 
-type plugin struct{}
+var _ interfaces.Lifecycle = (*plugin)(nil)
+
+type plugin struct {
+	interfaces.Base
+}
 
 var Plugin = new(plugin)
 
-func (p *plugin) Register(group *gin.Engine) {
-	ctx := context.Background()
+func (p *plugin) Name() string {
+	return "fans-club"
+}
 
+// Install 首次启用时注册API及菜单
+func (p *plugin) Install(ctx context.Context) error {
 	// 注册API到系统
 	initialize.Api(ctx)
 
 	// 注册菜单到系统
 	initialize.Menu(ctx)
+	return nil
+}
 
-	// 初始化数据库表
+// Enable 启用时初始化数据库表
+func (p *plugin) Enable(ctx context.Context) error {
 	initialize.Gorm(ctx)
+	return nil
+}
 
+// Uninstall 删除注册的API及菜单 保留粉丝团数据
+func (p *plugin) Uninstall(ctx context.Context) error {
+	if err := initialize.RemoveApi(ctx); err != nil {
+		return err
+	}
+	return initialize.RemoveMenu(ctx)
+}
+
+func (p *plugin) Health(ctx context.Context) error {
+	migrator := global.GVA_DB.WithContext(ctx).Migrator()
+	for _, table := range []interface{}{&model.FansClub{}, &model.FansClubMember{}, &model.FansClubPost{}} {
+		if !migrator.HasTable(table) {
+			return errors.Errorf("数据表%T不存在", table)
+		}
+	}
+	return nil
+}
+
+// Menus 父菜单使用 view/routerHolder.vue 需要声明
+func (p *plugin) Menus() []string {
+	return []string{"fansClub"}
+}
+
+func (p *plugin) Register(group *gin.Engine) {
 	// 初始化路由
 	initialize.Router(group)
 }
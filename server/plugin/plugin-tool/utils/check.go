@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	service "github.com/flipped-aurora/gin-vue-admin/server/service/system"
+	"gorm.io/gorm"
 )
 
 func RegisterApis(apis ...system.SysApi) {
@@ -48,3 +51,39 @@ func RegisterMenus(menus ...system.SysBaseMenu) {
 		fmt.Println(err)
 	}
 }
+
+// UnregisterApis 删除插件注册的api及其权限 不存在的api跳过
+func UnregisterApis(apis ...system.SysApi) error {
+	for i := range apis {
+		var entity system.SysApi
+		err := global.GVA_DB.First(&entity, "path = ? AND method = ?", apis[i].Path, apis[i].Method).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = service.ApiServiceApp.DeleteApi(entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnregisterMenus 删除插件注册的菜单 按注册的相反顺序删除 先删除子菜单 不存在的菜单跳过
+func UnregisterMenus(menus ...system.SysBaseMenu) error {
+	for i := len(menus) - 1; i >= 0; i-- {
+		var entity system.SysBaseMenu
+		err := global.GVA_DB.First(&entity, "name = ?", menus[i].Name).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = service.BaseMenuServiceApp.DeleteBaseMenu(int(entity.ID)); err != nil {
+			return fmt.Errorf("删除菜单[%s]失败: %w", entity.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+{{- if .IsAdd}}
+// 新增字段后请通过「更新模块」重新生成测试用例
+{{- else}}
+package {{.Package}}
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	{{- if .HasTimer }}
+	"time"
+	{{- end }}
+
+	"{{.Module}}/global"
+	"{{.Module}}/model/common/response"
+	"{{.Module}}/model/{{.Package}}"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	{{- if .NeedJSON }}
+	"gorm.io/datatypes"
+	{{- end }}
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// setup{{.StructName}}Router 使用内存SQLite作为{{.Description}}的数据库 注册与生成的接口一致的路由
+func setup{{.StructName}}Router(t *testing.T) *gin.Engine {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	models := []any{&{{.Package}}.{{.StructName}}{} {{- range .SubTables}}, &{{$.Package}}.{{.StructName}}{} {{- end}}}
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err = stmt.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if strings.HasPrefix(string(field.DataType), "enum") {
+				field.DataType = schema.String
+			}
+		}
+	} // SQLite不支持enum类型 按文本建表
+	if err = db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	{{- if eq .BusinessDB "" }}
+	global.GVA_DB = db
+	{{- else }}
+	global.GVA_DBList = map[string]*gorm.DB{"{{.BusinessDB}}": db}
+	{{- end }}
+	global.GVA_LOG = zap.NewNop()
+	t.Cleanup(func() { sqlDB.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := &{{.StructName}}Api{}
+	group := router.Group("{{.Abbreviation}}")
+	group.POST("create{{.StructName}}", api.Create{{.StructName}})
+	group.DELETE("delete{{.StructName}}", api.Delete{{.StructName}})
+	group.DELETE("delete{{.StructName}}ByIds", api.Delete{{.StructName}}ByIds)
+	group.PUT("update{{.StructName}}", api.Update{{.StructName}})
+	group.GET("find{{.StructName}}", api.Find{{.StructName}})
+	group.GET("get{{.StructName}}List", api.Get{{.StructName}}List)
+	return router
+}
+
+// {{.Abbreviation}}Ptr 取值的指针
+func {{.Abbreviation}}Ptr[T any](v T) *T {
+	return &v
+}
+
+// new{{.StructName}}Records 测试记录 搜索用例以第2条记录的取值作为搜索条件
+func new{{.StructName}}Records() []{{.Package}}.{{.StructName}} {
+	return []{{.Package}}.{{.StructName}}{
+		{{- range $n := TestRecordNumbers }}
+		{
+			{{- range $.Fields }}
+			{{- with GenerateTestValue . $.Abbreviation $n }}
+			{{.}}
+			{{- end }}
+			{{- end }}
+			{{- range $.SubTables }}
+			{{.FieldName}}: []{{$.Package}}.{{.StructName}}{ {
+				{{- range .Fields }}
+				{{- with GenerateTestValue . $.Abbreviation $n }}
+				{{.}}
+				{{- end }}
+				{{- end }}
+			} },
+			{{- end }}
+		},
+		{{- end }}
+	}
+}
+
+// {{.Abbreviation}}PrimaryKey 记录的主键
+func {{.Abbreviation}}PrimaryKey({{.Abbreviation}} {{.Package}}.{{.StructName}}) string {
+	{{- if .GvaModel }}
+	return fmt.Sprint({{.Abbreviation}}.ID)
+	{{- else }}
+	return fmt.Sprint(*{{.Abbreviation}}.{{.PrimaryField.FieldName}})
+	{{- end }}
+}
+
+// {{.Abbreviation}}Request 发起请求 body 不为空时以json提交 返回解析后的响应
+func {{.Abbreviation}}Request(t *testing.T, router *gin.Engine, method, path string, body any) response.Response {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, "/{{.Abbreviation}}/"+path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s %s status = %d", method, path, w.Code)
+	}
+	var resp response.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("%s %s decode response: %v", method, path, err)
+	}
+	return resp
+}
+
+// {{.Abbreviation}}Decode 将响应中的data解析到v
+func {{.Abbreviation}}Decode(t *testing.T, data any, v any) {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(raw, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// create{{.StructName}}Records 通过接口创建测试记录 返回接口查询到的全部记录
+func create{{.StructName}}Records(t *testing.T, router *gin.Engine) []{{.Package}}.{{.StructName}} {
+	t.Helper()
+	for _, record := range new{{.StructName}}Records() {
+		if resp := {{.Abbreviation}}Request(t, router, http.MethodPost, "create{{.StructName}}", record); resp.Code != response.SUCCESS {
+			t.Fatalf("create{{.StructName}} = %d %s", resp.Code, resp.Msg)
+		}
+	}
+	var list []{{.Package}}.{{.StructName}}
+	{{- if .IsTree }}
+	resp := {{.Abbreviation}}Request(t, router, http.MethodGet, "get{{.StructName}}List", nil)
+	if resp.Code != response.SUCCESS {
+		t.Fatalf("get{{.StructName}}List = %d %s", resp.Code, resp.Msg)
+	}
+	{{.Abbreviation}}Decode(t, resp.Data, &list)
+	{{- else }}
+	resp := {{.Abbreviation}}Request(t, router, http.MethodGet, "get{{.StructName}}List?page=1&pageSize=10", nil)
+	if resp.Code != response.SUCCESS {
+		t.Fatalf("get{{.StructName}}List = %d %s", resp.Code, resp.Msg)
+	}
+	var page response.PageResult
+	{{.Abbreviation}}Decode(t, resp.Data, &page)
+	{{.Abbreviation}}Decode(t, page.List, &list)
+	{{- end }}
+	return list
+}
+
+func Test{{.StructName}}Api(t *testing.T) {
+	router := setup{{.StructName}}Router(t)
+	list := create{{.StructName}}Records(t, router)
+	if len(list) != {{ len TestRecordNumbers }} {
+		t.Fatalf("get{{.StructName}}List = %d records, want {{ len TestRecordNumbers }}", len(list))
+	}
+
+	query := url.Values{"{{.PrimaryField.FieldJson}}": {
+		{{- .Abbreviation}}PrimaryKey(list[1])}}.Encode()
+	resp := {{.Abbreviation}}Request(t, router, http.MethodGet, "find{{.StructName}}?"+query, nil)
+	if resp.Code != response.SUCCESS {
+		t.Fatalf("find{{.StructName}} = %d %s", resp.Code, resp.Msg)
+	}
+	var record {{.Package}}.{{.StructName}}
+	{{.Abbreviation}}Decode(t, resp.Data, &record)
+	if resp = {{.Abbreviation}}Request(t, router, http.MethodPut, "update{{.StructName}}", record); resp.Code != response.SUCCESS {
+		t.Fatalf("update{{.StructName}} = %d %s", resp.Code, resp.Msg)
+	}
+
+	if resp = {{.Abbreviation}}Request(t, router, http.MethodDelete, "delete{{.StructName}}?"+query, nil); resp.Code != response.SUCCESS {
+		t.Fatalf("delete{{.StructName}} = %d %s", resp.Code, resp.Msg)
+	}
+	if resp = {{.Abbreviation}}Request(t, router, http.MethodGet, "find{{.StructName}}?"+query, nil); resp.Code != response.ERROR {
+		t.Fatalf("find{{.StructName}} after delete = %d, want %d", resp.Code, response.ERROR)
+	}
+	ids := url.Values{"{{.PrimaryField.FieldJson}}s[]": {
+		{{- .Abbreviation}}PrimaryKey(list[0]), {{.Abbreviation}}PrimaryKey(list[2])}}.Encode()
+	if resp = {{.Abbreviation}}Request(t, router, http.MethodDelete, "delete{{.StructName}}ByIds?"+ids, nil); resp.Code != response.SUCCESS {
+		t.Fatalf("delete{{.StructName}}ByIds = %d %s", resp.Code, resp.Msg)
+	}
+	{{- if .IsTree }}
+	resp = {{.Abbreviation}}Request(t, router, http.MethodGet, "get{{.StructName}}List", nil)
+	var rest []{{.Package}}.{{.StructName}}
+	{{.Abbreviation}}Decode(t, resp.Data, &rest)
+	if len(rest) != 0 {
+		t.Fatalf("get{{.StructName}}List after delete = %d records, want 0", len(rest))
+	}
+	{{- else }}
+	resp = {{.Abbreviation}}Request(t, router, http.MethodGet, "get{{.StructName}}List?page=1&pageSize=10", nil)
+	var page response.PageResult
+	{{.Abbreviation}}Decode(t, resp.Data, &page)
+	if page.Total != 0 {
+		t.Fatalf("get{{.StructName}}List after delete total = %d, want 0", page.Total)
+	}
+	{{- end }}
+}
+{{- if not .IsTree }}
+
+func Test{{.StructName}}ApiSearch(t *testing.T) {
+	router := setup{{.StructName}}Router(t)
+	create{{.StructName}}Records(t, router)
+	tests := []struct {
+		name  string
+		query string
+		want  int64
+	}{
+		{name: "all", want: {{ len TestRecordNumbers }}},
+		{{ GenerateTestSearchCases . "api" }}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "get{{.StructName}}List?page=1&pageSize=10"
+			if tt.query != "" {
+				path += "&" + tt.query
+			}
+			resp := {{.Abbreviation}}Request(t, router, http.MethodGet, path, nil)
+			if resp.Code != response.SUCCESS {
+				t.Fatalf("get{{.StructName}}List = %d %s", resp.Code, resp.Msg)
+			}
+			var page response.PageResult
+			{{.Abbreviation}}Decode(t, resp.Data, &page)
+			if page.Total != tt.want {
+				t.Fatalf("get{{.StructName}}List total = %d, want %d", page.Total, tt.want)
+			}
+		})
+	}
+}
+{{- end }}
+{{- end }}
@@ -40,6 +40,9 @@ type {{.StructName}} struct {
     Children   []*{{.StructName}} `json:"children" gorm:"-"`     //子节点
     ParentID   int             `json:"parentID" gorm:"column:parent_id;comment:父节点"`
     {{- end }}
+    {{- range .SubTables }}
+    {{.FieldName}}  []{{.StructName}} `json:"{{.FieldJson}}" gorm:"foreignKey:{{.ForeignKey}};references:{{$.PrimaryField.FieldName}}"`  //{{.Description}}
+    {{- end }}
 {{- end }}
 }
 
@@ -50,6 +53,26 @@ func ({{.StructName}}) TableName() string {
 }
 {{ end }}
 
+{{- if not .OnlyTemplate}}
+{{- $parent := . }}
+{{- range .SubTables }}
+
+// {{.Description}} 结构体  {{.StructName}} 通过{{.ForeignKey}}关联{{$parent.Description}}
+type {{.StructName}} struct {
+    global.GVA_MODEL
+    {{.ForeignKey}}  {{$parent.PrimaryField.FieldType}} `json:"{{.ForeignKeyJson}}" form:"{{.ForeignKeyJson}}" gorm:"index;comment:{{$parent.Description}}ID;column:{{.ForeignKeyColumn}};"`  //{{$parent.Description}}ID
+{{- range .Fields}}
+  {{ GenerateField . }}
+{{- end }}
+}
+
+// TableName {{.Description}} {{.StructName}}自定义表名 {{.TableName}}
+func ({{.StructName}}) TableName() string {
+    return "{{.TableName}}"
+}
+{{- end }}
+{{- end }}
+
 {{if .IsTree }}
 // GetChildren 实现TreeNode接口
 func (s *{{.StructName}}) GetChildren() []*{{.StructName}} {
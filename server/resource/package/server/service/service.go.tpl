@@ -45,9 +45,12 @@ import (
     "{{.Module}}/utils"
     "errors"
     {{- end }}
-    {{- if .AutoCreateResource }}
+    {{- if or .AutoCreateResource .HasSubTable }}
     "gorm.io/gorm"
     {{- end}}
+    {{- if .HasSubTable }}
+    "gorm.io/gorm/clause"
+    {{- end}}
 {{- end }}
 )
 
@@ -57,7 +60,28 @@ type {{.StructName}}Service struct {}
 // Create{{.StructName}} 创建{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service) Create{{.StructName}}(ctx context.Context, {{.Abbreviation}} *{{.Package}}.{{.StructName}}) (err error) {
+	{{- if .HasSubTable }}
+	// 主表与子表在同一事务中保存
+	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
+	    if err := tx.Omit(clause.Associations).Create({{.Abbreviation}}).Error; err != nil {
+	        return err
+	    }
+	    {{- range .SubTables }}
+	    if len({{$.Abbreviation}}.{{.FieldName}}) > 0 {
+	        for i := range {{$.Abbreviation}}.{{.FieldName}} {
+	            {{$.Abbreviation}}.{{.FieldName}}[i].ID = 0
+	            {{$.Abbreviation}}.{{.FieldName}}[i].{{.ForeignKey}} = {{$.Abbreviation}}.{{$.PrimaryField.FieldName}}
+	        }
+	        if err := tx.Create(&{{$.Abbreviation}}.{{.FieldName}}).Error; err != nil {
+	            return err
+	        }
+	    }
+	    {{- end }}
+	    return nil
+	})
+	{{- else }}
 	err = {{$db}}.Create({{.Abbreviation}}).Error
+	{{- end }}
 	return err
 }
 
@@ -75,12 +99,19 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}(ctx
        }
 	{{- end }}
 
-	{{- if .AutoCreateResource }}
+	{{- if or .AutoCreateResource .HasSubTable }}
 	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
+	    {{- if .AutoCreateResource }}
 	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).Update("deleted_by", userID).Error; err != nil {
               return err
         }
-        if err = tx.Delete(&{{.Package}}.{{.StructName}}{},"{{.PrimaryField.ColumnName}} = ?",{{.PrimaryField.FieldJson}}).Error; err != nil {
+        {{- end }}
+        {{- range .SubTables }}
+        if err := tx.Delete(&{{$.Package}}.{{.StructName}}{},"{{.ForeignKeyColumn}} = ?",{{$.PrimaryField.FieldJson}}).Error; err != nil {
+              return err
+        }
+        {{- end }}
+        if err := tx.Delete(&{{.Package}}.{{.StructName}}{},"{{.PrimaryField.ColumnName}} = ?",{{.PrimaryField.FieldJson}}).Error; err != nil {
               return err
         }
         return nil
@@ -94,11 +125,18 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}(ctx
 // Delete{{.StructName}}ByIds 批量删除{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}ByIds(ctx context.Context, {{.PrimaryField.FieldJson}}s []string {{- if .AutoCreateResource }},deleted_by uint{{- end}}) (err error) {
-	{{- if .AutoCreateResource }}
+	{{- if or .AutoCreateResource .HasSubTable }}
 	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
+	    {{- if .AutoCreateResource }}
 	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Update("deleted_by", deleted_by).Error; err != nil {
             return err
         }
+        {{- end }}
+        {{- range .SubTables }}
+        if err := tx.Where("{{.ForeignKeyColumn}} in ?", {{$.PrimaryField.FieldJson}}s).Delete(&{{$.Package}}.{{.StructName}}{}).Error; err != nil {
+            return err
+        }
+        {{- end }}
         if err := tx.Where("{{.PrimaryField.ColumnName}} in ?", {{.PrimaryField.FieldJson}}s).Delete(&{{.Package}}.{{.StructName}}{}).Error; err != nil {
             return err
         }
@@ -113,14 +151,46 @@ func ({{.Abbreviation}}Service *{{.StructName}}Service)Delete{{.StructName}}ById
 // Update{{.StructName}} 更新{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Update{{.StructName}}(ctx context.Context, {{.Abbreviation}} {{.Package}}.{{.StructName}}) (err error) {
+	{{- if .HasSubTable }}
+	// 主表与子表在同一事务中保存 子表按ID更新 无ID的新增 未提交的删除
+	err = {{$db}}.Transaction(func(tx *gorm.DB) error {
+	    if err := tx.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Omit(clause.Associations).Updates(&{{.Abbreviation}}).Error; err != nil {
+	        return err
+	    }
+	    {{- range .SubTables }}
+	    {{.FieldJson}}IDs := make([]uint, 0, len({{$.Abbreviation}}.{{.FieldName}}))
+	    for i := range {{$.Abbreviation}}.{{.FieldName}} {
+	        item := &{{$.Abbreviation}}.{{.FieldName}}[i]
+	        item.{{.ForeignKey}} = {{$.Abbreviation}}.{{$.PrimaryField.FieldName}}
+	        if item.ID == 0 {
+	            if err := tx.Create(item).Error; err != nil {
+	                return err
+	            }
+	        } else if err := tx.Where("{{.ForeignKeyColumn}} = ?", item.{{.ForeignKey}}).Updates(item).Error; err != nil {
+	            return err
+	        }
+	        {{.FieldJson}}IDs = append({{.FieldJson}}IDs, item.ID)
+	    }
+	    {{.FieldJson}}DB := tx.Where("{{.ForeignKeyColumn}} = ?", {{$.Abbreviation}}.{{$.PrimaryField.FieldName}})
+	    if len({{.FieldJson}}IDs) > 0 {
+	        {{.FieldJson}}DB = {{.FieldJson}}DB.Where("id NOT IN ?", {{.FieldJson}}IDs)
+	    }
+	    if err := {{.FieldJson}}DB.Delete(&{{$.Package}}.{{.StructName}}{}).Error; err != nil {
+	        return err
+	    }
+	    {{- end }}
+	    return nil
+	})
+	{{- else }}
 	err = {{$db}}.Model(&{{.Package}}.{{.StructName}}{}).Where("{{.PrimaryField.ColumnName}} = ?",{{.Abbreviation}}.{{.PrimaryField.FieldName}}).Updates(&{{.Abbreviation}}).Error
+	{{- end }}
 	return err
 }
 
 // Get{{.StructName}} 根据{{.PrimaryField.FieldJson}}获取{{.Description}}记录
 // Author [yourname](https://github.com/yourname)
 func ({{.Abbreviation}}Service *{{.StructName}}Service)Get{{.StructName}}(ctx context.Context, {{.PrimaryField.FieldJson}} string) ({{.Abbreviation}} {{.Package}}.{{.StructName}}, err error) {
-	err = {{$db}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
+	err = {{$db}}{{range .SubTables}}.Preload("{{.FieldName}}"){{end}}.Where("{{.PrimaryField.ColumnName}} = ?", {{.PrimaryField.FieldJson}}).First(&{{.Abbreviation}}).Error
 	return
 }
 
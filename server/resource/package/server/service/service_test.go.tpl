@@ -0,0 +1,190 @@
+{{- if .IsAdd}}
+// 新增字段后请通过「更新模块」重新生成测试用例
+{{- else}}
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	{{- if .HasTimer }}
+	"time"
+	{{- end }}
+
+	"{{.Module}}/global"
+	"{{.Module}}/model/{{.Package}}"
+	{{- if not .IsTree }}
+	{{.Package}}Req "{{.Module}}/model/{{.Package}}/request"
+	{{- end }}
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	{{- if .NeedJSON }}
+	"gorm.io/datatypes"
+	{{- end }}
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// setup{{.StructName}}DB 使用内存SQLite作为{{.Description}}的数据库
+func setup{{.StructName}}DB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	models := []any{&{{.Package}}.{{.StructName}}{} {{- range .SubTables}}, &{{$.Package}}.{{.StructName}}{} {{- end}}}
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err = stmt.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if strings.HasPrefix(string(field.DataType), "enum") {
+				field.DataType = schema.String
+			}
+		}
+	} // SQLite不支持enum类型 按文本建表
+	if err = db.AutoMigrate(models...); err != nil {
+		t.Fatal(err)
+	}
+	{{- if eq .BusinessDB "" }}
+	global.GVA_DB = db
+	{{- else }}
+	global.GVA_DBList = map[string]*gorm.DB{"{{.BusinessDB}}": db}
+	{{- end }}
+	global.GVA_LOG = zap.NewNop()
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+// {{.Abbreviation}}Ptr 取值的指针
+func {{.Abbreviation}}Ptr[T any](v T) *T {
+	return &v
+}
+
+// new{{.StructName}}Records 测试记录 搜索用例以第2条记录的取值作为搜索条件
+func new{{.StructName}}Records() []{{.Package}}.{{.StructName}} {
+	return []{{.Package}}.{{.StructName}}{
+		{{- range $n := TestRecordNumbers }}
+		{
+			{{- range $.Fields }}
+			{{- with GenerateTestValue . $.Abbreviation $n }}
+			{{.}}
+			{{- end }}
+			{{- end }}
+			{{- range $.SubTables }}
+			{{.FieldName}}: []{{$.Package}}.{{.StructName}}{ {
+				{{- range .Fields }}
+				{{- with GenerateTestValue . $.Abbreviation $n }}
+				{{.}}
+				{{- end }}
+				{{- end }}
+			} },
+			{{- end }}
+		},
+		{{- end }}
+	}
+}
+
+// {{.Abbreviation}}PrimaryKey 记录的主键
+func {{.Abbreviation}}PrimaryKey({{.Abbreviation}} {{.Package}}.{{.StructName}}) string {
+	{{- if .GvaModel }}
+	return fmt.Sprint({{.Abbreviation}}.ID)
+	{{- else }}
+	return fmt.Sprint(*{{.Abbreviation}}.{{.PrimaryField.FieldName}})
+	{{- end }}
+}
+
+func Test{{.StructName}}Service(t *testing.T) {
+	setup{{.StructName}}DB(t)
+	ctx := context.Background()
+	service := &{{.StructName}}Service{}
+
+	records := new{{.StructName}}Records()
+	for i := range records {
+		if err := service.Create{{.StructName}}(ctx, &records[i]); err != nil {
+			t.Fatalf("Create{{.StructName}}() error = %v", err)
+		}
+	}
+	id := {{.Abbreviation}}PrimaryKey(records[1])
+	record, err := service.Get{{.StructName}}(ctx, id)
+	if err != nil {
+		t.Fatalf("Get{{.StructName}}() error = %v", err)
+	}
+	{{- range .SubTables }}
+	if len(record.{{.FieldName}}) != 1 {
+		t.Fatalf("Get{{$.StructName}}() {{.FieldName}} = %d, want 1", len(record.{{.FieldName}}))
+	}
+	{{- end }}
+	if err = service.Update{{.StructName}}(ctx, record); err != nil {
+		t.Fatalf("Update{{.StructName}}() error = %v", err)
+	}
+	{{- if .IsTree }}
+	list, err := service.Get{{.StructName}}InfoList(ctx)
+	if err != nil || len(list) != len(records) {
+		t.Fatalf("Get{{.StructName}}InfoList() = %d, %v, want %d", len(list), err, len(records))
+	}
+	{{- else }}
+	var search {{.Package}}Req.{{.StructName}}Search
+	search.Page, search.PageSize = 1, 10
+	list, total, err := service.Get{{.StructName}}InfoList(ctx, search)
+	if err != nil || total != int64(len(records)) || len(list) != len(records) {
+		t.Fatalf("Get{{.StructName}}InfoList() = %d, %d, %v, want %d", len(list), total, err, len(records))
+	}
+	{{- end }}
+
+	if err = service.Delete{{.StructName}}(ctx, id {{- if .AutoCreateResource }}, 0{{ end }}); err != nil {
+		t.Fatalf("Delete{{.StructName}}() error = %v", err)
+	}
+	if _, err = service.Get{{.StructName}}(ctx, id); err == nil {
+		t.Fatalf("Get{{.StructName}}() after delete should fail")
+	}
+	ids := []string{ {{- .Abbreviation}}PrimaryKey(records[0]), {{.Abbreviation}}PrimaryKey(records[2])}
+	if err = service.Delete{{.StructName}}ByIds(ctx, ids {{- if .AutoCreateResource }}, 0{{ end }}); err != nil {
+		t.Fatalf("Delete{{.StructName}}ByIds() error = %v", err)
+	}
+	for _, id := range ids {
+		if _, err = service.Get{{.StructName}}(ctx, id); err == nil {
+			t.Fatalf("Get{{.StructName}}(%s) after delete should fail", id)
+		}
+	}
+}
+{{- if not .IsTree }}
+
+func Test{{.StructName}}ServiceSearch(t *testing.T) {
+	setup{{.StructName}}DB(t)
+	ctx := context.Background()
+	service := &{{.StructName}}Service{}
+
+	records := new{{.StructName}}Records()
+	for i := range records {
+		if err := service.Create{{.StructName}}(ctx, &records[i]); err != nil {
+			t.Fatalf("Create{{.StructName}}() error = %v", err)
+		}
+	}
+	tests := []struct {
+		name   string
+		search {{.Package}}Req.{{.StructName}}Search
+		want   int64
+	}{
+		{name: "all", want: int64(len(records))},
+		{{ GenerateTestSearchCases . "service" }}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.search.Page, tt.search.PageSize = 1, 10
+			_, total, err := service.Get{{.StructName}}InfoList(ctx, tt.search)
+			if err != nil {
+				t.Fatalf("Get{{.StructName}}InfoList() error = %v", err)
+			}
+			if total != tt.want {
+				t.Fatalf("Get{{.StructName}}InfoList() total = %d, want %d", total, tt.want)
+			}
+		})
+	}
+}
+{{- end }}
+{{- end }}
@@ -83,6 +83,30 @@ getDataSourceFunc()
       {{- if .Form }}
         {{ GenerateFormItem . }}
       {{- end }}
+      {{- end }}
+      {{- range .SubTables }}
+        <el-form-item label="{{.Description}}:" prop="{{.FieldJson}}">
+          <div class="w-full">
+            <el-table :data="formData.{{.FieldJson}}" border size="small" style="width: 100%">
+              <el-table-column type="index" label="序号" width="60" />
+              {{- range .Fields }}
+              {{- if .Form }}
+              <el-table-column label="{{.FieldDesc}}" min-width="140">
+                <template #default="scope">
+                  {{ GenerateSubTableCell . }}
+                </template>
+              </el-table-column>
+              {{- end }}
+              {{- end }}
+              <el-table-column label="操作" width="80" fixed="right">
+                <template #default="scope">
+                  <el-button type="danger" link icon="delete" @click="remove{{.StructName}}(scope.$index)">删除</el-button>
+                </template>
+              </el-table-column>
+            </el-table>
+            <el-button class="mt-2" type="primary" link icon="plus" @click="add{{.StructName}}">新增{{.Description}}</el-button>
+          </div>
+        </el-form-item>
       {{- end }}
         <el-form-item>
           <el-button :loading="btnLoading" type="primary" @click="save">保存</el-button>
@@ -181,6 +205,9 @@ const formData = ref({
             {{ GenerateDefaultFormValue . }}
           {{- end }}
         {{- end }}
+        {{- range .SubTables }}
+            {{.FieldJson}}: [],
+        {{- end }}
         })
 // 验证规则
 const rule = reactive({
@@ -196,6 +223,24 @@ const rule = reactive({
 })
 
 const elFormRef = ref()
+{{- range .SubTables }}
+
+// 新增{{.Description}}行
+const add{{.StructName}} = () => {
+    formData.value.{{.FieldJson}}.push({
+    {{- range .Fields}}
+      {{- if .Form}}
+        {{ GenerateDefaultFormValue . }}
+      {{- end }}
+    {{- end }}
+    })
+}
+
+// 删除{{.Description}}行
+const remove{{.StructName}} = (index) => {
+    formData.value.{{.FieldJson}}.splice(index, 1)
+}
+{{- end }}
 
 {{- if .HasDataSource }}
   const dataSource = ref([])
@@ -215,6 +260,9 @@ const init = async () => {
       const res = await find{{.StructName}}({ ID: route.query.id })
       if (res.code === 0) {
         formData.value = res.data
+        {{- range .SubTables }}
+        formData.value.{{.FieldJson}} = formData.value.{{.FieldJson}} || []
+        {{- end }}
         type.value = 'update'
       }
     } else {
@@ -224,6 +224,30 @@ getDataSourceFunc()
             {{ GenerateFormItem . }}
           {{- end }}
           {{- end }}
+          {{- range .SubTables }}
+            <el-form-item label="{{.Description}}:" prop="{{.FieldJson}}">
+              <div class="w-full">
+                <el-table :data="formData.{{.FieldJson}}" border size="small" style="width: 100%">
+                  <el-table-column type="index" label="序号" width="60" />
+                  {{- range .Fields }}
+                  {{- if .Form }}
+                  <el-table-column label="{{.FieldDesc}}" min-width="140">
+                    <template #default="scope">
+                      {{ GenerateSubTableCell . }}
+                    </template>
+                  </el-table-column>
+                  {{- end }}
+                  {{- end }}
+                  <el-table-column label="操作" width="80" fixed="right">
+                    <template #default="scope">
+                      <el-button type="danger" link icon="delete" @click="remove{{.StructName}}(scope.$index)">删除</el-button>
+                    </template>
+                  </el-table-column>
+                </el-table>
+                <el-button class="mt-2" type="primary" link icon="plus" @click="add{{.StructName}}">新增{{.Description}}</el-button>
+              </div>
+            </el-form-item>
+          {{- end }}
           </el-form>
     </el-drawer>
 
@@ -249,6 +273,18 @@ getDataSourceFunc()
                     {{ GenerateDescriptionItem . }}
               {{- end }}
             {{- end }}
+            {{- range .SubTables }}
+            <el-descriptions-item label="{{.Description}}">
+                <el-table :data="detailForm.{{.FieldJson}}" border size="small" style="width: 100%">
+                  <el-table-column type="index" label="序号" width="60" />
+                  {{- range .Fields }}
+                  {{- if .Desc }}
+                  {{ GenerateTableColumn . }}
+                  {{- end }}
+                  {{- end }}
+                </el-table>
+            </el-descriptions-item>
+            {{- end }}
             </el-descriptions>
         </el-drawer>
 
@@ -341,6 +377,9 @@ const formData = ref({
             {{ GenerateDefaultFormValue . }}
           {{- end }}
         {{- end }}
+        {{- range .SubTables }}
+            {{.FieldJson}}: [],
+        {{- end }}
         })
 
 {{- if .HasDataSource }}
@@ -557,6 +596,9 @@ const update{{.StructName}}Func = async(row) => {
     type.value = 'update'
     if (res.code === 0) {
         formData.value = res.data
+        {{- range .SubTables }}
+        formData.value.{{.FieldJson}} = formData.value.{{.FieldJson}} || []
+        {{- end }}
         dialogFormVisible.value = true
     }
 }
@@ -597,9 +639,30 @@ const closeDialog = () => {
       {{- if .Form}}
         {{ GenerateDefaultFormValue . }}
       {{- end }}
+    {{- end }}
+    {{- range .SubTables }}
+        {{.FieldJson}}: [],
     {{- end }}
         }
 }
+{{- range .SubTables }}
+
+// 新增{{.Description}}行
+const add{{.StructName}} = () => {
+    formData.value.{{.FieldJson}}.push({
+    {{- range .Fields}}
+      {{- if .Form}}
+        {{ GenerateDefaultFormValue . }}
+      {{- end }}
+    {{- end }}
+    })
+}
+
+// 删除{{.Description}}行
+const remove{{.StructName}} = (index) => {
+    formData.value.{{.FieldJson}}.splice(index, 1)
+}
+{{- end }}
 // 弹窗确定
 const enterDialog = async () => {
      btnLoading.value = true
@@ -7,20 +7,36 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-var _ interfaces.Plugin = (*plugin)(nil)
+var _ interfaces.Lifecycle = (*plugin)(nil)
 
 var Plugin = new(plugin)
 
-type plugin struct{}
+// plugin 可在系统工具中运行时启用、停用 未覆盖的生命周期方法使用 interfaces.Base 的默认实现
+type plugin struct {
+	interfaces.Base
+}
+
+func (p *plugin) Name() string {
+	return "{{ .Package }}"
+}
 
+// Install 首次启用时执行
 // 如果需要配置文件，请到config.Config中填充配置结构，且到下方发放中填入其在config.yaml中的key并添加如下方法
 // initialize.Viper()
 // 安装插件时候自动注册的api数据请到下方法.Api方法中实现并添加如下方法
 // initialize.Api(ctx)
 // 安装插件时候自动注册的api数据请到下方法.Menu方法中实现并添加如下方法
 // initialize.Menu(ctx)
-func (p *plugin) Register(group *gin.Engine) {
-	ctx := context.Background() 
+func (p *plugin) Install(ctx context.Context) error {
+	return nil
+}
+
+// Enable 启用时同步表结构
+func (p *plugin) Enable(ctx context.Context) error {
 	initialize.Gorm(ctx)
+	return nil
+}
+
+func (p *plugin) Register(group *gin.Engine) {
 	initialize.Router(group)
 }
@@ -21,6 +21,13 @@ type RouterGroup struct {
 	SysParamsRouter
 	SysVersionRouter
 	UserActionLogRouter
+	NotificationRouter
+	PluginRuntimeRouter
+	OpenApiRouter
+	RateLimitRouter
+	AlertRouter
+	WebhookRouter
+	DepartmentRouter
 }
 
 var (
@@ -45,4 +52,11 @@ var (
 	exportTemplateApi   = api.ApiGroupApp.SystemApiGroup.SysExportTemplateApi
 	sysVersionApi       = api.ApiGroupApp.SystemApiGroup.SysVersionApi
 	userActionLogApi    = api.ApiGroupApp.SystemApiGroup.UserActionLogApi
+	notificationApi     = api.ApiGroupApp.SystemApiGroup.NotificationApi
+	pluginRuntimeApi    = api.ApiGroupApp.SystemApiGroup.PluginRuntimeApi
+	openApiApi          = api.ApiGroupApp.SystemApiGroup.OpenApiApi
+	rateLimitApi        = api.ApiGroupApp.SystemApiGroup.RateLimitApi
+	alertApi            = api.ApiGroupApp.SystemApiGroup.AlertApi
+	webhookApi          = api.ApiGroupApp.SystemApiGroup.WebhookApi
+	departmentApi       = api.ApiGroupApp.SystemApiGroup.DepartmentApi
 )
@@ -0,0 +1,26 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type AlertRouter struct{}
+
+// InitAlertRouter 初始化 错误告警 路由信息
+func (s *AlertRouter) InitAlertRouter(Router *gin.RouterGroup) {
+	alertRouter := Router.Group("alert").Use(middleware.OperationRecord())
+	alertRouterWithoutRecord := Router.Group("alert")
+	{
+		alertRouter.POST("ackAlert", alertApi.AckAlert)                       // 确认告警
+		alertRouter.POST("resolveAlert", alertApi.ResolveAlert)               // 解决告警
+		alertRouter.POST("createAlertChannel", alertApi.CreateAlertChannel)   // 创建告警渠道
+		alertRouter.DELETE("deleteAlertChannel", alertApi.DeleteAlertChannel) // 删除告警渠道
+		alertRouter.PUT("updateAlertChannel", alertApi.UpdateAlertChannel)    // 更新告警渠道
+		alertRouter.POST("testAlertChannel", alertApi.TestAlertChannel)       // 发送测试通知
+	}
+	{
+		alertRouterWithoutRecord.GET("getAlertList", alertApi.GetAlertList)               // 分页获取告警
+		alertRouterWithoutRecord.GET("getAlertChannelList", alertApi.GetAlertChannelList) // 分页获取告警渠道
+	}
+}
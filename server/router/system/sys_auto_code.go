@@ -30,11 +30,18 @@ func (s *AutoCodeRouter) InitAutoCodeRouter(Router *gin.RouterGroup, RouterPubli
 		autoCodeRouter.POST("createPackage", autoCodePackageApi.Create) // 创建package包
 	}
 	{
-		autoCodeRouter.GET("getTemplates", autoCodePackageApi.Templates) // 创建package包
+		autoCodeRouter.GET("getTemplates", autoCodePackageApi.Templates)                 // 创建package包
+		autoCodeRouter.GET("getTemplatePacks", autoCodePackageApi.TemplatePacks)         // 获取模板包
+		autoCodeRouter.POST("uploadTemplatePack", autoCodePackageApi.UploadTemplatePack) // 上传模板包
+		autoCodeRouter.POST("delTemplatePack", autoCodePackageApi.DeleteTemplatePack)    // 删除模板包
+		autoCodeRouter.POST("setTemplatePack", autoCodePackageApi.SetTemplatePack)       // 设置package使用的模板包
 	}
 	{
-		autoCodeRouter.POST("pubPlug", autoCodePluginApi.Packaged)      // 打包插件
-		autoCodeRouter.POST("installPlugin", autoCodePluginApi.Install) // 自动安装插件
+		autoCodeRouter.POST("pubPlug", autoCodePluginApi.Packaged)          // 打包插件
+		autoCodeRouter.POST("installPlugin", autoCodePluginApi.Install)     // 自动安装插件
+		autoCodeRouter.POST("checkPlugin", autoCodePluginApi.Check)         // 检查插件是否满足安装条件
+		autoCodeRouter.GET("getPlugins", autoCodePluginApi.Plugins)         // 获取已安装的插件
+		autoCodeRouter.POST("uninstallPlugin", autoCodePluginApi.Uninstall) // 卸载插件
 
 	}
 	{
@@ -9,9 +9,11 @@ type AutoCodeHistoryRouter struct{}
 func (s *AutoCodeRouter) InitAutoCodeHistoryRouter(Router *gin.RouterGroup) {
 	autoCodeHistoryRouter := Router.Group("autoCode")
 	{
-		autoCodeHistoryRouter.POST("getMeta", autocodeHistoryApi.First)         // 根据id获取meta信息
-		autoCodeHistoryRouter.POST("rollback", autocodeHistoryApi.RollBack)     // 回滚
-		autoCodeHistoryRouter.POST("delSysHistory", autocodeHistoryApi.Delete)  // 删除回滚记录
-		autoCodeHistoryRouter.POST("getSysHistory", autocodeHistoryApi.GetList) // 获取回滚记录分页
+		autoCodeHistoryRouter.POST("getMeta", autocodeHistoryApi.First)               // 根据id获取meta信息
+		autoCodeHistoryRouter.POST("rollback", autocodeHistoryApi.RollBack)           // 回滚
+		autoCodeHistoryRouter.POST("delSysHistory", autocodeHistoryApi.Delete)        // 删除回滚记录
+		autoCodeHistoryRouter.POST("getSysHistory", autocodeHistoryApi.GetList)       // 获取回滚记录分页
+		autoCodeHistoryRouter.POST("updatePreview", autocodeHistoryApi.UpdatePreview) // 预览模块更新
+		autoCodeHistoryRouter.POST("update", autocodeHistoryApi.Update)               // 更新已生成的模块
 	}
 }
@@ -12,8 +12,14 @@ func (s *CasbinRouter) InitCasbinRouter(Router *gin.RouterGroup) {
 	casbinRouterWithoutRecord := Router.Group("casbin")
 	{
 		casbinRouter.POST("updateCasbin", casbinApi.UpdateCasbin)
+		casbinRouter.POST("createCasbinDeny", casbinApi.CreateCasbinDeny)
+		casbinRouter.PUT("updateCasbinDeny", casbinApi.UpdateCasbinDeny)
+		casbinRouter.DELETE("deleteCasbinDeny", casbinApi.DeleteCasbinDeny)
 	}
 	{
 		casbinRouterWithoutRecord.POST("getPolicyPathByAuthorityId", casbinApi.GetPolicyPathByAuthorityId)
+		casbinRouterWithoutRecord.POST("explain", casbinApi.Explain)
+		casbinRouterWithoutRecord.POST("getCasbinMatrix", casbinApi.GetCasbinMatrix)
+		casbinRouterWithoutRecord.POST("getCasbinDenyList", casbinApi.GetCasbinDenyList)
 	}
 }
@@ -0,0 +1,22 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type DepartmentRouter struct{}
+
+// InitDepartmentRouter 初始化 部门 路由信息
+func (s *DepartmentRouter) InitDepartmentRouter(Router *gin.RouterGroup) {
+	departmentRouter := Router.Group("department").Use(middleware.OperationRecord())
+	departmentRouterWithoutRecord := Router.Group("department")
+	{
+		departmentRouter.POST("createDepartment", departmentApi.CreateDepartment)   // 创建部门
+		departmentRouter.DELETE("deleteDepartment", departmentApi.DeleteDepartment) // 删除部门
+		departmentRouter.PUT("updateDepartment", departmentApi.UpdateDepartment)    // 更新部门
+	}
+	{
+		departmentRouterWithoutRecord.GET("getDepartmentTree", departmentApi.GetDepartmentTree) // 获取部门树
+	}
+}
@@ -0,0 +1,26 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationRouter struct{}
+
+// InitNotificationRouter 初始化 站内通知 路由信息
+func (s *NotificationRouter) InitNotificationRouter(Router *gin.RouterGroup) {
+	notificationRouter := Router.Group("notification").Use(middleware.OperationRecord())
+	notificationRouterWithoutRecord := Router.Group("notification")
+	{
+		notificationRouter.POST("createNotification", notificationApi.CreateNotification)   // 发送通知
+		notificationRouter.DELETE("deleteNotification", notificationApi.DeleteNotification) // 删除通知
+	}
+	{
+		notificationRouterWithoutRecord.GET("getNotificationList", notificationApi.GetNotificationList)     // 获取已发送的通知
+		notificationRouterWithoutRecord.GET("getMyNotificationList", notificationApi.GetMyNotificationList) // 获取当前用户的通知
+		notificationRouterWithoutRecord.PUT("markRead", notificationApi.MarkRead)                           // 标记已读
+		notificationRouterWithoutRecord.PUT("markAllRead", notificationApi.MarkAllRead)                     // 全部标记已读
+		notificationRouterWithoutRecord.PUT("archive", notificationApi.Archive)                             // 归档
+		notificationRouterWithoutRecord.GET("getUnreadCount", notificationApi.GetUnreadCount)               // 获取未读数量
+	}
+}
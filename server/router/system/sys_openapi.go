@@ -0,0 +1,12 @@
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type OpenApiRouter struct{}
+
+// InitOpenApiRouter 初始化 OpenAPI文档 路由信息 与 swagger 一样不做鉴权
+func (s *OpenApiRouter) InitOpenApiRouter(Router *gin.RouterGroup) {
+	Router.GET("openapi.json", openApiApi.GetDocument) // 获取OpenAPI文档
+}
@@ -0,0 +1,23 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type PluginRuntimeRouter struct{}
+
+// InitPluginRuntimeRouter 初始化 v2插件启停 路由信息
+func (s *PluginRuntimeRouter) InitPluginRuntimeRouter(Router *gin.RouterGroup) {
+	pluginRuntimeRouter := Router.Group("pluginRuntime").Use(middleware.OperationRecord())
+	pluginRuntimeRouterWithoutRecord := Router.Group("pluginRuntime")
+	{
+		pluginRuntimeRouter.POST("enable", pluginRuntimeApi.EnablePlugin)       // 启用插件
+		pluginRuntimeRouter.POST("disable", pluginRuntimeApi.DisablePlugin)     // 停用插件
+		pluginRuntimeRouter.POST("uninstall", pluginRuntimeApi.UninstallPlugin) // 卸载插件
+	}
+	{
+		pluginRuntimeRouterWithoutRecord.GET("getPlugins", pluginRuntimeApi.GetPlugins) // 获取插件运行状态
+		pluginRuntimeRouterWithoutRecord.GET("health", pluginRuntimeApi.PluginHealth)   // 插件健康检查
+	}
+}
@@ -0,0 +1,23 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type RateLimitRouter struct{}
+
+// InitRateLimitRouter 初始化 限流规则 路由信息
+func (s *RateLimitRouter) InitRateLimitRouter(Router *gin.RouterGroup) {
+	rateLimitRouter := Router.Group("rateLimit").Use(middleware.OperationRecord())
+	rateLimitRouterWithoutRecord := Router.Group("rateLimit")
+	{
+		rateLimitRouter.POST("createRateLimitRule", rateLimitApi.CreateRateLimitRule)   // 创建限流规则
+		rateLimitRouter.DELETE("deleteRateLimitRule", rateLimitApi.DeleteRateLimitRule) // 删除限流规则
+		rateLimitRouter.PUT("updateRateLimitRule", rateLimitApi.UpdateRateLimitRule)    // 更新限流规则
+	}
+	{
+		rateLimitRouterWithoutRecord.GET("getRateLimitRuleList", rateLimitApi.GetRateLimitRuleList) // 获取限流规则列表
+		rateLimitRouterWithoutRecord.GET("getRateLimitMetrics", rateLimitApi.GetRateLimitMetrics)   // 获取限流统计
+	}
+}
@@ -0,0 +1,27 @@
+package system
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookRouter struct{}
+
+// InitWebhookRouter 初始化 webhook事件推送 路由信息
+func (s *WebhookRouter) InitWebhookRouter(Router *gin.RouterGroup) {
+	webhookRouter := Router.Group("webhook").Use(middleware.OperationRecord())
+	webhookRouterWithoutRecord := Router.Group("webhook")
+	{
+		webhookRouter.POST("createWebhook", webhookApi.CreateWebhook)                       // 创建webhook订阅
+		webhookRouter.DELETE("deleteWebhook", webhookApi.DeleteWebhook)                     // 删除webhook订阅
+		webhookRouter.PUT("updateWebhook", webhookApi.UpdateWebhook)                        // 更新webhook订阅
+		webhookRouter.POST("pingWebhook", webhookApi.PingWebhook)                           // 发送测试推送
+		webhookRouter.POST("redeliverWebhookDelivery", webhookApi.RedeliverWebhookDelivery) // 重新推送
+	}
+	{
+		webhookRouterWithoutRecord.GET("getWebhookList", webhookApi.GetWebhookList)                 // 分页获取webhook订阅
+		webhookRouterWithoutRecord.GET("getWebhookEventTypes", webhookApi.GetWebhookEventTypes)     // 获取可订阅的事件类型
+		webhookRouterWithoutRecord.GET("getWebhookDeliveryList", webhookApi.GetWebhookDeliveryList) // 分页获取推送记录
+		webhookRouterWithoutRecord.GET("findWebhookDelivery", webhookApi.FindWebhookDelivery)       // 获取推送记录详情
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/example"
-	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	systemService "github.com/flipped-aurora/gin-vue-admin/server/service/system"
 )
 
@@ -59,23 +58,18 @@ func (exa *CustomerService) GetExaCustomer(id uint) (customer example.ExaCustome
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetCustomerInfoList
 //@description: 分页获取客户列表
-//@param: sysUserAuthorityID string, info request.PageInfo
+//@param: sysUserAuthorityIDs []uint, info request.PageInfo
 //@return: list interface{}, total int64, err error
 
-func (exa *CustomerService) GetCustomerInfoList(sysUserAuthorityID uint, info request.PageInfo) (list interface{}, total int64, err error) {
+func (exa *CustomerService) GetCustomerInfoList(sysUserAuthorityIDs []uint, info request.PageInfo) (list interface{}, total int64, err error) {
 	limit := info.PageSize
 	offset := info.PageSize * (info.Page - 1)
 	db := global.GVA_DB.Model(&example.ExaCustomer{})
-	var a system.SysAuthority
-	a.AuthorityId = sysUserAuthorityID
-	auth, err := systemService.AuthorityServiceApp.GetAuthorityInfo(a)
+	// 多角色模式下为各角色数据权限的并集
+	dataId, err := systemService.AuthorityServiceApp.GetDataAuthorityIds(sysUserAuthorityIDs...)
 	if err != nil {
 		return
 	}
-	var dataId []uint
-	for _, v := range auth.DataAuthorityId {
-		dataId = append(dataId, v.AuthorityId)
-	}
 	var CustomerList []example.ExaCustomer
 	err = db.Where("sys_user_authority_id in ?", dataId).Count(&total).Error
 	if err != nil {
@@ -34,6 +34,7 @@ func (s *autoCodeHistory) Create(ctx context.Context, info request.SysAutoHistor
 	if err != nil {
 		return errors.Wrap(err, "创建失败!")
 	}
+	utils.GlobalSystemEvents.Publish(utils.EventAutoCodeModuleCreated, autoCodeModuleEventData(create))
 	return nil
 }
 
@@ -178,6 +179,7 @@ func (s *autoCodeHistory) RollBack(ctx context.Context, info request.SysAutoHist
 	if err != nil {
 		return errors.Wrap(err, "更新失败!")
 	}
+	utils.GlobalSystemEvents.Publish(utils.EventAutoCodeModuleRollback, autoCodeModuleEventData(history))
 	return nil
 }
 
@@ -185,13 +187,30 @@ func (s *autoCodeHistory) RollBack(ctx context.Context, info request.SysAutoHist
 // Author [SliverHorn](https://github.com/SliverHorn)
 // Author [songzhibin97](https://github.com/songzhibin97)
 func (s *autoCodeHistory) Delete(ctx context.Context, info common.GetById) error {
+	var history model.SysAutoCodeHistory
+	if global.GVA_DB.WithContext(ctx).Where("id = ?", info.Uint()).First(&history).Error != nil {
+		history.ID = info.Uint()
+	}
 	err := global.GVA_DB.WithContext(ctx).Where("id = ?", info.Uint()).Delete(&model.SysAutoCodeHistory{}).Error
 	if err != nil {
 		return errors.Wrap(err, "删除失败!")
 	}
+	utils.GlobalSystemEvents.Publish(utils.EventAutoCodeModuleDeleted, autoCodeModuleEventData(history))
 	return nil
 }
 
+// autoCodeModuleEventData 自动化代码模块事件的数据 不包含模板和注入信息
+func autoCodeModuleEventData(history model.SysAutoCodeHistory) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          history.ID,
+		"package":     history.Package,
+		"tableName":   history.Table,
+		"structName":  history.StructName,
+		"description": history.Description,
+		"businessDb":  history.BusinessDB,
+	}
+}
+
 // GetList 获取系统历史数据
 // Author [SliverHorn](https://github.com/SliverHorn)
 // Author [songzhibin97](https://github.com/songzhibin97)
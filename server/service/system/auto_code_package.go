@@ -46,8 +46,13 @@ func (s *autoCodePackage) Create(ctx context.Context, info *request.SysAutoCodeP
 	if !errors.Is(global.GVA_DB.Where("package_name = ? and template = ?", info.PackageName, info.Template).First(&model.SysAutoCodePackage{}).Error, gorm.ErrRecordNotFound) {
 		return errors.New("存在相同PackageName")
 	}
+	if info.TemplatePack != "" {
+		if _, err := s.templatePack(info.TemplatePack, info.Template); err != nil {
+			return err
+		}
+	}
 	create := info.Create()
-	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		err := tx.Create(&create).Error
 		if err != nil {
 			return errors.Wrap(err, "创建失败!")
@@ -101,16 +106,26 @@ func (s *autoCodePackage) Create(ctx context.Context, info *request.SysAutoCodeP
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	utils.GlobalSystemEvents.Publish(utils.EventAutoCodePackageCreated, create)
+	return nil
 }
 
 // Delete 删除包记录
 // @author: [piexlmax](https://github.com/piexlmax)
 // @author: [SliverHorn](https://github.com/SliverHorn)
 func (s *autoCodePackage) Delete(ctx context.Context, info common.GetById) error {
+	var entity model.SysAutoCodePackage
+	if global.GVA_DB.WithContext(ctx).First(&entity, info.Uint()).Error != nil {
+		entity.ID = info.Uint()
+	}
 	err := global.GVA_DB.WithContext(ctx).Delete(&model.SysAutoCodePackage{}, info.Uint()).Error
 	if err != nil {
 		return errors.Wrap(err, "删除失败!")
 	}
+	utils.GlobalSystemEvents.Publish(utils.EventAutoCodePackageDeleted, entity)
 	return nil
 }
 
@@ -250,6 +265,9 @@ func (s *autoCodePackage) Templates(ctx context.Context) ([]string, error) {
 			if entries[i].Name() == "mcp" {
 				continue
 			} // preview 为mcp生成器的代码
+			if entries[i].Name() == templatePackDir {
+				continue
+			} // packs 为用户注册的模板包
 			templates = append(templates, entries[i].Name())
 		}
 	}
@@ -321,6 +339,10 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 						if ext != ".tpl" {
 							return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版后缀!", four)
 						}
+						isTest := strings.HasSuffix(threeDirs[k].Name(), "_test.go.tpl")
+						if isTest && (entity.Template != "package" || !info.GenerateTest || info.OnlyTemplate) {
+							continue
+						} // 测试模板仅在选择生成测试时使用
 						api := strings.Index(threeDirs[k].Name(), "api")
 						hasEnter := strings.Index(threeDirs[k].Name(), "enter")
 						router := strings.Index(threeDirs[k].Name(), "router")
@@ -329,9 +351,13 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 							return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版文件!", four)
 						}
 						if entity.Template == "package" {
-							create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, info.HumpPackageName+".go")
+							name := info.HumpPackageName + ".go"
+							if isTest {
+								name = info.HumpPackageName + "_test.go"
+							}
+							create := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, name)
 							if api != -1 {
-								create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), "v1", entity.PackageName, info.HumpPackageName+".go")
+								create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), "v1", entity.PackageName, name)
 							}
 							if hasEnter != -1 {
 								isApi := strings.Index(secondDirs[j].Name(), "api")
@@ -608,6 +634,9 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 								PackageName: entity.PackageName,
 								IsNew:       true,
 							}
+							for _, sub := range info.SubTables {
+								packageInitializeGorm.SubStructs = append(packageInitializeGorm.SubStructs, sub.StructName)
+							}
 							code[four] = packageInitializeGorm.Path
 							asts[packageInitializeGorm.Path+"=>"+packageInitializeGorm.Type.String()] = packageInitializeGorm
 							create = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, secondDirs[j].Name(), entity.PackageName, info.HumpPackageName+".go")
@@ -693,5 +722,11 @@ func (s *autoCodePackage) templates(ctx context.Context, entity model.SysAutoCod
 			return nil, nil, nil, errors.Errorf("[filpath:%s]非法模版文件!", second)
 		}
 	}
+	if entity.TemplatePack != "" {
+		err = s.applyTemplatePack(entity, info, isPackage, code, asts, creates)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	return code, asts, creates, nil
 }
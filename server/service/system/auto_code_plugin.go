@@ -1,18 +1,20 @@
 package system
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"fmt"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils/ast"
-	"github.com/mholt/archives"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin"
 	cp "github.com/otiai10/copy"
 	"github.com/pkg/errors"
-	"go.uber.org/zap"
 	"go/parser"
 	"go/printer"
 	"go/token"
@@ -27,100 +29,103 @@ var AutoCodePlugin = new(autoCodePlugin)
 
 type autoCodePlugin struct{}
 
-// Install 插件安装
-func (s *autoCodePlugin) Install(file *multipart.FileHeader) (web, server int, err error) {
-	const GVAPLUGPINATH = "./gva-plug-temp/"
-	defer os.RemoveAll(GVAPLUGPINATH)
-	_, err = os.Stat(GVAPLUGPINATH)
-	if os.IsNotExist(err) {
-		os.Mkdir(GVAPLUGPINATH, os.ModePerm)
+// Check 检查上传的插件是否满足安装条件 不安装
+func (s *autoCodePlugin) Check(ctx context.Context, file *multipart.FileHeader) (report response.AutoCodePluginCheck, err error) {
+	dir, err := s.extract(file)
+	if err != nil {
+		return report, err
 	}
-
-	src, err := file.Open()
+	defer os.RemoveAll(dir)
+	archive, err := s.locate(dir)
 	if err != nil {
-		return -1, -1, err
+		return report, err
 	}
-	defer src.Close()
-
-	out, err := os.Create(GVAPLUGPINATH + file.Filename)
+	report, err = s.check(ctx, archive)
 	if err != nil {
-		return -1, -1, err
+		return report, err
 	}
-	defer out.Close()
-
-	_, err = io.Copy(out, src)
-
-	paths, err := utils.Unzip(GVAPLUGPINATH+file.Filename, GVAPLUGPINATH)
-	paths = filterFile(paths)
-	var webIndex = -1
-	var serverIndex = -1
-	webPlugin := ""
-	serverPlugin := ""
+	return report, s.verify(dir, &report)
+}
 
-	for i := range paths {
-		paths[i] = filepath.ToSlash(paths[i])
-		pathArr := strings.Split(paths[i], "/")
-		ln := len(pathArr)
+// Install 插件安装 校验签名并按描述文件检查版本、依赖及文件冲突 不满足安装条件时不安装并返回检查结果
+func (s *autoCodePlugin) Install(ctx context.Context, file *multipart.FileHeader) (report response.AutoCodePluginCheck, err error) {
+	dir, err := s.extract(file)
+	if err != nil {
+		return report, err
+	}
+	defer os.RemoveAll(dir)
+	archive, err := s.locate(dir)
+	if err != nil {
+		return report, err
+	}
+	report, err = s.check(ctx, archive)
+	if err != nil {
+		return report, err
+	}
+	if err = s.verify(dir, &report); err != nil {
+		return report, err
+	}
+	if len(report.Errors) > 0 || len(report.Conflicts) > 0 {
+		return report, errors.New("插件不满足安装条件, 请查看检查结果")
+	}
 
-		if ln < 4 {
+	var installed []string
+	for _, target := range []struct{ from, root string }{
+		{archive.server, global.GVA_CONFIG.AutoCode.Server},
+		{archive.web, global.GVA_CONFIG.AutoCode.WebRoot()},
+	} {
+		if target.from == "" {
 			continue
 		}
-		if pathArr[2]+"/"+pathArr[3] == `server/plugin` && len(serverPlugin) == 0 {
-			serverPlugin = filepath.Join(pathArr[0], pathArr[1], pathArr[2], pathArr[3])
-		}
-		if pathArr[2]+"/"+pathArr[3] == `web/plugin` && len(webPlugin) == 0 {
-			webPlugin = filepath.Join(pathArr[0], pathArr[1], pathArr[2], pathArr[3])
+		to := filepath.Join(global.GVA_CONFIG.AutoCode.Root, target.root, "plugin", report.Name)
+		err = cp.Copy(target.from, to, cp.Options{Skip: skipMacSpecialDocument})
+		if err != nil {
+			s.removeFiles(installed)
+			return report, errors.Wrapf(err, "复制插件文件到[%s]失败!", to)
 		}
+		installed = append(installed, filepath.ToSlash(filepath.Join(target.root, "plugin", report.Name)))
 	}
-	if len(serverPlugin) == 0 && len(webPlugin) == 0 {
-		zap.L().Error("非标准插件，请按照文档自动迁移使用")
-		return webIndex, serverIndex, errors.New("非标准插件，请按照文档自动迁移使用")
+	err = s.register(ctx, report, installed)
+	if err != nil {
+		s.removeFiles(installed)
+		return report, err
 	}
+	report.Installed = true
+	return report, nil
+}
 
-	if len(serverPlugin) != 0 {
-		err = installation(serverPlugin, global.GVA_CONFIG.AutoCode.Server, global.GVA_CONFIG.AutoCode.Server)
-		if err != nil {
-			return webIndex, serverIndex, err
-		}
+// extract 保存上传的压缩包并按配置的限制解压到临时目录
+func (s *autoCodePlugin) extract(file *multipart.FileHeader) (dir string, err error) {
+	dir, err = os.MkdirTemp("", "gva-plugin-")
+	if err != nil {
+		return "", errors.Wrap(err, "创建临时文件夹失败!")
 	}
-
-	if len(webPlugin) != 0 {
-		err = installation(webPlugin, global.GVA_CONFIG.AutoCode.Server, global.GVA_CONFIG.AutoCode.Web)
+	defer func() {
 		if err != nil {
-			return webIndex, serverIndex, err
+			_ = os.RemoveAll(dir)
 		}
-	}
+	}()
 
-	return 1, 1, err
-}
-
-func installation(path string, formPath string, toPath string) error {
-	arr := strings.Split(filepath.ToSlash(path), "/")
-	ln := len(arr)
-	if ln < 3 {
-		return errors.New("arr")
+	src, err := file.Open()
+	if err != nil {
+		return "", errors.Wrap(err, "读取上传文件失败!")
 	}
-	name := arr[ln-3]
-
-	var form = filepath.Join(global.GVA_CONFIG.AutoCode.Root, formPath, path)
-	var to = filepath.Join(global.GVA_CONFIG.AutoCode.Root, toPath, "plugin")
-	_, err := os.Stat(to + name)
-	if err == nil {
-		zap.L().Error("autoPath 已存在同名插件，请自行手动安装", zap.String("to", to))
-		return errors.New(toPath + "已存在同名插件，请自行手动安装")
+	defer src.Close()
+	archive := filepath.Join(dir, "plugin.zip")
+	out, err := os.Create(archive)
+	if err != nil {
+		return "", errors.Wrap(err, "保存上传文件失败!")
 	}
-	return cp.Copy(form, to, cp.Options{Skip: skipMacSpecialDocument})
-}
-
-func filterFile(paths []string) []string {
-	np := make([]string, 0, len(paths))
-	for _, path := range paths {
-		if ok, _ := skipMacSpecialDocument(nil, path, ""); ok {
-			continue
-		}
-		np = append(np, path)
+	_, err = io.Copy(out, src)
+	_ = out.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "保存上传文件失败!")
+	}
+	_, err = utils.UnzipWithLimit(archive, filepath.Join(dir, "files"), pluginUnzipLimit())
+	if err != nil {
+		return "", errors.Wrap(err, "解压插件失败!")
 	}
-	return np
+	return dir, nil
 }
 
 func skipMacSpecialDocument(_ os.FileInfo, src, _ string) (bool, error) {
@@ -130,56 +135,77 @@ func skipMacSpecialDocument(_ os.FileInfo, src, _ string) (bool, error) {
 	return false, nil
 }
 
-func (s *autoCodePlugin) PubPlug(plugName string) (zipPath string, err error) {
+// PubPlug 打包插件 压缩包根目录包含全部文件的校验文件 配置了签名私钥时同时生成校验文件的签名
+func (s *autoCodePlugin) PubPlug(plugName string) (zipPath string, signed bool, err error) {
 	if plugName == "" {
-		return "", errors.New("插件名称不能为空")
+		return "", false, errors.New("插件名称不能为空")
 	}
 
 	// 防止路径穿越
-	plugName = filepath.Clean(plugName)
+	if plugName != filepath.Base(plugName) || !filepath.IsLocal(plugName) {
+		return "", false, errors.New("插件名称不合法")
+	}
+
+	var key ed25519.PrivateKey
+	if global.GVA_CONFIG.Plugin.SignKey != "" {
+		key, err = plugin.ParsePrivateKey(global.GVA_CONFIG.Plugin.SignKey)
+		if err != nil {
+			return "", false, errors.Wrap(err, "签名私钥配置错误")
+		}
+	}
 
 	webPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Web, "plugin", plugName)
 	serverPath := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "plugin", plugName)
-	// 创建一个新的zip文件
 
 	// 判断目录是否存在
 	_, err = os.Stat(webPath)
 	if err != nil {
-		return "", errors.New("web路径不存在")
+		return "", false, errors.New("web路径不存在")
 	}
 	_, err = os.Stat(serverPath)
 	if err != nil {
-		return "", errors.New("server路径不存在")
+		return "", false, errors.New("server路径不存在")
 	}
 
-	fileName := plugName + ".zip"
-	// 创建一个新的zip文件
-	files, err := archives.FilesFromDisk(context.Background(), nil, map[string]string{
-		webPath:    plugName + "/web/plugin/" + plugName,
-		serverPath: plugName + "/server/plugin/" + plugName,
-	})
-
-	// create the output file we'll write to
-	out, err := os.Create(fileName)
+	zipPath = filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, plugName+".zip")
+	out, err := os.Create(zipPath)
 	if err != nil {
-		return
+		return "", false, errors.Wrap(err, "创建压缩包失败!")
 	}
-	defer out.Close()
-
-	// we can use the CompressedArchive type to gzip a tarball
-	// (compression is not required; you could use Tar directly)
-	format := archives.CompressedArchive{
-		//Compression: archives.Gz{},
-		Archival: archives.Zip{},
+	defer func() {
+		_ = out.Close()
+		if err != nil {
+			_ = os.Remove(zipPath)
+		}
+	}()
+
+	writer := zip.NewWriter(out)
+	checksums := plugin.Checksums{}
+	for _, source := range []struct{ dir, name string }{
+		{webPath, plugName + "/web/plugin/" + plugName},
+		{serverPath, plugName + "/server/plugin/" + plugName},
+	} {
+		err = packDir(writer, checksums, source.dir, source.name)
+		if err != nil {
+			return "", false, err
+		}
 	}
-
-	// create the archive
-	err = format.Archive(context.Background(), out, files)
+	data := checksums.Marshal()
+	err = packFile(writer, plugin.ChecksumFile, data)
 	if err != nil {
-		return
+		return "", false, err
 	}
-
-	return filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, fileName), nil
+	if key != nil {
+		err = packFile(writer, plugin.SignatureFile, plugin.Sign(key, data))
+		if err != nil {
+			return "", false, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", false, errors.Wrap(err, "写入压缩包失败!")
+	}
+	return zipPath, key != nil, nil
 }
 
 func (s *autoCodePlugin) InitMenu(menuInfo request.InitMenu) (err error) {
@@ -0,0 +1,376 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+	"gorm.io/gorm"
+)
+
+// pluginArchive 解压后的插件 server 及 web 为插件目录的绝对路径 不包含对应端时为空
+type pluginArchive struct {
+	server string
+	web    string
+}
+
+// locate 查找解压目录中的 server/plugin/{插件名} 与 web/plugin/{插件名} 允许包含一层顶层目录
+func (s *autoCodePlugin) locate(dir string) (archive pluginArchive, err error) {
+	root := filepath.Join(dir, "files")
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || path == root {
+			return nil
+		}
+		if skip, _ := skipMacSpecialDocument(nil, path, ""); skip {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(root, path)
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) > 4 {
+			return filepath.SkipDir
+		}
+		n := len(parts)
+		if n < 3 || parts[n-2] != "plugin" {
+			return nil
+		}
+		switch {
+		case parts[n-3] == "server" && archive.server == "":
+			archive.server = path
+		case parts[n-3] == "web" && archive.web == "":
+			archive.web = path
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return archive, errors.Wrap(err, "读取插件文件失败!")
+	}
+	if archive.server == "" && archive.web == "" {
+		return archive, errors.New("非标准插件，请按照文档自动迁移使用")
+	}
+	return archive, nil
+}
+
+// check 检查插件描述文件、版本、依赖及文件冲突
+func (s *autoCodePlugin) check(ctx context.Context, archive pluginArchive) (report response.AutoCodePluginCheck, err error) {
+	report.Errors, report.Warnings, report.Conflicts = []string{}, []string{}, []string{}
+	report.Server, report.Web = archive.server != "", archive.web != ""
+	if report.Server {
+		report.Name = filepath.Base(archive.server)
+	}
+	if report.Web {
+		if report.Server && filepath.Base(archive.web) != report.Name {
+			report.Errors = append(report.Errors, fmt.Sprintf("server端插件[%s]与web端插件[%s]名称不一致", report.Name, filepath.Base(archive.web)))
+		}
+		if report.Name == "" {
+			report.Name = filepath.Base(archive.web)
+		}
+	}
+
+	var installed model.SysPlugin
+	err = global.GVA_DB.WithContext(ctx).Where("name = ?", report.Name).First(&installed).Error
+	if err == nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("插件[%s]已安装(版本%s), 请先卸载", report.Name, installed.Version))
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return report, errors.Wrap(err, "查询已安装插件失败!")
+	}
+
+	var manifest *plugin.Manifest
+	err = nil
+	if report.Server {
+		manifest, err = plugin.LoadManifest(archive.server)
+	}
+	switch {
+	case manifest != nil:
+		report.Manifest, report.Version = manifest, manifest.Version
+		if err = manifest.Validate(); err != nil {
+			report.Errors = append(report.Errors, strings.Split(err.Error(), "\n")...)
+			break
+		}
+		if manifest.Name != report.Name {
+			report.Errors = append(report.Errors, fmt.Sprintf("描述文件中的插件名[%s]与目录名[%s]不一致", manifest.Name, report.Name))
+		}
+		errs, warnings, e := s.compatible(ctx, manifest)
+		if e != nil {
+			return report, e
+		}
+		report.Errors = append(report.Errors, errs...)
+		report.Warnings = append(report.Warnings, warnings...)
+	case err == nil || errors.Is(err, os.ErrNotExist):
+		report.Warnings = append(report.Warnings, fmt.Sprintf("插件缺少描述文件%s, 无法检查版本及依赖", strings.Join(plugin.ManifestNames, "/")))
+	default:
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	for _, target := range []struct{ from, root string }{
+		{archive.server, global.GVA_CONFIG.AutoCode.Server},
+		{archive.web, global.GVA_CONFIG.AutoCode.WebRoot()},
+	} {
+		if target.from == "" {
+			continue
+		}
+		conflicts, e := pluginConflicts(target.from, filepath.Join(target.root, "plugin", report.Name))
+		if e != nil {
+			return report, e
+		}
+		report.Conflicts = append(report.Conflicts, conflicts...)
+	}
+	return report, nil
+}
+
+// compatible 检查gva版本、Go模块依赖、插件依赖、配置项及待创建的菜单与api
+func (s *autoCodePlugin) compatible(ctx context.Context, manifest *plugin.Manifest) (errs, warnings []string, err error) {
+	rng, _ := plugin.ParseVersionRange(manifest.Gva)
+	if !rng.Match(global.Version) {
+		errs = append(errs, fmt.Sprintf("插件要求gin-vue-admin版本%s, 当前版本为%s", manifest.Gva, global.Version))
+	}
+
+	if len(manifest.Dependencies) > 0 {
+		path := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "go.mod")
+		data, e := os.ReadFile(path)
+		if e != nil {
+			return nil, nil, errors.Wrap(e, "读取go.mod失败!")
+		}
+		file, e := modfile.ParseLax(path, data, nil)
+		if e != nil {
+			return nil, nil, errors.Wrap(e, "解析go.mod失败!")
+		}
+		requires := make(map[string]string, len(file.Require))
+		for _, require := range file.Require {
+			requires[require.Mod.Path] = require.Mod.Version
+		}
+		for _, dep := range manifest.Dependencies {
+			version, ok := requires[dep.Module]
+			switch {
+			case !ok:
+				errs = append(errs, fmt.Sprintf("缺少Go依赖%s, 请先执行 go get %s@%s", dep.Module, dep.Module, dep.Version))
+			case semver.Compare(version, dep.Version) < 0:
+				errs = append(errs, fmt.Sprintf("Go依赖%s的版本%s低于插件要求的%s, 请先执行 go get %s@%s", dep.Module, version, dep.Version, dep.Module, dep.Version))
+			}
+		}
+	}
+
+	for name, expr := range manifest.Plugins {
+		var dependency model.SysPlugin
+		e := global.GVA_DB.WithContext(ctx).Where("name = ?", name).First(&dependency).Error
+		if errors.Is(e, gorm.ErrRecordNotFound) {
+			errs = append(errs, fmt.Sprintf("依赖的插件[%s]未安装", name))
+			continue
+		}
+		if e != nil {
+			return nil, nil, errors.Wrap(e, "查询已安装插件失败!")
+		}
+		if rng, _ = plugin.ParseVersionRange(expr); !rng.Match(dependency.Version) {
+			errs = append(errs, fmt.Sprintf("依赖的插件[%s]版本为%s, 插件要求%s", name, dependency.Version, expr))
+		}
+	}
+
+	for _, key := range manifest.Config {
+		if global.GVA_VP == nil || !global.GVA_VP.IsSet(key) {
+			warnings = append(warnings, fmt.Sprintf("缺少配置项%s, 安装后请在配置文件中补充", key))
+		}
+	}
+
+	menus := make(map[string]bool, len(manifest.Menus))
+	for _, menu := range manifest.Menus {
+		if menu.Parent != "" && !menus[menu.Parent] {
+			e := global.GVA_DB.WithContext(ctx).Where("name = ?", menu.Parent).First(&model.SysBaseMenu{}).Error
+			if errors.Is(e, gorm.ErrRecordNotFound) {
+				errs = append(errs, fmt.Sprintf("菜单[%s]的父菜单[%s]不存在", menu.Name, menu.Parent))
+			}
+		}
+		menus[menu.Name] = true
+		if global.GVA_DB.WithContext(ctx).Where("name = ?", menu.Name).First(&model.SysBaseMenu{}).Error == nil {
+			warnings = append(warnings, fmt.Sprintf("菜单[%s]已存在, 不会重复创建", menu.Name))
+		}
+	}
+	for _, api := range manifest.Apis {
+		if global.GVA_DB.WithContext(ctx).Where("path = ? AND method = ?", api.Path, api.Method).First(&model.SysApi{}).Error == nil {
+			warnings = append(warnings, fmt.Sprintf("api[%s %s]已存在, 不会重复创建", api.Method, api.Path))
+		}
+	}
+	return errs, warnings, nil
+}
+
+// pluginConflicts 插件中在项目内已存在的文件 target 为相对项目根目录的插件目录
+func pluginConflicts(from string, target string) ([]string, error) {
+	conflicts := make([]string, 0)
+	err := filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip, _ := skipMacSpecialDocument(nil, path, ""); skip {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(from, path)
+		name := filepath.Join(target, rel)
+		if _, e := os.Stat(filepath.Join(global.GVA_CONFIG.AutoCode.Root, name)); e == nil {
+			conflicts = append(conflicts, filepath.ToSlash(name))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "检查文件冲突失败!")
+	}
+	return conflicts, nil
+}
+
+// register 创建描述文件中的菜单与api 并记录已安装的插件
+func (s *autoCodePlugin) register(ctx context.Context, report response.AutoCodePluginCheck, files []string) error {
+	entity := model.SysPlugin{Name: report.Name, Version: report.Version, Files: files}
+	if report.Manifest != nil {
+		entity.Description, entity.Author, entity.Gva = report.Manifest.Description, report.Manifest.Author, report.Manifest.Gva
+		manifest, _ := json.Marshal(report.Manifest)
+		entity.Manifest = string(manifest)
+	}
+	return global.GVA_DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if report.Manifest != nil {
+			created := make(map[string]uint, len(report.Manifest.Menus))
+			for _, item := range report.Manifest.Menus {
+				if tx.Where("name = ?", item.Name).First(&model.SysBaseMenu{}).Error == nil {
+					continue
+				}
+				menu := model.SysBaseMenu{
+					Path:      item.Path,
+					Name:      item.Name,
+					Hidden:    item.Hidden,
+					Component: item.Component,
+					Sort:      item.Sort,
+					Meta:      model.Meta{Title: item.Title, Icon: item.Icon},
+				}
+				if item.Parent != "" {
+					if id, ok := created[item.Parent]; ok {
+						menu.ParentId = id
+					} else {
+						var parent model.SysBaseMenu
+						if err := tx.Where("name = ?", item.Parent).First(&parent).Error; err != nil {
+							return errors.Wrapf(err, "查询父菜单[%s]失败!", item.Parent)
+						}
+						menu.ParentId = parent.ID
+					}
+				}
+				if err := tx.Create(&menu).Error; err != nil {
+					return errors.Wrapf(err, "创建菜单[%s]失败!", item.Name)
+				}
+				created[item.Name] = menu.ID
+				entity.MenuIDs = append(entity.MenuIDs, menu.ID)
+			}
+			for _, item := range report.Manifest.Apis {
+				if tx.Where("path = ? AND method = ?", item.Path, item.Method).First(&model.SysApi{}).Error == nil {
+					continue
+				}
+				api := model.SysApi{Path: item.Path, Method: item.Method, ApiGroup: item.Group, Description: item.Description}
+				if err := tx.Create(&api).Error; err != nil {
+					return errors.Wrapf(err, "创建api[%s %s]失败!", item.Method, item.Path)
+				}
+				entity.ApiIDs = append(entity.ApiIDs, api.ID)
+			}
+		}
+		if err := tx.Create(&entity).Error; err != nil {
+			return errors.Wrap(err, "记录已安装插件失败!")
+		}
+		return nil
+	})
+}
+
+// removeFiles 删除安装的文件 files 为相对项目根目录的路径
+func (s *autoCodePlugin) removeFiles(files []string) {
+	for _, file := range files {
+		_ = os.RemoveAll(filepath.Join(global.GVA_CONFIG.AutoCode.Root, filepath.FromSlash(file)))
+	}
+}
+
+// Plugins 获取已安装的插件
+func (s *autoCodePlugin) Plugins(ctx context.Context) (list []model.SysPlugin, err error) {
+	err = global.GVA_DB.WithContext(ctx).Order("id desc").Find(&list).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "获取已安装插件失败!")
+	}
+	return list, nil
+}
+
+// Uninstall 卸载插件 删除安装的文件及创建的菜单与api 被其他插件依赖或仍在initialize中注册时不允许卸载
+func (s *autoCodePlugin) Uninstall(ctx context.Context, name string) error {
+	var entity model.SysPlugin
+	err := global.GVA_DB.WithContext(ctx).Where("name = ?", name).First(&entity).Error
+	if err != nil {
+		return errors.Errorf("插件[%s]未安装!", name)
+	}
+
+	var plugins []model.SysPlugin
+	err = global.GVA_DB.WithContext(ctx).Where("name <> ?", name).Find(&plugins).Error
+	if err != nil {
+		return errors.Wrap(err, "获取已安装插件失败!")
+	}
+	for _, other := range plugins {
+		var manifest plugin.Manifest
+		if other.Manifest == "" || json.Unmarshal([]byte(other.Manifest), &manifest) != nil {
+			continue
+		}
+		if _, ok := manifest.Plugins[name]; ok {
+			return errors.Errorf("插件[%s]依赖插件[%s], 请先卸载", other.Name, name)
+		}
+	}
+
+	initialize := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "initialize")
+	entries, _ := os.ReadDir(initialize)
+	importPath := fmt.Sprintf("%q", global.GVA_CONFIG.AutoCode.Module+"/plugin/"+name)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".go" {
+			continue
+		}
+		data, _ := os.ReadFile(filepath.Join(initialize, e.Name()))
+		if strings.Contains(string(data), importPath) {
+			return errors.Errorf("插件[%s]仍在initialize/%s中注册, 请先移除注册代码", name, e.Name())
+		}
+	}
+
+	if len(entity.ApiIDs) > 0 {
+		var apis []model.SysApi
+		err = global.GVA_DB.WithContext(ctx).Find(&apis, "id in ?", entity.ApiIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "查询插件创建的api失败!")
+		}
+		err = global.GVA_DB.WithContext(ctx).Delete(&model.SysApi{}, "id in ?", entity.ApiIDs).Error
+		if err != nil {
+			return errors.Wrap(err, "删除插件创建的api失败!")
+		}
+		for _, api := range apis {
+			CasbinServiceApp.ClearCasbin(2, api.Path, api.Method)
+		}
+	}
+	for i := len(entity.MenuIDs) - 1; i >= 0; i-- {
+		id := entity.MenuIDs[i]
+		if errors.Is(global.GVA_DB.WithContext(ctx).First(&model.SysBaseMenu{}, id).Error, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err = BaseMenuServiceApp.DeleteBaseMenu(int(id)); err != nil {
+			return errors.Wrapf(err, "删除插件创建的菜单[%d]失败!", id)
+		}
+	}
+	s.removeFiles(entity.Files)
+	err = global.GVA_DB.WithContext(ctx).Unscoped().Delete(&entity).Error
+	if err != nil {
+		return errors.Wrap(err, "删除插件记录失败!")
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package system
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// pluginUpload 将文件打包为zip并构造上传的文件
+func pluginUpload(t *testing.T, files map[string]string) *multipart.FileHeader {
+	t.Helper()
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return pluginUploadZip(t, archive.Bytes())
+}
+
+// pluginUploadZip 构造上传的zip文件
+func pluginUploadZip(t *testing.T, archive []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	w, _ := mw.CreateFormFile("plug", "plugin.zip")
+	_, _ = w.Write(archive)
+	_ = mw.Close()
+	form, err := multipart.NewReader(&body, mw.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["plug"][0]
+}
+
+// setupPluginTest 使用内存数据库及临时项目根目录 返回数据库与根目录
+func setupPluginTest(t *testing.T, files map[string]string) (*gorm.DB, string) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&model.SysPlugin{}, &model.SysBaseMenu{}, &model.SysBaseMenuParameter{}, &model.SysBaseMenuBtn{}, &model.SysAuthorityBtn{}, &model.SysAuthority{}, &model.SysApi{}); err != nil {
+		t.Fatal(err)
+	}
+	dbBefore, logBefore, autoCode, pluginConfig := global.GVA_DB, global.GVA_LOG, global.GVA_CONFIG.AutoCode, global.GVA_CONFIG.Plugin
+	t.Cleanup(func() {
+		global.GVA_DB, global.GVA_LOG, global.GVA_CONFIG.AutoCode, global.GVA_CONFIG.Plugin = dbBefore, logBefore, autoCode, pluginConfig
+	})
+	global.GVA_DB, global.GVA_LOG = db, zap.NewNop()
+	root := t.TempDir()
+	global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, global.GVA_CONFIG.AutoCode.Web = root, "server", "web/src"
+	global.GVA_CONFIG.AutoCode.Module = "github.com/flipped-aurora/gin-vue-admin/server"
+	for name, content := range files {
+		name = filepath.Join(root, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(name), 0o755)
+		if err = os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db, root
+}
+
+func Test_autoCodePlugin_Install(t *testing.T) {
+	db, root := setupPluginTest(t, map[string]string{
+		"server/go.mod":                         "module github.com/flipped-aurora/gin-vue-admin/server\n\nrequire github.com/robfig/cron/v3 v3.0.1\n",
+		"server/initialize/plugin_biz_v2.go":    "package initialize\n",
+		"web/src/plugin/notice/view/old.vue":    "<template />",
+		"server/plugin/announcement/plugin.yml": "",
+	})
+	err := db.Create(&model.SysBaseMenu{Name: "superAdmin", Path: "admin", Component: "view/routerHolder.vue"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := `name: notice
+version: 1.2.0
+gva: ">=v2.8.0 <v3.0.0"
+dependencies:
+  - module: github.com/robfig/cron/v3
+    version: v3.0.1
+config: [notice.channel]
+menus:
+  - parent: superAdmin
+    name: notice
+    path: notice
+    component: view/routerHolder.vue
+    title: 通知
+  - parent: notice
+    name: noticeList
+    path: list
+    component: plugin/notice/view/list.vue
+    title: 通知列表
+apis:
+  - path: /notice/list
+    method: GET
+    group: 通知
+    description: 获取通知列表
+`
+	files := map[string]string{
+		"notice/server/plugin/notice/plugin.yaml":  manifest,
+		"notice/server/plugin/notice/plugin.go":    "package notice\n",
+		"notice/web/plugin/notice/view/list.vue":   "<template />",
+		"notice/web/plugin/notice/view/old.vue":    "<template />",
+		"__MACOSX/notice/server/plugin/notice/._x": "",
+	}
+	ctx := context.Background()
+	s := &autoCodePlugin{}
+
+	report, err := s.Install(ctx, pluginUpload(t, files))
+	if err == nil || len(report.Conflicts) != 1 || report.Conflicts[0] != "web/src/plugin/notice/view/old.vue" {
+		t.Fatalf("Install() should report conflicts, got %+v %v", report, err)
+	}
+	if !report.Server || !report.Web || report.Version != "1.2.0" || len(report.Errors) != 0 {
+		t.Errorf("Install() report = %+v", report)
+	}
+	if len(report.Warnings) != 2 || !strings.Contains(report.Warnings[0], "notice.channel") || !strings.Contains(report.Warnings[1], "未签名") {
+		t.Errorf("Install() warnings = %v", report.Warnings)
+	}
+
+	delete(files, "notice/web/plugin/notice/view/old.vue")
+	_ = os.RemoveAll(filepath.Join(root, "web"))
+	if report, err = s.Install(ctx, pluginUpload(t, files)); err != nil || !report.Installed {
+		t.Fatalf("Install() = %+v %v", report, err)
+	}
+	if _, err = os.Stat(filepath.Join(root, "server", "plugin", "notice", "plugin.go")); err != nil {
+		t.Errorf("server plugin should be copied: %v", err)
+	}
+	var noticeList model.SysBaseMenu
+	if err = db.Where("name = ?", "noticeList").First(&noticeList).Error; err != nil {
+		t.Fatal(err)
+	}
+	var notice model.SysBaseMenu
+	if db.Where("name = ?", "notice").First(&notice); noticeList.ParentId != notice.ID {
+		t.Errorf("menu parent = %d, want %d", noticeList.ParentId, notice.ID)
+	}
+	plugins, err := s.Plugins(ctx)
+	if err != nil || len(plugins) != 1 || len(plugins[0].MenuIDs) != 2 || len(plugins[0].ApiIDs) != 1 || len(plugins[0].Files) != 2 {
+		t.Fatalf("Plugins() = %+v %v", plugins, err)
+	}
+
+	dependent := `name: reminder
+version: 0.1.0
+gva: ">=v3.0.0"
+plugins:
+  notice: ">=1.3.0"
+  missing: "*"
+dependencies:
+  - module: github.com/robfig/cron/v3
+    version: v3.1.0
+  - module: github.com/example/missing
+    version: v1.0.0
+`
+	report, err = s.Check(ctx, pluginUpload(t, map[string]string{"server/plugin/reminder/plugin.yaml": dependent}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"gin-vue-admin版本>=v3.0.0", "插件[notice]版本为1.2.0", "插件[missing]未安装", "低于插件要求的v3.1.0", "缺少Go依赖github.com/example/missing"} {
+		found := false
+		for _, e := range report.Errors {
+			found = found || strings.Contains(e, want)
+		}
+		if !found {
+			t.Errorf("Check() errors = %v, should contain %s", report.Errors, want)
+		}
+	}
+
+	if err = os.WriteFile(filepath.Join(root, "server", "initialize", "plugin_biz_v2.go"), []byte("package initialize\n\nimport \"github.com/flipped-aurora/gin-vue-admin/server/plugin/notice\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Uninstall(ctx, "notice"); err == nil || !strings.Contains(err.Error(), "plugin_biz_v2.go") {
+		t.Fatalf("Uninstall() should refuse a registered plugin, got %v", err)
+	}
+	_ = os.WriteFile(filepath.Join(root, "server", "initialize", "plugin_biz_v2.go"), []byte("package initialize\n"), 0o644)
+	enforcer := utils.GetCasbin()
+	if enforcer == nil {
+		t.Fatal("casbin enforcer not initialized")
+	}
+	_, err = enforcer.AddPolicies([][]string{
+		{"888", utils.CasbinDomain, "/notice/list", "GET", utils.CasbinAllow},
+		{"9528", utils.CasbinDomain, "/notice/list", "GET", utils.CasbinAllow},
+		{"888", utils.CasbinDomain, "/user/list", "GET", utils.CasbinAllow},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = s.Uninstall(ctx, "notice"); err != nil {
+		t.Fatal(err)
+	}
+	if policies, _ := enforcer.GetFilteredPolicy(2, "/notice/list", "GET"); len(policies) != 0 {
+		t.Errorf("policies of uninstalled plugin apis = %v, want none", policies)
+	}
+	if ok, _ := enforcer.HasPolicy("888", utils.CasbinDomain, "/user/list", "GET", utils.CasbinAllow); !ok {
+		t.Error("unrelated policy should be kept")
+	}
+	var count int64
+	db.Model(&model.SysBaseMenu{}).Count(&count)
+	if count != 1 {
+		t.Errorf("menus after uninstall = %d, want 1", count)
+	}
+	if db.Model(&model.SysApi{}).Count(&count); count != 0 {
+		t.Errorf("apis after uninstall = %d, want 0", count)
+	}
+	if _, err = os.Stat(filepath.Join(root, "server", "plugin", "notice")); !os.IsNotExist(err) {
+		t.Errorf("plugin files should be removed: %v", err)
+	}
+	if plugins, _ = s.Plugins(ctx); len(plugins) != 0 {
+		t.Errorf("Plugins() after uninstall = %+v", plugins)
+	}
+}
@@ -0,0 +1,119 @@
+package system
+
+import (
+	"archive/zip"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin"
+	"github.com/pkg/errors"
+)
+
+// pluginUnzipLimit 解压插件的限制 未配置的项使用默认值
+func pluginUnzipLimit() utils.UnzipLimit {
+	limit, config := utils.DefaultUnzipLimit, global.GVA_CONFIG.Plugin
+	if config.MaxFileSize > 0 {
+		limit.MaxFileSize = config.MaxFileSize << 20
+	}
+	if config.MaxTotalSize > 0 {
+		limit.MaxTotalSize = config.MaxTotalSize << 20
+	}
+	if config.MaxFiles > 0 {
+		limit.MaxFiles = config.MaxFiles
+	}
+	return limit
+}
+
+// verify 校验插件的校验文件及签名 结果写入检查结果 未签名时按配置作为错误或提示
+func (s *autoCodePlugin) verify(dir string, report *response.AutoCodePluginCheck) error {
+	keys := make(map[string]ed25519.PublicKey, len(global.GVA_CONFIG.Plugin.TrustedKeys))
+	for i, key := range global.GVA_CONFIG.Plugin.TrustedKeys {
+		public, err := plugin.ParsePublicKey(key.Key)
+		if err != nil {
+			return errors.Wrapf(err, "受信任公钥[%d]%s配置错误", i, key.Name)
+		}
+		name := key.Name
+		if name == "" {
+			name = fmt.Sprintf("trusted-keys[%d]", i)
+		}
+		keys[name] = public
+	}
+
+	signer, err := plugin.VerifyArchive(filepath.Join(dir, "files"), keys, func(path string) bool {
+		skip, _ := skipMacSpecialDocument(nil, path, "")
+		return skip
+	})
+	switch {
+	case err == nil:
+		report.Signer = signer
+	case errors.Is(err, plugin.ErrUnsigned) && global.GVA_CONFIG.Plugin.RequireSignature:
+		report.Errors = append(report.Errors, "插件未签名, 当前配置只允许安装已签名的插件")
+	case errors.Is(err, plugin.ErrUnsigned):
+		report.Warnings = append(report.Warnings, "插件未签名, 无法确认插件来源及完整性")
+	default:
+		report.Errors = append(report.Errors, err.Error())
+	}
+	return nil
+}
+
+// packDir 将目录下的文件写入压缩包并记录校验值 name 为目录在压缩包中的路径
+func packDir(writer *zip.Writer, checksums plugin.Checksums, dir, name string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip, _ := skipMacSpecialDocument(nil, path, ""); skip {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return errors.Errorf("插件中不能包含符号链接等特殊文件: %s", path)
+		}
+		rel, _ := filepath.Rel(dir, path)
+		entry := name + "/" + filepath.ToSlash(rel)
+		file, err := os.Open(path)
+		if err != nil {
+			return errors.Wrapf(err, "读取文件[%s]失败!", path)
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name, header.Method = entry, zip.Deflate
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			return errors.Wrap(err, "写入压缩包失败!")
+		}
+		if err = checksums.Add(entry, io.TeeReader(file, w)); err != nil {
+			return errors.Wrapf(err, "写入文件[%s]失败!", entry)
+		}
+		return nil
+	})
+}
+
+// packFile 将内容写入压缩包根目录
+func packFile(writer *zip.Writer, name string, data []byte) error {
+	w, err := writer.Create(name)
+	if err != nil {
+		return errors.Wrap(err, "写入压缩包失败!")
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,137 @@
+package system
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/plugin"
+)
+
+// rewriteZip 读取压缩包内容 经 modify 修改后重新打包
+func rewriteZip(t *testing.T, data []byte, modify func(files map[string]string)) []byte {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range reader.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[f.Name] = string(content)
+	}
+	modify(files)
+	var out bytes.Buffer
+	zw := zip.NewWriter(&out)
+	for name, content := range files {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	_ = zw.Close()
+	return out.Bytes()
+}
+
+func Test_autoCodePlugin_PubPlug(t *testing.T) {
+	setupPluginTest(t, map[string]string{
+		"server/plugin/notice/plugin.go":      "package notice\n",
+		"server/plugin/notice/plugin.yaml":    "name: notice\nversion: 1.0.0\n",
+		"server/plugin/notice/.DS_Store":      "",
+		"web/src/plugin/notice/view/list.vue": "<template />",
+	})
+	publicKey, privateKey, err := plugin.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, _ := plugin.GenerateKey()
+	global.GVA_CONFIG.Plugin = config.Plugin{
+		SignKey:     privateKey,
+		TrustedKeys: []config.PluginKey{{Name: "other", Key: otherKey}, {Name: "gva", Key: publicKey}},
+	}
+	s := &autoCodePlugin{}
+	ctx := context.Background()
+
+	if _, _, err = s.PubPlug("../notice"); err == nil {
+		t.Error("PubPlug() should reject path traversal")
+	}
+	zipPath, signed, err := s.PubPlug("notice")
+	if err != nil || !signed {
+		t.Fatalf("PubPlug() = %v %v", signed, err)
+	}
+	archive, err := os.ReadFile(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewriteZip(t, archive, func(files map[string]string) {
+		for _, name := range []string{plugin.ChecksumFile, plugin.SignatureFile, "notice/server/plugin/notice/plugin.go", "notice/web/plugin/notice/view/list.vue"} {
+			if _, ok := files[name]; !ok {
+				t.Errorf("archive should contain %s", name)
+			}
+		}
+		if _, ok := files["notice/server/plugin/notice/.DS_Store"]; ok {
+			t.Error("archive should skip .DS_Store")
+		}
+	})
+
+	// 插件已存在于项目中 只检查签名相关的结果
+	checkSignature := func(archive []byte) (signer string, errs []string) {
+		report, err := s.Check(ctx, pluginUploadZip(t, archive))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range append(report.Errors, report.Warnings...) {
+			if strings.Contains(e, "签名") || strings.Contains(e, "校验") {
+				errs = append(errs, e)
+			}
+		}
+		return report.Signer, errs
+	}
+
+	if signer, errs := checkSignature(archive); signer != "gva" || len(errs) != 0 {
+		t.Errorf("Check() signer = %s, errors = %v", signer, errs)
+	}
+
+	tampered := rewriteZip(t, archive, func(files map[string]string) {
+		files["notice/server/plugin/notice/plugin.go"] = "package notice\n\nfunc init() { panic(1) }\n"
+	})
+	if _, errs := checkSignature(tampered); len(errs) != 1 || !strings.Contains(errs[0], "plugin.go校验失败") {
+		t.Errorf("Check() of a tampered archive = %v", errs)
+	}
+
+	extra := rewriteZip(t, archive, func(files map[string]string) {
+		files["notice/server/plugin/notice/extra.go"] = "package notice\n"
+		files["__MACOSX/notice/._plugin.go"] = ""
+	})
+	if _, errs := checkSignature(extra); len(errs) != 1 || !strings.Contains(errs[0], "extra.go不在校验文件中") {
+		t.Errorf("Check() of an archive with extra files = %v", errs)
+	}
+
+	global.GVA_CONFIG.Plugin.TrustedKeys = global.GVA_CONFIG.Plugin.TrustedKeys[:1]
+	if signer, errs := checkSignature(archive); signer != "" || len(errs) != 1 || !strings.Contains(errs[0], "无法通过受信任的公钥验证") {
+		t.Errorf("Check() with an untrusted key = %s %v", signer, errs)
+	}
+
+	unsigned := rewriteZip(t, archive, func(files map[string]string) {
+		delete(files, plugin.SignatureFile)
+	})
+	if _, errs := checkSignature(unsigned); len(errs) != 1 || !strings.Contains(errs[0], "插件未签名, 无法确认") {
+		t.Errorf("Check() of an unsigned archive = %v", errs)
+	}
+	global.GVA_CONFIG.Plugin.RequireSignature = true
+	report, err := s.Install(ctx, pluginUploadZip(t, unsigned))
+	if err == nil || !strings.Contains(strings.Join(report.Errors, "\n"), "只允许安装已签名的插件") {
+		t.Errorf("Install() of an unsigned archive = %+v %v", report, err)
+	}
+
+	global.GVA_CONFIG.Plugin.MaxFiles = 2
+	if _, err = s.Check(ctx, pluginUploadZip(t, archive)); err == nil || !strings.Contains(err.Error(), "超过限制") {
+		t.Errorf("Check() should respect plugin.max-files, got %v", err)
+	}
+}
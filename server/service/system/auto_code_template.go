@@ -217,7 +217,29 @@ func (s *autoCodeTemplate) Preview(ctx context.Context, info request.AutoCode) (
 	return preview, nil
 }
 
+// Render 按模块描述离线生成代码 不依赖数据库 返回文件绝对路径及生成后的完整内容
+// 与 Create 使用相同的模版及AST注入 但不创建api、菜单、导出模板及历史记录 templatePack 为空时使用内置模板
+func (s *autoCodeTemplate) Render(ctx context.Context, info request.AutoCode, template string, templatePack string) (map[string]string, error) {
+	err := s.checkPackage(info.Package, template)
+	if err != nil {
+		return nil, err
+	}
+	entity := model.SysAutoCodePackage{PackageName: info.Package, Template: template, TemplatePack: templatePack}
+	codes, _, _, err := s.generate(ctx, info, entity)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string, len(codes))
+	for key, builder := range codes {
+		files[key] = builder.String()
+	}
+	return files, nil
+}
+
 func (s *autoCodeTemplate) generate(ctx context.Context, info request.AutoCode, entity model.SysAutoCodePackage) (map[string]strings.Builder, map[string]string, map[string]utilsAst.Ast, error) {
+	if info.HasSubTable && entity.Template != "package" {
+		return nil, nil, nil, errors.New("子表暂仅支持package模板!")
+	}
 	templates, asts, _, err := AutoCodePackage.templates(ctx, entity, info, false)
 	if err != nil {
 		return nil, nil, nil, err
@@ -0,0 +1,245 @@
+package system
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ast"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/autocode"
+	"github.com/pkg/errors"
+)
+
+// templatePackDir 模板包存放目录 每个模板包一个子目录 目录名即模板包名称
+const templatePackDir = "packs"
+
+func (s *autoCodePackage) templatePackRoot() string {
+	return filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "resource", templatePackDir)
+}
+
+func (s *autoCodePackage) builtinTemplateDir(template string) string {
+	return filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, "resource", template)
+}
+
+// templatePack 读取并校验已注册的模板包 template 不为空时校验模板包适用于该内置模板
+func (s *autoCodePackage) templatePack(name string, template string) (*autocode.TemplatePack, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, errors.Errorf("模板包名称[%s]不合法!", name)
+	}
+	pack, err := autocode.LoadTemplatePack(filepath.Join(s.templatePackRoot(), name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "模板包[%s]不存在或无法读取!", name)
+	}
+	if pack.Name != name {
+		return nil, errors.Errorf("模板包[%s]的名称与目录名[%s]不一致!", pack.Name, name)
+	}
+	if template != "" && pack.Base != template {
+		return nil, errors.Errorf("模板包[%s]适用于%s模板, 不能用于%s模板!", name, pack.Base, template)
+	}
+	if err = pack.Validate(s.builtinTemplateDir(pack.Base)); err != nil {
+		return nil, errors.Wrapf(err, "模板包[%s]校验失败", name)
+	}
+	return pack, nil
+}
+
+// TemplatePacks 获取已注册的模板包 校验失败的模板包同样返回并附带原因
+func (s *autoCodePackage) TemplatePacks(ctx context.Context) ([]response.AutoCodeTemplatePack, error) {
+	packs := make([]response.AutoCodeTemplatePack, 0)
+	entries, err := os.ReadDir(s.templatePackRoot())
+	if os.IsNotExist(err) {
+		return packs, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "读取模板包文件夹失败!")
+	}
+	var entities []model.SysAutoCodePackage
+	err = global.GVA_DB.WithContext(ctx).Where("template_pack <> ''").Find(&entities).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "获取使用模板包的package失败!")
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info := response.AutoCodeTemplatePack{Name: entry.Name(), Files: []string{}, Packages: []string{}}
+		for _, entity := range entities {
+			if entity.TemplatePack == entry.Name() {
+				info.Packages = append(info.Packages, entity.PackageName)
+			}
+		}
+		pack, e := autocode.LoadTemplatePack(filepath.Join(s.templatePackRoot(), entry.Name()))
+		if e == nil {
+			info.Description, info.Base, info.Injections = pack.Description, pack.Base, pack.Injections
+			for _, file := range pack.Files {
+				info.Files = append(info.Files, file.Template)
+			}
+			_, e = s.templatePack(entry.Name(), "")
+		}
+		if e != nil {
+			info.Error = e.Error()
+		}
+		packs = append(packs, info)
+	}
+	return packs, nil
+}
+
+// UploadTemplatePack 上传zip格式的模板包 描述文件位于zip根目录或唯一的顶层目录中 校验通过后替换同名模板包
+func (s *autoCodePackage) UploadTemplatePack(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	if !strings.EqualFold(filepath.Ext(file.Filename), ".zip") {
+		return "", errors.New("模板包必须为zip文件!")
+	}
+	root := s.templatePackRoot()
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return "", errors.Wrap(err, "创建模板包文件夹失败!")
+	}
+	temp, err := os.MkdirTemp(root, ".upload-")
+	if err != nil {
+		return "", errors.Wrap(err, "创建临时文件夹失败!")
+	}
+	defer os.RemoveAll(temp)
+
+	src, err := file.Open()
+	if err != nil {
+		return "", errors.Wrap(err, "读取上传文件失败!")
+	}
+	defer src.Close()
+	archive := filepath.Join(temp, "pack.zip")
+	out, err := os.Create(archive)
+	if err != nil {
+		return "", errors.Wrap(err, "保存上传文件失败!")
+	}
+	_, err = io.Copy(out, src)
+	_ = out.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "保存上传文件失败!")
+	}
+	dir := filepath.Join(temp, "pack")
+	if _, err = utils.Unzip(archive, dir); err != nil {
+		return "", errors.Wrap(err, "解压模板包失败!")
+	}
+
+	if _, err = autocode.LoadTemplatePack(dir); err != nil {
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 || !entries[0].IsDir() {
+			return "", err
+		}
+		dir = filepath.Join(dir, entries[0].Name())
+	} // 压缩时通常会包含顶层目录
+	pack, err := autocode.LoadTemplatePack(dir)
+	if err != nil {
+		return "", err
+	}
+	if err = pack.Validate(s.builtinTemplateDir(pack.Base)); err != nil {
+		return "", errors.Wrap(err, "模板包校验失败")
+	}
+
+	target := filepath.Join(root, pack.Name)
+	backup := filepath.Join(temp, "backup")
+	if _, err = os.Stat(target); err == nil {
+		if err = os.Rename(target, backup); err != nil {
+			return "", errors.Wrapf(err, "替换模板包[%s]失败!", pack.Name)
+		}
+	}
+	if err = os.Rename(dir, target); err != nil {
+		_ = os.Rename(backup, target)
+		return "", errors.Wrapf(err, "注册模板包[%s]失败!", pack.Name)
+	}
+	return pack.Name, nil
+}
+
+// DeleteTemplatePack 删除模板包 仍被package使用时不允许删除
+func (s *autoCodePackage) DeleteTemplatePack(ctx context.Context, name string) error {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return errors.Errorf("模板包名称[%s]不合法!", name)
+	}
+	var count int64
+	err := global.GVA_DB.WithContext(ctx).Model(&model.SysAutoCodePackage{}).Where("template_pack = ?", name).Count(&count).Error
+	if err != nil {
+		return errors.Wrap(err, "查询使用模板包的package失败!")
+	}
+	if count > 0 {
+		return errors.Errorf("模板包[%s]正在被%d个package使用, 请先取消使用!", name, count)
+	}
+	err = os.RemoveAll(filepath.Join(s.templatePackRoot(), name))
+	if err != nil {
+		return errors.Wrap(err, "删除模板包失败!")
+	}
+	return nil
+}
+
+// SetTemplatePack 设置package使用的模板包 之后生成的代码使用该模板包
+func (s *autoCodePackage) SetTemplatePack(ctx context.Context, info request.SysAutoCodePackageTemplatePack) error {
+	var entity model.SysAutoCodePackage
+	err := global.GVA_DB.WithContext(ctx).First(&entity, info.Uint()).Error
+	if err != nil {
+		return errors.Wrap(err, "查询包失败!")
+	}
+	if info.TemplatePack != "" {
+		if _, err = s.templatePack(info.TemplatePack, entity.Template); err != nil {
+			return err
+		}
+	}
+	err = global.GVA_DB.WithContext(ctx).Model(&entity).Update("template_pack", info.TemplatePack).Error
+	if err != nil {
+		return errors.Wrap(err, "设置模板包失败!")
+	}
+	return nil
+}
+
+// applyTemplatePack 按package使用的模板包替换内置模板、追加生成文件并过滤AST注入
+func (s *autoCodePackage) applyTemplatePack(entity model.SysAutoCodePackage, info request.AutoCode, isPackage bool, code map[string]string, asts map[string]ast.Ast, creates map[string]string) error {
+	pack, err := s.templatePack(entity.TemplatePack, entity.Template)
+	if err != nil {
+		return err
+	}
+	templateDir := s.builtinTemplateDir(entity.Template)
+	overrides := pack.Overrides()
+	for _, files := range []map[string]string{code, creates} {
+		for key, value := range files {
+			rel, e := filepath.Rel(templateDir, key)
+			if e != nil {
+				continue
+			}
+			if override, ok := overrides[rel]; ok {
+				delete(files, key)
+				files[override] = value
+			}
+		}
+	}
+	if !isPackage {
+		for _, file := range pack.Files {
+			if file.Target == "" {
+				continue
+			}
+			target, e := pack.Target(file, info)
+			if e != nil {
+				return e
+			}
+			root, rest, _ := strings.Cut(target, "/")
+			switch {
+			case root == "server" && info.GenerateServer:
+				root = global.GVA_CONFIG.AutoCode.Server
+			case root == "web" && info.GenerateWeb:
+				root = global.GVA_CONFIG.AutoCode.WebRoot()
+			default:
+				continue
+			}
+			code[filepath.Join(pack.Dir, filepath.FromSlash(file.Template))] = filepath.Join(global.GVA_CONFIG.AutoCode.Root, root, filepath.FromSlash(rest))
+		}
+	}
+	for key := range asts {
+		keys := strings.Split(key, "=>")
+		if len(keys) == 2 && !pack.HasInjection(keys[1]) {
+			delete(asts, key)
+		}
+	}
+	return nil
+}
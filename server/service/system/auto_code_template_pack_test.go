@@ -0,0 +1,85 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ast"
+)
+
+func Test_autoCodePackage_applyTemplatePack(t *testing.T) {
+	root := t.TempDir()
+	autoCode := global.GVA_CONFIG.AutoCode
+	t.Cleanup(func() { global.GVA_CONFIG.AutoCode = autoCode })
+	global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server, global.GVA_CONFIG.AutoCode.Web = root, "server", "web/src"
+
+	files := map[string]string{
+		"server/resource/package/server/service/service.go.tpl": "package {{.Package}}",
+		"server/resource/package/server/api/api.go.tpl":         "package {{.Package}}",
+		"server/resource/packs/audit/manifest.yaml": `name: audit
+base: package
+files:
+  - template: server/service/service.go.tpl
+  - template: server/audit.go.tpl
+    target: server/service/{{.Package}}/{{.HumpPackageName}}_audit.go
+  - template: web/audit.vue.tpl
+    target: web/view/{{.Package}}/audit.vue
+injections: [PackageServiceEnter]
+`,
+		"server/resource/packs/audit/server/service/service.go.tpl": "package {{.Package}}",
+		"server/resource/packs/audit/server/audit.go.tpl":           "package {{.Package}}",
+		"server/resource/packs/audit/web/audit.vue.tpl":             "<template />",
+	}
+	for name, content := range files {
+		name = filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	builtin := filepath.Join(root, "server", "resource", "package", "server")
+	pack := filepath.Join(root, "server", "resource", "packs", "audit")
+	code := map[string]string{
+		filepath.Join(builtin, "service", "service.go.tpl"): filepath.Join(root, "server", "service", "example", "book.go"),
+		filepath.Join(builtin, "api", "api.go.tpl"):         filepath.Join(root, "server", "api", "v1", "example", "book.go"),
+	}
+	asts := map[string]ast.Ast{
+		"service/enter.go=>" + ast.TypePackageServiceEnter: nil,
+		"api/enter.go=>" + ast.TypePackageApiEnter:         nil,
+	}
+	entity := model.SysAutoCodePackage{PackageName: "example", Template: "package", TemplatePack: "audit"}
+	info := request.AutoCode{Package: "example", HumpPackageName: "book", GenerateServer: true}
+	err := AutoCodePackage.applyTemplatePack(entity, info, false, code, asts, map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		filepath.Join(pack, "server", "service", "service.go.tpl"): filepath.Join(root, "server", "service", "example", "book.go"),
+		filepath.Join(builtin, "api", "api.go.tpl"):                filepath.Join(root, "server", "api", "v1", "example", "book.go"),
+		filepath.Join(pack, "server", "audit.go.tpl"):              filepath.Join(root, "server", "service", "example", "book_audit.go"),
+	}
+	if len(code) != len(want) {
+		t.Errorf("applyTemplatePack() code = %v, want %v", code, want)
+	}
+	for key, value := range want {
+		if code[key] != value {
+			t.Errorf("applyTemplatePack() code[%s] = %s, want %s", key, code[key], value)
+		}
+	}
+	if _, ok := asts["api/enter.go=>"+ast.TypePackageApiEnter]; ok || len(asts) != 1 {
+		t.Errorf("applyTemplatePack() asts = %v, should only keep declared injections", asts)
+	}
+
+	entity.Template = "plugin"
+	if err = AutoCodePackage.applyTemplatePack(entity, info, false, code, asts, nil); err == nil {
+		t.Error("applyTemplatePack() should reject a pack for another template")
+	}
+}
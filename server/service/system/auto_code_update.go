@@ -0,0 +1,138 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	utilsAst "github.com/flipped-aurora/gin-vue-admin/server/utils/ast"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/autocode"
+	"github.com/pkg/errors"
+)
+
+// Update 更新已生成的模块 preview 为 true 时只返回变更预览不写入文件
+// 分别按生成历史中的结构化信息与新的结构化信息生成代码 将两者的差异合并进磁盘上的文件
+// 已被手动修改而无法合并的内容保持不变 在结果中列出
+func (s *autoCodeTemplate) Update(ctx context.Context, info request.SysAutoHistoryUpdate, preview bool) (result response.AutoCodeUpdate, err error) {
+	var history model.SysAutoCodeHistory
+	err = global.GVA_DB.WithContext(ctx).Where("id = ?", info.Uint()).First(&history).Error
+	if err != nil {
+		return result, errors.Wrap(err, "查询生成历史失败!")
+	}
+	if history.Flag == 1 {
+		return result, errors.New("已回滚的模块无法更新!")
+	}
+	var old request.AutoCode
+	err = json.Unmarshal([]byte(history.Request), &old)
+	if err != nil {
+		return result, errors.Wrap(err, "解析生成历史失败!")
+	}
+	err = old.Pretreatment()
+	if err != nil {
+		return result, err
+	}
+	old.IsAdd, info.AutoCode.IsAdd = false, false
+	result.Changes, err = info.Changes(old)
+	if err != nil {
+		return result, err
+	}
+	if len(result.Changes) == 0 {
+		return result, errors.New("没有需要更新的内容!")
+	}
+
+	var autoPkg model.SysAutoCodePackage
+	err = global.GVA_DB.WithContext(ctx).Where("package_name = ?", info.AutoCode.Package).First(&autoPkg).Error
+	if err != nil {
+		return result, errors.Wrap(err, "查询包失败!")
+	}
+	err = s.checkPackage(info.AutoCode.Package, autoPkg.Template)
+	if err != nil {
+		return result, err
+	}
+	before, _, _, err := s.generate(ctx, old, autoPkg)
+	if err != nil {
+		return result, err
+	}
+	after, templates, _, err := s.generate(ctx, info.AutoCode, autoPkg)
+	if err != nil {
+		return result, err
+	}
+
+	paths := make([]string, 0, len(templates))
+	for _, path := range templates {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	merged := make(map[string]string, len(paths))
+	for _, path := range paths {
+		code := after[path]
+		generated := code.String()
+		base := before[path]
+		var content string
+		var conflicts []string
+		current, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			content = generated
+		case err != nil:
+			return result, errors.Wrapf(err, "[filepath:%s]读取文件失败!", path)
+		case filepath.Ext(path) == ".go":
+			var data []byte
+			data, conflicts, err = utilsAst.MergeGenerated([]byte(base.String()), []byte(generated), current)
+			if err != nil {
+				return result, errors.Wrapf(err, "[filepath:%s]合并代码失败!", path)
+			}
+			content = string(data)
+		default:
+			content, conflicts = autocode.MergeText(base.String(), generated, string(current))
+		}
+		if content == string(current) && len(conflicts) == 0 {
+			continue
+		}
+		rel, _ := filepath.Rel(global.GVA_CONFIG.AutoCode.Root, path)
+		result.Files = append(result.Files, response.AutoCodeUpdateFile{
+			Path:      filepath.ToSlash(rel),
+			Diff:      autocode.Diff(filepath.ToSlash(rel), string(current), content),
+			Conflicts: conflicts,
+		})
+		merged[path] = content
+	}
+	if preview {
+		return result, nil
+	}
+
+	for path, content := range merged {
+		err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err != nil {
+			return result, errors.Wrapf(err, "[filepath:%s]创建文件夹失败!", path)
+		}
+		err = os.WriteFile(path, []byte(content), 0666)
+		if err != nil {
+			return result, errors.Wrapf(err, "[filepath:%s]写入文件失败!", path)
+		}
+	}
+	if history.ExportTemplateID != 0 {
+		fieldsMap := make(map[string]string, len(info.AutoCode.Fields))
+		for _, field := range info.AutoCode.Fields {
+			if field.Excel {
+				fieldsMap[field.ColumnName] = field.FieldDesc
+			}
+		}
+		templateInfo, _ := json.Marshal(fieldsMap)
+		err = global.GVA_DB.WithContext(ctx).Model(&model.SysExportTemplate{}).Where("id = ?", history.ExportTemplateID).Update("template_info", string(templateInfo)).Error
+		if err != nil {
+			return result, errors.Wrap(err, "更新导出模板失败!")
+		}
+	} // 新增字段同步到导出模板
+	err = global.GVA_DB.WithContext(ctx).Model(&history).Update("request", info.AutoCode.History().Request).Error
+	if err != nil {
+		return result, errors.Wrap(err, "更新生成历史失败!")
+	}
+	return result, nil
+}
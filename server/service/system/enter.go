@@ -19,6 +19,13 @@ type ServiceGroup struct {
 	SysParamsService
 	SysVersionService
 	UserActionLogService
+	NotificationService
+	PluginRuntimeService
+	OpenApiService
+	RateLimitService
+	AlertService
+	WebhookService
+	DepartmentService
 	AutoCodePlugin   autoCodePlugin
 	AutoCodePackage  autoCodePackage
 	AutoCodeHistory  autoCodeHistory
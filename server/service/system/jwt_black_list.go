@@ -2,32 +2,124 @@ package system
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 )
 
 type JwtService struct{}
 
 var JwtServiceApp = new(JwtService)
 
+// jwtBlacklistPrefix 黑名单的键 后接jwt的sha256
+const jwtBlacklistPrefix = "gva:jwt_blacklist:"
+
+// jwtBlacklistMissTTL 不使用redis时 数据库中查不到的jwt在本地缓存的时间
+const jwtBlacklistMissTTL = time.Minute
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: JsonInBlacklist
-//@description: 拉黑jwt
+//@description: 拉黑jwt 使用redis时写入redis 过期时间为jwt的剩余有效期 多个实例共享 否则写入数据库及本地缓存
 //@param: jwtList model.JwtBlacklist
 //@return: err error
 
 func (jwtService *JwtService) JsonInBlacklist(jwtList system.JwtBlacklist) (err error) {
+	ttl := utils.TokenRemaining(jwtList.Jwt)
+	if ttl <= 0 {
+		return nil
+	} // 已过期的jwt无需拉黑
+	jwtList.Hash = utils.TokenHash(jwtList.Jwt)
+	expiresAt := time.Now().Add(ttl)
+	jwtList.ExpiresAt = &expiresAt
+	key := jwtBlacklistPrefix + jwtList.Hash
+	if global.GVA_REDIS != nil {
+		return global.GVA_REDIS.Set(context.Background(), key, 1, ttl).Err()
+	}
 	err = global.GVA_DB.Create(&jwtList).Error
 	if err != nil {
 		return
 	}
-	global.BlackCache.SetDefault(jwtList.Jwt, struct{}{})
+	global.BlackCache.Set(key, true, ttl)
 	return
 }
 
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: IsBlacklist
+//@description: 判断jwt是否在黑名单中 不使用redis时先查本地缓存 未命中再查数据库并缓存结果
+//@param: jwt string
+//@return: bool
+
+func (jwtService *JwtService) IsBlacklist(jwt string) bool {
+	hash := utils.TokenHash(jwt)
+	key := jwtBlacklistPrefix + hash
+	if global.GVA_REDIS != nil {
+		n, err := global.GVA_REDIS.Exists(context.Background(), key).Result()
+		if err != nil {
+			global.GVA_LOG.Error("查询jwt黑名单失败!", zap.Error(err))
+			return false
+		}
+		return n > 0
+	}
+	if v, ok := global.BlackCache.Get(key); ok {
+		return v.(bool)
+	}
+	if global.GVA_DB == nil {
+		return false
+	}
+	var count int64
+	// 旧数据没有哈希 按jwt查询 升级时新增的列为NULL
+	err := global.GVA_DB.Model(&system.JwtBlacklist{}).Where("hash = ? OR ((hash IS NULL OR hash = '') AND jwt = ?)", hash, jwt).Count(&count).Error
+	if err != nil {
+		global.GVA_LOG.Error("查询jwt黑名单失败!", zap.Error(err))
+		return false
+	}
+	if count > 0 {
+		global.BlackCache.Set(key, true, utils.TokenRemaining(jwt))
+		return true
+	}
+	global.BlackCache.Set(key, false, jwtBlacklistMissTTL)
+	return false
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: MigrateJwtBlacklist
+//@description: 迁移升级前没有哈希的jwt黑名单 补齐哈希及过期时间并删除已过期的jwt 使用redis时写入redis 迁移后不再查询到数据
+
+func MigrateJwtBlacklist() {
+	var data []system.JwtBlacklist
+	err := global.GVA_DB.Select("id", "jwt").Where("hash IS NULL OR hash = ''").
+		FindInBatches(&data, 100, func(tx *gorm.DB, batch int) error {
+			for i := range data {
+				ttl := utils.TokenRemaining(data[i].Jwt)
+				if ttl <= 0 {
+					if err := global.GVA_DB.Unscoped().Delete(&data[i]).Error; err != nil {
+						return err
+					}
+					continue
+				} // 已过期的jwt无需保留
+				hash, expiresAt := utils.TokenHash(data[i].Jwt), time.Now().Add(ttl)
+				err := global.GVA_DB.Model(&data[i]).Updates(map[string]interface{}{"hash": hash, "expires_at": expiresAt}).Error
+				if err != nil {
+					return err
+				}
+				if global.GVA_REDIS != nil {
+					if err = global.GVA_REDIS.Set(context.Background(), jwtBlacklistPrefix+hash, 1, ttl).Err(); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		global.GVA_LOG.Error("迁移jwt黑名单失败!", zap.Error(err))
+	}
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetRedisJWT
 //@description: 从redis取jwt
@@ -38,15 +130,3 @@ func (jwtService *JwtService) GetRedisJWT(userName string) (redisJWT string, err
 	redisJWT, err = global.GVA_REDIS.Get(context.Background(), userName).Result()
 	return redisJWT, err
 }
-
-func LoadAll() {
-	var data []string
-	err := global.GVA_DB.Model(&system.JwtBlacklist{}).Select("jwt").Find(&data).Error
-	if err != nil {
-		global.GVA_LOG.Error("加载数据库jwt黑名单失败!", zap.Error(err))
-		return
-	}
-	for i := 0; i < len(data); i++ {
-		global.BlackCache.SetDefault(data[i], struct{}{})
-	} // jwt黑名单 加入 BlackCache 中
-}
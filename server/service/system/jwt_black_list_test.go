@@ -0,0 +1,133 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestJwtBlacklistWithoutRedis(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&system.JwtBlacklist{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB, global.GVA_REDIS, global.GVA_LOG = db, nil, zap.NewNop()
+	global.GVA_CONFIG.JWT.SigningKey, global.GVA_CONFIG.JWT.ExpiresTime, global.GVA_CONFIG.JWT.BufferTime = "test", "1h", "10m"
+	global.BlackCache = local_cache.NewCache()
+
+	j := utils.NewJWT()
+	newToken := func(id uint) string {
+		token, err := j.CreateToken(j.CreateClaims(systemReq.BaseClaims{ID: id}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	s := &JwtService{}
+	token, other, legacy := newToken(1), newToken(2), newToken(3)
+	if s.IsBlacklist(token) {
+		t.Fatal("token should not be blacklisted")
+	}
+	// 未命中的结果已缓存 拉黑后立即生效
+	if err = s.JsonInBlacklist(system.JwtBlacklist{Jwt: token}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.IsBlacklist(token) || s.IsBlacklist(other) {
+		t.Error("only the blacklisted token should be rejected")
+	}
+
+	// 重启后本地缓存为空 从数据库查询 包括升级前哈希列为NULL的旧数据
+	db.Exec("INSERT INTO jwt_blacklists (created_at, updated_at, jwt) VALUES (?, ?, ?)", time.Now(), time.Now(), legacy)
+	global.BlackCache = local_cache.NewCache()
+	if !s.IsBlacklist(token) || !s.IsBlacklist(legacy) || s.IsBlacklist(other) {
+		t.Error("blacklist should be loaded from database on cache miss")
+	}
+
+	// 迁移补齐旧数据的哈希及过期时间 删除已过期的jwt
+	claims := j.CreateClaims(systemReq.BaseClaims{ID: 4})
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	expired, err := j.CreateToken(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Exec("INSERT INTO jwt_blacklists (created_at, updated_at, jwt) VALUES (?, ?, ?)", time.Now(), time.Now(), expired)
+	MigrateJwtBlacklist()
+	var legacyRow system.JwtBlacklist
+	db.Where("hash = ?", utils.TokenHash(legacy)).First(&legacyRow)
+	if legacyRow.ExpiresAt == nil || time.Until(*legacyRow.ExpiresAt) <= 0 {
+		t.Errorf("MigrateJwtBlacklist should backfill the hash and expiry of legacy rows: %+v", legacyRow)
+	}
+	var count int64
+	db.Unscoped().Model(&system.JwtBlacklist{}).Where("jwt = ?", expired).Count(&count)
+	if count != 0 {
+		t.Error("MigrateJwtBlacklist should delete expired legacy rows")
+	}
+}
+
+func TestMigrateJwtBlacklistToRedis(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&system.JwtBlacklist{}); err != nil {
+		t.Fatal(err)
+	}
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	global.GVA_DB, global.GVA_REDIS, global.GVA_LOG = db, rdb, zap.NewNop()
+	t.Cleanup(func() { global.GVA_REDIS = nil })
+	global.GVA_CONFIG.JWT.SigningKey, global.GVA_CONFIG.JWT.ExpiresTime, global.GVA_CONFIG.JWT.BufferTime = "test", "1h", "10m"
+
+	j := utils.NewJWT()
+	newToken := func(id uint) string {
+		token, err := j.CreateToken(j.CreateClaims(systemReq.BaseClaims{ID: id}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	s := &JwtService{}
+	// 升级前拉黑的jwt只在数据库中且没有哈希
+	legacy, other := newToken(1), newToken(2)
+	db.Exec("INSERT INTO jwt_blacklists (created_at, updated_at, jwt) VALUES (?, ?, ?)", time.Now(), time.Now(), legacy)
+	if s.IsBlacklist(legacy) {
+		t.Fatal("redis should be empty before the migration")
+	}
+
+	MigrateJwtBlacklist()
+	if !s.IsBlacklist(legacy) || s.IsBlacklist(other) {
+		t.Error("MigrateJwtBlacklist should copy legacy rows into redis")
+	}
+	if ttl := mr.TTL(jwtBlacklistPrefix + utils.TokenHash(legacy)); ttl <= 0 || ttl > time.Hour {
+		t.Errorf("redis key should expire with the token, got ttl %v", ttl)
+	}
+
+	// 已迁移的数据不再处理
+	mr.FlushAll()
+	MigrateJwtBlacklist()
+	if s.IsBlacklist(legacy) {
+		t.Error("migrated rows should not be loaded again")
+	}
+}
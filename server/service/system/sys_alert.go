@@ -0,0 +1,387 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/alert"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/jobqueue"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ratelimit"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/webhook"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AlertService struct{}
+
+var AlertServiceApp = new(AlertService)
+
+const (
+	defaultAlertMinStatus  = 500
+	defaultAlertThreshold  = 1
+	defaultAlertCooldown   = 10 * time.Minute
+	defaultAlertMaxPerHour = 30
+)
+
+var (
+	// alertNotifyStore 统计每小时已发送的通知数
+	alertNotifyStore = ratelimit.NewMemoryStore()
+	alertHTTPClient  = webhook.NewClient(5*time.Second, func() bool { return global.GVA_CONFIG.Alert.AllowPrivate })
+)
+
+var alertWebhookFormats = map[string]bool{
+	alert.FormatGeneric:  true,
+	alert.FormatSlack:    true,
+	alert.FormatDingTalk: true,
+	alert.FormatFeishu:   true,
+	alert.FormatWeCom:    true,
+}
+
+// AlertMinStatus 响应状态码大于等于此值时记为错误
+func AlertMinStatus() int {
+	if s := global.GVA_CONFIG.Alert.MinStatus; s > 0 {
+		return s
+	}
+	return defaultAlertMinStatus
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: FlushAlerts
+//@description: 取出错误聚合器中的错误入库 并按阈值、冷却时间和每小时上限发送通知
+//@return: err error
+
+func (alertService *AlertService) FlushAlerts() (err error) {
+	for _, b := range alert.Default().Flush(time.Now()) {
+		if e := alertService.HandleAlertBatch(b); e != nil {
+			err = errors.Join(err, e)
+		}
+	}
+	return err
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: HandleAlertBatch
+//@description: 将一个分组的错误计入未解决的告警 没有时新建 达到通知条件时通知全部启用的渠道
+//@param: b alert.Batch
+//@return: err error
+
+func (alertService *AlertService) HandleAlertBatch(b alert.Batch) (err error) {
+	var a system.SysAlert
+	err = global.GVA_DB.Where("fingerprint = ? AND state <> ?", b.Fingerprint, system.AlertStateResolved).
+		Order("id desc").First(&a).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		a = system.SysAlert{
+			Fingerprint: b.Fingerprint,
+			Method:      b.Method,
+			Route:       b.Route,
+			Status:      b.Status,
+			Message:     b.Message,
+			LastIP:      b.IP,
+			Count:       b.Count,
+			FirstSeen:   b.First,
+			LastSeen:    b.Last,
+			State:       system.AlertStateOpen,
+		}
+		if err = global.GVA_DB.Create(&a).Error; err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		err = global.GVA_DB.Model(&system.SysAlert{}).Where("id = ?", a.ID).Updates(map[string]interface{}{
+			"count":     gorm.Expr("count + ?", b.Count),
+			"status":    b.Status,
+			"message":   b.Message,
+			"last_ip":   b.IP,
+			"last_seen": b.Last,
+		}).Error
+		if err != nil {
+			return err
+		}
+		a.Count += b.Count
+		a.Status, a.Message, a.LastIP, a.LastSeen = b.Status, b.Message, b.IP, b.Last
+	}
+
+	cfg := global.GVA_CONFIG.Alert
+	threshold := int64(cfg.Threshold)
+	if threshold <= 0 {
+		threshold = defaultAlertThreshold
+	}
+	cooldown := time.Duration(cfg.Cooldown) * time.Second
+	if cooldown <= 0 {
+		cooldown = defaultAlertCooldown
+	}
+	if a.State != system.AlertStateOpen || b.WindowCount < threshold {
+		return nil
+	}
+	now := time.Now()
+	if a.LastNotifiedAt != nil && now.Sub(*a.LastNotifiedAt) < cooldown {
+		return nil
+	}
+	maxPerHour := cfg.MaxPerHour
+	if maxPerHour <= 0 {
+		maxPerHour = defaultAlertMaxPerHour
+	}
+	if ok, _, _ := alertNotifyStore.Take(context.Background(), "alert", ratelimit.SlidingWindow, maxPerHour, time.Hour); !ok {
+		global.GVA_LOG.Warn("告警通知超出每小时上限 本次只记录不通知", zap.Uint("alertID", a.ID))
+		return nil
+	}
+	// 以 notify_count 作为版本号占用本次通知 多实例同时处理时只有一个发送
+	res := global.GVA_DB.Model(&system.SysAlert{}).
+		Where("id = ? AND notify_count = ?", a.ID, a.NotifyCount).
+		Updates(map[string]interface{}{
+			"notify_count":     a.NotifyCount + 1,
+			"notified_count":   a.Count,
+			"last_notified_at": now,
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return res.Error
+	}
+	sendErr := alertService.notify(alertNotification(a, a.Count-a.NotifiedCount))
+	lastErr := ""
+	if sendErr != nil {
+		lastErr = jobqueue.Truncate(sendErr.Error(), 500)
+		global.GVA_LOG.Error("发送告警通知失败!", zap.Uint("alertID", a.ID), zap.Error(sendErr))
+	}
+	return global.GVA_DB.Model(&system.SysAlert{}).Where("id = ?", a.ID).Update("last_notify_error", lastErr).Error
+}
+
+func alertNotification(a system.SysAlert, count int64) alert.Notification {
+	n := alert.Notification{
+		AlertID:     a.ID,
+		Fingerprint: a.Fingerprint,
+		Method:      a.Method,
+		Route:       a.Route,
+		Status:      a.Status,
+		Message:     a.Message,
+		Count:       count,
+		Total:       a.Count,
+		FirstSeen:   a.FirstSeen,
+		LastSeen:    a.LastSeen,
+		Title:       fmt.Sprintf("[告警] %s %s 出现错误", a.Method, a.Route),
+	}
+	n.Content = fmt.Sprintf("状态码: %d\n错误信息: %s\n次数: 新增%d次 累计%d次\n首次出现: %s\n最近出现: %s\n最近请求IP: %s",
+		a.Status, a.Message, count, a.Count,
+		a.FirstSeen.Format(time.DateTime), a.LastSeen.Format(time.DateTime), a.LastIP)
+	return n
+}
+
+// notify 通知全部启用的渠道 没有启用的渠道时发送至 email.to
+func (alertService *AlertService) notify(n alert.Notification) error {
+	var channels []system.SysAlertChannel
+	if err := global.GVA_DB.Where("enable = ?", true).Find(&channels).Error; err != nil {
+		return err
+	}
+	if len(channels) == 0 && global.GVA_CONFIG.Email.To != "" {
+		channels = append(channels, system.SysAlertChannel{Name: "email.to", Type: system.AlertChannelEmail, Target: global.GVA_CONFIG.Email.To})
+	}
+	var errs error
+	for _, ch := range channels {
+		if err := sendAlertChannel(ch, n); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", ch.Name, err))
+		}
+	}
+	return errs
+}
+
+func sendAlertChannel(ch system.SysAlertChannel, n alert.Notification) error {
+	switch ch.Type {
+	case system.AlertChannelEmail:
+		body := "<pre>" + html.EscapeString(n.Content) + "</pre>"
+		return sendEmail(ch.Target, n.Title, body)
+	case system.AlertChannelWebhook:
+		payload, err := alert.WebhookBody(ch.Format, n)
+		if err != nil {
+			return err
+		}
+		resp, err := alertHTTPClient.Post(ch.Target, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook返回状态码 %d", resp.StatusCode)
+		}
+		return nil
+	default:
+		return errors.New("未知的渠道类型 " + ch.Type)
+	}
+}
+
+func checkAlertChannel(ch *system.SysAlertChannel) error {
+	ch.Target = strings.TrimSpace(ch.Target)
+	switch ch.Type {
+	case system.AlertChannelEmail:
+		ch.Format = ""
+	case system.AlertChannelWebhook:
+		if ch.Format == "" {
+			ch.Format = alert.FormatGeneric
+		}
+		if !alertWebhookFormats[ch.Format] {
+			return errors.New("消息格式只能为 generic|slack|dingtalk|feishu|wecom")
+		}
+		if err := webhook.CheckURL(ch.Target, global.GVA_CONFIG.Alert.AllowPrivate); err != nil {
+			return errors.New("webhook" + err.Error())
+		}
+	default:
+		return errors.New("渠道类型只能为 email|webhook")
+	}
+	return nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: AckAlert
+//@description: 确认告警 确认后继续计数但不再通知
+//@param: id uint, userID uint
+//@return: err error
+
+func (alertService *AlertService) AckAlert(id uint, userID uint) (err error) {
+	res := global.GVA_DB.Model(&system.SysAlert{}).
+		Where("id = ? AND state = ?", id, system.AlertStateOpen).
+		Updates(map[string]interface{}{"state": system.AlertStateAcked, "acked_by": userID, "acked_at": time.Now()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("告警不存在或已处理")
+	}
+	return nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: ResolveAlert
+//@description: 解决告警 之后再出现同样的错误时产生新的告警
+//@param: id uint, userID uint
+//@return: err error
+
+func (alertService *AlertService) ResolveAlert(id uint, userID uint) (err error) {
+	res := global.GVA_DB.Model(&system.SysAlert{}).
+		Where("id = ? AND state <> ?", id, system.AlertStateResolved).
+		Updates(map[string]interface{}{"state": system.AlertStateResolved, "resolved_by": userID, "resolved_at": time.Now()})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return errors.New("告警不存在或已解决")
+	}
+	return nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetAlertList
+//@description: 分页获取告警
+//@param: info systemReq.AlertSearch
+//@return: list []system.SysAlert, total int64, err error
+
+func (alertService *AlertService) GetAlertList(info systemReq.AlertSearch) (list []system.SysAlert, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysAlert{})
+	if info.State != "" {
+		db = db.Where("state = ?", info.State)
+	}
+	if info.Route != "" {
+		db = db.Where("route LIKE ?", "%"+info.Route+"%")
+	}
+	if info.Status != 0 {
+		db = db.Where("status = ?", info.Status)
+	}
+	if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
+		db = db.Where("created_at BETWEEN ? AND ?", info.StartCreatedAt, info.EndCreatedAt)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Scopes(info.Paginate()).Order("last_seen desc").Find(&list).Error
+	return list, total, err
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: CreateAlertChannel
+//@description: 创建告警渠道
+//@param: ch *system.SysAlertChannel
+//@return: err error
+
+func (alertService *AlertService) CreateAlertChannel(ch *system.SysAlertChannel) (err error) {
+	if err = checkAlertChannel(ch); err != nil {
+		return err
+	}
+	return global.GVA_DB.Create(ch).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: DeleteAlertChannel
+//@description: 删除告警渠道
+//@param: id uint
+//@return: err error
+
+func (alertService *AlertService) DeleteAlertChannel(id uint) (err error) {
+	return global.GVA_DB.Delete(&system.SysAlertChannel{}, "id = ?", id).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: UpdateAlertChannel
+//@description: 更新告警渠道
+//@param: ch system.SysAlertChannel
+//@return: err error
+
+func (alertService *AlertService) UpdateAlertChannel(ch system.SysAlertChannel) (err error) {
+	if err = checkAlertChannel(&ch); err != nil {
+		return err
+	}
+	return global.GVA_DB.Model(&system.SysAlertChannel{}).Where("id = ?", ch.ID).
+		Select("name", "type", "target", "format", "enable").Updates(&ch).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetAlertChannelList
+//@description: 分页获取告警渠道
+//@param: info systemReq.AlertChannelSearch
+//@return: list []system.SysAlertChannel, total int64, err error
+
+func (alertService *AlertService) GetAlertChannelList(info systemReq.AlertChannelSearch) (list []system.SysAlertChannel, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysAlertChannel{})
+	if info.Name != "" {
+		db = db.Where("name LIKE ?", "%"+info.Name+"%")
+	}
+	if info.Type != "" {
+		db = db.Where("type = ?", info.Type)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Scopes(info.Paginate()).Order("id desc").Find(&list).Error
+	return list, total, err
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: TestAlertChannel
+//@description: 向渠道发送一条测试通知 邮件渠道加入发送队列即视为成功
+//@param: id uint
+//@return: err error
+
+func (alertService *AlertService) TestAlertChannel(id uint) (err error) {
+	var ch system.SysAlertChannel
+	if err = global.GVA_DB.First(&ch, "id = ?", id).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	n := alertNotification(system.SysAlert{
+		Method:    "GET",
+		Route:     "/alert/test",
+		Status:    http.StatusInternalServerError,
+		Message:   "这是一条测试告警",
+		Count:     1,
+		FirstSeen: now,
+		LastSeen:  now,
+	}, 1)
+	return sendAlertChannel(ch, n)
+}
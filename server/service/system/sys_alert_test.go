@@ -0,0 +1,142 @@
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/alert"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ratelimit"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupAlertDB(t *testing.T) (*[]alert.Notification, *sync.Mutex) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&system.SysAlert{}, &system.SysAlertChannel{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.Alert = config.Alert{Threshold: 2, Cooldown: 600, MaxPerHour: 2, AllowPrivate: true}
+	alertNotifyStore = ratelimit.NewMemoryStore()
+	t.Cleanup(func() { sqlDB.Close() })
+
+	var mu sync.Mutex
+	var received []alert.Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n alert.Notification
+		_ = json.NewDecoder(r.Body).Decode(&n)
+		mu.Lock()
+		received = append(received, n)
+		mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+	s := &AlertService{}
+	if err = s.CreateAlertChannel(&system.SysAlertChannel{Name: "hook", Type: system.AlertChannelWebhook, Target: srv.URL, Enable: true}); err != nil {
+		t.Fatal(err)
+	}
+	return &received, &mu
+}
+
+func TestAlertService(t *testing.T) {
+	received, mu := setupAlertDB(t)
+	s := &AlertService{}
+	now := time.Now()
+	batch := alert.Batch{Fingerprint: "fp1", Method: "GET", Route: "/a", Status: 500, Message: "boom", Count: 1, WindowCount: 1, First: now, Last: now}
+
+	if err := s.HandleAlertBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(*received) != 0 {
+		t.Fatal("alert below the threshold should not notify")
+	}
+	batch.WindowCount = 2
+	if err := s.HandleAlertBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.HandleAlertBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	if len(*received) != 1 || (*received)[0].Count != 2 || (*received)[0].Total != 2 {
+		t.Fatalf("expected one notification after reaching the threshold, got %+v", *received)
+	}
+	mu.Unlock()
+
+	var a system.SysAlert
+	global.GVA_DB.First(&a, "fingerprint = ?", "fp1")
+	if a.Count != 3 || a.NotifyCount != 1 || a.State != system.AlertStateOpen {
+		t.Fatalf("unexpected alert: %+v", a)
+	}
+
+	// 冷却时间过后再次通知 只统计上次通知以来的新增次数
+	global.GVA_DB.Model(&a).Update("last_notified_at", now.Add(-time.Hour))
+	if err := s.HandleAlertBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(*received) != 2 || (*received)[1].Count != 2 || (*received)[1].Total != 4 {
+		t.Fatalf("expected a second notification after the cooldown, got %+v", *received)
+	}
+
+	// 每小时上限为2 之后只记录不通知
+	other := batch
+	other.Fingerprint = "fp2"
+	if err := s.HandleAlertBatch(other); err != nil {
+		t.Fatal(err)
+	}
+	if len(*received) != 2 {
+		t.Fatal("notifications beyond the hourly cap should be dropped")
+	}
+
+	if err := s.AckAlert(a.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AckAlert(a.ID, 1); err == nil {
+		t.Fatal("acked alert cannot be acked again")
+	}
+	if err := s.ResolveAlert(a.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.HandleAlertBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	global.GVA_DB.Model(&system.SysAlert{}).Where("fingerprint = ?", "fp1").Count(&count)
+	if count != 2 {
+		t.Fatalf("error after resolve should open a new alert, got %d alerts", count)
+	}
+}
+
+func TestCheckAlertChannel(t *testing.T) {
+	global.GVA_CONFIG.Alert = config.Alert{}
+	cases := []struct {
+		ch system.SysAlertChannel
+		ok bool
+	}{
+		{system.SysAlertChannel{Type: system.AlertChannelEmail, Target: "a@example.com"}, true},
+		{system.SysAlertChannel{Type: system.AlertChannelWebhook, Target: "https://example.com/hook", Format: alert.FormatFeishu}, true},
+		{system.SysAlertChannel{Type: system.AlertChannelWebhook, Target: "ftp://example.com"}, false},
+		{system.SysAlertChannel{Type: system.AlertChannelWebhook, Target: "http://169.254.169.254/latest/meta-data"}, false},
+		{system.SysAlertChannel{Type: system.AlertChannelWebhook, Target: "http://127.0.0.1:8888/hook"}, false},
+		{system.SysAlertChannel{Type: system.AlertChannelWebhook, Target: "https://example.com", Format: "unknown"}, false},
+		{system.SysAlertChannel{Type: "sms", Target: "1"}, false},
+	}
+	for i, c := range cases {
+		if err := checkAlertChannel(&c.ch); (err == nil) != c.ok {
+			t.Errorf("case %d: err = %v", i, err)
+		}
+	}
+}
@@ -23,6 +23,7 @@ type ApiService struct{}
 var ApiServiceApp = new(ApiService)
 
 func (apiService *ApiService) CreateApi(api system.SysApi) (err error) {
+	defer OpenApiServiceApp.Invalidate() // OpenAPI 文档中的接口描述及分组取自 sys_apis
 	if !errors.Is(global.GVA_DB.Where("path = ? AND method = ?", api.Path, api.Method).First(&system.SysApi{}).Error, gorm.ErrRecordNotFound) {
 		return errors.New("存在相同api")
 	}
@@ -134,6 +135,7 @@ func (apiService *ApiService) IgnoreApi(ignoreApi system.SysIgnoreApi) (err erro
 }
 
 func (apiService *ApiService) EnterSyncApi(syncApis systemRes.SysSyncApis) (err error) {
+	defer OpenApiServiceApp.Invalidate()
 	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		var txErr error
 		if len(syncApis.NewApis) > 0 {
@@ -143,7 +145,7 @@ func (apiService *ApiService) EnterSyncApi(syncApis systemRes.SysSyncApis) (err
 			}
 		}
 		for i := range syncApis.DeleteApis {
-			CasbinServiceApp.ClearCasbin(1, syncApis.DeleteApis[i].Path, syncApis.DeleteApis[i].Method)
+			CasbinServiceApp.ClearCasbin(2, syncApis.DeleteApis[i].Path, syncApis.DeleteApis[i].Method)
 			txErr = tx.Delete(&system.SysApi{}, "path = ? AND method = ?", syncApis.DeleteApis[i].Path, syncApis.DeleteApis[i].Method).Error
 			if txErr != nil {
 				return txErr
@@ -160,6 +162,7 @@ func (apiService *ApiService) EnterSyncApi(syncApis systemRes.SysSyncApis) (err
 //@return: err error
 
 func (apiService *ApiService) DeleteApi(api system.SysApi) (err error) {
+	defer OpenApiServiceApp.Invalidate()
 	var entity system.SysApi
 	err = global.GVA_DB.First(&entity, "id = ?", api.ID).Error // 根据id查询api记录
 	if errors.Is(err, gorm.ErrRecordNotFound) {                // api记录不存在
@@ -169,7 +172,7 @@ func (apiService *ApiService) DeleteApi(api system.SysApi) (err error) {
 	if err != nil {
 		return err
 	}
-	CasbinServiceApp.ClearCasbin(1, entity.Path, entity.Method)
+	CasbinServiceApp.ClearCasbin(2, entity.Path, entity.Method)
 	return nil
 }
 
@@ -274,6 +277,7 @@ func (apiService *ApiService) GetApiById(id int) (api system.SysApi, err error)
 //@return: err error
 
 func (apiService *ApiService) UpdateApi(api system.SysApi) (err error) {
+	defer OpenApiServiceApp.Invalidate()
 	var oldA system.SysApi
 	err = global.GVA_DB.First(&oldA, "id = ?", api.ID).Error
 	if oldA.Path != api.Path || oldA.Method != api.Method {
@@ -308,6 +312,7 @@ func (apiService *ApiService) UpdateApi(api system.SysApi) (err error) {
 //@return: err error
 
 func (apiService *ApiService) DeleteApisByIds(ids request.IdsReq) (err error) {
+	defer OpenApiServiceApp.Invalidate()
 	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		var apis []system.SysApi
 		err = tx.Find(&apis, "id in ?", ids.Ids).Error
@@ -319,7 +324,7 @@ func (apiService *ApiService) DeleteApisByIds(ids request.IdsReq) (err error) {
 			return err
 		}
 		for _, sysApi := range apis {
-			CasbinServiceApp.ClearCasbin(1, sysApi.Path, sysApi.Method)
+			CasbinServiceApp.ClearCasbin(2, sysApi.Path, sysApi.Method)
 		}
 		return err
 	})
@@ -10,6 +10,8 @@ import (
 	"github.com/flipped-aurora/gin-vue-admin/server/model/common/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -31,6 +33,7 @@ func (authorityService *AuthorityService) CreateAuthority(auth system.SysAuthori
 		return auth, ErrRoleExistence
 	}
 
+	var rules, links [][]string
 	e := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 
 		if err = tx.Create(&auth).Error; err != nil {
@@ -43,16 +46,41 @@ func (authorityService *AuthorityService) CreateAuthority(auth system.SysAuthori
 		}
 		casbinInfos := systemReq.DefaultCasbin()
 		authorityId := strconv.Itoa(int(auth.AuthorityId))
-		rules := [][]string{}
 		for _, v := range casbinInfos {
-			rules = append(rules, []string{authorityId, v.Path, v.Method})
+			rules = append(rules, apiPolicy(authorityId, v.Path, v.Method, utils.CasbinAllow))
 		}
-		return CasbinServiceApp.AddPolicies(tx, rules)
+		if err = CasbinServiceApp.AddPolicies(tx, rules); err != nil {
+			return err
+		}
+		// 子角色继承父角色的权限
+		if auth.ParentId != nil && *auth.ParentId != 0 {
+			links = append(links, authorityLink(authorityId, strconv.Itoa(int(*auth.ParentId))))
+		}
+		return CasbinServiceApp.AddGroupingPolicies(tx, links)
 	})
+	if e == nil {
+		// 事务提交后更新本地策略并通知其他实例
+		if err = utils.CasbinPoliciesAdded("g", links); err == nil {
+			err = utils.CasbinPoliciesAdded("p", rules)
+		}
+		if err != nil {
+			global.GVA_LOG.Error("同步角色默认权限失败!", zap.Error(err))
+		}
+		utils.GlobalSystemEvents.Publish(utils.EventAuthorityCreated, authorityEventData(auth))
+	}
 
 	return auth, e
 }
 
+// authorityEventData 角色事件的数据 不包含菜单等关联数据
+func authorityEventData(auth system.SysAuthority) map[string]interface{} {
+	return map[string]interface{}{
+		"authorityId":   auth.AuthorityId,
+		"authorityName": auth.AuthorityName,
+		"parentId":      auth.ParentId,
+	}
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: CopyAuthority
 //@description: 复制一个角色
@@ -98,11 +126,22 @@ func (authorityService *AuthorityService) CopyAuthority(adminAuthorityID uint, c
 		}
 	}
 	paths := CasbinServiceApp.GetPolicyPathByAuthorityId(copyInfo.OldAuthorityId)
+	if copyInfo.Authority.ParentId != nil && *copyInfo.Authority.ParentId != 0 {
+		err = CasbinServiceApp.linkAuthority(copyInfo.Authority.AuthorityId, *copyInfo.Authority.ParentId)
+		if err != nil {
+			_ = authorityService.DeleteAuthority(&copyInfo.Authority)
+			return
+		}
+	}
 	err = CasbinServiceApp.UpdateCasbin(adminAuthorityID, copyInfo.Authority.AuthorityId, paths)
 	if err != nil {
 		_ = authorityService.DeleteAuthority(&copyInfo.Authority)
+		return copyInfo.Authority, err
 	}
-	return copyInfo.Authority, err
+	data := authorityEventData(copyInfo.Authority)
+	data["copiedFrom"] = copyInfo.OldAuthorityId
+	utils.GlobalSystemEvents.Publish(utils.EventAuthorityCreated, data)
+	return copyInfo.Authority, nil
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -118,7 +157,19 @@ func (authorityService *AuthorityService) UpdateAuthority(auth system.SysAuthori
 		global.GVA_LOG.Debug(err.Error())
 		return system.SysAuthority{}, errors.New("查询角色数据失败")
 	}
+	// 父角色变更时保持角色原有的实际权限
+	var keep []systemReq.CasbinInfo
+	parentChanged := auth.ParentId != nil && oldAuthority.ParentId != nil && *auth.ParentId != *oldAuthority.ParentId
+	if parentChanged {
+		keep = CasbinServiceApp.GetPolicyPathByAuthorityId(auth.AuthorityId)
+	}
 	err = global.GVA_DB.Model(&oldAuthority).Updates(&auth).Error
+	if err == nil && parentChanged {
+		err = CasbinServiceApp.SetAuthorityParent(auth.AuthorityId, *auth.ParentId, keep)
+	}
+	if err == nil {
+		utils.GlobalSystemEvents.Publish(utils.EventAuthorityUpdated, authorityEventData(auth))
+	}
 	return auth, err
 }
 
@@ -142,7 +193,8 @@ func (authorityService *AuthorityService) DeleteAuthority(auth *system.SysAuthor
 		return errors.New("此角色存在子角色不允许删除")
 	}
 
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	authorityId := strconv.Itoa(int(auth.AuthorityId))
+	err := global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		var err error
 		if err = tx.Preload("SysBaseMenus").Preload("DataAuthorityId").Where("authority_id = ?", auth.AuthorityId).First(auth).Unscoped().Delete(auth).Error; err != nil {
 			return err
@@ -167,14 +219,24 @@ func (authorityService *AuthorityService) DeleteAuthority(auth *system.SysAuthor
 			return err
 		}
 
-		authorityId := strconv.Itoa(int(auth.AuthorityId))
-
 		if err = CasbinServiceApp.RemoveFilteredPolicy(tx, authorityId); err != nil {
 			return err
 		}
+		if err = tx.Unscoped().Where("authority_id = ?", auth.AuthorityId).Delete(&system.SysCasbinDeny{}).Error; err != nil {
+			return err
+		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	// 事务提交后删除本地策略并通知其他实例
+	if err = utils.CasbinSubjectRemoved(authorityId); err != nil {
+		global.GVA_LOG.Error("同步删除角色权限失败!", zap.Error(err))
+	}
+	utils.GlobalSystemEvents.Publish(utils.EventAuthorityDeleted, authorityEventData(*auth))
+	return nil
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -268,6 +330,30 @@ func (authorityService *AuthorityService) GetAuthorityInfo(auth system.SysAuthor
 	return sa, err
 }
 
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetDataAuthorityIds
+//@description: 获取角色可以访问的数据对应的角色id 多个角色时取并集
+//@param: authorityIds ...uint
+//@return: ids []uint, err error
+
+func (authorityService *AuthorityService) GetDataAuthorityIds(authorityIds ...uint) (ids []uint, err error) {
+	var authorities []system.SysAuthority
+	err = global.GVA_DB.Preload("DataAuthorityId").Where("authority_id in ?", authorityIds).Find(&authorities).Error
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uint]bool)
+	for _, authority := range authorities {
+		for _, data := range authority.DataAuthorityId {
+			if !seen[data.AuthorityId] {
+				seen[data.AuthorityId] = true
+				ids = append(ids, data.AuthorityId)
+			}
+		}
+	}
+	return ids, nil
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: SetDataAuthority
 //@description: 设置角色资源权限
@@ -8,6 +8,7 @@ import (
 
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
 	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
 	"github.com/flipped-aurora/gin-vue-admin/server/utils"
 	_ "github.com/go-sql-driver/mysql"
@@ -50,27 +51,8 @@ func (casbinService *CasbinService) UpdateCasbin(adminAuthorityID, AuthorityID u
 		}
 	}
 
-	authorityId := strconv.Itoa(int(AuthorityID))
-	casbinService.ClearCasbin(0, authorityId)
-	rules := [][]string{}
-	//做权限去重处理
-	deduplicateMap := make(map[string]bool)
-	for _, v := range casbinInfos {
-		key := authorityId + v.Path + v.Method
-		if _, ok := deduplicateMap[key]; !ok {
-			deduplicateMap[key] = true
-			rules = append(rules, []string{authorityId, v.Path, v.Method})
-		}
-	}
-	if len(rules) == 0 {
-		return nil
-	} // 设置空权限无需调用 AddPolicies 方法
-	e := utils.GetCasbin()
-	success, _ := e.AddPolicies(rules)
-	if !success {
-		return errors.New("存在相同api,添加失败,请联系管理员")
-	}
-	return nil
+	// 角色继承父角色的权限 未勾选的父角色权限写入 deny 策略
+	return casbinService.setApiPolicies(strconv.Itoa(int(AuthorityID)), casbinInfos)
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -80,35 +62,43 @@ func (casbinService *CasbinService) UpdateCasbin(adminAuthorityID, AuthorityID u
 //@return: error
 
 func (casbinService *CasbinService) UpdateCasbinApi(oldPath string, newPath string, oldMethod string, newMethod string) error {
-	err := global.GVA_DB.Model(&gormadapter.CasbinRule{}).Where("v1 = ? AND v2 = ?", oldPath, oldMethod).Updates(map[string]interface{}{
-		"v1": newPath,
-		"v2": newMethod,
+	e := utils.GetCasbin()
+	oldRules, _ := e.GetFilteredPolicy(1, utils.CasbinDomain, oldPath, oldMethod)
+	err := global.GVA_DB.Model(&gormadapter.CasbinRule{}).Where("ptype = ? AND v1 = ? AND v2 = ? AND v3 = ?", "p", utils.CasbinDomain, oldPath, oldMethod).Updates(map[string]interface{}{
+		"v2": newPath,
+		"v3": newMethod,
 	}).Error
 	if err != nil {
 		return err
 	}
+	err = global.GVA_DB.Model(&system.SysCasbinDeny{}).Where("path = ? AND method = ?", oldPath, oldMethod).Updates(map[string]interface{}{
+		"path":   newPath,
+		"method": newMethod,
+	}).Error
+	if err != nil {
+		return err
+	}
+	if len(oldRules) == 0 {
+		return nil
+	}
 
-	e := utils.GetCasbin()
-	return e.LoadPolicy()
+	newRules := make([][]string, 0, len(oldRules))
+	for _, rule := range oldRules {
+		newRule := append([]string(nil), rule...)
+		newRule[2], newRule[3] = newPath, newMethod
+		newRules = append(newRules, newRule)
+	}
+	return utils.CasbinPoliciesUpdated(oldRules, newRules)
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetPolicyPathByAuthorityId
-//@description: 获取权限列表
+//@description: 获取角色实际拥有的权限 包括从父角色继承的
 //@param: authorityId string
 //@return: pathMaps []request.CasbinInfo
 
 func (casbinService *CasbinService) GetPolicyPathByAuthorityId(AuthorityID uint) (pathMaps []request.CasbinInfo) {
-	e := utils.GetCasbin()
-	authorityId := strconv.Itoa(int(AuthorityID))
-	list, _ := e.GetFilteredPolicy(0, authorityId)
-	for _, v := range list {
-		pathMaps = append(pathMaps, request.CasbinInfo{
-			Path:   v[1],
-			Method: v[2],
-		})
-	}
-	return pathMaps
+	return effectiveApis(utils.GetCasbin(), strconv.Itoa(int(AuthorityID)))
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -120,12 +110,18 @@ func (casbinService *CasbinService) GetPolicyPathByAuthorityId(AuthorityID uint)
 func (casbinService *CasbinService) ClearCasbin(v int, p ...string) bool {
 	e := utils.GetCasbin()
 	success, _ := e.RemoveFilteredPolicy(v, p...)
+	// SyncedCachedEnforcer 的 RemoveFilteredPolicy 不清除鉴权缓存
+	_ = e.InvalidateCache()
+	// 按接口清除时同时删除该接口的显式拒绝规则
+	if v == 2 && len(p) == 2 {
+		global.GVA_DB.Unscoped().Where("path = ? AND method = ?", p[0], p[1]).Delete(&system.SysCasbinDeny{})
+	}
 	return success
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: RemoveFilteredPolicy
-//@description: 使用数据库方法清理筛选的politicy 此方法需要调用FreshCasbin方法才可以在系统中即刻生效
+//@description: 使用数据库方法清理角色的politicy及继承关系 此方法需要调用FreshCasbin方法才可以在系统中即刻生效
 //@param: db *gorm.DB, authorityId string
 //@return: error
 
@@ -149,25 +145,23 @@ func (casbinService *CasbinService) SyncPolicy(db *gorm.DB, authorityId string,
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: AddPolicies
-//@description: 添加匹配的权限
-//@param: v int, p ...string
+//@description: 添加匹配的权限 只写入数据库 事务提交后需调用 utils.CasbinPoliciesAdded 加载
+//@param: db *gorm.DB, rules [][]string
 //@return: bool
 
 func (casbinService *CasbinService) AddPolicies(db *gorm.DB, rules [][]string) error {
-	var casbinRules []gormadapter.CasbinRule
-	for i := range rules {
-		casbinRules = append(casbinRules, gormadapter.CasbinRule{
-			Ptype: "p",
-			V0:    rules[i][0],
-			V1:    rules[i][1],
-			V2:    rules[i][2],
-		})
-	}
-	return db.Create(&casbinRules).Error
+	return db.Create(casbinRules("p", rules)).Error
 }
 
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: FreshCasbin
+//@description: 重新加载全部策略 使用redis时通知其他实例同时重新加载
+//@return: error
+
 func (casbinService *CasbinService) FreshCasbin() (err error) {
 	e := utils.GetCasbin()
-	err = e.LoadPolicy()
-	return err
+	if err = e.LoadPolicy(); err != nil {
+		return err
+	}
+	return utils.CasbinReloaded()
 }
@@ -0,0 +1,154 @@
+package system
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"gorm.io/gorm"
+)
+
+// casbinDenies 角色的显式拒绝规则
+func casbinDenies(authorityId string) (infos []request.CasbinInfo, err error) {
+	var denies []system.SysCasbinDeny
+	if err = global.GVA_DB.Select("path", "method").Where("authority_id = ?", authorityId).Find(&denies).Error; err != nil {
+		return nil, err
+	}
+	for _, deny := range denies {
+		infos = append(infos, request.CasbinInfo{Path: deny.Path, Method: deny.Method})
+	}
+	return infos, nil
+}
+
+// apiPoliciesWithDenies 在 apiPolicies 的基础上加入显式拒绝规则
+// 显式拒绝的接口在权限编辑中为未勾选 保留角色原有的允许策略 删除拒绝规则后恢复访问
+func apiPoliciesWithDenies(e apiEnforcer, sub string, infos, denies []request.CasbinInfo) [][]string {
+	own := make(map[request.CasbinInfo]bool)
+	for _, info := range ownAllows(e, sub) {
+		own[info] = true
+	}
+	for _, info := range denies {
+		if own[info] {
+			infos = append(infos, info)
+		}
+	}
+	return withDenies(apiPolicies(e, sub, infos), sub, denies)
+}
+
+// withDenies 在策略中加入显式拒绝规则对应的 deny 策略 已存在的不重复添加
+func withDenies(rules [][]string, sub string, denies []request.CasbinInfo) [][]string {
+	seen := make(map[request.CasbinInfo]bool)
+	for _, rule := range rules {
+		if rule[4] == utils.CasbinDeny {
+			seen[request.CasbinInfo{Path: rule[2], Method: rule[3]}] = true
+		}
+	}
+	for _, info := range denies {
+		if !seen[info] {
+			seen[info] = true
+			rules = append(rules, apiPolicy(sub, info.Path, info.Method, utils.CasbinDeny))
+		}
+	}
+	return rules
+}
+
+// ownAllows 角色自身的允许策略
+func ownAllows(e apiEnforcer, sub string) []request.CasbinInfo {
+	policies, _ := e.GetPolicy()
+	var infos []request.CasbinInfo
+	for _, p := range policies {
+		if len(p) >= 5 && p[0] == sub && p[1] == utils.CasbinDomain && p[4] == utils.CasbinAllow {
+			infos = append(infos, request.CasbinInfo{Path: p[2], Method: p[3]})
+		}
+	}
+	return infos
+}
+
+// refreshCasbinDenies 显式拒绝规则变更后重写角色的策略 保持角色原有的权限
+// 只从父角色继承的接口被拒绝时同时写入角色自身的允许策略 删除拒绝规则后恢复访问
+func (casbinService *CasbinService) refreshCasbinDenies(authorityID uint) error {
+	e := utils.GetCasbin()
+	authorityId := strconv.Itoa(int(authorityID))
+	return casbinService.setApiPolicies(authorityId, append(effectiveApis(e, authorityId), ownAllows(e, authorityId)...))
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: CreateCasbinDeny
+//@description: 创建显式拒绝规则 角色及其子角色不能访问该接口
+//@param: adminAuthorityID uint, deny system.SysCasbinDeny
+//@return: err error
+
+func (casbinService *CasbinService) CreateCasbinDeny(adminAuthorityID uint, deny system.SysCasbinDeny) (err error) {
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, deny.AuthorityId); err != nil {
+		return err
+	}
+	if !errors.Is(global.GVA_DB.Where("authority_id = ? AND path = ? AND method = ?", deny.AuthorityId, deny.Path, deny.Method).First(&system.SysCasbinDeny{}).Error, gorm.ErrRecordNotFound) {
+		return errors.New("存在相同的拒绝规则")
+	}
+	if err = global.GVA_DB.Create(&deny).Error; err != nil {
+		return err
+	}
+	return casbinService.refreshCasbinDenies(deny.AuthorityId)
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: UpdateCasbinDeny
+//@description: 更新显式拒绝规则的路径、方法及备注 不能修改所属角色
+//@param: adminAuthorityID uint, deny system.SysCasbinDeny
+//@return: err error
+
+func (casbinService *CasbinService) UpdateCasbinDeny(adminAuthorityID uint, deny system.SysCasbinDeny) (err error) {
+	var old system.SysCasbinDeny
+	if err = global.GVA_DB.First(&old, deny.ID).Error; err != nil {
+		return err
+	}
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, old.AuthorityId); err != nil {
+		return err
+	}
+	if !errors.Is(global.GVA_DB.Where("id <> ? AND authority_id = ? AND path = ? AND method = ?", old.ID, old.AuthorityId, deny.Path, deny.Method).First(&system.SysCasbinDeny{}).Error, gorm.ErrRecordNotFound) {
+		return errors.New("存在相同的拒绝规则")
+	}
+	err = global.GVA_DB.Model(&old).Updates(map[string]interface{}{
+		"path":   deny.Path,
+		"method": deny.Method,
+		"remark": deny.Remark,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return casbinService.refreshCasbinDenies(old.AuthorityId)
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: DeleteCasbinDeny
+//@description: 删除显式拒绝规则 角色恢复原有的权限
+//@param: adminAuthorityID uint, id uint
+//@return: err error
+
+func (casbinService *CasbinService) DeleteCasbinDeny(adminAuthorityID uint, id uint) (err error) {
+	var deny system.SysCasbinDeny
+	if err = global.GVA_DB.First(&deny, id).Error; err != nil {
+		return err
+	}
+	if err = AuthorityServiceApp.CheckAuthorityIDAuth(adminAuthorityID, deny.AuthorityId); err != nil {
+		return err
+	}
+	if err = global.GVA_DB.Unscoped().Delete(&deny).Error; err != nil {
+		return err
+	}
+	return casbinService.refreshCasbinDenies(deny.AuthorityId)
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetCasbinDenyList
+//@description: 获取角色的显式拒绝规则
+//@param: authorityID uint
+//@return: list []system.SysCasbinDeny, err error
+
+func (casbinService *CasbinService) GetCasbinDenyList(authorityID uint) (list []system.SysCasbinDeny, err error) {
+	err = global.GVA_DB.Where("authority_id = ?", authorityID).Order("id").Find(&list).Error
+	return list, err
+}
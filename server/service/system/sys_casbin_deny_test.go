@@ -0,0 +1,43 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+func TestApiPoliciesWithDenies(t *testing.T) {
+	links := [][]string{authorityLink("8881", "888")}
+	e := newInheritEnforcer(t, [][]string{
+		apiPolicy("888", "/api/a", "GET", "allow"),
+		apiPolicy("888", "/api/b", "GET", "allow"),
+		apiPolicy("8881", "/api/c", "GET", "allow"),
+	}, links)
+	allowed := func(rules [][]string, path string) bool {
+		parent, _ := e.GetFilteredPolicy(0, "888")
+		ok, _ := newInheritEnforcer(t, append(parent, rules...), links).Enforce("8881", "api", path, "GET")
+		return ok
+	}
+
+	// 拒绝继承的 /api/b 及自身的 /api/c
+	denies := []request.CasbinInfo{{Path: "/api/b", Method: "GET"}, {Path: "/api/c", Method: "GET"}}
+	rules := apiPoliciesWithDenies(e, "8881", append(effectiveApis(e, "8881"), ownAllows(e, "8881")...), denies)
+	if !allowed(rules, "/api/a") || allowed(rules, "/api/b") || allowed(rules, "/api/c") {
+		t.Fatalf("rules = %v", rules)
+	}
+
+	// 权限编辑中被拒绝的接口为未勾选 保存后拒绝规则及原有的允许策略均保留
+	parent, _ := e.GetFilteredPolicy(0, "888")
+	e = newInheritEnforcer(t, append(parent, rules...), links)
+	rules = apiPoliciesWithDenies(e, "8881", effectiveApis(e, "8881"), denies)
+	if !allowed(rules, "/api/a") || allowed(rules, "/api/b") || allowed(rules, "/api/c") {
+		t.Fatalf("rules after editing = %v", rules)
+	}
+
+	// 删除拒绝规则后恢复访问
+	e = newInheritEnforcer(t, append(parent, rules...), links)
+	rules = apiPoliciesWithDenies(e, "8881", append(effectiveApis(e, "8881"), ownAllows(e, "8881")...), nil)
+	if !allowed(rules, "/api/a") || !allowed(rules, "/api/b") || !allowed(rules, "/api/c") {
+		t.Fatalf("rules after removing denies = %v", rules)
+	}
+}
@@ -0,0 +1,182 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	casbinUtil "github.com/casbin/casbin/v2/util"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+)
+
+// explainEnforcer 解释鉴权结果时用到的 enforcer 方法
+type explainEnforcer interface {
+	apiEnforcer
+	EnforceEx(rvals ...interface{}) (bool, []string, error)
+}
+
+// apiEffect 角色能否访问接口及决定结果的策略 与 CasbinHandler 的判断一致
+func apiEffect(e explainEnforcer, sub, path, method string) (allowed bool, effect string, policy []string) {
+	allowed, policy, _ = e.EnforceEx(sub, utils.CasbinDomain, path, method)
+	switch {
+	case allowed && len(policy) > 0 && policy[0] == sub:
+		effect = response.CasbinEffectAllow
+	case allowed:
+		effect = response.CasbinEffectInherit
+	case len(policy) > 0:
+		effect = response.CasbinEffectDeny
+	default:
+		effect = response.CasbinEffectNone
+	}
+	return allowed, effect, policy
+}
+
+// explainAuthority 单个角色的鉴权结果及说明
+func explainAuthority(e explainEnforcer, authority system.SysAuthority, path, method string) response.CasbinDecision {
+	sub := strconv.Itoa(int(authority.AuthorityId))
+	decision := response.CasbinDecision{AuthorityId: authority.AuthorityId, AuthorityName: authority.AuthorityName, Roles: []string{sub}}
+	roles, _ := e.GetImplicitRolesForUser(sub, utils.CasbinDomain)
+	decision.Roles = append(decision.Roles, roles...)
+	decision.Allowed, decision.Effect, decision.Policy = apiEffect(e, sub, path, method)
+	switch decision.Effect {
+	case response.CasbinEffectAllow:
+		decision.Reason = "角色自身的策略允许访问"
+	case response.CasbinEffectInherit:
+		decision.Reason = fmt.Sprintf("继承自角色%s的策略允许访问", decision.Policy[0])
+	case response.CasbinEffectDeny:
+		if decision.Policy[0] == sub {
+			decision.Reason = "角色自身的策略拒绝访问"
+		} else {
+			decision.Reason = fmt.Sprintf("继承自角色%s的策略拒绝访问", decision.Policy[0])
+		}
+	default:
+		decision.Reason = "角色及其父角色没有允许访问此接口的策略"
+	}
+	return decision
+}
+
+// matchApi 请求对应的api 路径完全相同的优先 其次为 keyMatch2 匹配的带参数路径
+func matchApi(apis []system.SysApi, path, method string) *system.SysApi {
+	var matched *system.SysApi
+	for i := range apis {
+		if apis[i].Method != method {
+			continue
+		}
+		if apis[i].Path == path {
+			return &apis[i]
+		}
+		if matched == nil && casbinUtil.KeyMatch2(path, apis[i].Path) {
+			matched = &apis[i]
+		}
+	}
+	return matched
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: Explain
+//@description: 权限模拟 判断用户或角色能否访问接口并说明原因
+//@param: info request.CasbinExplain
+//@return: res response.CasbinExplainResponse, err error
+
+func (casbinService *CasbinService) Explain(info request.CasbinExplain) (res response.CasbinExplainResponse, err error) {
+	path := info.Path
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if prefix := global.GVA_CONFIG.System.RouterPrefix; prefix != "" {
+		path = strings.TrimPrefix(path, prefix)
+	}
+	res.Path, res.Method = path, strings.ToUpper(info.Method)
+
+	var ids []uint
+	switch {
+	case info.UserId != 0:
+		var user system.SysUser
+		if err = global.GVA_DB.Select("id", "authority_id").First(&user, info.UserId).Error; err != nil {
+			return res, errors.New("用户不存在")
+		}
+		if err = global.GVA_DB.Model(&system.SysUserAuthority{}).Where("sys_user_id = ?", user.ID).
+			Pluck("sys_authority_authority_id", &ids).Error; err != nil {
+			return res, err
+		}
+		res.AuthorityId, ids = user.AuthorityId, append(ids, user.AuthorityId)
+	case info.AuthorityId != 0:
+		res.AuthorityId, ids = info.AuthorityId, []uint{info.AuthorityId}
+	default:
+		return res, errors.New("请指定用户或角色")
+	}
+	var authorities []system.SysAuthority
+	if err = global.GVA_DB.Select("authority_id", "authority_name").Where("authority_id IN ?", ids).
+		Order("authority_id").Find(&authorities).Error; err != nil {
+		return res, err
+	}
+	if len(authorities) == 0 {
+		return res, errors.New("角色不存在")
+	}
+
+	var apis []system.SysApi
+	if err = global.GVA_DB.Where("method = ?", res.Method).Find(&apis).Error; err != nil {
+		return res, err
+	}
+	res.Api = matchApi(apis, res.Path, res.Method)
+
+	e := utils.GetCasbin()
+	for _, authority := range authorities {
+		decision := explainAuthority(e, authority, res.Path, res.Method)
+		// 多角色模式下任一角色允许即可访问
+		if authority.AuthorityId == res.AuthorityId || global.GVA_CONFIG.System.UseAllAuthorities {
+			res.Allowed = res.Allowed || decision.Allowed
+		}
+		res.Decisions = append(res.Decisions, decision)
+	}
+	return res, nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetCasbinMatrix
+//@description: 角色与接口的权限矩阵 用于权限审计
+//@param: info request.CasbinMatrix
+//@return: res response.CasbinMatrixResponse, err error
+
+func (casbinService *CasbinService) GetCasbinMatrix(info request.CasbinMatrix) (res response.CasbinMatrixResponse, err error) {
+	var authorities []system.SysAuthority
+	db := global.GVA_DB.Select("authority_id", "authority_name", "parent_id").Order("authority_id")
+	if len(info.AuthorityIds) > 0 {
+		db = db.Where("authority_id IN ?", info.AuthorityIds)
+	}
+	if err = db.Find(&authorities).Error; err != nil {
+		return res, err
+	}
+	apiDb := global.GVA_DB.Order("api_group, path, method")
+	if info.ApiGroup != "" {
+		apiDb = apiDb.Where("api_group = ?", info.ApiGroup)
+	}
+	if err = apiDb.Find(&res.Apis).Error; err != nil {
+		return res, err
+	}
+
+	res.Authorities = make([]response.CasbinMatrixAuthority, 0, len(authorities))
+	for _, authority := range authorities {
+		item := response.CasbinMatrixAuthority{AuthorityId: authority.AuthorityId, AuthorityName: authority.AuthorityName}
+		if authority.ParentId != nil {
+			item.ParentId = *authority.ParentId
+		}
+		res.Authorities = append(res.Authorities, item)
+	}
+	e := utils.GetCasbin()
+	res.Cells = make([][]string, 0, len(res.Apis))
+	for _, api := range res.Apis {
+		row := make([]string, 0, len(authorities))
+		for _, authority := range authorities {
+			_, effect, _ := apiEffect(e, strconv.Itoa(int(authority.AuthorityId)), api.Path, api.Method)
+			row = append(row, effect)
+		}
+		res.Cells = append(res.Cells, row)
+	}
+	return res, nil
+}
@@ -0,0 +1,56 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+)
+
+func TestExplainAuthority(t *testing.T) {
+	e := newInheritEnforcer(t, [][]string{
+		apiPolicy("888", "/api/a", "GET", "allow"),
+		apiPolicy("888", "/user/:id", "GET", "allow"),
+		apiPolicy("8881", "/api/b", "GET", "allow"),
+		apiPolicy("8881", "/user/:id", "GET", "deny"),
+	}, [][]string{authorityLink("8881", "888")})
+
+	cases := []struct {
+		path, method, effect, policy string
+		allowed                      bool
+	}{
+		{"/api/b", "GET", response.CasbinEffectAllow, "8881", true},
+		{"/api/a", "GET", response.CasbinEffectInherit, "888", true},
+		{"/user/1", "GET", response.CasbinEffectDeny, "8881", false},
+		{"/api/c", "GET", response.CasbinEffectNone, "", false},
+	}
+	for _, c := range cases {
+		d := explainAuthority(e, system.SysAuthority{AuthorityId: 8881}, c.path, c.method)
+		if d.Allowed != c.allowed || d.Effect != c.effect {
+			t.Errorf("%s %s: allowed = %v, effect = %q", c.method, c.path, d.Allowed, d.Effect)
+		}
+		if c.policy != "" && (len(d.Policy) == 0 || d.Policy[0] != c.policy) {
+			t.Errorf("%s %s: policy = %v, want subject %s", c.method, c.path, d.Policy, c.policy)
+		}
+		if len(d.Roles) != 2 || d.Reason == "" {
+			t.Errorf("%s %s: roles = %v, reason = %q", c.method, c.path, d.Roles, d.Reason)
+		}
+	}
+}
+
+func TestMatchApi(t *testing.T) {
+	apis := []system.SysApi{
+		{Path: "/user/:id", Method: "GET"},
+		{Path: "/user/self", Method: "GET"},
+		{Path: "/user/self", Method: "PUT"},
+	}
+	if api := matchApi(apis, "/user/self", "GET"); api == nil || api.Path != "/user/self" {
+		t.Errorf("exact path should win, got %v", api)
+	}
+	if api := matchApi(apis, "/user/1", "GET"); api == nil || api.Path != "/user/:id" {
+		t.Errorf("parameterized path should match, got %v", api)
+	}
+	if api := matchApi(apis, "/user/1", "DELETE"); api != nil {
+		t.Errorf("method should match, got %v", api)
+	}
+}
@@ -0,0 +1,321 @@
+package system
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	casbinUtil "github.com/casbin/casbin/v2/util"
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// apiEnforcer 计算角色实际权限时用到的 enforcer 方法 运行时为 SyncedCachedEnforcer 迁移时为内存中的 Enforcer
+type apiEnforcer interface {
+	GetPolicy() ([][]string, error)
+	GetRolesForUser(name string, domain ...string) ([]string, error)
+	GetImplicitRolesForUser(name string, domain ...string) ([]string, error)
+	Enforce(rvals ...interface{}) (bool, error)
+}
+
+// apiPolicy 接口权限策略 [角色, 域, 路径, 方法, 效果]
+func apiPolicy(sub, path, method, eft string) []string {
+	return []string{sub, utils.CasbinDomain, path, method, eft}
+}
+
+// authorityLink 角色继承关系 [子角色, 父角色, 域]
+func authorityLink(child, parent string) []string {
+	return []string{child, parent, utils.CasbinDomain}
+}
+
+// effectiveApis 角色实际可以访问的接口 取自角色及其祖先的允许策略 排除被拒绝的接口
+func effectiveApis(e apiEnforcer, sub string) []request.CasbinInfo {
+	subs := map[string]bool{sub: true}
+	roles, _ := e.GetImplicitRolesForUser(sub, utils.CasbinDomain)
+	for _, role := range roles {
+		subs[role] = true
+	}
+	policies, _ := e.GetPolicy()
+	seen := make(map[request.CasbinInfo]bool)
+	var list []request.CasbinInfo
+	for _, p := range policies {
+		if len(p) < 5 || !subs[p[0]] || p[1] != utils.CasbinDomain || p[4] != utils.CasbinAllow {
+			continue
+		}
+		info := request.CasbinInfo{Path: p[2], Method: p[3]}
+		if seen[info] {
+			continue
+		}
+		seen[info] = true
+		if ok, _ := e.Enforce(sub, utils.CasbinDomain, info.Path, info.Method); ok {
+			list = append(list, info)
+		}
+	}
+	return list
+}
+
+// apiPolicies 使角色实际权限为 infos 的策略 允许 infos 中的接口 拒绝从父角色继承但不在 infos 中的接口
+func apiPolicies(e apiEnforcer, sub string, infos []request.CasbinInfo) [][]string {
+	want := make(map[request.CasbinInfo]bool)
+	var rules [][]string
+	for _, info := range infos {
+		if !want[info] {
+			want[info] = true
+			rules = append(rules, apiPolicy(sub, info.Path, info.Method, utils.CasbinAllow))
+		}
+	}
+	parents, _ := e.GetRolesForUser(sub, utils.CasbinDomain)
+	denied := make(map[request.CasbinInfo]bool)
+	for _, parent := range parents {
+		for _, info := range effectiveApis(e, parent) {
+			if !want[info] && !denied[info] {
+				denied[info] = true
+				rules = append(rules, apiPolicy(sub, info.Path, info.Method, utils.CasbinDeny))
+			}
+		}
+	}
+	return rules
+}
+
+// setApiPolicies 重写角色自身的策略 使其实际权限为 infos 并保留显式拒绝规则
+func (casbinService *CasbinService) setApiPolicies(authorityId string, infos []request.CasbinInfo) error {
+	e := utils.GetCasbin()
+	denies, err := casbinDenies(authorityId)
+	if err != nil {
+		return err
+	}
+	rules := apiPoliciesWithDenies(e, authorityId, infos, denies)
+	casbinService.ClearCasbin(0, authorityId)
+	if len(rules) == 0 {
+		return nil
+	} // 设置空权限无需调用 AddPolicies 方法
+	success, _ := e.AddPolicies(rules)
+	if !success {
+		return errors.New("存在相同api,添加失败,请联系管理员")
+	}
+	// 子角色继承本角色的权限 按策略清除的缓存不包括子角色
+	return e.InvalidateCache()
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: SetAuthorityParent
+//@description: 角色的父角色变更后更新继承关系 并保持角色原有的实际权限
+//@param: authorityID uint, parentID uint, keep []request.CasbinInfo
+//@return: error
+
+func (casbinService *CasbinService) SetAuthorityParent(authorityID, parentID uint, keep []request.CasbinInfo) error {
+	if err := casbinService.linkAuthority(authorityID, parentID); err != nil {
+		return err
+	}
+	return casbinService.setApiPolicies(strconv.Itoa(int(authorityID)), keep)
+}
+
+// linkAuthority 替换角色的继承关系 parentID 为0时不继承
+func (casbinService *CasbinService) linkAuthority(authorityID, parentID uint) error {
+	e := utils.GetCasbin()
+	authorityId := strconv.Itoa(int(authorityID))
+	if _, err := e.RemoveFilteredNamedGroupingPolicy("g", 0, authorityId); err != nil {
+		return err
+	}
+	if parentID != 0 {
+		link := authorityLink(authorityId, strconv.Itoa(int(parentID)))
+		if _, err := e.AddNamedGroupingPolicy("g", link[0], link[1], link[2]); err != nil {
+			return err
+		}
+	}
+	return e.InvalidateCache()
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: AddGroupingPolicies
+//@description: 添加角色继承关系 只写入数据库 事务提交后需调用 utils.CasbinPoliciesAdded 加载
+//@param: db *gorm.DB, rules [][]string
+//@return: error
+
+func (casbinService *CasbinService) AddGroupingPolicies(db *gorm.DB, rules [][]string) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	return db.Create(casbinRules("g", rules)).Error
+}
+
+func casbinRules(ptype string, rules [][]string) []gormadapter.CasbinRule {
+	casbinRules := make([]gormadapter.CasbinRule, 0, len(rules))
+	for _, rule := range rules {
+		r := gormadapter.CasbinRule{Ptype: ptype}
+		for i, v := range rule {
+			switch i {
+			case 0:
+				r.V0 = v
+			case 1:
+				r.V1 = v
+			case 2:
+				r.V2 = v
+			case 3:
+				r.V3 = v
+			case 4:
+				r.V4 = v
+			case 5:
+				r.V5 = v
+			}
+		}
+		casbinRules = append(casbinRules, r)
+	}
+	return casbinRules
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: MigrateInheritance
+//@description: 将旧版 [角色, 路径, 方法] 的策略转换为带域及效果的策略 并按 ParentId 生成继承关系 转换前后实际权限不变
+//@param: db *gorm.DB
+//@return: error
+
+func (casbinService *CasbinService) MigrateInheritance(db *gorm.DB) error {
+	var legacy []gormadapter.CasbinRule
+	if err := db.Where("ptype = ? AND (v4 = '' OR v4 IS NULL)", "p").Find(&legacy).Error; err != nil {
+		return err
+	}
+	if len(legacy) == 0 {
+		return nil
+	}
+	var authorities []system.SysAuthority
+	if err := db.Select("authority_id", "parent_id").Find(&authorities).Error; err != nil {
+		return err
+	}
+	parents := make(map[string]string, len(authorities))
+	for _, authority := range authorities {
+		if authority.ParentId != nil && *authority.ParentId != 0 {
+			parents[strconv.Itoa(int(authority.AuthorityId))] = strconv.Itoa(int(*authority.ParentId))
+		}
+	}
+	rules := make([][]string, 0, len(legacy))
+	ids := make([]uint, 0, len(legacy))
+	for _, rule := range legacy {
+		rules = append(rules, []string{rule.V0, rule.V1, rule.V2})
+		ids = append(ids, rule.ID)
+	}
+	policies, links, flat, err := inheritPolicies(rules, parents)
+	if err != nil {
+		return err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&gormadapter.CasbinRule{}, ids).Error; err != nil {
+			return err
+		}
+		if err := tx.CreateInBatches(casbinRules("p", policies), 100).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("ptype = ?", "g").Delete(&gormadapter.CasbinRule{}).Error; err != nil {
+			return err
+		}
+		return casbinService.AddGroupingPolicies(tx, links)
+	})
+	if err != nil {
+		return err
+	}
+	if len(flat) > 0 && global.GVA_LOG != nil {
+		// 子角色拥有父角色被拒绝的权限时 继承后无法保持原有权限 这些角色暂不继承父角色
+		global.GVA_LOG.Warn("以下角色继承父角色后权限会变化, 未建立继承关系", zap.Strings("authorities", flat))
+	}
+	return nil
+}
+
+// inheritPolicies 在内存中转换旧版策略 按层级从上到下为每个子角色建立继承关系
+// 继承后多出的权限添加 deny 策略 仍与原有权限不一致的角色不建立继承关系 返回于 flat
+func inheritPolicies(legacy [][]string, parents map[string]string) (policies, links [][]string, flat []string, err error) {
+	m, err := model.NewModelFromString(utils.CasbinModel)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	own := make(map[string][]request.CasbinInfo)
+	var candidates []request.CasbinInfo
+	seen := make(map[request.CasbinInfo]bool)
+	var allows [][]string
+	for _, rule := range legacy {
+		info := request.CasbinInfo{Path: rule[1], Method: rule[2]}
+		own[rule[0]] = append(own[rule[0]], info)
+		allows = append(allows, apiPolicy(rule[0], info.Path, info.Method, utils.CasbinAllow))
+		if !seen[info] {
+			seen[info] = true
+			candidates = append(candidates, info)
+		}
+	}
+	if _, err = e.AddPoliciesEx(allows); err != nil {
+		return nil, nil, nil, err
+	}
+
+	// 旧版模型下角色是否可以访问
+	legacyAllowed := func(sub string, info request.CasbinInfo) bool {
+		for _, r := range own[sub] {
+			if r.Method == info.Method && casbinUtil.KeyMatch2(info.Path, r.Path) {
+				return true
+			}
+		}
+		return false
+	}
+	depth := func(sub string) int {
+		d := 0
+		for p, ok := parents[sub]; ok && d <= len(parents); p, ok = parents[p] {
+			d++
+		}
+		return d
+	}
+	subs := make([]string, 0, len(parents))
+	for sub := range parents {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		if di, dj := depth(subs[i]), depth(subs[j]); di != dj {
+			return di < dj
+		}
+		return subs[i] < subs[j]
+	})
+
+	for _, sub := range subs {
+		link := authorityLink(sub, parents[sub])
+		if _, err = e.AddGroupingPolicy(link[0], link[1], link[2]); err != nil {
+			return nil, nil, nil, err
+		}
+		var denies [][]string
+		for _, info := range candidates {
+			if ok, _ := e.Enforce(sub, utils.CasbinDomain, info.Path, info.Method); ok && !legacyAllowed(sub, info) {
+				denies = append(denies, apiPolicy(sub, info.Path, info.Method, utils.CasbinDeny))
+			}
+		}
+		if len(denies) > 0 {
+			if _, err = e.AddPolicies(denies); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		consistent := true
+		for _, info := range candidates {
+			if ok, _ := e.Enforce(sub, utils.CasbinDomain, info.Path, info.Method); ok != legacyAllowed(sub, info) {
+				consistent = false
+				break
+			}
+		}
+		if !consistent {
+			if len(denies) > 0 {
+				_, _ = e.RemovePolicies(denies)
+			}
+			_, _ = e.RemoveGroupingPolicy(link[0], link[1], link[2])
+			flat = append(flat, sub)
+			continue
+		}
+		links = append(links, link)
+	}
+	policies, err = e.GetPolicy()
+	return policies, links, flat, err
+}
@@ -0,0 +1,152 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 888 -> 8881 -> 88811(拥有 8881 没有的 /api/b) / 88812
+var legacyRules = [][]string{
+	{"888", "/api/a", "GET"}, {"888", "/api/b", "GET"}, {"888", "/user/:id", "GET"},
+	{"8881", "/api/a", "GET"},
+	{"88811", "/api/a", "GET"}, {"88811", "/api/b", "GET"},
+	{"88812", "/api/c", "POST"},
+	{"9528", "/api/c", "POST"},
+}
+
+var legacyParents = map[string]string{"8881": "888", "88811": "8881", "88812": "8881"}
+
+func newInheritEnforcer(t *testing.T, policies, links [][]string) *casbin.Enforcer {
+	t.Helper()
+	m, err := model.NewModelFromString(utils.CasbinModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = e.AddPolicies(policies); err != nil {
+		t.Fatal(err)
+	}
+	for _, link := range links {
+		if _, err = e.AddGroupingPolicy(link[0], link[1], link[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return e
+}
+
+func TestInheritPolicies(t *testing.T) {
+	policies, links, flat, err := inheritPolicies(legacyRules, legacyParents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 2 || links[0][0] != "8881" || links[1][0] != "88812" {
+		t.Errorf("links = %v", links)
+	}
+	if len(flat) != 1 || flat[0] != "88811" {
+		t.Errorf("flat = %v, want [88811]", flat)
+	}
+
+	// 转换后每个角色的实际权限与旧版一致
+	e := newInheritEnforcer(t, policies, links)
+	cases := []struct {
+		sub, obj, act string
+		want          bool
+	}{
+		{"888", "/user/1", "GET", true},
+		{"8881", "/api/a", "GET", true},
+		{"8881", "/api/b", "GET", false},
+		{"8881", "/user/1", "GET", false},
+		{"88811", "/api/b", "GET", true},
+		{"88811", "/api/c", "POST", false},
+		{"88812", "/api/a", "GET", false},
+		{"88812", "/api/b", "GET", false},
+		{"88812", "/api/c", "POST", true},
+		{"9528", "/api/a", "GET", false},
+	}
+	for _, c := range cases {
+		if ok, _ := e.Enforce(c.sub, utils.CasbinDomain, c.obj, c.act); ok != c.want {
+			t.Errorf("Enforce(%s, %s, %s) = %v, want %v", c.sub, c.obj, c.act, ok, c.want)
+		}
+	}
+
+	// 子角色的实际权限包括继承的权限 设置权限时未勾选的父角色权限写入 deny
+	if apis := effectiveApis(e, "88812"); len(apis) != 1 || apis[0].Path != "/api/c" {
+		t.Errorf("effectiveApis(88812) = %v", apis)
+	}
+	rules := apiPolicies(e, "88812", []request.CasbinInfo{{Path: "/api/c", Method: "POST"}})
+	if len(rules) != 2 {
+		t.Errorf("apiPolicies(88812) = %v", rules)
+	}
+	var denied []string
+	for _, rule := range rules {
+		if rule[4] == utils.CasbinDeny {
+			denied = append(denied, rule[2])
+		}
+	}
+	if len(denied) != 1 || denied[0] != "/api/a" {
+		t.Errorf("denied = %v, want [/api/a]", denied)
+	}
+}
+
+func TestMigrateInheritance(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&gormadapter.CasbinRule{}, &system.SysAuthority{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_LOG = zap.NewNop()
+
+	parent := func(id uint) *uint { return &id }
+	db.Create(&[]system.SysAuthority{
+		{AuthorityId: 888, AuthorityName: "a", ParentId: parent(0)},
+		{AuthorityId: 8881, AuthorityName: "b", ParentId: parent(888)},
+		{AuthorityId: 88811, AuthorityName: "c", ParentId: parent(8881)},
+		{AuthorityId: 88812, AuthorityName: "d", ParentId: parent(8881)},
+		{AuthorityId: 9528, AuthorityName: "e", ParentId: parent(0)},
+	})
+	var rows []gormadapter.CasbinRule
+	for _, rule := range legacyRules {
+		rows = append(rows, gormadapter.CasbinRule{Ptype: "p", V0: rule[0], V1: rule[1], V2: rule[2]})
+	}
+	db.Create(&rows)
+
+	s := &CasbinService{}
+	if err = s.MigrateInheritance(db); err != nil {
+		t.Fatal(err)
+	}
+	var legacy, links int64
+	db.Model(&gormadapter.CasbinRule{}).Where("ptype = ? AND v4 = ?", "p", "").Count(&legacy)
+	db.Model(&gormadapter.CasbinRule{}).Where("ptype = ?", "g").Count(&links)
+	if legacy != 0 || links != 2 {
+		t.Errorf("legacy = %d, links = %d", legacy, links)
+	}
+
+	// 已转换的数据再次执行不变化
+	var before, after int64
+	db.Model(&gormadapter.CasbinRule{}).Count(&before)
+	if err = s.MigrateInheritance(db); err != nil {
+		t.Fatal(err)
+	}
+	db.Model(&gormadapter.CasbinRule{}).Count(&after)
+	if before != after {
+		t.Errorf("rules = %d after second migration, want %d", after, before)
+	}
+}
@@ -0,0 +1,136 @@
+package system
+
+import (
+	"errors"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"gorm.io/gorm"
+)
+
+type DepartmentService struct{}
+
+var DepartmentServiceApp = new(DepartmentService)
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: CreateDepartment
+//@description: 创建部门
+//@param: department system.SysDepartment
+//@return: err error
+
+func (departmentService *DepartmentService) CreateDepartment(department system.SysDepartment) (err error) {
+	if department.ParentID != 0 {
+		if err = global.GVA_DB.First(&system.SysDepartment{}, department.ParentID).Error; err != nil {
+			return errors.New("上级部门不存在")
+		}
+	}
+	return global.GVA_DB.Create(&department).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: UpdateDepartment
+//@description: 更新部门 上级部门不能是自身或下级部门
+//@param: department system.SysDepartment
+//@return: err error
+
+func (departmentService *DepartmentService) UpdateDepartment(department system.SysDepartment) (err error) {
+	if department.ParentID != 0 {
+		ids, err := departmentDescendants(global.GVA_DB, []uint{department.ID})
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if id == department.ParentID {
+				return errors.New("上级部门不能是自身或下级部门")
+			}
+		}
+		if err = global.GVA_DB.First(&system.SysDepartment{}, department.ParentID).Error; err != nil {
+			return errors.New("上级部门不存在")
+		}
+	}
+	return global.GVA_DB.Model(&system.SysDepartment{}).Where("id = ?", department.ID).
+		Select("parent_id", "name", "sort").
+		Updates(&department).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: DeleteDepartment
+//@description: 删除部门 存在下级部门或部门下仍有用户时不允许删除
+//@param: id uint
+//@return: err error
+
+func (departmentService *DepartmentService) DeleteDepartment(id uint) (err error) {
+	var count int64
+	if err = global.GVA_DB.Model(&system.SysDepartment{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("请先删除下级部门")
+	}
+	if err = global.GVA_DB.Model(&system.SysUser{}).Where("department_id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("部门下仍有用户, 请先调整用户所属部门")
+	}
+	return global.GVA_DB.Delete(&system.SysDepartment{}, id).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetDepartmentTree
+//@description: 获取部门树
+//@return: list []system.SysDepartment, err error
+
+func (departmentService *DepartmentService) GetDepartmentTree() (list []system.SysDepartment, err error) {
+	var all []system.SysDepartment
+	if err = global.GVA_DB.Order("sort, id").Find(&all).Error; err != nil {
+		return nil, err
+	}
+	children := make(map[uint][]system.SysDepartment)
+	for _, d := range all {
+		children[d.ParentID] = append(children[d.ParentID], d)
+	}
+	var build func(parentID uint) []system.SysDepartment
+	build = func(parentID uint) []system.SysDepartment {
+		nodes := children[parentID]
+		for i := range nodes {
+			nodes[i].Children = build(nodes[i].ID)
+		}
+		return nodes
+	}
+	return build(0), nil
+}
+
+// departmentDescendants 返回部门及其全部下级部门的ID
+func departmentDescendants(db *gorm.DB, ids []uint) ([]uint, error) {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for level := ids; len(level) > 0; {
+		next := make([]uint, 0)
+		for _, id := range level {
+			if !seen[id] {
+				seen[id] = true
+				result = append(result, id)
+				next = append(next, id)
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		level = nil
+		if err := db.Model(&system.SysDepartment{}).Where("parent_id in ?", next).Pluck("id", &level).Error; err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// resolveNotificationDepartments 部门及其下级部门中的用户
+func resolveNotificationDepartments(db *gorm.DB, ids []uint) (userIDs []uint, err error) {
+	departmentIDs, err := departmentDescendants(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Model(&system.SysUser{}).Where("department_id in ?", departmentIDs).Pluck("id", &userIDs).Error
+	return
+}
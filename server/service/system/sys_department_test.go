@@ -0,0 +1,79 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupDepartmentDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysDepartment{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func TestDepartmentService(t *testing.T) {
+	db := setupDepartmentDB(t)
+	s := &DepartmentService{}
+
+	// 1 总部 > 2 研发部 > 3 前端组  4 市场部
+	for _, d := range []system.SysDepartment{
+		{Name: "总部"},
+		{Name: "研发部", ParentID: 1},
+		{Name: "前端组", ParentID: 2},
+		{Name: "市场部"},
+	} {
+		if err := s.CreateDepartment(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.CreateDepartment(system.SysDepartment{Name: "x", ParentID: 99}); err == nil {
+		t.Fatalf("parent should exist")
+	}
+
+	tree, err := s.GetDepartmentTree()
+	if err != nil || len(tree) != 2 || len(tree[0].Children) != 1 || len(tree[0].Children[0].Children) != 1 {
+		t.Fatalf("GetDepartmentTree() = %+v, %v", tree, err)
+	}
+
+	for _, parent := range []uint{1, 3} {
+		if err = s.UpdateDepartment(system.SysDepartment{GVA_MODEL: global.GVA_MODEL{ID: 1}, Name: "总部", ParentID: parent}); err == nil {
+			t.Fatalf("parent %d is the department itself or its descendant", parent)
+		}
+	}
+	if err = s.UpdateDepartment(system.SysDepartment{GVA_MODEL: global.GVA_MODEL{ID: 2}, Name: "研发中心", ParentID: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	db.Create(&system.SysUser{Username: "a", DepartmentID: 3})
+	db.Create(&system.SysUser{Username: "b", DepartmentID: 1})
+	userIDs, err := resolveNotificationDepartments(db, []uint{4})
+	if err != nil || len(userIDs) != 1 || userIDs[0] != 1 {
+		t.Fatalf("resolveNotificationDepartments() = %v, %v", userIDs, err)
+	}
+
+	if err = s.DeleteDepartment(2); err == nil {
+		t.Fatalf("department with children should not be deleted")
+	}
+	if err = s.DeleteDepartment(3); err == nil {
+		t.Fatalf("department with users should not be deleted")
+	}
+	db.Model(&system.SysUser{}).Where("username = ?", "a").Update("department_id", 0)
+	if err = s.DeleteDepartment(3); err != nil {
+		t.Fatal(err)
+	}
+}
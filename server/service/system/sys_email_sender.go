@@ -0,0 +1,37 @@
+package system
+
+import (
+	"errors"
+	"sync"
+)
+
+// EmailSender 发送邮件 to 为收件人 多个以英文逗号分隔 body 为html
+type EmailSender func(to, subject, body string) error
+
+var (
+	emailSenderMu sync.RWMutex
+	emailSender   EmailSender
+)
+
+// RegisterEmailSender 注册通知及告警使用的邮件发送函数 由邮件插件在启用时注册
+func RegisterEmailSender(sender EmailSender) {
+	emailSenderMu.Lock()
+	defer emailSenderMu.Unlock()
+	emailSender = sender
+}
+
+// getEmailSender 已注册的邮件发送函数 未安装邮件插件时为nil
+func getEmailSender() EmailSender {
+	emailSenderMu.RLock()
+	defer emailSenderMu.RUnlock()
+	return emailSender
+}
+
+// sendEmail 使用已注册的邮件发送函数发送邮件
+func sendEmail(to, subject, body string) error {
+	sender := getEmailSender()
+	if sender == nil {
+		return errors.New("未安装邮件插件")
+	}
+	return sender(to, subject, body)
+}
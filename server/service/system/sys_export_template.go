@@ -336,6 +336,11 @@ func (sysExportTemplateService *SysExportTemplateService) ExportExcel(templateID
 	if err != nil {
 		return nil, "", err
 	}
+	utils.GlobalSystemEvents.Publish(utils.EventExportFinished, map[string]interface{}{
+		"templateId": templateID,
+		"name":       template.Name,
+		"size":       file.Len(),
+	})
 
 	return file, template.Name, nil
 }
@@ -11,25 +11,29 @@ import (
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: getMenuTreeMap
-//@description: 获取路由总树map
-//@param: authorityId string
+//@description: 获取路由总树map 多个角色时合并各角色的菜单及按钮
+//@param: authorityIds ...uint
 //@return: treeMap map[string][]system.SysMenu, err error
 
 type MenuService struct{}
 
 var MenuServiceApp = new(MenuService)
 
-func (menuService *MenuService) getMenuTreeMap(authorityId uint) (treeMap map[uint][]system.SysMenu, err error) {
+func (menuService *MenuService) getMenuTreeMap(authorityIds ...uint) (treeMap map[uint][]system.SysMenu, err error) {
 	var allMenus []system.SysMenu
 	var baseMenu []system.SysBaseMenu
 	var btns []system.SysAuthorityBtn
 	treeMap = make(map[uint][]system.SysMenu)
 
 	var SysAuthorityMenus []system.SysAuthorityMenu
-	err = global.GVA_DB.Where("sys_authority_authority_id = ?", authorityId).Find(&SysAuthorityMenus).Error
+	err = global.GVA_DB.Where("sys_authority_authority_id in ?", authorityIds).Find(&SysAuthorityMenus).Error
 	if err != nil {
 		return
 	}
+	var authorityId uint
+	if len(authorityIds) > 0 {
+		authorityId = authorityIds[0]
+	}
 
 	var MenuIds []string
 
@@ -43,6 +47,10 @@ func (menuService *MenuService) getMenuTreeMap(authorityId uint) (treeMap map[ui
 	}
 
 	for i := range baseMenu {
+		// 已停用插件的菜单不再显示
+		if PluginRuntimeServiceApp.MenuHidden(baseMenu[i]) {
+			continue
+		}
 		allMenus = append(allMenus, system.SysMenu{
 			SysBaseMenu: baseMenu[i],
 			AuthorityId: authorityId,
@@ -51,7 +59,7 @@ func (menuService *MenuService) getMenuTreeMap(authorityId uint) (treeMap map[ui
 		})
 	}
 
-	err = global.GVA_DB.Where("authority_id = ?", authorityId).Preload("SysBaseMenuBtn").Find(&btns).Error
+	err = global.GVA_DB.Where("authority_id in ?", authorityIds).Preload("SysBaseMenuBtn").Find(&btns).Error
 	if err != nil {
 		return
 	}
@@ -60,7 +68,7 @@ func (menuService *MenuService) getMenuTreeMap(authorityId uint) (treeMap map[ui
 		if btnMap[v.SysMenuID] == nil {
 			btnMap[v.SysMenuID] = make(map[string]uint)
 		}
-		btnMap[v.SysMenuID][v.SysBaseMenuBtn.Name] = authorityId
+		btnMap[v.SysMenuID][v.SysBaseMenuBtn.Name] = v.AuthorityId
 	}
 	for _, v := range allMenus {
 		v.Btns = btnMap[v.SysBaseMenu.ID]
@@ -71,12 +79,12 @@ func (menuService *MenuService) getMenuTreeMap(authorityId uint) (treeMap map[ui
 
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: GetMenuTree
-//@description: 获取动态菜单树
-//@param: authorityId string
+//@description: 获取动态菜单树 传入多个角色时为各角色菜单的并集 第一个为用户当前角色
+//@param: authorityIds ...uint
 //@return: menus []system.SysMenu, err error
 
-func (menuService *MenuService) GetMenuTree(authorityId uint) (menus []system.SysMenu, err error) {
-	menuTree, err := menuService.getMenuTreeMap(authorityId)
+func (menuService *MenuService) GetMenuTree(authorityIds ...uint) (menus []system.SysMenu, err error) {
+	menuTree, err := menuService.getMenuTreeMap(authorityIds...)
 	menus = menuTree[0]
 	for i := 0; i < len(menus); i++ {
 		err = menuService.getChildrenList(&menus[i], menuTree)
@@ -0,0 +1,334 @@
+package system
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/socket"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// NotificationTargetResolver 将目标ID解析为接收用户ID
+type NotificationTargetResolver func(db *gorm.DB, ids []uint) ([]uint, error)
+
+var (
+	notificationTargetsMu sync.RWMutex
+	notificationTargets   = map[string]NotificationTargetResolver{
+		system.NotificationTargetUser:       resolveNotificationUsers,
+		system.NotificationTargetAuthority:  resolveNotificationAuthorities,
+		system.NotificationTargetAll:        resolveNotificationAll,
+		system.NotificationTargetDepartment: resolveNotificationDepartments,
+	}
+)
+
+// RegisterNotificationTarget 注册通知目标类型的解析函数 业务模块可以注册新的目标类型或替换内置的解析函数
+func RegisterNotificationTarget(targetType string, resolver NotificationTargetResolver) {
+	notificationTargetsMu.Lock()
+	defer notificationTargetsMu.Unlock()
+	notificationTargets[targetType] = resolver
+}
+
+func resolveNotificationUsers(db *gorm.DB, ids []uint) (userIDs []uint, err error) {
+	err = db.Model(&system.SysUser{}).Where("id in ?", ids).Pluck("id", &userIDs).Error
+	return
+}
+
+func resolveNotificationAuthorities(db *gorm.DB, ids []uint) (userIDs []uint, err error) {
+	err = db.Model(&system.SysUserAuthority{}).Where("sys_authority_authority_id in ?", ids).Distinct().Pluck("sys_user_id", &userIDs).Error
+	return
+}
+
+func resolveNotificationAll(db *gorm.DB, _ []uint) (userIDs []uint, err error) {
+	err = db.Model(&system.SysUser{}).Where("enable = ?", 1).Pluck("id", &userIDs).Error
+	return
+}
+
+type NotificationService struct{}
+
+// Send 发送通知 为每个接收用户生成接收记录 在线用户通过websocket推送 离线用户按偏好发送邮件
+func (notificationService *NotificationService) Send(req systemReq.NotificationCreate, senderID uint) (notification system.SysNotification, err error) {
+	notificationTargetsMu.RLock()
+	resolver, ok := notificationTargets[req.TargetType]
+	notificationTargetsMu.RUnlock()
+	if !ok {
+		return notification, fmt.Errorf("未注册的通知目标类型: %s", req.TargetType)
+	}
+	if req.TargetType != system.NotificationTargetAll && len(req.TargetIDs) == 0 {
+		return notification, errors.New("通知目标不能为空")
+	}
+
+	targetIDs, _ := json.Marshal(req.TargetIDs)
+	notification = system.SysNotification{
+		Title:      req.Title,
+		Content:    req.Content,
+		Type:       req.Type,
+		Level:      req.Level,
+		Link:       req.Link,
+		TargetType: req.TargetType,
+		TargetIDs:  targetIDs,
+		SenderID:   senderID,
+	}
+	if notification.Type == "" {
+		notification.Type = "system"
+	}
+	if notification.Level == "" {
+		notification.Level = "info"
+	}
+
+	var receipts []system.SysNotificationReceipt
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		userIDs, err := resolver(tx, req.TargetIDs)
+		if err != nil {
+			return err
+		}
+		if len(userIDs) == 0 {
+			return errors.New("没有符合条件的接收用户")
+		}
+		notification.Receivers = len(userIDs)
+		if err = tx.Create(&notification).Error; err != nil {
+			return err
+		}
+		receipts = make([]system.SysNotificationReceipt, 0, len(userIDs))
+		for _, userID := range userIDs {
+			receipts = append(receipts, system.SysNotificationReceipt{NotificationID: notification.ID, UserID: userID})
+		}
+		return tx.CreateInBatches(&receipts, 200).Error
+	})
+	if err != nil {
+		return notification, err
+	}
+
+	go notificationService.deliver(notification, receipts)
+	return notification, nil
+}
+
+// deliver 投递通知 在线用户走websocket 离线且开启邮件提醒的用户走邮件
+func (notificationService *NotificationService) deliver(notification system.SysNotification, receipts []system.SysNotificationReceipt) {
+	var online, offline []system.SysNotificationReceipt
+	for _, receipt := range receipts {
+		if global.GVA_WS != nil && global.GVA_WS.IsOnline(strconv.Itoa(int(receipt.UserID))) {
+			online = append(online, receipt)
+			continue
+		}
+		offline = append(offline, receipt)
+	}
+
+	channels := map[string][]uint{}
+	if len(online) > 0 {
+		userIDs := make([]uint, 0, len(online))
+		for _, receipt := range online {
+			userIDs = append(userIDs, receipt.UserID)
+		}
+		unread, err := notificationService.unreadCounts(userIDs)
+		if err != nil {
+			global.GVA_LOG.Error("查询未读通知数量失败!", zap.Error(err))
+		}
+		for _, receipt := range online {
+			global.GVA_WS.SendToUser(strconv.Itoa(int(receipt.UserID)), socket.Message{
+				Type: "notification",
+				From: socket.SystemUser,
+				Content: map[string]interface{}{
+					"receiptID":    receipt.ID,
+					"notification": notification,
+					"unreadCount":  unread[receipt.UserID],
+				},
+				Time: time.Now().Unix(),
+			})
+			channels["websocket"] = append(channels["websocket"], receipt.UserID)
+		}
+	}
+
+	// 未安装邮件插件时离线用户只保留接收记录
+	if sender := getEmailSender(); sender != nil && len(offline) > 0 {
+		userIDs := make([]uint, 0, len(offline))
+		for _, receipt := range offline {
+			userIDs = append(userIDs, receipt.UserID)
+		}
+		var users []system.SysUser
+		if err := global.GVA_DB.Select("id", "email", "origin_setting").Where("id in ?", userIDs).Find(&users).Error; err != nil {
+			global.GVA_LOG.Error("查询离线通知用户失败!", zap.Error(err))
+		}
+		for _, user := range users {
+			if user.Email == "" || !notificationEmailEnabled(user) {
+				continue
+			}
+			// 加入邮件发送队列 发送结果可在邮件插件的发送记录中查看
+			if err := sender(user.Email, notification.Title, notificationEmailBody(notification)); err != nil {
+				global.GVA_LOG.Error("发送通知邮件失败!", zap.Uint("userID", user.ID), zap.Error(err))
+				continue
+			}
+			channels["email"] = append(channels["email"], user.ID)
+		}
+	}
+
+	for channel, userIDs := range channels {
+		err := global.GVA_DB.Model(&system.SysNotificationReceipt{}).
+			Where("notification_id = ? AND user_id in ?", notification.ID, userIDs).
+			Update("channel", channel).Error
+		if err != nil {
+			global.GVA_LOG.Error("更新通知投递渠道失败!", zap.Error(err))
+		}
+	}
+}
+
+// notificationEmailEnabled 读取用户离线时是否接收邮件的偏好 默认不发送
+func notificationEmailEnabled(user system.SysUser) bool {
+	setting, ok := user.OriginSetting[system.NotificationSettingKey].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	enabled, _ := setting["emailWhenOffline"].(bool)
+	return enabled
+}
+
+// notificationEmailTemplate 离线邮件正文 字段均按html转义
+var notificationEmailTemplate = template.Must(template.New("notification").Parse(
+	`<h3>{{.Title}}</h3><div style="white-space: pre-wrap">{{.Content}}</div>{{if .Link}}<p><a href="{{.Link}}">查看详情</a></p>{{end}}`))
+
+func notificationEmailBody(notification system.SysNotification) string {
+	data := struct{ Title, Content, Link string }{Title: notification.Title, Content: notification.Content}
+	// 只允许http(s)地址 避免 javascript: 等链接
+	if u, err := url.Parse(notification.Link); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		data.Link = u.String()
+	}
+	var body bytes.Buffer
+	if err := notificationEmailTemplate.Execute(&body, data); err != nil {
+		global.GVA_LOG.Error("渲染通知邮件失败!", zap.Error(err))
+	}
+	return body.String()
+}
+
+// DeleteNotification 删除通知及其接收记录
+func (notificationService *NotificationService) DeleteNotification(ID uint) (err error) {
+	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&system.SysNotificationReceipt{}, "notification_id = ?", ID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&system.SysNotification{}, "id = ?", ID).Error
+	})
+}
+
+// GetNotificationList 分页获取已发送的通知
+func (notificationService *NotificationService) GetNotificationList(info systemReq.NotificationSearch) (list []system.SysNotification, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysNotification{})
+	if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
+		db = db.Where("created_at BETWEEN ? AND ?", info.StartCreatedAt, info.EndCreatedAt)
+	}
+	if info.Title != "" {
+		db = db.Where("title LIKE ?", "%"+info.Title+"%")
+	}
+	if info.Type != "" {
+		db = db.Where("type = ?", info.Type)
+	}
+	if info.TargetType != "" {
+		db = db.Where("target_type = ?", info.TargetType)
+	}
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("id desc").Find(&list).Error
+	return list, total, err
+}
+
+// GetMyNotificationList 分页获取当前用户的通知
+func (notificationService *NotificationService) GetMyNotificationList(userID uint, info systemReq.MyNotificationSearch) (list []system.SysNotificationReceipt, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysNotificationReceipt{}).Where("sys_notification_receipts.user_id = ?", userID)
+	switch info.Status {
+	case "unread":
+		db = db.Where("read_at IS NULL AND archived_at IS NULL")
+	case "read":
+		db = db.Where("read_at IS NOT NULL AND archived_at IS NULL")
+	case "archived":
+		db = db.Where("archived_at IS NOT NULL")
+	default:
+		db = db.Where("archived_at IS NULL")
+	}
+	if info.Type != "" {
+		db = db.Joins("Notification").Where("Notification.type = ?", info.Type)
+	}
+	err = db.Count(&total).Error
+	if err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Preload("Notification").Order("sys_notification_receipts.id desc").Find(&list).Error
+	return list, total, err
+}
+
+// MarkRead 将当前用户的通知标记为已读
+func (notificationService *NotificationService) MarkRead(userID uint, ids []uint) (err error) {
+	return global.GVA_DB.Model(&system.SysNotificationReceipt{}).
+		Where("user_id = ? AND id in ? AND read_at IS NULL", userID, ids).
+		Update("read_at", time.Now()).Error
+}
+
+// MarkAllRead 将当前用户的全部通知标记为已读
+func (notificationService *NotificationService) MarkAllRead(userID uint) (err error) {
+	return global.GVA_DB.Model(&system.SysNotificationReceipt{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+// Archive 归档当前用户的通知 归档同时视为已读
+func (notificationService *NotificationService) Archive(userID uint, ids []uint) (err error) {
+	now := time.Now()
+	return global.GVA_DB.Model(&system.SysNotificationReceipt{}).
+		Where("user_id = ? AND id in ?", userID, ids).
+		Updates(map[string]interface{}{
+			"archived_at": now,
+			"read_at":     gorm.Expr("COALESCE(read_at, ?)", now),
+		}).Error
+}
+
+// unreadCounts 按用户分组统计未读且未归档的通知数量 没有未读通知的用户不在结果中
+func (notificationService *NotificationService) unreadCounts(userIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(userIDs))
+	for start := 0; start < len(userIDs); start += 500 {
+		end := min(start+500, len(userIDs))
+		var rows []struct {
+			UserID uint
+			Count  int64
+		}
+		err := global.GVA_DB.Model(&system.SysNotificationReceipt{}).
+			Select("user_id, count(*) AS count").
+			Where("user_id in ? AND read_at IS NULL AND archived_at IS NULL", userIDs[start:end]).
+			Group("user_id").
+			Scan(&rows).Error
+		if err != nil {
+			return counts, err
+		}
+		for _, row := range rows {
+			counts[row.UserID] = row.Count
+		}
+	}
+	return counts, nil
+}
+
+// GetUnreadCount 获取当前用户未读且未归档的通知数量
+func (notificationService *NotificationService) GetUnreadCount(userID uint) (count int64, err error) {
+	err = global.GVA_DB.Model(&system.SysNotificationReceipt{}).
+		Where("user_id = ? AND read_at IS NULL AND archived_at IS NULL", userID).
+		Count(&count).Error
+	return
+}
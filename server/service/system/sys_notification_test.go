@@ -0,0 +1,162 @@
+package system
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/common"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func setupNotificationDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&system.SysUser{}, &system.SysUserAuthority{}, &system.SysNotification{}, &system.SysNotificationReceipt{}, &system.SysDepartment{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	t.Cleanup(func() { sqlDB.Close() })
+
+	departments := []system.SysDepartment{{Name: "总部"}, {Name: "研发部", ParentID: 1}}
+	db.Create(&departments)
+	users := []system.SysUser{{Username: "a", AuthorityId: 888}, {Username: "b", AuthorityId: 9528, DepartmentID: 2}, {Username: "c", AuthorityId: 9528}}
+	db.Create(&users)
+	db.Create(&[]system.SysUserAuthority{
+		{SysUserId: users[0].ID, SysAuthorityAuthorityId: 888},
+		{SysUserId: users[1].ID, SysAuthorityAuthorityId: 9528},
+		{SysUserId: users[2].ID, SysAuthorityAuthorityId: 9528},
+	})
+}
+
+func TestNotificationService(t *testing.T) {
+	setupNotificationDB(t)
+	s := &NotificationService{}
+
+	n, err := s.Send(systemReq.NotificationCreate{Title: "hello", TargetType: system.NotificationTargetAuthority, TargetIDs: []uint{9528}}, 0)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if n.Receivers != 2 {
+		t.Fatalf("expected 2 receivers, got %d", n.Receivers)
+	}
+	if _, err = s.Send(systemReq.NotificationCreate{Title: "all", TargetType: system.NotificationTargetAll}, 0); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	count, _ := s.GetUnreadCount(2)
+	if count != 2 {
+		t.Fatalf("expected 2 unread, got %d", count)
+	}
+
+	list, total, err := s.GetMyNotificationList(2, systemReq.MyNotificationSearch{})
+	if err != nil || total != 2 || list[0].Notification.Title != "all" {
+		t.Fatalf("unexpected list %v %d %v", list, total, err)
+	}
+
+	if err = s.MarkRead(2, []uint{list[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ = s.GetUnreadCount(2); count != 1 {
+		t.Fatalf("expected 1 unread, got %d", count)
+	}
+	// 其他用户不能修改不属于自己的接收记录
+	if err = s.Archive(3, []uint{list[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ = s.GetUnreadCount(2); count != 1 {
+		t.Fatalf("expected 1 unread, got %d", count)
+	}
+
+	if err = s.Archive(2, []uint{list[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ = s.GetUnreadCount(2); count != 0 {
+		t.Fatalf("expected 0 unread, got %d", count)
+	}
+	_, total, _ = s.GetMyNotificationList(2, systemReq.MyNotificationSearch{Status: "archived"})
+	if total != 1 {
+		t.Fatalf("expected 1 archived, got %d", total)
+	}
+
+	// 按上级部门发送时下级部门的用户同时接收
+	n, err = s.Send(systemReq.NotificationCreate{Title: "dept", TargetType: system.NotificationTargetDepartment, TargetIDs: []uint{1}}, 0)
+	if err != nil || n.Receivers != 1 {
+		t.Fatalf("Send() to department = %d, %v", n.Receivers, err)
+	}
+	counts, err := s.unreadCounts([]uint{1, 2, 3})
+	if err != nil || len(counts) != 3 || counts[1] != 1 || counts[2] != 1 || counts[3] != 2 {
+		t.Fatalf("unreadCounts() = %v, %v", counts, err)
+	}
+}
+
+func TestNotificationEmailSender(t *testing.T) {
+	setupNotificationDB(t)
+	global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", 2).Updates(&system.SysUser{
+		Email:         "b@example.com",
+		OriginSetting: common.JSONMap{system.NotificationSettingKey: map[string]interface{}{"emailWhenOffline": true}},
+	})
+	// 其他测试发送的通知在后台投递 只记录本测试的通知
+	sent := make(chan string, 2)
+	RegisterEmailSender(func(to, subject, body string) error {
+		if subject == "offline" {
+			sent <- to
+		}
+		return nil
+	})
+	t.Cleanup(func() { RegisterEmailSender(nil) })
+
+	s := &NotificationService{}
+	n, err := s.Send(systemReq.NotificationCreate{Title: "offline", TargetType: system.NotificationTargetAuthority, TargetIDs: []uint{9528}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-sent:
+		if got != "b@example.com" {
+			t.Fatalf("sent = %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("offline user with email enabled should receive an email")
+	}
+	// 投递渠道在发送后更新
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var receipt system.SysNotificationReceipt
+		global.GVA_DB.Where("notification_id = ? AND user_id = ?", n.ID, 2).First(&receipt)
+		if receipt.Channel == "email" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("receipt channel = %q, want email", receipt.Channel)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(sent) != 0 {
+		t.Fatalf("only users with email enabled should receive an email: %q", <-sent)
+	}
+}
+
+func TestNotificationEmailBody(t *testing.T) {
+	body := notificationEmailBody(system.SysNotification{
+		Title:   `<script>alert(1)</script>`,
+		Content: `<img src=x onerror=alert(1)>`,
+		Link:    `javascript:alert(1)`,
+	})
+	if strings.Contains(body, "<script>") || strings.Contains(body, "<img") || strings.Contains(body, "javascript:") || strings.Contains(body, "查看详情") {
+		t.Errorf("unsafe email body: %s", body)
+	}
+	body = notificationEmailBody(system.SysNotification{Title: "t", Link: `https://example.com/a?b=1&c="2"`})
+	if !strings.Contains(body, `href="https://example.com/a?b=1&amp;c=%222%22"`) {
+		t.Errorf("link not rendered: %s", body)
+	}
+}
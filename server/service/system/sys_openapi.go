@@ -0,0 +1,113 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/sdk"
+	"github.com/gin-gonic/gin"
+)
+
+// openApi 已注册的路由引擎及生成的文档 路由在启动时注册完成 文档生成一次后缓存
+// 插件启停或 sys_apis 变更时清空缓存 下次请求时重新生成
+var openApi = struct {
+	sync.RWMutex
+	engine *gin.Engine
+	info   sdk.Info
+	probe  func(method, fullPath string) []string
+	doc    map[string]any
+}{}
+
+type OpenApiService struct{}
+
+var OpenApiServiceApp = new(OpenApiService)
+
+// Register 注册用于生成文档的路由引擎 在所有路由(包括插件)注册完成后调用
+// probe 返回路由的处理链 用于判断接口是否经过 JWTAuth 及 CasbinHandler
+func (s *OpenApiService) Register(engine *gin.Engine, info sdk.Info, probe func(method, fullPath string) []string) {
+	openApi.Lock()
+	openApi.engine, openApi.info, openApi.probe, openApi.doc = engine, info, probe, nil
+	openApi.Unlock()
+}
+
+// Invalidate 清空已生成的文档 插件启停及 sys_apis 变更后调用
+func (s *OpenApiService) Invalidate() {
+	openApi.Lock()
+	defer openApi.Unlock()
+	openApi.doc = nil
+}
+
+// Document 获取 OpenAPI 3.1 文档 未生成时按当前路由表生成并缓存
+// 返回的文档由所有请求共享 调用方不能修改
+func (s *OpenApiService) Document(ctx context.Context) (map[string]any, error) {
+	openApi.RLock()
+	doc := openApi.doc
+	openApi.RUnlock()
+	if doc != nil {
+		return doc, nil
+	}
+	// 持有写锁生成 同时到达的请求等待同一次生成
+	openApi.Lock()
+	defer openApi.Unlock()
+	if openApi.doc != nil {
+		return openApi.doc, nil
+	}
+	if openApi.engine == nil {
+		return nil, errors.New("路由尚未注册")
+	}
+	doc, err := s.build(ctx, openApi.engine, openApi.info, openApi.probe)
+	if err != nil {
+		return nil, err
+	}
+	openApi.doc = doc
+	return doc, nil
+}
+
+// build 按当前路由表生成文档
+// 请求及返回结构体取自 autocode.root 下的后端源码 没有源码时只生成 sys_apis 中登记过的接口
+func (s *OpenApiService) build(ctx context.Context, engine *gin.Engine, info sdk.Info, probe func(method, fullPath string) []string) (map[string]any, error) {
+
+	prefix := global.GVA_CONFIG.System.RouterPrefix
+	apis := make(map[string]system.SysApi)
+	if global.GVA_DB != nil {
+		var list []system.SysApi
+		if err := global.GVA_DB.WithContext(ctx).Find(&list).Error; err != nil {
+			return nil, err
+		}
+		for _, api := range list {
+			apis[api.Method+" "+api.Path] = api
+		}
+	}
+
+	routes := engine.Routes()
+	list := make([]sdk.Route, 0, len(routes))
+	for _, route := range routes {
+		path := strings.TrimPrefix(route.Path, prefix)
+		api := apis[route.Method+" "+path]
+		item := sdk.Route{
+			Method:      route.Method,
+			Path:        route.Path,
+			Handler:     route.Handler,
+			Description: api.Description,
+			Group:       api.ApiGroup,
+		}
+		for _, name := range probe(route.Method, route.Path) {
+			switch {
+			case strings.Contains(name, "middleware.JWTAuth"):
+				item.Auth = true
+			case strings.Contains(name, "middleware.CasbinHandler"):
+				item.Casbin = true
+			}
+		}
+		plugin, enabled := PluginRuntimeServiceApp.RouteState(route.Method, route.Path)
+		item.Plugin, item.Disabled = plugin, !enabled
+		list = append(list, item)
+	}
+	root := filepath.Join(global.GVA_CONFIG.AutoCode.Root, global.GVA_CONFIG.AutoCode.Server)
+	return sdk.NewTypes(root, global.GVA_CONFIG.AutoCode.Module).Document(info, list), nil
+}
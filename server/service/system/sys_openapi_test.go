@@ -0,0 +1,61 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/sdk"
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenApiDocumentCache(t *testing.T) {
+	db, _ := setupPluginTest(t, nil)
+	if err := db.AutoMigrate(&model.SysPluginState{}); err != nil {
+		t.Fatal(err)
+	}
+	resetPluginRuntime(t)
+	t.Cleanup(func() {
+		resetPluginRuntime(t)
+		openApi.Lock()
+		openApi.engine, openApi.doc = nil, nil
+		openApi.Unlock()
+	})
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	engine := gin.New()
+	if err := PluginRuntimeServiceApp.Register(ctx, engine, &lifecyclePlugin{calls: map[string]int{}}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&model.SysApi{Path: "/notice/list", Method: "GET", ApiGroup: "通知", Description: "通知列表"})
+	s := &OpenApiService{}
+	s.Register(engine, sdk.Info{Title: "test"}, func(method, fullPath string) []string { return nil })
+
+	operation := func() sdk.Schema {
+		doc, err := s.Document(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		op, _ := doc["paths"].(map[string]sdk.Schema)["/notice/list"]["get"].(sdk.Schema)
+		if op == nil {
+			t.Fatalf("GET /notice/list missing from document: %v", doc["paths"])
+		}
+		return op
+	}
+	if op := operation(); op["summary"] != "通知列表" || !op["x-plugin"].(sdk.Schema)["enabled"].(bool) {
+		t.Fatalf("operation = %v", op)
+	}
+
+	// 文档已缓存 不再查询 sys_apis
+	db.Model(&model.SysApi{}).Where("path = ?", "/notice/list").Update("description", "公告列表")
+	if op := operation(); op["summary"] != "通知列表" {
+		t.Errorf("cached summary = %v", op["summary"])
+	}
+
+	if err := PluginRuntimeServiceApp.Disable(ctx, "notice"); err != nil {
+		t.Fatal(err)
+	}
+	if op := operation(); op["summary"] != "公告列表" || op["x-plugin"].(sdk.Schema)["enabled"].(bool) {
+		t.Errorf("document should be rebuilt after the plugin is disabled: %v", op)
+	}
+}
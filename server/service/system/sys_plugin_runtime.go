@@ -0,0 +1,377 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	interfaces "github.com/flipped-aurora/gin-vue-admin/server/utils/plugin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// pluginHealthTimeout 插件列表中单个插件健康检查的超时时间
+const pluginHealthTimeout = 3 * time.Second
+
+// runtimePlugin 已注册的v2插件 enabled、installed、message 由 pluginRuntime 的锁保护
+type runtimePlugin struct {
+	name      string
+	plugin    interfaces.Plugin
+	lifecycle interfaces.Lifecycle // 未实现生命周期接口时为nil 只能启停路由
+	routes    []string             // "METHOD 路径"
+	menus     map[string]bool
+	enabled   bool
+	installed bool
+	message   string
+	op        sync.Mutex // 串行执行同一插件的生命周期方法
+}
+
+// pluginRuntime v2插件的运行时注册表 启停状态持久化在 sys_plugin_states 启动时加载
+// 使用redis时通过 watcher 通知其他实例重新加载状态 否则其他实例重启后才生效
+var pluginRuntime = struct {
+	sync.RWMutex
+	plugins []*runtimePlugin
+	byName  map[string]*runtimePlugin
+	routes  map[string]*runtimePlugin
+	watcher *utils.PluginWatcher
+}{byName: map[string]*runtimePlugin{}, routes: map[string]*runtimePlugin{}}
+
+type PluginRuntimeService struct{}
+
+var PluginRuntimeServiceApp = new(PluginRuntimeService)
+
+// Register 注册v2插件 按保存的状态执行安装及启用后注册路由 路由始终注册 停用时由 RouteState 拦截
+func (s *PluginRuntimeService) Register(ctx context.Context, engine *gin.Engine, p interfaces.Plugin) error {
+	rp := &runtimePlugin{name: pluginName(p), plugin: p, menus: map[string]bool{}, enabled: true}
+	if lifecycle, ok := p.(interfaces.Lifecycle); ok {
+		rp.lifecycle, rp.name = lifecycle, lifecycle.Name()
+		for _, menu := range lifecycle.Menus() {
+			rp.menus[menu] = true
+		}
+	}
+	pluginRuntime.RLock()
+	_, exists := pluginRuntime.byName[rp.name]
+	pluginRuntime.RUnlock()
+	if exists {
+		return errors.Errorf("插件[%s]重复注册", rp.name)
+	}
+
+	state := system.SysPluginState{Name: rp.name}
+	err := global.GVA_DB.WithContext(ctx).Where("name = ?", rp.name).Attrs(system.SysPluginState{Enabled: true}).FirstOrCreate(&state).Error
+	if err != nil {
+		global.GVA_LOG.Error("读取插件状态失败, 按启用处理!", zap.String("plugin", rp.name), zap.Error(err))
+		state.Enabled = true
+	}
+	rp.enabled, rp.installed = state.Enabled, state.Installed
+	if rp.lifecycle != nil && rp.enabled {
+		if err = s.start(ctx, rp); err != nil {
+			// 只停用本次运行 保存的状态仍为启用 下次启动时重试
+			rp.enabled, rp.message = false, err.Error()
+			global.GVA_LOG.Error("启用插件失败!", zap.String("plugin", rp.name), zap.Error(err))
+		}
+	}
+
+	before := make(map[string]bool)
+	for _, route := range engine.Routes() {
+		before[route.Method+" "+route.Path] = true
+	}
+	p.Register(engine)
+	for _, route := range engine.Routes() {
+		if key := route.Method + " " + route.Path; !before[key] {
+			rp.routes = append(rp.routes, key)
+		}
+	}
+	sort.Strings(rp.routes)
+
+	pluginRuntime.Lock()
+	pluginRuntime.plugins = append(pluginRuntime.plugins, rp)
+	pluginRuntime.byName[rp.name] = rp
+	for _, route := range rp.routes {
+		pluginRuntime.routes[route] = rp
+	}
+	pluginRuntime.Unlock()
+	return s.saveState(ctx, rp, state.Enabled)
+}
+
+// Enable 启用插件 未安装时先执行安装
+func (s *PluginRuntimeService) Enable(ctx context.Context, name string) error {
+	rp, err := s.plugin(name)
+	if err != nil {
+		return err
+	}
+	defer s.publish(name)
+	rp.op.Lock()
+	defer rp.op.Unlock()
+	if s.enabled(rp) {
+		return nil
+	}
+	if rp.lifecycle != nil {
+		if err = s.start(ctx, rp); err != nil {
+			s.setState(rp, func() { rp.message = err.Error() })
+			_ = s.saveState(ctx, rp, false)
+			return err
+		}
+	}
+	s.setState(rp, func() { rp.enabled, rp.message = true, "" })
+	return s.saveState(ctx, rp, true)
+}
+
+// Disable 停用插件 停用后插件的路由返回错误 菜单对用户隐藏
+func (s *PluginRuntimeService) Disable(ctx context.Context, name string) error {
+	rp, err := s.plugin(name)
+	if err != nil {
+		return err
+	}
+	defer s.publish(name)
+	rp.op.Lock()
+	defer rp.op.Unlock()
+	if !s.enabled(rp) {
+		return nil
+	}
+	if rp.lifecycle != nil {
+		if err = rp.lifecycle.Disable(ctx); err != nil {
+			return errors.Wrapf(err, "停用插件[%s]失败", name)
+		}
+	}
+	s.setState(rp, func() { rp.enabled, rp.message = false, "" })
+	return s.saveState(ctx, rp, false)
+}
+
+// Uninstall 执行插件的卸载 清理安装时注册的数据 插件需已停用 再次启用时重新安装
+func (s *PluginRuntimeService) Uninstall(ctx context.Context, name string) error {
+	rp, err := s.plugin(name)
+	if err != nil {
+		return err
+	}
+	defer s.publish(name)
+	rp.op.Lock()
+	defer rp.op.Unlock()
+	if rp.lifecycle == nil {
+		return errors.Errorf("插件[%s]未实现生命周期接口, 不支持卸载", name)
+	}
+	if s.enabled(rp) {
+		return errors.Errorf("插件[%s]正在运行, 请先停用", name)
+	}
+	if err = rp.lifecycle.Uninstall(ctx); err != nil {
+		return errors.Wrapf(err, "卸载插件[%s]失败", name)
+	}
+	s.setState(rp, func() { rp.installed = false })
+	return s.saveState(ctx, rp, false)
+}
+
+// Watch 订阅其他实例的插件状态变更 在所有插件注册完成后调用
+func (s *PluginRuntimeService) Watch(rdb redis.UniversalClient) error {
+	w, err := utils.NewPluginWatcher(rdb, func(name string) {
+		if err := s.reload(context.Background(), name); err != nil {
+			global.GVA_LOG.Error("同步插件状态失败!", zap.String("plugin", name), zap.Error(err))
+		}
+	})
+	if err != nil {
+		return err
+	}
+	pluginRuntime.Lock()
+	defer pluginRuntime.Unlock()
+	if pluginRuntime.watcher != nil {
+		pluginRuntime.watcher.Close()
+	}
+	pluginRuntime.watcher = w
+	return nil
+}
+
+// publish 通知其他实例重新加载插件状态 在 op 锁释放后调用 保证状态已保存
+func (s *PluginRuntimeService) publish(name string) {
+	pluginRuntime.RLock()
+	w := pluginRuntime.watcher
+	pluginRuntime.RUnlock()
+	if w != nil {
+		_ = w.Publish(name)
+	}
+}
+
+// reload 按 sys_plugin_states 加载其他实例修改的插件状态 并在本实例执行启用或停用
+// 安装及卸载已由发起的实例完成 本实例只同步安装状态
+func (s *PluginRuntimeService) reload(ctx context.Context, name string) error {
+	rp, err := s.plugin(name)
+	if err != nil {
+		return err
+	}
+	rp.op.Lock()
+	defer rp.op.Unlock()
+	var state system.SysPluginState
+	if err = global.GVA_DB.WithContext(ctx).Where("name = ?", name).First(&state).Error; err != nil {
+		return errors.Wrapf(err, "读取插件[%s]状态失败", name)
+	}
+	enabled, message := s.enabled(rp), state.Message
+	if rp.lifecycle != nil && state.Enabled != enabled {
+		if state.Enabled {
+			err = errors.Wrapf(rp.lifecycle.Enable(ctx), "启用插件[%s]失败", name)
+		} else {
+			err = errors.Wrapf(rp.lifecycle.Disable(ctx), "停用插件[%s]失败", name)
+		}
+	}
+	if err != nil {
+		message = err.Error()
+		// 本实例启用失败时保持停用 停用失败时同样拦截路由 与保存的状态一致
+		state.Enabled = false
+	}
+	s.setState(rp, func() { rp.enabled, rp.installed, rp.message = state.Enabled, state.Installed, message })
+	return err
+}
+
+// Health 执行插件的健康检查 未实现生命周期接口的插件视为正常
+func (s *PluginRuntimeService) Health(ctx context.Context, name string) error {
+	rp, err := s.plugin(name)
+	if err != nil {
+		return err
+	}
+	if rp.lifecycle == nil {
+		return nil
+	}
+	return rp.lifecycle.Health(ctx)
+}
+
+// Plugins 已注册的v2插件及其状态 对已启用的插件执行健康检查
+func (s *PluginRuntimeService) Plugins(ctx context.Context) []response.PluginRuntime {
+	pluginRuntime.RLock()
+	plugins := append([]*runtimePlugin(nil), pluginRuntime.plugins...)
+	pluginRuntime.RUnlock()
+
+	list := make([]response.PluginRuntime, 0, len(plugins))
+	for _, rp := range plugins {
+		pluginRuntime.RLock()
+		item := response.PluginRuntime{
+			Name:      rp.name,
+			Lifecycle: rp.lifecycle != nil,
+			Enabled:   rp.enabled,
+			Installed: rp.installed || rp.lifecycle == nil,
+			Healthy:   true,
+			Message:   rp.message,
+			Routes:    rp.routes,
+			Menus:     []string{},
+			Config:    []response.PluginConfigField{},
+		}
+		pluginRuntime.RUnlock()
+		for menu := range rp.menus {
+			item.Menus = append(item.Menus, menu)
+		}
+		sort.Strings(item.Menus)
+		if rp.lifecycle != nil {
+			for _, field := range rp.lifecycle.ConfigSchema() {
+				configured := global.GVA_VP != nil && global.GVA_VP.IsSet(field.Key)
+				item.Config = append(item.Config, response.PluginConfigField{ConfigField: field, Configured: configured})
+			}
+			if item.Enabled {
+				hctx, cancel := context.WithTimeout(ctx, pluginHealthTimeout)
+				if err := rp.lifecycle.Health(hctx); err != nil {
+					item.Healthy, item.Health = false, err.Error()
+				}
+				cancel()
+			}
+		}
+		list = append(list, item)
+	}
+	return list
+}
+
+// RouteState 返回路由所属的插件及是否启用 不属于插件的路由视为启用
+func (s *PluginRuntimeService) RouteState(method, fullPath string) (name string, enabled bool) {
+	pluginRuntime.RLock()
+	defer pluginRuntime.RUnlock()
+	rp, ok := pluginRuntime.routes[method+" "+fullPath]
+	if !ok {
+		return "", true
+	}
+	return rp.name, rp.enabled
+}
+
+// MenuHidden 菜单是否属于已停用的插件 插件声明的菜单及组件位于 plugin/{插件名}/ 下的菜单属于该插件
+func (s *PluginRuntimeService) MenuHidden(menu system.SysBaseMenu) bool {
+	pluginRuntime.RLock()
+	defer pluginRuntime.RUnlock()
+	for _, rp := range pluginRuntime.plugins {
+		if rp.enabled {
+			continue
+		}
+		if rp.menus[menu.Name] || strings.HasPrefix(menu.Component, "plugin/"+rp.name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// start 未安装时执行安装 然后执行启用
+func (s *PluginRuntimeService) start(ctx context.Context, rp *runtimePlugin) error {
+	pluginRuntime.RLock()
+	installed := rp.installed
+	pluginRuntime.RUnlock()
+	if !installed {
+		if err := rp.lifecycle.Install(ctx); err != nil {
+			return errors.Wrapf(err, "安装插件[%s]失败", rp.name)
+		}
+		s.setState(rp, func() { rp.installed = true })
+	}
+	if err := rp.lifecycle.Enable(ctx); err != nil {
+		return errors.Wrapf(err, "启用插件[%s]失败", rp.name)
+	}
+	return nil
+}
+
+func (s *PluginRuntimeService) plugin(name string) (*runtimePlugin, error) {
+	pluginRuntime.RLock()
+	defer pluginRuntime.RUnlock()
+	rp, ok := pluginRuntime.byName[name]
+	if !ok {
+		return nil, errors.Errorf("插件[%s]未注册", name)
+	}
+	return rp, nil
+}
+
+func (s *PluginRuntimeService) enabled(rp *runtimePlugin) bool {
+	pluginRuntime.RLock()
+	defer pluginRuntime.RUnlock()
+	return rp.enabled
+}
+
+// setState 修改插件状态 并清空已生成的 OpenAPI 文档
+// 生成文档时会读取插件状态 需在释放锁之后清空 避免与生成文档互相等待
+func (s *PluginRuntimeService) setState(rp *runtimePlugin, update func()) {
+	pluginRuntime.Lock()
+	update()
+	pluginRuntime.Unlock()
+	OpenApiServiceApp.Invalidate()
+}
+
+// saveState 保存插件状态 enabled 为期望的启用状态
+func (s *PluginRuntimeService) saveState(ctx context.Context, rp *runtimePlugin, enabled bool) error {
+	pluginRuntime.RLock()
+	values := map[string]interface{}{"enabled": enabled, "installed": rp.installed, "message": rp.message}
+	pluginRuntime.RUnlock()
+	err := global.GVA_DB.WithContext(ctx).Model(&system.SysPluginState{}).Where("name = ?", rp.name).Updates(values).Error
+	if err != nil {
+		return errors.Wrapf(err, "保存插件[%s]状态失败", rp.name)
+	}
+	return nil
+}
+
+// pluginName 未实现生命周期接口的插件以包路径的最后一段作为插件名
+func pluginName(p interfaces.Plugin) string {
+	t := reflect.TypeOf(p)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return fmt.Sprintf("%T", p)
+	}
+	return path.Base(t.PkgPath())
+}
@@ -0,0 +1,228 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	model "github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	interfaces "github.com/flipped-aurora/gin-vue-admin/server/utils/plugin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// lifecyclePlugin 记录生命周期方法调用次数的测试插件
+type lifecyclePlugin struct {
+	interfaces.Base
+	calls     map[string]int
+	enableErr error
+}
+
+func (p *lifecyclePlugin) Name() string { return "notice" }
+
+func (p *lifecyclePlugin) Register(engine *gin.Engine) {
+	engine.GET("/notice/list", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.POST("/notice/create", func(c *gin.Context) { c.Status(http.StatusOK) })
+}
+
+func (p *lifecyclePlugin) Install(context.Context) error   { p.calls["install"]++; return nil }
+func (p *lifecyclePlugin) Disable(context.Context) error   { p.calls["disable"]++; return nil }
+func (p *lifecyclePlugin) Uninstall(context.Context) error { p.calls["uninstall"]++; return nil }
+func (p *lifecyclePlugin) Enable(context.Context) error {
+	p.calls["enable"]++
+	return p.enableErr
+}
+func (p *lifecyclePlugin) Health(context.Context) error { return errors.New("表不存在") }
+func (p *lifecyclePlugin) Menus() []string              { return []string{"noticeRoot"} }
+func (p *lifecyclePlugin) ConfigSchema() []interfaces.ConfigField {
+	return []interfaces.ConfigField{{Key: "notice.channel", Type: "string", Required: true}}
+}
+
+// plainPlugin 未实现生命周期接口的插件
+type plainPlugin struct{}
+
+func (p *plainPlugin) Register(engine *gin.Engine) {
+	engine.GET("/plain/list", func(c *gin.Context) { c.Status(http.StatusOK) })
+}
+
+// resetPluginRuntime 清空进程内的插件注册表 模拟服务重启
+func resetPluginRuntime(t *testing.T) {
+	pluginRuntime.Lock()
+	defer pluginRuntime.Unlock()
+	pluginRuntime.plugins = nil
+	pluginRuntime.byName = map[string]*runtimePlugin{}
+	pluginRuntime.routes = map[string]*runtimePlugin{}
+	if pluginRuntime.watcher != nil {
+		pluginRuntime.watcher.Close()
+		pluginRuntime.watcher = nil
+	}
+}
+
+func TestPluginRuntimeService(t *testing.T) {
+	db, _ := setupPluginTest(t, nil)
+	if err := db.AutoMigrate(&model.SysPluginState{}); err != nil {
+		t.Fatal(err)
+	}
+	resetPluginRuntime(t)
+	t.Cleanup(func() { resetPluginRuntime(t) })
+	gin.SetMode(gin.TestMode)
+	s := &PluginRuntimeService{}
+	ctx := context.Background()
+
+	p := &lifecyclePlugin{calls: map[string]int{}}
+	engine := gin.New()
+	if err := s.Register(ctx, engine, p); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(ctx, engine, &plainPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(ctx, engine, p); err == nil {
+		t.Error("Register() should reject duplicate plugins")
+	}
+	if p.calls["install"] != 1 || p.calls["enable"] != 1 {
+		t.Errorf("Register() calls = %v, want install and enable once", p.calls)
+	}
+	if name, enabled := s.RouteState("GET", "/notice/list"); name != "notice" || !enabled {
+		t.Errorf("RouteState() = %s %v", name, enabled)
+	}
+
+	plugins := s.Plugins(ctx)
+	if len(plugins) != 2 || plugins[0].Name != "notice" || plugins[1].Name != "system" || plugins[1].Lifecycle {
+		t.Fatalf("Plugins() = %+v", plugins)
+	}
+	if len(plugins[0].Routes) != 2 || plugins[0].Healthy || plugins[0].Health != "表不存在" || len(plugins[0].Config) != 1 || plugins[0].Config[0].Configured {
+		t.Errorf("Plugins()[0] = %+v", plugins[0])
+	}
+
+	if err := s.Uninstall(ctx, "notice"); err == nil || !strings.Contains(err.Error(), "请先停用") {
+		t.Errorf("Uninstall() of an enabled plugin = %v", err)
+	}
+	if err := s.Disable(ctx, "notice"); err != nil {
+		t.Fatal(err)
+	}
+	if name, enabled := s.RouteState("POST", "/notice/create"); name != "notice" || enabled {
+		t.Errorf("RouteState() after Disable = %s %v", name, enabled)
+	}
+	for _, tt := range []struct {
+		menu model.SysBaseMenu
+		want bool
+	}{
+		{model.SysBaseMenu{Name: "noticeRoot", Component: "view/routerHolder.vue"}, true},
+		{model.SysBaseMenu{Name: "noticeList", Component: "plugin/notice/view/list.vue"}, true},
+		{model.SysBaseMenu{Name: "noticeX", Component: "plugin/noticeX/view/list.vue"}, false},
+		{model.SysBaseMenu{Name: "dashboard", Component: "view/dashboard/index.vue"}, false},
+	} {
+		if got := s.MenuHidden(tt.menu); got != tt.want {
+			t.Errorf("MenuHidden(%s) = %v, want %v", tt.menu.Name, got, tt.want)
+		}
+	}
+	if err := s.Uninstall(ctx, "notice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Uninstall(ctx, "system"); err == nil {
+		t.Error("Uninstall() should reject plugins without lifecycle")
+	}
+
+	// 重启后保持停用 不执行安装及启用
+	resetPluginRuntime(t)
+	p.calls = map[string]int{}
+	engine = gin.New()
+	if err := s.Register(ctx, engine, p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.calls) != 0 {
+		t.Errorf("Register() of a disabled plugin calls = %v", p.calls)
+	}
+	if _, enabled := s.RouteState("GET", "/notice/list"); enabled {
+		t.Error("disabled state should survive a restart")
+	}
+
+	// 卸载后再次启用会重新安装 启用失败时保持停用并记录原因
+	p.enableErr = errors.New("连接失败")
+	if err := s.Enable(ctx, "notice"); err == nil {
+		t.Fatal("Enable() should return the plugin error")
+	}
+	var state model.SysPluginState
+	global.GVA_DB.First(&state, "name = ?", "notice")
+	if state.Enabled || !state.Installed || !strings.Contains(state.Message, "连接失败") || p.calls["install"] != 1 {
+		t.Errorf("state after failed Enable() = %+v, calls = %v", state, p.calls)
+	}
+	p.enableErr = nil
+	if err := s.Enable(ctx, "notice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, enabled := s.RouteState("GET", "/notice/list"); !enabled || p.calls["install"] != 1 {
+		t.Errorf("Enable() should not reinstall, calls = %v", p.calls)
+	}
+	if err := s.Enable(ctx, "missing"); err == nil {
+		t.Error("Enable() of an unknown plugin should fail")
+	}
+}
+
+func TestPluginRuntimeWatch(t *testing.T) {
+	db, _ := setupPluginTest(t, nil)
+	if err := db.AutoMigrate(&model.SysPluginState{}); err != nil {
+		t.Fatal(err)
+	}
+	resetPluginRuntime(t)
+	t.Cleanup(func() { resetPluginRuntime(t) })
+	gin.SetMode(gin.TestMode)
+	s := &PluginRuntimeService{}
+	ctx := context.Background()
+	p := &lifecyclePlugin{calls: map[string]int{}}
+	if err := s.Register(ctx, gin.New(), p); err != nil {
+		t.Fatal(err)
+	}
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	if err := s.Watch(rdb); err != nil {
+		t.Fatal(err)
+	}
+	// other 模拟另一个实例
+	received := make(chan string, 1)
+	other, err := utils.NewPluginWatcher(rdb, func(name string) { received <- name })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	if err = s.Disable(ctx, "notice"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case name := <-received:
+		if name != "notice" {
+			t.Errorf("published plugin = %s", name)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Disable() should notify other instances")
+	}
+
+	// 另一个实例启用后 本实例执行启用并放行路由
+	db.Model(&model.SysPluginState{}).Where("name = ?", "notice").Update("enabled", true)
+	if err = other.Publish("notice"); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, enabled := s.RouteState("GET", "/notice/list"); enabled {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("plugin should be enabled after another instance enabled it")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if p.calls["enable"] != 2 || p.calls["install"] != 1 {
+		t.Errorf("calls after reload = %v, want enable without install", p.calls)
+	}
+}
@@ -0,0 +1,202 @@
+package system
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ratelimit"
+)
+
+type RateLimitService struct{}
+
+var RateLimitServiceApp = new(RateLimitService)
+
+// rateLimitKeyBys 可用的计数维度
+var rateLimitKeyBys = map[string]bool{
+	ratelimit.KeyByIP:        true,
+	ratelimit.KeyByUser:      true,
+	ratelimit.KeyByAuthority: true,
+	ratelimit.KeyByRoute:     true,
+}
+
+// defaultRateLimitRule 按 system.iplimit-count 和 iplimit-time 对每个IP限流 两者均大于0时生效
+func defaultRateLimitRule() (ratelimit.Rule, bool) {
+	cfg := global.GVA_CONFIG
+	if cfg.System.LimitCountIP <= 0 || cfg.System.LimitTimeIP <= 0 {
+		return ratelimit.Rule{}, false
+	}
+	return ratelimit.Rule{
+		Name:      "默认IP限流",
+		KeyBy:     ratelimit.KeyByIP,
+		Algorithm: cfg.RateLimit.Algorithm,
+		Limit:     cfg.System.LimitCountIP,
+		Window:    time.Duration(cfg.System.LimitTimeIP) * time.Second,
+	}, true
+}
+
+func checkRateLimitRule(rule *system.SysRateLimitRule) error {
+	if !rateLimitKeyBys[rule.KeyBy] {
+		return errors.New("计数维度只能为 ip|user|authority|route")
+	}
+	switch rule.Algorithm {
+	case "":
+		rule.Algorithm = ratelimit.TokenBucket
+	case ratelimit.TokenBucket, ratelimit.SlidingWindow:
+	default:
+		return errors.New("限流算法只能为 token_bucket|sliding_window")
+	}
+	if rule.Limit <= 0 || rule.Window <= 0 {
+		return errors.New("请求次数和时间窗口必须大于0")
+	}
+	rule.Method = strings.ToUpper(rule.Method)
+	if rule.Path != "" {
+		db := global.GVA_DB.Model(&system.SysApi{}).Where("path = ?", rule.Path)
+		if rule.Method != "" {
+			db = db.Where("method = ?", rule.Method)
+		}
+		var count int64
+		if err := db.Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("接口不存在 请先在api管理中添加")
+		}
+	}
+	return nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: CreateRateLimitRule
+//@description: 创建限流规则 创建后立即生效
+//@param: rule *system.SysRateLimitRule
+//@return: err error
+
+func (rateLimitService *RateLimitService) CreateRateLimitRule(rule *system.SysRateLimitRule) (err error) {
+	if err = checkRateLimitRule(rule); err != nil {
+		return err
+	}
+	if err = global.GVA_DB.Create(rule).Error; err != nil {
+		return err
+	}
+	return rateLimitService.LoadRateLimitRules()
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: DeleteRateLimitRule
+//@description: 删除限流规则
+//@param: id uint
+//@return: err error
+
+func (rateLimitService *RateLimitService) DeleteRateLimitRule(id uint) (err error) {
+	if err = global.GVA_DB.Delete(&system.SysRateLimitRule{}, "id = ?", id).Error; err != nil {
+		return err
+	}
+	return rateLimitService.LoadRateLimitRules()
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: UpdateRateLimitRule
+//@description: 更新限流规则
+//@param: rule system.SysRateLimitRule
+//@return: err error
+
+func (rateLimitService *RateLimitService) UpdateRateLimitRule(rule system.SysRateLimitRule) (err error) {
+	if err = checkRateLimitRule(&rule); err != nil {
+		return err
+	}
+	err = global.GVA_DB.Model(&system.SysRateLimitRule{}).Where("id = ?", rule.ID).
+		Select("name", "key_by", "target", "method", "path", "algorithm", "limit_count", "window_seconds", "enable", "sort").
+		Updates(&rule).Error
+	if err != nil {
+		return err
+	}
+	return rateLimitService.LoadRateLimitRules()
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetRateLimitRuleList
+//@description: 分页获取限流规则
+//@param: info systemReq.RateLimitRuleSearch
+//@return: list []system.SysRateLimitRule, total int64, err error
+
+func (rateLimitService *RateLimitService) GetRateLimitRuleList(info systemReq.RateLimitRuleSearch) (list []system.SysRateLimitRule, total int64, err error) {
+	limit := info.PageSize
+	offset := info.PageSize * (info.Page - 1)
+	db := global.GVA_DB.Model(&system.SysRateLimitRule{})
+	if info.Name != "" {
+		db = db.Where("name LIKE ?", "%"+info.Name+"%")
+	}
+	if info.KeyBy != "" {
+		db = db.Where("key_by = ?", info.KeyBy)
+	}
+	if info.Path != "" {
+		db = db.Where("path LIKE ?", "%"+info.Path+"%")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	if limit != 0 {
+		db = db.Limit(limit).Offset(offset)
+	}
+	err = db.Order("sort, id").Find(&list).Error
+	return list, total, err
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: LoadRateLimitRules
+//@description: 加载默认IP限流及已启用的限流规则 多实例部署时由定时任务定期同步
+//@return: err error
+
+func (rateLimitService *RateLimitService) LoadRateLimitRules() (err error) {
+	var rules []ratelimit.Rule
+	if rule, ok := defaultRateLimitRule(); ok {
+		rules = append(rules, rule)
+	}
+	if global.GVA_DB != nil {
+		var list []system.SysRateLimitRule
+		if err = global.GVA_DB.Where("enable = ?", true).Order("sort, id").Find(&list).Error; err != nil {
+			return err
+		}
+		for _, r := range list {
+			rules = append(rules, ratelimit.Rule{
+				ID:        r.ID,
+				Name:      r.Name,
+				KeyBy:     r.KeyBy,
+				Target:    r.Target,
+				Method:    r.Method,
+				Path:      r.Path,
+				Algorithm: r.Algorithm,
+				Limit:     r.Limit,
+				Window:    time.Duration(r.Window) * time.Second,
+			})
+		}
+	}
+	ratelimit.Default().SetRules(rules)
+	return nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetRateLimitMetrics
+//@description: 获取当前实例各限流规则的判断及拒绝次数
+//@return: res systemRes.RateLimitMetrics
+
+func (rateLimitService *RateLimitService) GetRateLimitMetrics() (res systemRes.RateLimitMetrics) {
+	res.Enable = global.GVA_CONFIG.RateLimit.Enable
+	res.Rules = []systemRes.RateLimitMetric{}
+	for _, m := range ratelimit.Default().Metrics() {
+		res.Rejected += m.Rejected
+		res.Rules = append(res.Rules, systemRes.RateLimitMetric{
+			RuleID:         m.RuleID,
+			Name:           m.Name,
+			Checked:        m.Checked,
+			Rejected:       m.Rejected,
+			LastRejectedAt: m.LastRejectedAt,
+		})
+	}
+	return res
+}
@@ -34,9 +34,29 @@ func (userService *UserService) Register(u system.SysUser) (userInter system.Sys
 	u.Password = utils.BcryptHash(u.Password)
 	u.UUID = uuid.New()
 	err = global.GVA_DB.Create(&u).Error
+	if err == nil {
+		utils.GlobalSystemEvents.Publish(utils.EventUserCreated, userEventData(u))
+	}
 	return u, err
 }
 
+// userEventData 用户事件内容 不包含密码及联系方式
+func userEventData(u system.SysUser) map[string]interface{} {
+	authorityIds := make([]uint, 0, len(u.Authorities))
+	for _, a := range u.Authorities {
+		authorityIds = append(authorityIds, a.AuthorityId)
+	}
+	return map[string]interface{}{
+		"userId":       u.ID,
+		"uuid":         u.UUID,
+		"username":     u.Username,
+		"nickName":     u.NickName,
+		"authorityId":  u.AuthorityId,
+		"authorityIds": authorityIds,
+		"enable":       u.Enable,
+	}
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@author: [SliverHorn](https://github.com/SliverHorn)
 //@function: Login
@@ -169,7 +189,7 @@ func (userService *UserService) SetUserAuthority(id uint, authorityId uint) (err
 //@return: err error
 
 func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, authorityIds []uint) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		var user system.SysUser
 		TxErr := tx.Where("id = ?", id).First(&user).Error
 		if TxErr != nil {
@@ -201,6 +221,12 @@ func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, au
 		// 返回 nil 提交事务
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	utils.GlobalSystemEvents.Publish(utils.EventUserAuthoritiesChanged, map[string]interface{}{"userId": id, "authorityIds": authorityIds})
+	// 角色变更后用户需要重新登录
+	return utils.BumpTokenVersion(id)
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -210,7 +236,7 @@ func (userService *UserService) SetUserAuthorities(adminAuthorityID, id uint, au
 //@return: err error
 
 func (userService *UserService) DeleteUser(id int) (err error) {
-	return global.GVA_DB.Transaction(func(tx *gorm.DB) error {
+	err = global.GVA_DB.Transaction(func(tx *gorm.DB) error {
 		if err := tx.Where("id = ?", id).Delete(&system.SysUser{}).Error; err != nil {
 			return err
 		}
@@ -219,6 +245,11 @@ func (userService *UserService) DeleteUser(id int) (err error) {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	utils.GlobalSystemEvents.Publish(utils.EventUserDeleted, map[string]interface{}{"userId": id})
+	return utils.BumpTokenVersion(uint(id))
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -228,17 +259,32 @@ func (userService *UserService) DeleteUser(id int) (err error) {
 //@return: err error, user model.SysUser
 
 func (userService *UserService) SetUserInfo(req system.SysUser) error {
-	return global.GVA_DB.Model(&system.SysUser{}).
-		Select("updated_at", "nick_name", "header_img", "phone", "email", "enable").
+	var old system.SysUser
+	if err := global.GVA_DB.Select("id", "enable").Where("id = ?", req.ID).First(&old).Error; err != nil {
+		return err
+	}
+	err := global.GVA_DB.Model(&system.SysUser{}).
+		Select("updated_at", "nick_name", "header_img", "phone", "email", "enable", "department_id").
 		Where("id=?", req.ID).
 		Updates(map[string]interface{}{
-			"updated_at": time.Now(),
-			"nick_name":  req.NickName,
-			"header_img": req.HeaderImg,
-			"phone":      req.Phone,
-			"email":      req.Email,
-			"enable":     req.Enable,
+			"updated_at":    time.Now(),
+			"nick_name":     req.NickName,
+			"header_img":    req.HeaderImg,
+			"phone":         req.Phone,
+			"email":         req.Email,
+			"enable":        req.Enable,
+			"department_id": req.DepartmentID,
 		}).Error
+	if err != nil || old.Enable == req.Enable {
+		return err
+	}
+	event := utils.EventUserEnabled
+	if req.Enable == 2 {
+		event = utils.EventUserDisabled
+	}
+	utils.GlobalSystemEvents.Publish(event, map[string]interface{}{"userId": req.ID, "enable": req.Enable})
+	// 冻结或解冻用户后 之前签发的令牌失效
+	return utils.BumpTokenVersion(req.ID)
 }
 
 //@author: [piexlmax](https://github.com/piexlmax)
@@ -314,5 +360,8 @@ func (userService *UserService) FindUserByUuid(uuid string) (user *system.SysUse
 
 func (userService *UserService) ResetPassword(ID uint, password string) (err error) {
 	err = global.GVA_DB.Model(&system.SysUser{}).Where("id = ?", ID).Update("password", utils.BcryptHash(password)).Error
-	return err
+	if err != nil {
+		return err
+	}
+	return utils.BumpTokenVersion(ID)
 }
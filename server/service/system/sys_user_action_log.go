@@ -2,8 +2,8 @@ package system
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/flipped-aurora/gin-vue-admin/server/global"
@@ -15,51 +15,129 @@ import (
 	"go.uber.org/zap"
 )
 
-type UserActionLogService struct{}
+// UserActionLogStore 用户操作日志存储
+type UserActionLogStore interface {
+	Init(ctx context.Context) error                                                                            // 初始化索引或表结构
+	Save(ctx context.Context, logs []system.UserActionLog) error                                               // 批量写入 相同ID重复写入不产生重复记录
+	Get(ctx context.Context, id string) (*system.UserActionLog, error)                                         // 获取单条日志
+	Search(ctx context.Context, req *request.UserActionLogSearch) (*response.UserActionLogListResponse, error) // 搜索日志
+	Stats(ctx context.Context, req *request.UserActionLogStats) (*response.UserActionLogStatsResponse, error)  // 分组统计
+	Delete(ctx context.Context, id string) error                                                               // 删除单条日志
+	Drop(ctx context.Context) error                                                                            // 删除全部日志
+}
 
-// InitIndex 初始化索引（创建mapping）
-func (s *UserActionLogService) InitIndex() error {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return fmt.Errorf("ES客户端未初始化")
+const (
+	ActionLogStoreElasticsearch = "elasticsearch"
+	ActionLogStoreGorm          = "gorm"
+	ActionLogStoreMongo         = "mongo"
+)
+
+var (
+	actionLogStoresMu sync.RWMutex
+	actionLogStores   = map[string]func() (UserActionLogStore, error){
+		ActionLogStoreElasticsearch: func() (UserActionLogStore, error) {
+			client := elasticsearch.NewESClient()
+			if client == nil {
+				return nil, fmt.Errorf("ES客户端未初始化")
+			}
+			return &esActionLogStore{client: client}, nil
+		},
+		ActionLogStoreGorm: func() (UserActionLogStore, error) {
+			if global.GVA_DB == nil {
+				return nil, fmt.Errorf("数据库未初始化")
+			}
+			return &gormActionLogStore{db: global.GVA_DB}, nil
+		},
+		ActionLogStoreMongo: func() (UserActionLogStore, error) {
+			if global.GVA_MONGO == nil {
+				return nil, fmt.Errorf("MongoDB未初始化")
+			}
+			return &mongoActionLogStore{coll: global.GVA_MONGO.Database.Collection(system.UserActionLog{}.TableName())}, nil
+		},
 	}
+)
 
-	log := system.UserActionLog{}
-	indexName := log.GetESIndexName()
+// RegisterUserActionLogStore 注册用户操作日志存储 通过 action-log.store 配置选择
+func RegisterUserActionLogStore(name string, factory func() (UserActionLogStore, error)) {
+	actionLogStoresMu.Lock()
+	defer actionLogStoresMu.Unlock()
+	actionLogStores[name] = factory
+}
 
-	// 检查索引是否存在
-	exists, err := client.IndexExists(ctx, indexName)
-	if err != nil {
-		global.GVA_LOG.Error("检查索引失败", zap.Error(err))
-		return err
+// actionLogGroupFields 允许统计分组的字段 user 为 username 的别名
+var actionLogGroupFields = map[string]string{
+	"":         "action",
+	"action":   "action",
+	"module":   "module",
+	"user":     "username",
+	"username": "username",
+	"user_id":  "user_id",
+	"method":   "method",
+	"status":   "status",
+	"ip":       "ip",
+	"path":     "path",
+}
+
+func actionLogGroupField(groupBy string) (string, error) {
+	field, ok := actionLogGroupFields[groupBy]
+	if !ok {
+		return "", fmt.Errorf("不支持的分组字段: %s", groupBy)
 	}
+	return field, nil
+}
 
-	if exists {
-		global.GVA_LOG.Info("索引已存在", zap.String("index", indexName))
-		return nil
+// actionLogSortFields 允许排序的字段
+var actionLogSortFields = map[string]bool{
+	"create_time": true,
+	"latency":     true,
+	"status":      true,
+	"user_id":     true,
+	"username":    true,
+	"action":      true,
+	"module":      true,
+}
+
+// parseActionLogTime 解析查询条件中的时间 支持日期时间、RFC3339及日期格式
+func parseActionLogTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.DateTime, time.RFC3339, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
 	}
+	return time.Time{}, fmt.Errorf("无法解析时间: %s", value)
+}
 
-	// 创建索引和mapping
-	mapping := log.GetESMapping()
-	if err := client.CreateIndex(ctx, indexName, mapping); err != nil {
-		global.GVA_LOG.Error("创建索引失败", zap.Error(err))
-		return err
+type UserActionLogService struct{}
+
+// store 按配置获取用户操作日志存储 未配置时开启ES则使用ES 否则使用数据库
+func (s *UserActionLogService) store() (UserActionLogStore, error) {
+	name := global.GVA_CONFIG.ActionLog.Store
+	if name == "" {
+		name = ActionLogStoreGorm
+		if global.GVA_CONFIG.System.UseElasticsearch {
+			name = ActionLogStoreElasticsearch
+		}
+	}
+	actionLogStoresMu.RLock()
+	factory, ok := actionLogStores[name]
+	actionLogStoresMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("未注册的用户操作日志存储: %s", name)
 	}
+	return factory()
+}
 
-	global.GVA_LOG.Info("索引创建成功", zap.String("index", indexName))
-	return nil
+// InitIndex 初始化存储（ES索引、数据库表或MongoDB索引）
+func (s *UserActionLogService) InitIndex() error {
+	store, err := s.store()
+	if err != nil {
+		return err
+	}
+	return store.Init(context.Background())
 }
 
 // CreateLog 创建日志
 func (s *UserActionLogService) CreateLog(req *request.UserActionLogCreate) error {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return fmt.Errorf("ES客户端未初始化")
-	}
-
-	// 构建日志对象
 	log := system.UserActionLog{
 		ID:         uuid.New().String(),
 		UserID:     req.UserID,
@@ -77,11 +155,7 @@ func (s *UserActionLogService) CreateLog(req *request.UserActionLogCreate) error
 		ErrorMsg:   req.ErrorMsg,
 		CreateTime: time.Now(),
 	}
-
-	// 索引文档
-	indexName := log.GetESIndexName()
-	if err := client.IndexDocument(ctx, indexName, log.ID, log); err != nil {
-		global.GVA_LOG.Error("创建日志失败", zap.Error(err))
+	if err := s.BatchCreateLogs([]system.UserActionLog{log}); err != nil {
 		return err
 	}
 
@@ -91,177 +165,32 @@ func (s *UserActionLogService) CreateLog(req *request.UserActionLogCreate) error
 
 // GetLog 获取单条日志
 func (s *UserActionLogService) GetLog(id string) (*system.UserActionLog, error) {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return nil, fmt.Errorf("ES客户端未初始化")
-	}
-
-	log := system.UserActionLog{}
-	indexName := log.GetESIndexName()
-
-	var result system.UserActionLog
-	if err := client.GetDocument(ctx, indexName, id, &result); err != nil {
-		global.GVA_LOG.Error("获取日志失败", zap.Error(err))
+	store, err := s.store()
+	if err != nil {
 		return nil, err
 	}
-
-	return &result, nil
+	return store.Get(context.Background(), id)
 }
 
 // SearchLogs 搜索日志
 func (s *UserActionLogService) SearchLogs(req *request.UserActionLogSearch) (*response.UserActionLogListResponse, error) {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return nil, fmt.Errorf("ES客户端未初始化")
-	}
-
-	log := system.UserActionLog{}
-	indexName := log.GetESIndexName()
-
-	// 构建查询条件
-	boolQuery := &elasticsearch.BoolQuery{
-		Must: []map[string]interface{}{},
-	}
-
-	// 用户ID精确匹配
-	if req.UserID != nil {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("user_id", *req.UserID))
-	}
-
-	// 用户名模糊搜索
-	if req.Username != "" {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.MatchQuery("username", req.Username))
-	}
-
-	// 操作动作
-	if req.Action != "" {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("action", req.Action))
-	}
-
-	// 操作模块
-	if req.Module != "" {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("module", req.Module))
-	}
-
-	// 请求方法
-	if req.Method != "" {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("method", req.Method))
-	}
-
-	// IP地址
-	if req.IP != "" {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("ip", req.IP))
-	}
-
-	// 状态码
-	if req.Status != nil {
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("status", *req.Status))
-	}
-
-	// 时间范围
-	if req.StartTime != "" || req.EndTime != "" {
-		var gte, lte interface{}
-		if req.StartTime != "" {
-			gte = req.StartTime
-		}
-		if req.EndTime != "" {
-			lte = req.EndTime
-		}
-		boolQuery.Must = append(boolQuery.Must, elasticsearch.RangeQuery("create_time", gte, lte))
-	}
-
-	// 关键词搜索（搜索path和error_msg）
-	if req.Keyword != "" {
-		keywordQuery := &elasticsearch.BoolQuery{
-			Should: []map[string]interface{}{
-				elasticsearch.MatchQuery("path", req.Keyword),
-				elasticsearch.MatchQuery("error_msg", req.Keyword),
-			},
-			MinimumShouldMatch: 1,
-		}
-		boolQuery.Must = append(boolQuery.Must, keywordQuery.ToMap())
-	}
-
-	// 如果没有任何查询条件，使用match_all
-	var query map[string]interface{}
-	if len(boolQuery.Must) == 0 {
-		query = elasticsearch.MatchAllQuery()
-	} else {
-		query = boolQuery.ToMap()
-	}
-
-	// 构建排序
-	sort := []map[string]interface{}{}
-	if req.OrderField != "" {
-		orderType := "desc"
-		if req.OrderType == "asc" {
-			orderType = "asc"
-		}
-		sort = append(sort, map[string]interface{}{
-			req.OrderField: map[string]interface{}{
-				"order": orderType,
-			},
-		})
-	} else {
-		// 默认按创建时间降序
-		sort = append(sort, map[string]interface{}{
-			"create_time": map[string]interface{}{
-				"order": "desc",
-			},
-		})
-	}
-
-	// 构建搜索请求
-	searchReq := &elasticsearch.SearchRequest{
-		Query: query,
-		From:  (req.Page - 1) * req.PageSize,
-		Size:  req.PageSize,
-		Sort:  sort,
-	}
-
-	// 执行搜索
-	result, err := client.Search(ctx, indexName, searchReq)
+	store, err := s.store()
 	if err != nil {
-		global.GVA_LOG.Error("搜索日志失败", zap.Error(err))
 		return nil, err
 	}
-
-	// 解析结果
-	logs := make([]system.UserActionLog, 0, len(result.Hits.Hits))
-	for _, hit := range result.Hits.Hits {
-		var log system.UserActionLog
-		// 将map转为json再转为struct
-		jsonData, _ := json.Marshal(hit.Source)
-		if err := json.Unmarshal(jsonData, &log); err != nil {
-			global.GVA_LOG.Warn("解析日志失败", zap.Error(err))
-			continue
-		}
-		logs = append(logs, log)
+	if req.OrderField != "" && !actionLogSortFields[req.OrderField] {
+		return nil, fmt.Errorf("不支持的排序字段: %s", req.OrderField)
 	}
-
-	return &response.UserActionLogListResponse{
-		List:     logs,
-		Total:    result.Hits.Total.Value,
-		Page:     req.Page,
-		PageSize: req.PageSize,
-	}, nil
+	return store.Search(context.Background(), req)
 }
 
 // DeleteLog 删除日志
 func (s *UserActionLogService) DeleteLog(id string) error {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return fmt.Errorf("ES客户端未初始化")
+	store, err := s.store()
+	if err != nil {
+		return err
 	}
-
-	log := system.UserActionLog{}
-	indexName := log.GetESIndexName()
-
-	if err := client.DeleteDocument(ctx, indexName, id); err != nil {
-		global.GVA_LOG.Error("删除日志失败", zap.Error(err))
+	if err = store.Delete(context.Background(), id); err != nil {
 		return err
 	}
 
@@ -271,130 +200,52 @@ func (s *UserActionLogService) DeleteLog(id string) error {
 
 // BatchCreateLogs 批量创建日志
 func (s *UserActionLogService) BatchCreateLogs(logs []system.UserActionLog) error {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return fmt.Errorf("ES客户端未初始化")
-	}
-
 	if len(logs) == 0 {
 		return nil
 	}
+	store, err := s.store()
+	if err != nil {
+		return err
+	}
 
-	// 转换为map格式，添加id字段
-	documents := make([]map[string]interface{}, 0, len(logs))
-	for _, log := range logs {
-		if log.ID == "" {
-			log.ID = uuid.New().String()
+	for i := range logs {
+		if logs[i].ID == "" {
+			logs[i].ID = uuid.New().String()
 		}
-		if log.CreateTime.IsZero() {
-			log.CreateTime = time.Now()
+		if logs[i].CreateTime.IsZero() {
+			logs[i].CreateTime = time.Now()
 		}
-
-		// 转换为map
-		jsonData, _ := json.Marshal(log)
-		var doc map[string]interface{}
-		json.Unmarshal(jsonData, &doc)
-		doc["id"] = log.ID // 添加id字段用于批量操作
-
-		documents = append(documents, doc)
-	}
-
-	// 批量索引
-	indexName := logs[0].GetESIndexName()
-	if err := client.BulkIndexDocument(ctx, indexName, documents); err != nil {
-		global.GVA_LOG.Error("批量创建日志失败", zap.Error(err))
-		return err
 	}
-
-	global.GVA_LOG.Info("批量创建日志成功", zap.Int("count", len(logs)))
-	return nil
+	return store.Save(context.Background(), logs)
 }
 
 // GetStats 获取统计数据
 func (s *UserActionLogService) GetStats(req *request.UserActionLogStats) (*response.UserActionLogStatsResponse, error) {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return nil, fmt.Errorf("ES客户端未初始化")
-	}
-
-	log := system.UserActionLog{}
-	indexName := log.GetESIndexName()
-
-	// 设置默认分组字段
-	groupByField := "action"
-	if req.GroupBy != "" {
-		groupByField = req.GroupBy
-	}
-
-	// 构建聚合查询
-	aggs := map[string]interface{}{
-		"group_stats": map[string]interface{}{
-			"terms": map[string]interface{}{
-				"field": groupByField,
-				"size":  20,
-			},
-		},
-	}
-
-	// 执行聚合
-	aggResult, err := client.Aggregate(ctx, indexName, aggs)
+	store, err := s.store()
 	if err != nil {
-		global.GVA_LOG.Error("统计查询失败", zap.Error(err))
 		return nil, err
 	}
-
-	// 解析聚合结果
-	stats := make([]map[string]interface{}, 0)
-	if groupStats, ok := aggResult["group_stats"].(map[string]interface{}); ok {
-		if buckets, ok := groupStats["buckets"].([]interface{}); ok {
-			for _, bucket := range buckets {
-				if b, ok := bucket.(map[string]interface{}); ok {
-					stats = append(stats, b)
-				}
-			}
-		}
-	}
-
-	// 获取总数
-	var gte, lte interface{}
-	if req.StartTime != "" {
-		gte = req.StartTime
-	}
-	if req.EndTime != "" {
-		lte = req.EndTime
-	}
-	query := elasticsearch.RangeQuery("create_time", gte, lte)
-
-	total, err := client.Count(ctx, indexName, query)
-	if err != nil {
-		global.GVA_LOG.Warn("统计总数失败", zap.Error(err))
-		total = 0
-	}
-
-	return &response.UserActionLogStatsResponse{
-		Total: total,
-		Stats: stats,
-	}, nil
+	return store.Stats(context.Background(), req)
 }
 
-// DeleteIndex 删除索引（危险操作，谨慎使用）
+// DeleteIndex 删除全部日志（危险操作，谨慎使用）
 func (s *UserActionLogService) DeleteIndex() error {
-	ctx := context.Background()
-	client := elasticsearch.NewESClient()
-	if client == nil {
-		return fmt.Errorf("ES客户端未初始化")
+	store, err := s.store()
+	if err != nil {
+		return err
 	}
+	return store.Drop(context.Background())
+}
 
-	log := system.UserActionLog{}
-	indexName := log.GetESIndexName()
-
-	if err := client.DeleteIndex(ctx, indexName); err != nil {
-		global.GVA_LOG.Error("删除索引失败", zap.Error(err))
+// ApplyRetention 滚动写入索引并清理过期索引 仅ES存储需要处理
+func (s *UserActionLogService) ApplyRetention() error {
+	store, err := s.store()
+	if err != nil {
 		return err
 	}
-
-	global.GVA_LOG.Info("索引删除成功", zap.String("index", indexName))
-	return nil
+	es, ok := store.(*esActionLogStore)
+	if !ok {
+		return nil
+	}
+	return es.ApplyRetention(context.Background())
 }
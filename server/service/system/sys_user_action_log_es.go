@@ -0,0 +1,506 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/elasticsearch"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// esActionLogStore 基于Elasticsearch的用户操作日志存储 按周期写入时间索引
+type esActionLogStore struct {
+	client *elasticsearch.ESClient
+}
+
+var (
+	actionLogIndexMu    sync.Mutex
+	actionLogWriteIndex string // 已确认可以写入的当前周期索引
+	actionLogLegacyKept bool   // 旧版同名索引仍占用别名的名称 此时直接写入周期索引
+)
+
+// Init 初始化索引（创建当前周期索引并挂载写入别名）
+// 旧版本使用与别名同名的单一索引 会先迁移到当前周期索引 文档数量一致后删除
+func (s *esActionLogStore) Init(ctx context.Context) error {
+	log := system.UserActionLog{}
+	alias := log.GetESIndexName()
+	actionLogIndexMu.Lock()
+	actionLogLegacyKept = false
+	actionLogIndexMu.Unlock()
+
+	// 检查是否存在旧版单一索引
+	exists, err := s.client.IndexExists(ctx, alias)
+	if err != nil {
+		global.GVA_LOG.Error("检查索引失败", zap.Error(err))
+		return err
+	}
+	if exists {
+		isAlias, err := s.client.IsAlias(ctx, alias)
+		if err != nil {
+			global.GVA_LOG.Error("检查别名失败", zap.Error(err))
+			return err
+		}
+		if !isAlias {
+			if err := s.migrateLegacyIndex(ctx); err != nil {
+				global.GVA_LOG.Error("迁移旧索引失败", zap.Error(err))
+				return err
+			}
+		}
+	}
+
+	actionLogIndexMu.Lock()
+	actionLogWriteIndex = ""
+	actionLogIndexMu.Unlock()
+	if _, err := s.ensureWriteIndex(ctx); err != nil {
+		global.GVA_LOG.Error("创建索引失败", zap.Error(err))
+		return err
+	}
+
+	global.GVA_LOG.Info("索引初始化成功", zap.String("alias", alias))
+	return nil
+}
+
+// migrateLegacyIndex 将旧版单一索引的数据复制到当前周期索引 文档数量一致时删除旧索引 使别名可以使用该名称
+// 数量不一致时保留旧索引 日志继续直接写入周期索引 下次启动时重新迁移
+func (s *esActionLogStore) migrateLegacyIndex(ctx context.Context) error {
+	log := system.UserActionLog{}
+	legacy := log.GetESIndexName()
+
+	// 先将写入切换到周期索引 复制期间的新日志不会写入即将删除的旧索引
+	actionLogIndexMu.Lock()
+	actionLogLegacyKept, actionLogWriteIndex = true, ""
+	actionLogIndexMu.Unlock()
+	index, err := s.ensureWriteIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = s.client.ReIndex(ctx, legacy, index); err != nil {
+		return err
+	}
+	if err = s.client.Refresh(ctx, legacy, index); err != nil {
+		return err
+	}
+	source, err := s.client.Count(ctx, legacy, nil)
+	if err != nil {
+		return err
+	}
+	dest, err := s.client.Count(ctx, index, nil)
+	if err != nil {
+		return err
+	}
+	// 周期索引还包括复制期间写入的新日志 数量不少于旧索引时才删除旧索引
+	if dest < source {
+		global.GVA_LOG.Error("旧索引迁移后文档数量不一致 已保留旧索引",
+			zap.String("from", legacy), zap.String("to", index), zap.Int64("source", source), zap.Int64("dest", dest))
+		return nil
+	}
+	if err = s.client.DeleteIndex(ctx, legacy); err != nil {
+		return err
+	}
+	actionLogIndexMu.Lock()
+	actionLogLegacyKept, actionLogWriteIndex = false, ""
+	actionLogIndexMu.Unlock()
+	global.GVA_LOG.Info("旧索引迁移完成", zap.String("from", legacy), zap.String("to", index), zap.Int64("count", source))
+	return nil
+}
+
+// ensureWriteIndex 确保当前周期的索引已创建并挂载为写入索引 返回写入别名
+// 旧版同名索引仍存在时无法创建别名 返回周期索引本身
+func (s *esActionLogStore) ensureWriteIndex(ctx context.Context) (string, error) {
+	log := system.UserActionLog{}
+	alias := log.GetESIndexName()
+	index := log.GetESWriteIndex(time.Now())
+
+	actionLogIndexMu.Lock()
+	defer actionLogIndexMu.Unlock()
+	target := alias
+	if actionLogLegacyKept {
+		target = index
+	}
+	if actionLogWriteIndex == index {
+		return target, nil
+	}
+	if actionLogLegacyKept {
+		exists, err := s.client.IndexExists(ctx, index)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			if err = s.client.CreateIndex(ctx, index, log.GetESMapping()); err != nil {
+				return "", err
+			}
+		}
+	} else if err := s.client.EnsureWriteIndex(ctx, alias, index, log.GetESMapping()); err != nil {
+		return "", err
+	}
+	actionLogWriteIndex = index
+	return target, nil
+}
+
+// findLog 在全部周期索引中按ID查找日志 返回所在索引
+func (s *esActionLogStore) findLog(ctx context.Context, id string) (string, map[string]interface{}, error) {
+	log := system.UserActionLog{}
+	result, err := s.client.Search(ctx, log.GetESIndexPattern(), &elasticsearch.SearchRequest{
+		Query: elasticsearch.BuildIdsQuery([]string{id}),
+		Size:  1,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(result.Hits.Hits) == 0 {
+		return "", nil, fmt.Errorf("文档不存在")
+	}
+	hit := result.Hits.Hits[0]
+	return hit.Index, hit.Source, nil
+}
+
+// Get 获取单条日志
+func (s *esActionLogStore) Get(ctx context.Context, id string) (*system.UserActionLog, error) {
+	_, source, err := s.findLog(ctx, id)
+	if err != nil {
+		global.GVA_LOG.Error("获取日志失败", zap.Error(err))
+		return nil, err
+	}
+
+	var result system.UserActionLog
+	jsonData, _ := json.Marshal(source)
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Search 搜索日志
+func (s *esActionLogStore) Search(ctx context.Context, req *request.UserActionLogSearch) (*response.UserActionLogListResponse, error) {
+	log := system.UserActionLog{}
+	indexName := log.GetESIndexPattern()
+
+	// 构建查询条件
+	boolQuery := &elasticsearch.BoolQuery{
+		Must: []map[string]interface{}{},
+	}
+
+	// 用户ID精确匹配
+	if req.UserID != nil {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("user_id", *req.UserID))
+	}
+
+	// 用户名模糊搜索
+	if req.Username != "" {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.MatchQuery("username", req.Username))
+	}
+
+	// 操作动作
+	if req.Action != "" {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("action", req.Action))
+	}
+
+	// 操作模块
+	if req.Module != "" {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("module", req.Module))
+	}
+
+	// 请求方法
+	if req.Method != "" {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("method", req.Method))
+	}
+
+	// IP地址
+	if req.IP != "" {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("ip", req.IP))
+	}
+
+	// 状态码
+	if req.Status != nil {
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.TermQuery("status", *req.Status))
+	}
+
+	// 时间范围
+	if req.StartTime != "" || req.EndTime != "" {
+		var gte, lte interface{}
+		if req.StartTime != "" {
+			gte = req.StartTime
+		}
+		if req.EndTime != "" {
+			lte = req.EndTime
+		}
+		boolQuery.Must = append(boolQuery.Must, elasticsearch.RangeQuery("create_time", gte, lte))
+	}
+
+	// 关键词搜索（搜索path和error_msg）
+	if req.Keyword != "" {
+		keywordQuery := &elasticsearch.BoolQuery{
+			Should: []map[string]interface{}{
+				elasticsearch.MatchQuery("path", req.Keyword),
+				elasticsearch.MatchQuery("error_msg", req.Keyword),
+			},
+			MinimumShouldMatch: 1,
+		}
+		boolQuery.Must = append(boolQuery.Must, keywordQuery.ToMap())
+	}
+
+	// 如果没有任何查询条件，使用match_all
+	var query map[string]interface{}
+	if len(boolQuery.Must) == 0 {
+		query = elasticsearch.MatchAllQuery()
+	} else {
+		query = boolQuery.ToMap()
+	}
+
+	// 构建排序
+	sort := []map[string]interface{}{}
+	if req.OrderField != "" {
+		orderType := "desc"
+		if req.OrderType == "asc" {
+			orderType = "asc"
+		}
+		sort = append(sort, map[string]interface{}{
+			req.OrderField: map[string]interface{}{
+				"order": orderType,
+			},
+		})
+	} else {
+		// 默认按创建时间降序
+		sort = append(sort, map[string]interface{}{
+			"create_time": map[string]interface{}{
+				"order": "desc",
+			},
+		})
+	}
+
+	// 构建搜索请求
+	searchReq := &elasticsearch.SearchRequest{
+		Query: query,
+		From:  (req.Page - 1) * req.PageSize,
+		Size:  req.PageSize,
+		Sort:  sort,
+	}
+
+	// 执行搜索
+	result, err := s.client.Search(ctx, indexName, searchReq)
+	if err != nil {
+		global.GVA_LOG.Error("搜索日志失败", zap.Error(err))
+		return nil, err
+	}
+
+	// 解析结果
+	logs := make([]system.UserActionLog, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		var log system.UserActionLog
+		// 将map转为json再转为struct
+		jsonData, _ := json.Marshal(hit.Source)
+		if err := json.Unmarshal(jsonData, &log); err != nil {
+			global.GVA_LOG.Warn("解析日志失败", zap.Error(err))
+			continue
+		}
+		logs = append(logs, log)
+	}
+
+	return &response.UserActionLogListResponse{
+		List:     logs,
+		Total:    result.Hits.Total.Value,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// Delete 删除日志
+func (s *esActionLogStore) Delete(ctx context.Context, id string) error {
+	indexName, _, err := s.findLog(ctx, id)
+	if err != nil {
+		global.GVA_LOG.Error("删除日志失败", zap.Error(err))
+		return err
+	}
+
+	if err := s.client.DeleteDocument(ctx, indexName, id); err != nil {
+		global.GVA_LOG.Error("删除日志失败", zap.Error(err))
+		return err
+	}
+
+	global.GVA_LOG.Info("日志删除成功", zap.String("id", id))
+	return nil
+}
+
+// Save 批量写入日志
+func (s *esActionLogStore) Save(ctx context.Context, logs []system.UserActionLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	// 转换为map格式，添加id字段
+	documents := make([]map[string]interface{}, 0, len(logs))
+	for _, log := range logs {
+		if log.ID == "" {
+			log.ID = uuid.New().String()
+		}
+		if log.CreateTime.IsZero() {
+			log.CreateTime = time.Now()
+		}
+
+		// 转换为map
+		jsonData, _ := json.Marshal(log)
+		var doc map[string]interface{}
+		json.Unmarshal(jsonData, &doc)
+		doc["id"] = log.ID // 添加id字段用于批量操作
+
+		documents = append(documents, doc)
+	}
+
+	// 通过写入别名批量索引
+	indexName, err := s.ensureWriteIndex(ctx)
+	if err != nil {
+		global.GVA_LOG.Error("准备写入索引失败", zap.Error(err))
+		return err
+	}
+	if err := s.client.BulkIndexDocument(ctx, indexName, documents); err != nil {
+		global.GVA_LOG.Error("批量创建日志失败", zap.Error(err))
+		return err
+	}
+
+	global.GVA_LOG.Info("批量创建日志成功", zap.Int("count", len(logs)))
+	return nil
+}
+
+// Stats 获取统计数据
+func (s *esActionLogStore) Stats(ctx context.Context, req *request.UserActionLogStats) (*response.UserActionLogStatsResponse, error) {
+	log := system.UserActionLog{}
+	indexName := log.GetESIndexPattern()
+
+	// 分组字段 path 为text类型 需要使用keyword子字段聚合
+	groupByField, err := actionLogGroupField(req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	if groupByField == "path" {
+		groupByField = "path.keyword"
+	}
+
+	// 构建聚合查询
+	aggs := map[string]interface{}{
+		"group_stats": map[string]interface{}{
+			"terms": map[string]interface{}{
+				"field": groupByField,
+				"size":  20,
+			},
+		},
+	}
+
+	// 执行聚合
+	aggResult, err := s.client.Aggregate(ctx, indexName, aggs)
+	if err != nil {
+		global.GVA_LOG.Error("统计查询失败", zap.Error(err))
+		return nil, err
+	}
+
+	// 解析聚合结果
+	stats := make([]map[string]interface{}, 0)
+	if groupStats, ok := aggResult["group_stats"].(map[string]interface{}); ok {
+		if buckets, ok := groupStats["buckets"].([]interface{}); ok {
+			for _, bucket := range buckets {
+				if b, ok := bucket.(map[string]interface{}); ok {
+					stats = append(stats, b)
+				}
+			}
+		}
+	}
+
+	// 获取总数
+	var gte, lte interface{}
+	if req.StartTime != "" {
+		gte = req.StartTime
+	}
+	if req.EndTime != "" {
+		lte = req.EndTime
+	}
+	query := elasticsearch.RangeQuery("create_time", gte, lte)
+
+	total, err := s.client.Count(ctx, indexName, query)
+	if err != nil {
+		global.GVA_LOG.Warn("统计总数失败", zap.Error(err))
+		total = 0
+	}
+
+	return &response.UserActionLogStatsResponse{
+		Total: total,
+		Stats: stats,
+	}, nil
+}
+
+// Drop 删除全部周期索引（危险操作，谨慎使用）
+func (s *esActionLogStore) Drop(ctx context.Context) error {
+	log := system.UserActionLog{}
+	indices, err := s.client.ListIndices(ctx, log.GetESIndexPattern())
+	if err != nil {
+		global.GVA_LOG.Error("获取索引列表失败", zap.Error(err))
+		return err
+	}
+
+	// 集群默认禁止通配删除 逐个按名称删除
+	for _, index := range indices {
+		if err := s.client.DeleteIndex(ctx, index); err != nil {
+			global.GVA_LOG.Error("删除索引失败", zap.Error(err))
+			return err
+		}
+	}
+
+	actionLogIndexMu.Lock()
+	actionLogWriteIndex = ""
+	actionLogIndexMu.Unlock()
+
+	global.GVA_LOG.Info("索引删除成功", zap.Strings("indices", indices))
+	return nil
+}
+
+// ApplyRetention 滚动写入索引并处理超出保留期限的周期索引
+// retention-action 为 snapshot 时先将过期索引快照到配置的仓库再删除
+func (s *esActionLogStore) ApplyRetention(ctx context.Context) error {
+	if _, err := s.ensureWriteIndex(ctx); err != nil {
+		global.GVA_LOG.Error("滚动写入索引失败", zap.Error(err))
+		return err
+	}
+
+	cfg := global.GVA_CONFIG.Elasticsearch
+	if cfg.RetentionDays <= 0 {
+		return nil
+	}
+
+	log := system.UserActionLog{}
+	alias := log.GetESIndexName()
+	indices, err := s.client.ListIndices(ctx, log.GetESIndexPattern())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expired := elasticsearch.ExpiredIndices(alias, indices, time.Duration(cfg.RetentionDays)*24*time.Hour, now)
+	if len(expired) == 0 {
+		return nil
+	}
+
+	if cfg.RetentionAction == "snapshot" {
+		if cfg.SnapshotRepository == "" {
+			return fmt.Errorf("未配置快照仓库 snapshot-repository")
+		}
+		snapshot := alias + "-" + now.Format("20060102150405")
+		if err := s.client.CreateSnapshot(ctx, cfg.SnapshotRepository, snapshot, expired); err != nil {
+			return err
+		}
+	}
+
+	for _, index := range expired {
+		if err := s.client.DeleteIndex(ctx, index); err != nil {
+			return err
+		}
+	}
+	global.GVA_LOG.Info("过期索引清理完成", zap.Strings("indices", expired), zap.String("action", cfg.RetentionAction))
+	return nil
+}
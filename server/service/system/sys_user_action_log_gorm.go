@@ -0,0 +1,170 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// gormActionLogStore 基于数据库的用户操作日志存储 适用于未部署ES的场景
+type gormActionLogStore struct {
+	db *gorm.DB
+}
+
+// Init 创建日志表
+func (s *gormActionLogStore) Init(ctx context.Context) error {
+	return s.db.WithContext(ctx).AutoMigrate(&system.UserActionLog{})
+}
+
+// Save 批量写入日志 ID已存在的记录忽略 保证重试及补写不会重复
+func (s *gormActionLogStore) Save(ctx context.Context, logs []system.UserActionLog) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&logs, 200).Error
+}
+
+// Get 获取单条日志
+func (s *gormActionLogStore) Get(ctx context.Context, id string) (*system.UserActionLog, error) {
+	var log system.UserActionLog
+	err := s.db.WithContext(ctx).Where("id = ?", id).First(&log).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("文档不存在")
+	}
+	return &log, err
+}
+
+// filterTime 构建时间范围条件
+func (s *gormActionLogStore) filterTime(db *gorm.DB, startTime, endTime string) (*gorm.DB, error) {
+	if startTime != "" {
+		start, err := parseActionLogTime(startTime)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("create_time >= ?", start)
+	}
+	if endTime != "" {
+		end, err := parseActionLogTime(endTime)
+		if err != nil {
+			return nil, err
+		}
+		db = db.Where("create_time <= ?", end)
+	}
+	return db, nil
+}
+
+// Search 搜索日志
+func (s *gormActionLogStore) Search(ctx context.Context, req *request.UserActionLogSearch) (*response.UserActionLogListResponse, error) {
+	db := s.db.WithContext(ctx).Model(&system.UserActionLog{})
+	if req.UserID != nil {
+		db = db.Where("user_id = ?", *req.UserID)
+	}
+	if req.Username != "" {
+		db = db.Where("username LIKE ?", "%"+req.Username+"%")
+	}
+	if req.Action != "" {
+		db = db.Where("action = ?", req.Action)
+	}
+	if req.Module != "" {
+		db = db.Where("module = ?", req.Module)
+	}
+	if req.Method != "" {
+		db = db.Where("method = ?", req.Method)
+	}
+	if req.IP != "" {
+		db = db.Where("ip = ?", req.IP)
+	}
+	if req.Status != nil {
+		db = db.Where("status = ?", *req.Status)
+	}
+	db, err := s.filterTime(db, req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if req.Keyword != "" {
+		db = db.Where("(path LIKE ? OR error_msg LIKE ?)", "%"+req.Keyword+"%", "%"+req.Keyword+"%")
+	}
+
+	var total int64
+	if err = db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	order := "create_time desc"
+	if req.OrderField != "" {
+		order = req.OrderField + " desc"
+		if req.OrderType == "asc" {
+			order = req.OrderField + " asc"
+		}
+	}
+	logs := make([]system.UserActionLog, 0)
+	if req.PageSize > 0 {
+		db = db.Limit(req.PageSize).Offset((req.Page - 1) * req.PageSize)
+	}
+	if err = db.Order(order).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return &response.UserActionLogListResponse{
+		List:     logs,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// Stats 获取统计数据
+func (s *gormActionLogStore) Stats(ctx context.Context, req *request.UserActionLogStats) (*response.UserActionLogStatsResponse, error) {
+	field, err := actionLogGroupField(req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	db, err := s.filterTime(s.db.WithContext(ctx).Model(&system.UserActionLog{}), req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err = db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		GroupKey string
+		DocCount int64
+	}
+	err = db.Select(field + " AS group_key, COUNT(*) AS doc_count").
+		Group(field).Order("doc_count desc").Limit(20).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, map[string]interface{}{"key": row.GroupKey, "doc_count": row.DocCount})
+	}
+	return &response.UserActionLogStatsResponse{
+		Total: total,
+		Stats: stats,
+	}, nil
+}
+
+// Delete 删除单条日志
+func (s *gormActionLogStore) Delete(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Where("id = ?", id).Delete(&system.UserActionLog{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("文档不存在")
+	}
+	return nil
+}
+
+// Drop 清空日志表
+func (s *gormActionLogStore) Drop(ctx context.Context) error {
+	return s.db.WithContext(ctx).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&system.UserActionLog{}).Error
+}
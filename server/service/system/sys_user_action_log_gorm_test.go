@@ -0,0 +1,76 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestGormActionLogStore(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	ctx := context.Background()
+	store := &gormActionLogStore{db: db}
+	if err = store.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	logs := []system.UserActionLog{
+		{ID: "1", UserID: 1, Username: "admin", Action: "创建用户", Module: "用户", Path: "/user/admin_register", CreateTime: now},
+		{ID: "2", UserID: 1, Username: "admin", Action: "删除用户", Module: "用户", Path: "/user/deleteUser", ErrorMsg: "删除失败", CreateTime: now},
+		{ID: "3", UserID: 2, Username: "test", Action: "新增菜单", Module: "菜单", Path: "/menu/addBaseMenu", CreateTime: now.Add(-48 * time.Hour)},
+	}
+	if err = store.Save(ctx, logs); err != nil {
+		t.Fatal(err)
+	}
+	// 重试写入相同ID的日志不会产生重复记录
+	if err = store.Save(ctx, logs[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := store.Search(ctx, &request.UserActionLogSearch{Username: "adm"})
+	if err != nil || res.Total != 2 {
+		t.Fatalf("search by username: %v %v", res, err)
+	}
+	res, err = store.Search(ctx, &request.UserActionLogSearch{Keyword: "失败"})
+	if err != nil || res.Total != 1 || res.List[0].ID != "2" {
+		t.Fatalf("search by keyword: %v %v", res, err)
+	}
+	res, err = store.Search(ctx, &request.UserActionLogSearch{StartTime: now.Add(-time.Hour).Format(time.DateTime)})
+	if err != nil || res.Total != 2 {
+		t.Fatalf("search by time: %v %v", res, err)
+	}
+
+	stats, err := store.Stats(ctx, &request.UserActionLogStats{GroupBy: "module"})
+	if err != nil || stats.Total != 3 || len(stats.Stats) != 2 || stats.Stats[0]["key"] != "用户" {
+		t.Fatalf("stats: %v %v", stats, err)
+	}
+	if _, err = store.Stats(ctx, &request.UserActionLogStats{GroupBy: "id; drop table x"}); err == nil {
+		t.Fatalf("unexpected group field accepted")
+	}
+
+	if err = store.Delete(ctx, "3"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get(ctx, "3"); err == nil {
+		t.Fatalf("deleted log still exists")
+	}
+	if err = store.Drop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if res, _ = store.Search(ctx, &request.UserActionLogSearch{}); res.Total != 0 {
+		t.Fatalf("expected empty store, got %d", res.Total)
+	}
+}
@@ -0,0 +1,191 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/qiniu/qmgo"
+	qmgoOptions "github.com/qiniu/qmgo/options"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoActionLogStore 基于MongoDB的用户操作日志存储
+type mongoActionLogStore struct {
+	coll *qmgo.Collection
+}
+
+// Init 创建常用查询字段的索引
+func (s *mongoActionLogStore) Init(ctx context.Context) error {
+	return s.coll.CreateIndexes(ctx, []qmgoOptions.IndexModel{
+		{Key: []string{"-create_time"}},
+		{Key: []string{"user_id"}},
+		{Key: []string{"action"}},
+		{Key: []string{"module"}},
+	})
+}
+
+// Save 批量写入日志 ID已存在的文档忽略 保证重试及补写不会重复
+func (s *mongoActionLogStore) Save(ctx context.Context, logs []system.UserActionLog) error {
+	_, err := s.coll.InsertMany(ctx, logs, qmgoOptions.InsertManyOptions{
+		InsertManyOptions: options.InsertMany().SetOrdered(false),
+	})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+	return nil
+}
+
+// Get 获取单条日志
+func (s *mongoActionLogStore) Get(ctx context.Context, id string) (*system.UserActionLog, error) {
+	var log system.UserActionLog
+	err := s.coll.Find(ctx, bson.M{"_id": id}).One(&log)
+	if qmgo.IsErrNoDocuments(err) {
+		return nil, fmt.Errorf("文档不存在")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// filterTime 构建时间范围条件
+func (s *mongoActionLogStore) filterTime(filter bson.M, startTime, endTime string) error {
+	createTime := bson.M{}
+	if startTime != "" {
+		start, err := parseActionLogTime(startTime)
+		if err != nil {
+			return err
+		}
+		createTime["$gte"] = start
+	}
+	if endTime != "" {
+		end, err := parseActionLogTime(endTime)
+		if err != nil {
+			return err
+		}
+		createTime["$lte"] = end
+	}
+	if len(createTime) > 0 {
+		filter["create_time"] = createTime
+	}
+	return nil
+}
+
+// Search 搜索日志
+func (s *mongoActionLogStore) Search(ctx context.Context, req *request.UserActionLogSearch) (*response.UserActionLogListResponse, error) {
+	filter := bson.M{}
+	if req.UserID != nil {
+		filter["user_id"] = *req.UserID
+	}
+	if req.Username != "" {
+		filter["username"] = bson.M{"$regex": regexp.QuoteMeta(req.Username), "$options": "i"}
+	}
+	if req.Action != "" {
+		filter["action"] = req.Action
+	}
+	if req.Module != "" {
+		filter["module"] = req.Module
+	}
+	if req.Method != "" {
+		filter["method"] = req.Method
+	}
+	if req.IP != "" {
+		filter["ip"] = req.IP
+	}
+	if req.Status != nil {
+		filter["status"] = *req.Status
+	}
+	if err := s.filterTime(filter, req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+	if req.Keyword != "" {
+		keyword := bson.M{"$regex": regexp.QuoteMeta(req.Keyword), "$options": "i"}
+		filter["$or"] = []bson.M{{"path": keyword}, {"error_msg": keyword}}
+	}
+
+	total, err := s.coll.Find(ctx, filter).Count()
+	if err != nil {
+		return nil, err
+	}
+
+	sort := "-create_time"
+	if req.OrderField != "" {
+		sort = "-" + req.OrderField
+		if req.OrderType == "asc" {
+			sort = req.OrderField
+		}
+	}
+	query := s.coll.Find(ctx, filter).Sort(sort)
+	if req.PageSize > 0 {
+		query = query.Skip(int64((req.Page - 1) * req.PageSize)).Limit(int64(req.PageSize))
+	}
+	logs := make([]system.UserActionLog, 0)
+	if err = query.All(&logs); err != nil {
+		return nil, err
+	}
+
+	return &response.UserActionLogListResponse{
+		List:     logs,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}, nil
+}
+
+// Stats 获取统计数据
+func (s *mongoActionLogStore) Stats(ctx context.Context, req *request.UserActionLogStats) (*response.UserActionLogStatsResponse, error) {
+	field, err := actionLogGroupField(req.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.M{}
+	if err = s.filterTime(filter, req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+
+	total, err := s.coll.Find(ctx, filter).Count()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []bson.M
+	err = s.coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "doc_count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.M{"doc_count": -1}}},
+		{{Key: "$limit", Value: 20}},
+	}).All(&rows)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, map[string]interface{}{"key": row["_id"], "doc_count": row["doc_count"]})
+	}
+	return &response.UserActionLogStatsResponse{
+		Total: total,
+		Stats: stats,
+	}, nil
+}
+
+// Delete 删除单条日志
+func (s *mongoActionLogStore) Delete(ctx context.Context, id string) error {
+	err := s.coll.RemoveId(ctx, id)
+	if errors.Is(err, qmgo.ErrNoSuchDocuments) {
+		return fmt.Errorf("文档不存在")
+	}
+	return err
+}
+
+// Drop 删除日志集合
+func (s *mongoActionLogStore) Drop(ctx context.Context) error {
+	return s.coll.DropCollection(ctx)
+}
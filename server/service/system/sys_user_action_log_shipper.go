@@ -0,0 +1,286 @@
+package system
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// actionLogShipper 异步批量写入用户操作日志
+// 写入失败或队列已满时写入本地暂存文件 由单独的协程按指数退避补写 不阻塞消费队列
+type actionLogShipper struct {
+	queue    chan system.UserActionLog
+	sink     func([]system.UserActionLog) error
+	batch    int
+	interval time.Duration
+	retries  int
+	spoolDir string
+	done     chan struct{}
+	stopped  chan struct{}
+
+	overflowMu sync.Mutex
+	overflow   []system.UserActionLog // 队列已满时的日志 满一批或每个周期写入暂存
+}
+
+var (
+	actionLogShipperOnce sync.Once
+	actionLogShipperInst *actionLogShipper
+	actionLogCloseOnce   sync.Once
+)
+
+func newActionLogShipper(sink func([]system.UserActionLog) error) *actionLogShipper {
+	cfg := global.GVA_CONFIG.ActionLog
+	s := &actionLogShipper{
+		queue:    make(chan system.UserActionLog, cfg.QueueSize),
+		sink:     sink,
+		batch:    cfg.BatchSize,
+		interval: time.Duration(cfg.FlushInterval) * time.Second,
+		retries:  cfg.MaxRetries,
+		spoolDir: cfg.SpoolDir,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	if cfg.QueueSize <= 0 {
+		s.queue = make(chan system.UserActionLog, 10000)
+	}
+	if s.batch <= 0 {
+		s.batch = 200
+	}
+	if s.interval <= 0 {
+		s.interval = 5 * time.Second
+	}
+	if s.retries < 0 {
+		s.retries = 0
+	}
+	if s.spoolDir == "" {
+		s.spoolDir = filepath.Join(global.GVA_CONFIG.Zap.Director, "action-log-spool")
+	}
+	return s
+}
+
+func (s *actionLogShipper) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	replayed := make(chan struct{})
+	go func() {
+		defer close(replayed)
+		s.replayLoop()
+	}()
+
+	buf := make([]system.UserActionLog, 0, s.batch)
+	for {
+		select {
+		case log := <-s.queue:
+			buf = append(buf, log)
+			if len(buf) >= s.batch {
+				s.flush(buf)
+				buf = buf[:0]
+			}
+		case <-ticker.C:
+			if len(buf) > 0 {
+				s.flush(buf)
+				buf = buf[:0]
+			}
+			s.spoolOverflow()
+		case <-s.done:
+		drain:
+			for {
+				select {
+				case log := <-s.queue:
+					buf = append(buf, log)
+				default:
+					break drain
+				}
+			}
+			if len(buf) > 0 {
+				// 失败则写入暂存 下次启动后补写
+				s.flush(buf)
+			}
+			s.spoolOverflow()
+			<-replayed
+			return
+		}
+	}
+}
+
+// flush 写入一批日志 失败时直接写入暂存 由补写协程重试
+func (s *actionLogShipper) flush(logs []system.UserActionLog) {
+	if err := s.sink(logs); err != nil {
+		global.GVA_LOG.Warn("用户操作日志写入失败 已写入本地暂存", zap.Int("count", len(logs)), zap.Error(err))
+		s.spool(logs)
+	}
+}
+
+// offer 放入队列 队列已满时放入溢出缓冲 满一批时写入暂存 不丢弃日志
+func (s *actionLogShipper) offer(log system.UserActionLog) {
+	select {
+	case s.queue <- log:
+		return
+	default:
+	}
+	s.overflowMu.Lock()
+	s.overflow = append(s.overflow, log)
+	var full []system.UserActionLog
+	if len(s.overflow) >= s.batch {
+		full, s.overflow = s.overflow, nil
+	}
+	s.overflowMu.Unlock()
+	if full != nil {
+		global.GVA_LOG.Warn("用户操作日志队列已满 已写入本地暂存", zap.Int("count", len(full)))
+		s.spool(full)
+	}
+}
+
+// spoolOverflow 将溢出缓冲中剩余的日志写入暂存
+func (s *actionLogShipper) spoolOverflow() {
+	s.overflowMu.Lock()
+	logs := s.overflow
+	s.overflow = nil
+	s.overflowMu.Unlock()
+	if len(logs) > 0 {
+		s.spool(logs)
+	}
+}
+
+// replayLoop 每个周期补写暂存文件 连续失败时等待时间翻倍 最多翻倍 retries 次
+func (s *actionLogShipper) replayLoop() {
+	failures := 0
+	timer := time.NewTimer(s.interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-timer.C:
+		}
+		if s.replay() {
+			failures = 0
+		} else if failures < s.retries {
+			failures++
+		}
+		timer.Reset(s.interval << failures)
+	}
+}
+
+// spool 将日志以JSON行写入暂存目录 先写临时文件再重命名 避免补写读到半个文件
+func (s *actionLogShipper) spool(logs []system.UserActionLog) {
+	if err := os.MkdirAll(s.spoolDir, os.ModePerm); err != nil {
+		global.GVA_LOG.Error("创建用户操作日志暂存目录失败", zap.Error(err))
+		return
+	}
+	name := filepath.Join(s.spoolDir, strconv.FormatInt(time.Now().UnixNano(), 10)+".jsonl")
+	file, err := os.Create(name + ".tmp")
+	if err != nil {
+		global.GVA_LOG.Error("写入用户操作日志暂存失败", zap.Error(err))
+		return
+	}
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, log := range logs {
+		if err = encoder.Encode(log); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	file.Close()
+	if err == nil {
+		err = os.Rename(name+".tmp", name)
+	}
+	if err != nil {
+		os.Remove(name + ".tmp")
+		global.GVA_LOG.Error("写入用户操作日志暂存失败", zap.Error(err))
+	}
+}
+
+// replay 按时间顺序补写暂存文件 遇到失败即停止 等待下个周期 全部补写完成时返回true
+func (s *actionLogShipper) replay() bool {
+	files, err := filepath.Glob(filepath.Join(s.spoolDir, "*.jsonl"))
+	if err != nil || len(files) == 0 {
+		return err == nil
+	}
+	sort.Strings(files)
+	for _, name := range files {
+		logs, err := readActionLogSpool(name)
+		if err != nil {
+			// 损坏的暂存文件改名保留 不再参与补写
+			global.GVA_LOG.Error("读取用户操作日志暂存失败", zap.String("file", name), zap.Error(err))
+			os.Rename(name, name+".bad")
+			continue
+		}
+		for start := 0; start < len(logs); start += s.batch {
+			end := min(start+s.batch, len(logs))
+			if err := s.sink(logs[start:end]); err != nil {
+				return false
+			}
+		}
+		os.Remove(name)
+		global.GVA_LOG.Info("用户操作日志暂存补写成功", zap.String("file", name), zap.Int("count", len(logs)))
+	}
+	return true
+}
+
+func readActionLogSpool(name string) ([]system.UserActionLog, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	logs := make([]system.UserActionLog, 0)
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var log system.UserActionLog
+		if err := decoder.Decode(&log); err != nil {
+			return logs, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+func (s *actionLogShipper) close() {
+	close(s.done)
+	<-s.stopped
+}
+
+// Enqueue 将日志放入异步写入队列 不阻塞调用方 队列已满时写入本地暂存
+func (s *UserActionLogService) Enqueue(log system.UserActionLog) {
+	actionLogShipperOnce.Do(func() {
+		actionLogShipperInst = newActionLogShipper(s.BatchCreateLogs)
+		go actionLogShipperInst.run()
+	})
+	if actionLogShipperInst == nil {
+		return
+	}
+	// 入队时生成ID 保证重试及补写不会产生重复文档
+	if log.ID == "" {
+		log.ID = uuid.New().String()
+	}
+	if log.CreateTime.IsZero() {
+		log.CreateTime = time.Now()
+	}
+	actionLogShipperInst.offer(log)
+}
+
+// Close 停止异步写入 将队列中剩余日志写入存储或暂存
+func (s *UserActionLogService) Close() {
+	// 未启动时阻止之后再启动
+	actionLogShipperOnce.Do(func() {})
+	if actionLogShipperInst == nil {
+		return
+	}
+	actionLogCloseOnce.Do(actionLogShipperInst.close)
+}
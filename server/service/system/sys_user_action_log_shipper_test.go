@@ -0,0 +1,166 @@
+package system
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	"go.uber.org/zap"
+)
+
+func TestActionLogShipperSpool(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	available := false
+	var written []system.UserActionLog
+	shipper := &actionLogShipper{
+		sink: func(logs []system.UserActionLog) error {
+			if !available {
+				return errors.New("unavailable")
+			}
+			written = append(written, logs...)
+			return nil
+		},
+		batch:    2,
+		retries:  1,
+		spoolDir: t.TempDir(),
+	}
+
+	logs := []system.UserActionLog{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	shipper.flush(logs)
+	files, _ := filepath.Glob(filepath.Join(shipper.spoolDir, "*.jsonl"))
+	if len(files) != 1 || len(written) != 0 {
+		t.Fatalf("expected logs spooled, files=%v written=%d", files, len(written))
+	}
+
+	// 存储不可用时补写保留暂存文件
+	shipper.replay()
+	if files, _ = filepath.Glob(filepath.Join(shipper.spoolDir, "*.jsonl")); len(files) != 1 {
+		t.Fatalf("spool should be kept while sink is unavailable")
+	}
+
+	available = true
+	shipper.replay()
+	if files, _ = filepath.Glob(filepath.Join(shipper.spoolDir, "*.jsonl")); len(files) != 0 {
+		t.Fatalf("spool should be removed after replay, got %v", files)
+	}
+	if len(written) != 3 || written[0].ID != "1" || written[2].ID != "3" {
+		t.Fatalf("unexpected replayed logs %v", written)
+	}
+}
+
+func TestActionLogShipperClose(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	var written int
+	shipper := &actionLogShipper{
+		queue: make(chan system.UserActionLog, 10),
+		sink: func(logs []system.UserActionLog) error {
+			written += len(logs)
+			return nil
+		},
+		batch:    100,
+		interval: time.Hour,
+		spoolDir: t.TempDir(),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go shipper.run()
+	for i := 0; i < 5; i++ {
+		shipper.queue <- system.UserActionLog{}
+	}
+	shipper.close()
+	if written != 5 {
+		t.Fatalf("expected queued logs flushed on close, got %d", written)
+	}
+}
+
+func TestActionLogShipperOverflow(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	shipper := &actionLogShipper{
+		queue:    make(chan system.UserActionLog, 1),
+		batch:    2,
+		spoolDir: t.TempDir(),
+	}
+	// 队列已满时不丢弃 满一批写入暂存 剩余的在下个周期写入
+	for _, id := range []string{"1", "2", "3", "4"} {
+		shipper.offer(system.UserActionLog{ID: id})
+	}
+	files, _ := filepath.Glob(filepath.Join(shipper.spoolDir, "*.jsonl"))
+	if len(shipper.queue) != 1 || len(files) != 1 || len(shipper.overflow) != 1 {
+		t.Fatalf("unexpected overflow state: queue=%d files=%v overflow=%d", len(shipper.queue), files, len(shipper.overflow))
+	}
+	shipper.spoolOverflow()
+	files, _ = filepath.Glob(filepath.Join(shipper.spoolDir, "*.jsonl"))
+	var ids []string
+	for _, name := range files {
+		logs, err := readActionLogSpool(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, log := range logs {
+			ids = append(ids, log.ID)
+		}
+	}
+	if len(ids) != 3 {
+		t.Fatalf("expected overflowed logs spooled, got %v", ids)
+	}
+}
+
+func TestActionLogShipperReplayOffConsumer(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	var mu sync.Mutex
+	available := false
+	var written []system.UserActionLog
+	shipper := &actionLogShipper{
+		queue: make(chan system.UserActionLog, 10),
+		sink: func(logs []system.UserActionLog) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if !available {
+				return errors.New("unavailable")
+			}
+			written = append(written, logs...)
+			return nil
+		},
+		batch:    1,
+		interval: 10 * time.Millisecond,
+		retries:  2,
+		spoolDir: t.TempDir(),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go shipper.run()
+	// 写入失败时立即写入暂存 消费不被重试阻塞
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		shipper.queue <- system.UserActionLog{ID: strconv.Itoa(i)}
+	}
+	for len(shipper.queue) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("consumer blocked by failing sink")
+	}
+
+	mu.Lock()
+	available = true
+	mu.Unlock()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(written)
+		mu.Unlock()
+		if n == 5 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("spooled logs not replayed, written %d", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	shipper.close()
+}
@@ -0,0 +1,26 @@
+package system
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+)
+
+func TestUserEventData(t *testing.T) {
+	u := system.SysUser{Username: "admin", Password: "$2a$10$hash", Email: "a@example.com", Phone: "13800000000", AuthorityId: 888,
+		Authorities: []system.SysAuthority{{AuthorityId: 888}, {AuthorityId: 9528}}}
+	b, err := json.Marshal(userEventData(u))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{u.Password, u.Email, u.Phone} {
+		if strings.Contains(string(b), leaked) {
+			t.Errorf("event data should not contain %q: %s", leaked, b)
+		}
+	}
+	if !strings.Contains(string(b), `"authorityIds":[888,9528]`) {
+		t.Errorf("event data should contain authority ids: %s", b)
+	}
+}
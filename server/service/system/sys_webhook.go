@@ -0,0 +1,414 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	systemRes "github.com/flipped-aurora/gin-vue-admin/server/model/system/response"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/jobqueue"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/webhook"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type WebhookService struct{}
+
+var WebhookServiceApp = new(WebhookService)
+
+// WebhookPingEvent 测试推送的事件类型 只推送给被测试的webhook
+const WebhookPingEvent = "ping"
+
+const (
+	defaultWebhookWorkers      = 2
+	defaultWebhookMaxAttempts  = 6
+	defaultWebhookRetryBackoff = 10 * time.Second
+	defaultWebhookTimeout      = 10 * time.Second
+	maxWebhookResponseLength   = 2000
+)
+
+var webhookQueue = jobqueue.New(jobqueue.Options{
+	Name:  "webhook",
+	Model: &system.SysWebhookDelivery{},
+	Status: jobqueue.Status{
+		Pending: system.WebhookDeliveryPending,
+		Running: system.WebhookDeliverySending,
+		Done:    system.WebhookDeliverySuccess,
+		Dead:    system.WebhookDeliveryDead,
+	},
+	DoneAt: "delivered_at",
+	Workers: func() int {
+		if n := global.GVA_CONFIG.Webhook.Workers; n > 0 {
+			return n
+		}
+		return defaultWebhookWorkers
+	},
+	RetryBackoff: func() time.Duration {
+		if b := global.GVA_CONFIG.Webhook.RetryBackoff; b > 0 {
+			return time.Duration(b) * time.Second
+		}
+		return defaultWebhookRetryBackoff
+	},
+	Lease: func() time.Duration { return 2 * webhookTimeout() },
+})
+
+var webhookHTTPClient = webhook.NewClient(0, func() bool { return global.GVA_CONFIG.Webhook.AllowPrivate })
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: StartWebhookDispatcher
+//@description: 启动推送协程 重复调用只启动一次
+//@return:
+
+func (webhookService *WebhookService) StartWebhookDispatcher() {
+	webhookQueue.Start(func() jobqueue.Worker { return webhookWorker{} })
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: DispatchEvent
+//@description: 为订阅了事件的全部启用的webhook创建推送记录 由推送协程异步推送
+//@param: ev utils.Event
+//@return: err error
+
+func (webhookService *WebhookService) DispatchEvent(ev utils.Event) (err error) {
+	if global.GVA_DB == nil {
+		return nil
+	}
+	var hooks []system.SysWebhook
+	if err = global.GVA_DB.Where("enable = ?", true).Find(&hooks).Error; err != nil {
+		return err
+	}
+	var payload []byte
+	for _, w := range hooks {
+		if !w.Subscribed(ev.Type) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(ev); err != nil {
+				return err
+			}
+		}
+		d := system.SysWebhookDelivery{WebhookID: w.ID, EventID: ev.ID, EventType: ev.Type, Payload: string(payload)}
+		if e := enqueueWebhookDelivery(&d); e != nil {
+			err = errors.Join(err, e)
+		}
+	}
+	return err
+}
+
+// enqueueWebhookDelivery 将推送记录加入推送队列
+func enqueueWebhookDelivery(d *system.SysWebhookDelivery) error {
+	d.Status = system.WebhookDeliveryPending
+	d.Attempts = 0
+	d.MaxAttempts = global.GVA_CONFIG.Webhook.MaxAttempts
+	if d.MaxAttempts <= 0 {
+		d.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	d.NextAttemptAt = time.Now()
+	if err := global.GVA_DB.Create(d).Error; err != nil {
+		return err
+	}
+	webhookQueue.Notify()
+	return nil
+}
+
+// deliverNext 推送一条到期的记录 没有可推送的记录时返回false
+func (webhookService *WebhookService) deliverNext() bool {
+	return webhookQueue.Next(webhookWorker{})
+}
+
+// webhookWorker 推送协程 不持有连接 http客户端自行复用连接
+type webhookWorker struct{}
+
+// Handle 推送已领取的记录 webhook已删除或停用时不再重试 响应内容一并记录
+func (webhookWorker) Handle(job jobqueue.Job) (map[string]interface{}, error) {
+	var d system.SysWebhookDelivery
+	if err := global.GVA_DB.First(&d, "id = ?", job.ID).Error; err != nil {
+		return nil, jobqueue.Permanent(err)
+	}
+	var w system.SysWebhook
+	if err := global.GVA_DB.First(&w, "id = ?", d.WebhookID).Error; err != nil {
+		return nil, jobqueue.Permanent(fmt.Errorf("webhook不存在: %w", err))
+	}
+	if !w.Enable && d.EventType != WebhookPingEvent {
+		return nil, jobqueue.Permanent(errors.New("webhook已停用"))
+	}
+	result, err := sendWebhook(w, &d)
+	if err != nil {
+		global.GVA_LOG.Warn("webhook推送失败", zap.Uint("id", d.ID), zap.Int("attempts", job.Attempts), zap.Error(err))
+	}
+	return map[string]interface{}{
+		"response_status": result.status,
+		"response_body":   result.body,
+		"duration":        result.duration.Milliseconds(),
+	}, err
+}
+
+func (webhookWorker) Idle() {}
+
+type webhookResult struct {
+	status   int
+	body     string
+	duration time.Duration
+}
+
+// sendWebhook 发送签名后的推送请求 响应状态码非2xx时返回错误
+func sendWebhook(w system.SysWebhook, d *system.SysWebhookDelivery) (result webhookResult, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout())
+	defer cancel()
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return result, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gin-vue-admin-webhook")
+	req.Header.Set(webhook.HeaderEvent, d.EventType)
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(w.Secret, ts, body))
+	start := time.Now()
+	resp, err := webhookHTTPClient.Do(req)
+	result.duration = time.Since(start)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseLength))
+	result.status = resp.StatusCode
+	result.body = string(b)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return result, fmt.Errorf("webhook返回状态码 %d", resp.StatusCode)
+	}
+	return result, nil
+}
+
+func webhookTimeout() time.Duration {
+	if t := global.GVA_CONFIG.Webhook.Timeout; t > 0 {
+		return time.Duration(t) * time.Second
+	}
+	return defaultWebhookTimeout
+}
+
+func checkWebhook(w *system.SysWebhook) error {
+	w.URL = strings.TrimSpace(w.URL)
+	if err := webhook.CheckURL(w.URL, global.GVA_CONFIG.Webhook.AllowPrivate); err != nil {
+		return errors.New("推送" + err.Error())
+	}
+	if len(w.Events) == 0 {
+		return errors.New("请选择订阅的事件")
+	}
+	known := map[string]bool{system.WebhookAllEvents: true}
+	for _, t := range utils.EventTypes {
+		known[t.Type] = true
+	}
+	for _, e := range w.Events {
+		if !known[e] {
+			return errors.New("未知的事件类型 " + e)
+		}
+	}
+	return nil
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: CreateWebhook
+//@description: 创建webhook订阅 未填写密钥时自动生成
+//@param: w *system.SysWebhook
+//@return: err error
+
+func (webhookService *WebhookService) CreateWebhook(w *system.SysWebhook) (err error) {
+	if err = checkWebhook(w); err != nil {
+		return err
+	}
+	if w.Secret == "" {
+		w.Secret = webhook.NewSecret()
+	}
+	return global.GVA_DB.Create(w).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: DeleteWebhook
+//@description: 删除webhook订阅 未完成的推送将不再发送
+//@param: id uint
+//@return: err error
+
+func (webhookService *WebhookService) DeleteWebhook(id uint) (err error) {
+	return global.GVA_DB.Delete(&system.SysWebhook{}, "id = ?", id).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: UpdateWebhook
+//@description: 更新webhook订阅 未填写密钥时保留原密钥
+//@param: w system.SysWebhook
+//@return: err error
+
+func (webhookService *WebhookService) UpdateWebhook(w system.SysWebhook) (err error) {
+	if err = checkWebhook(&w); err != nil {
+		return err
+	}
+	fields := []interface{}{"url", "events", "enable", "description"}
+	if w.Secret != "" {
+		fields = append(fields, "secret")
+	}
+	return global.GVA_DB.Model(&system.SysWebhook{}).Where("id = ?", w.ID).
+		Select("name", fields...).Updates(&w).Error
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetWebhookList
+//@description: 分页获取webhook订阅 签名密钥脱敏
+//@param: info systemReq.WebhookSearch
+//@return: list []system.SysWebhook, total int64, err error
+
+func (webhookService *WebhookService) GetWebhookList(info systemReq.WebhookSearch) (list []system.SysWebhook, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysWebhook{})
+	if info.Name != "" {
+		db = db.Where("name LIKE ?", "%"+info.Name+"%")
+	}
+	if info.Event != "" {
+		db = db.Where("events LIKE ? OR events LIKE ?", "%\""+info.Event+"\"%", "%\""+system.WebhookAllEvents+"\"%")
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Scopes(info.Paginate()).Order("id desc").Find(&list).Error
+	for i := range list {
+		list[i].MaskSecret()
+	}
+	return list, total, err
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetWebhookEventTypes
+//@description: 获取可订阅的事件类型
+//@return: list []systemRes.WebhookEventType
+
+func (webhookService *WebhookService) GetWebhookEventTypes() (list []systemRes.WebhookEventType) {
+	for _, t := range utils.EventTypes {
+		list = append(list, systemRes.WebhookEventType{Type: t.Type, Description: t.Description})
+	}
+	return list
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: PingWebhook
+//@description: 立即向webhook发送一条测试推送 不重试 结果记入推送记录
+//@param: id uint
+//@return: delivery system.SysWebhookDelivery, err error
+
+func (webhookService *WebhookService) PingWebhook(id uint) (delivery system.SysWebhookDelivery, err error) {
+	var w system.SysWebhook
+	if err = global.GVA_DB.First(&w, "id = ?", id).Error; err != nil {
+		return delivery, err
+	}
+	ev := utils.Event{
+		ID:   uuid.NewString(),
+		Type: WebhookPingEvent,
+		Time: time.Now(),
+		Data: map[string]interface{}{"webhookId": w.ID, "message": "这是一条测试推送"},
+	}
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return delivery, err
+	}
+	// 直接以推送中的状态创建 避免被推送协程领取
+	delivery = system.SysWebhookDelivery{
+		WebhookID:     w.ID,
+		EventID:       ev.ID,
+		EventType:     ev.Type,
+		Payload:       string(payload),
+		Status:        system.WebhookDeliverySending,
+		Attempts:      1,
+		MaxAttempts:   1,
+		NextAttemptAt: time.Now().Add(2 * webhookTimeout()),
+	}
+	if err = global.GVA_DB.Create(&delivery).Error; err != nil {
+		return delivery, err
+	}
+	sendErr := webhookQueue.Process(webhookWorker{}, jobqueue.Job{ID: delivery.ID, Attempts: 1, MaxAttempts: 1})
+	if err = global.GVA_DB.First(&delivery, "id = ?", delivery.ID).Error; err != nil {
+		return delivery, err
+	}
+	return delivery, sendErr
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: GetWebhookDeliveryList
+//@description: 分页获取推送记录 不包含推送和响应内容
+//@param: info systemReq.WebhookDeliverySearch
+//@return: list []system.SysWebhookDelivery, total int64, err error
+
+func (webhookService *WebhookService) GetWebhookDeliveryList(info systemReq.WebhookDeliverySearch) (list []system.SysWebhookDelivery, total int64, err error) {
+	db := global.GVA_DB.Model(&system.SysWebhookDelivery{})
+	if info.WebhookID != 0 {
+		db = db.Where("webhook_id = ?", info.WebhookID)
+	}
+	if info.EventID != "" {
+		db = db.Where("event_id = ?", info.EventID)
+	}
+	if info.EventType != "" {
+		db = db.Where("event_type = ?", info.EventType)
+	}
+	if info.Status != "" {
+		db = db.Where("status = ?", info.Status)
+	}
+	if info.StartCreatedAt != nil && info.EndCreatedAt != nil {
+		db = db.Where("created_at BETWEEN ? AND ?", info.StartCreatedAt, info.EndCreatedAt)
+	}
+	if err = db.Count(&total).Error; err != nil {
+		return
+	}
+	err = db.Omit("payload", "response_body").Scopes(info.Paginate()).Order("id desc").Find(&list).Error
+	return list, total, err
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: FindWebhookDelivery
+//@description: 获取推送记录详情
+//@param: id uint
+//@return: delivery system.SysWebhookDelivery, err error
+
+func (webhookService *WebhookService) FindWebhookDelivery(id uint) (delivery system.SysWebhookDelivery, err error) {
+	err = global.GVA_DB.First(&delivery, "id = ?", id).Error
+	return
+}
+
+//@author: [piexlmax](https://github.com/piexlmax)
+//@function: RedeliverWebhookDelivery
+//@description: 以相同的事件内容重新推送 新建一条推送记录 事件ID不变
+//@param: id uint
+//@return: delivery system.SysWebhookDelivery, err error
+
+func (webhookService *WebhookService) RedeliverWebhookDelivery(id uint) (delivery system.SysWebhookDelivery, err error) {
+	var old system.SysWebhookDelivery
+	if err = global.GVA_DB.First(&old, "id = ?", id).Error; err != nil {
+		return delivery, err
+	}
+	var w system.SysWebhook
+	if err = global.GVA_DB.First(&w, "id = ?", old.WebhookID).Error; err != nil {
+		return delivery, errors.New("webhook不存在")
+	}
+	if !w.Enable {
+		return delivery, errors.New("webhook已停用")
+	}
+	delivery = system.SysWebhookDelivery{
+		WebhookID:    old.WebhookID,
+		EventID:      old.EventID,
+		EventType:    old.EventType,
+		Payload:      old.Payload,
+		RedeliveryOf: old.ID,
+	}
+	err = enqueueWebhookDelivery(&delivery)
+	return delivery, err
+}
@@ -0,0 +1,170 @@
+package system
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/config"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/webhook"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (r *webhookReceiver) setStatus(status int) {
+	r.mu.Lock()
+	r.status = status
+	r.mu.Unlock()
+}
+
+func setupWebhookDB(t *testing.T) (*webhookReceiver, string) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	if err = db.AutoMigrate(&system.SysWebhook{}, &system.SysWebhookDelivery{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB = db
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_CONFIG.Webhook = config.Webhook{MaxAttempts: 2, RetryBackoff: 60, Timeout: 5, AllowPrivate: true}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	r := &webhookReceiver{status: http.StatusOK}
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return r, srv.URL
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	r, url := setupWebhookDB(t)
+	s := &WebhookService{}
+	hook := system.SysWebhook{Name: "users", URL: url, Events: []string{utils.EventUserCreated}, Enable: true}
+	if err := s.CreateWebhook(&hook); err != nil {
+		t.Fatal(err)
+	}
+	if hook.Secret == "" {
+		t.Fatal("secret not generated")
+	}
+	hooks, _, err := s.GetWebhookList(systemReq.WebhookSearch{})
+	if err != nil || len(hooks) != 1 {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if hooks[0].Secret != "" || hooks[0].SecretHint != "****"+hook.Secret[len(hook.Secret)-4:] {
+		t.Errorf("secret should be masked in list, got %q %q", hooks[0].Secret, hooks[0].SecretHint)
+	}
+	others := []system.SysWebhook{
+		{Name: "roles", URL: url, Events: []string{utils.EventAuthorityCreated}, Enable: true},
+		{Name: "disabled", URL: url, Events: []string{system.WebhookAllEvents}},
+	}
+	for i := range others {
+		if err := s.CreateWebhook(&others[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.CreateWebhook(&system.SysWebhook{Name: "bad", URL: url, Events: []string{"unknown"}}); err == nil {
+		t.Error("unknown event type accepted")
+	}
+
+	ev := utils.Event{ID: "ev-1", Type: utils.EventUserCreated, Time: time.Now(), Data: map[string]interface{}{"userId": 1}}
+	if err := s.DispatchEvent(ev); err != nil {
+		t.Fatal(err)
+	}
+	var list []system.SysWebhookDelivery
+	global.GVA_DB.Find(&list)
+	if len(list) != 1 || list[0].WebhookID != hook.ID || list[0].Status != system.WebhookDeliveryPending {
+		t.Fatalf("unexpected deliveries: %+v", list)
+	}
+
+	// 失败后按退避时间重试
+	r.setStatus(http.StatusInternalServerError)
+	if !s.deliverNext() {
+		t.Fatal("delivery not claimed")
+	}
+	d, _ := s.FindWebhookDelivery(list[0].ID)
+	if d.Status != system.WebhookDeliveryPending || d.Attempts != 1 || d.ResponseStatus != 500 || !d.NextAttemptAt.After(time.Now().Add(50*time.Second)) {
+		t.Fatalf("unexpected delivery after failure: %+v", d)
+	}
+	if s.deliverNext() {
+		t.Fatal("delivery claimed before backoff")
+	}
+
+	// 超过最大尝试次数后转为死信
+	global.GVA_DB.Model(&system.SysWebhookDelivery{}).Where("id = ?", d.ID).Update("next_attempt_at", time.Now())
+	s.deliverNext()
+	d, _ = s.FindWebhookDelivery(d.ID)
+	if d.Status != system.WebhookDeliveryDead || d.Attempts != 2 || d.LastError == "" {
+		t.Fatalf("unexpected delivery after max attempts: %+v", d)
+	}
+
+	// 重新推送 事件ID不变 签名可校验
+	r.setStatus(http.StatusOK)
+	redelivery, err := s.RedeliverWebhookDelivery(d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.deliverNext()
+	redelivery, _ = s.FindWebhookDelivery(redelivery.ID)
+	if redelivery.Status != system.WebhookDeliverySuccess || redelivery.EventID != "ev-1" || redelivery.RedeliveryOf != d.ID || redelivery.DeliveredAt == nil {
+		t.Fatalf("unexpected redelivery: %+v", redelivery)
+	}
+	r.mu.Lock()
+	req, body := r.requests[len(r.requests)-1], r.bodies[len(r.bodies)-1]
+	r.mu.Unlock()
+	ts, _ := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	if !webhook.Verify(hook.Secret, ts, body, req.Header.Get(webhook.HeaderSignature), time.Minute) {
+		t.Error("signature verification failed")
+	}
+	if req.Header.Get(webhook.HeaderEvent) != utils.EventUserCreated || req.Header.Get(webhook.HeaderDelivery) != strconv.Itoa(int(redelivery.ID)) {
+		t.Errorf("unexpected headers: %v", req.Header)
+	}
+}
+
+func TestPingWebhook(t *testing.T) {
+	r, url := setupWebhookDB(t)
+	s := &WebhookService{}
+	hook := system.SysWebhook{Name: "ping", URL: url, Events: []string{system.WebhookAllEvents}}
+	if err := s.CreateWebhook(&hook); err != nil {
+		t.Fatal(err)
+	}
+	d, err := s.PingWebhook(hook.ID)
+	if err != nil || d.Status != system.WebhookDeliverySuccess || d.ResponseBody != "ok" {
+		t.Fatalf("ping failed: %v %+v", err, d)
+	}
+	r.setStatus(http.StatusNotFound)
+	d, err = s.PingWebhook(hook.ID)
+	if err == nil || d.Status != system.WebhookDeliveryDead || d.ResponseStatus != 404 {
+		t.Fatalf("expected failed ping: %v %+v", err, d)
+	}
+	if s.deliverNext() {
+		t.Error("ping delivery claimed by worker")
+	}
+}
@@ -43,7 +43,17 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 	if !ok {
 		return ctx, system.ErrMissingDBContext
 	}
-	entities := []sysModel.SysApi{
+	entities := Apis()
+	if err := db.Create(&entities).Error; err != nil {
+		return ctx, errors.Wrap(err, sysModel.SysApi{}.TableName()+"表数据初始化失败!")
+	}
+	next := context.WithValue(ctx, i.InitializerName(), entities)
+	return next, nil
+}
+
+// Apis 初始化时写入的api 生成前端SDK时也用作接口描述
+func Apis() []sysModel.SysApi {
+	return []sysModel.SysApi{
 		{ApiGroup: "jwt", Method: "POST", Path: "/jwt/jsonInBlacklist", Description: "jwt加入黑名单(退出，必选)"},
 
 		{ApiGroup: "系统用户", Method: "DELETE", Path: "/user/deleteUser", Description: "删除用户"},
@@ -79,6 +89,12 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 
 		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/updateCasbin", Description: "更改角色api权限"},
 		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/getPolicyPathByAuthorityId", Description: "获取权限列表"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/explain", Description: "权限模拟"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/getCasbinMatrix", Description: "获取角色接口权限矩阵"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/createCasbinDeny", Description: "创建显式拒绝规则"},
+		{ApiGroup: "casbin", Method: "PUT", Path: "/casbin/updateCasbinDeny", Description: "更新显式拒绝规则"},
+		{ApiGroup: "casbin", Method: "DELETE", Path: "/casbin/deleteCasbinDeny", Description: "删除显式拒绝规则"},
+		{ApiGroup: "casbin", Method: "POST", Path: "/casbin/getCasbinDenyList", Description: "获取显式拒绝规则"},
 
 		{ApiGroup: "菜单", Method: "POST", Path: "/menu/addBaseMenu", Description: "新增菜单"},
 		{ApiGroup: "菜单", Method: "POST", Path: "/menu/getMenu", Description: "获取菜单树(必选)"},
@@ -118,6 +134,14 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "代码生成器", Method: "GET", Path: "/autoCode/getColumn", Description: "获取所选table的所有字段"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/installPlugin", Description: "安装插件"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/pubPlug", Description: "打包插件"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/checkPlugin", Description: "检查插件"},
+		{ApiGroup: "代码生成器", Method: "GET", Path: "/autoCode/getPlugins", Description: "获取已安装的插件"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/uninstallPlugin", Description: "卸载插件"},
+		{ApiGroup: "代码生成器", Method: "GET", Path: "/pluginRuntime/getPlugins", Description: "获取插件运行状态"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/pluginRuntime/enable", Description: "启用插件"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/pluginRuntime/disable", Description: "停用插件"},
+		{ApiGroup: "代码生成器", Method: "POST", Path: "/pluginRuntime/uninstall", Description: "卸载插件数据"},
+		{ApiGroup: "代码生成器", Method: "GET", Path: "/pluginRuntime/health", Description: "插件健康检查"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/mcp", Description: "自动生成 MCP Tool 模板"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/mcpTest", Description: "MCP Tool 测试"},
 		{ApiGroup: "代码生成器", Method: "POST", Path: "/autoCode/mcpList", Description: "获取 MCP ToolList"},
@@ -126,10 +150,16 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "模板配置", Method: "GET", Path: "/autoCode/getTemplates", Description: "获取模板文件"},
 		{ApiGroup: "模板配置", Method: "POST", Path: "/autoCode/getPackage", Description: "获取所有模板"},
 		{ApiGroup: "模板配置", Method: "POST", Path: "/autoCode/delPackage", Description: "删除模板"},
+		{ApiGroup: "模板配置", Method: "GET", Path: "/autoCode/getTemplatePacks", Description: "获取模板包"},
+		{ApiGroup: "模板配置", Method: "POST", Path: "/autoCode/uploadTemplatePack", Description: "上传模板包"},
+		{ApiGroup: "模板配置", Method: "POST", Path: "/autoCode/delTemplatePack", Description: "删除模板包"},
+		{ApiGroup: "模板配置", Method: "POST", Path: "/autoCode/setTemplatePack", Description: "设置模板包"},
 
 		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/getMeta", Description: "获取meta信息"},
 		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/rollback", Description: "回滚自动生成代码"},
 		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/getSysHistory", Description: "查询回滚记录"},
+		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/updatePreview", Description: "预览模块更新"},
+		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/update", Description: "更新已生成的模块"},
 		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/delSysHistory", Description: "删除回滚记录"},
 		{ApiGroup: "代码生成器历史", Method: "POST", Path: "/autoCode/addFunc", Description: "增加模板方法"},
 
@@ -157,6 +187,14 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 
 		{ApiGroup: "email", Method: "POST", Path: "/email/emailTest", Description: "发送测试邮件"},
 		{ApiGroup: "email", Method: "POST", Path: "/email/sendEmail", Description: "发送邮件"},
+		{ApiGroup: "email", Method: "POST", Path: "/email/retryEmailMessage", Description: "重新发送死信邮件"},
+		{ApiGroup: "email", Method: "GET", Path: "/email/getEmailMessageList", Description: "分页获取邮件发送记录"},
+		{ApiGroup: "email", Method: "GET", Path: "/email/findEmailMessage", Description: "获取邮件发送记录详情"},
+		{ApiGroup: "email", Method: "POST", Path: "/email/createEmailTemplate", Description: "创建邮件模板"},
+		{ApiGroup: "email", Method: "PUT", Path: "/email/updateEmailTemplate", Description: "更新邮件模板"},
+		{ApiGroup: "email", Method: "DELETE", Path: "/email/deleteEmailTemplate", Description: "删除邮件模板"},
+		{ApiGroup: "email", Method: "GET", Path: "/email/getEmailTemplateList", Description: "分页获取邮件模板"},
+		{ApiGroup: "email", Method: "POST", Path: "/email/previewEmailTemplate", Description: "预览邮件模板"},
 
 		{ApiGroup: "按钮权限", Method: "POST", Path: "/authorityBtn/setAuthorityBtn", Description: "设置按钮权限"},
 		{ApiGroup: "按钮权限", Method: "POST", Path: "/authorityBtn/getAuthorityBtn", Description: "获取已有按钮权限"},
@@ -197,12 +235,46 @@ func (i *initApi) InitializeData(ctx context.Context) (context.Context, error) {
 		{ApiGroup: "版本控制", Method: "POST", Path: "/sysVersion/importVersion", Description: "同步版本"},
 		{ApiGroup: "版本控制", Method: "DELETE", Path: "/sysVersion/deleteSysVersion", Description: "删除版本"},
 		{ApiGroup: "版本控制", Method: "DELETE", Path: "/sysVersion/deleteSysVersionByIds", Description: "批量删除版本"},
+
+		{ApiGroup: "站内通知", Method: "POST", Path: "/notification/createNotification", Description: "发送通知"},
+		{ApiGroup: "站内通知", Method: "DELETE", Path: "/notification/deleteNotification", Description: "删除通知"},
+		{ApiGroup: "站内通知", Method: "GET", Path: "/notification/getNotificationList", Description: "获取已发送的通知"},
+		{ApiGroup: "站内通知", Method: "GET", Path: "/notification/getMyNotificationList", Description: "获取我的通知(必选)"},
+		{ApiGroup: "站内通知", Method: "PUT", Path: "/notification/markRead", Description: "通知标记已读(必选)"},
+		{ApiGroup: "站内通知", Method: "PUT", Path: "/notification/markAllRead", Description: "通知全部标记已读(必选)"},
+		{ApiGroup: "站内通知", Method: "PUT", Path: "/notification/archive", Description: "归档通知(必选)"},
+		{ApiGroup: "站内通知", Method: "GET", Path: "/notification/getUnreadCount", Description: "获取未读通知数量(必选)"},
+
+		{ApiGroup: "限流规则", Method: "POST", Path: "/rateLimit/createRateLimitRule", Description: "创建限流规则"},
+		{ApiGroup: "限流规则", Method: "DELETE", Path: "/rateLimit/deleteRateLimitRule", Description: "删除限流规则"},
+		{ApiGroup: "限流规则", Method: "PUT", Path: "/rateLimit/updateRateLimitRule", Description: "更新限流规则"},
+		{ApiGroup: "限流规则", Method: "GET", Path: "/rateLimit/getRateLimitRuleList", Description: "获取限流规则列表"},
+		{ApiGroup: "限流规则", Method: "GET", Path: "/rateLimit/getRateLimitMetrics", Description: "获取限流统计"},
+
+		{ApiGroup: "错误告警", Method: "GET", Path: "/alert/getAlertList", Description: "分页获取告警"},
+		{ApiGroup: "错误告警", Method: "POST", Path: "/alert/ackAlert", Description: "确认告警"},
+		{ApiGroup: "错误告警", Method: "POST", Path: "/alert/resolveAlert", Description: "解决告警"},
+		{ApiGroup: "错误告警", Method: "POST", Path: "/alert/createAlertChannel", Description: "创建告警渠道"},
+		{ApiGroup: "错误告警", Method: "DELETE", Path: "/alert/deleteAlertChannel", Description: "删除告警渠道"},
+		{ApiGroup: "错误告警", Method: "PUT", Path: "/alert/updateAlertChannel", Description: "更新告警渠道"},
+		{ApiGroup: "错误告警", Method: "GET", Path: "/alert/getAlertChannelList", Description: "分页获取告警渠道"},
+		{ApiGroup: "错误告警", Method: "POST", Path: "/alert/testAlertChannel", Description: "发送测试告警通知"},
+
+		{ApiGroup: "webhook推送", Method: "POST", Path: "/webhook/createWebhook", Description: "创建webhook订阅"},
+		{ApiGroup: "webhook推送", Method: "DELETE", Path: "/webhook/deleteWebhook", Description: "删除webhook订阅"},
+		{ApiGroup: "webhook推送", Method: "PUT", Path: "/webhook/updateWebhook", Description: "更新webhook订阅"},
+		{ApiGroup: "webhook推送", Method: "GET", Path: "/webhook/getWebhookList", Description: "分页获取webhook订阅"},
+		{ApiGroup: "webhook推送", Method: "GET", Path: "/webhook/getWebhookEventTypes", Description: "获取可订阅的事件类型"},
+		{ApiGroup: "webhook推送", Method: "POST", Path: "/webhook/pingWebhook", Description: "发送webhook测试推送"},
+		{ApiGroup: "webhook推送", Method: "GET", Path: "/webhook/getWebhookDeliveryList", Description: "分页获取webhook推送记录"},
+		{ApiGroup: "webhook推送", Method: "GET", Path: "/webhook/findWebhookDelivery", Description: "获取webhook推送记录详情"},
+		{ApiGroup: "webhook推送", Method: "POST", Path: "/webhook/redeliverWebhookDelivery", Description: "webhook重新推送"},
+
+		{ApiGroup: "部门管理", Method: "POST", Path: "/department/createDepartment", Description: "创建部门"},
+		{ApiGroup: "部门管理", Method: "DELETE", Path: "/department/deleteDepartment", Description: "删除部门"},
+		{ApiGroup: "部门管理", Method: "PUT", Path: "/department/updateDepartment", Description: "更新部门"},
+		{ApiGroup: "部门管理", Method: "GET", Path: "/department/getDepartmentTree", Description: "获取部门树"},
 	}
-	if err := db.Create(&entities).Error; err != nil {
-		return ctx, errors.Wrap(err, sysModel.SysApi{}.TableName()+"表数据初始化失败!")
-	}
-	next := context.WithValue(ctx, i.InitializerName(), entities)
-	return next, nil
 }
 
 func (i *initApi) DataInserted(ctx context.Context) bool {
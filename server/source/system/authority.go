@@ -70,6 +70,10 @@ func (i *initAuthority) InitializeData(ctx context.Context) (context.Context, er
 		return ctx, errors.Wrapf(err, "%s表数据初始化失败!",
 			db.Model(&entities[1]).Association("DataAuthorityId").Relationship.JoinTable.Name)
 	}
+	// casbin 初始数据按 [角色, 路径, 方法] 写入 角色写入后转换为继承父角色的策略
+	if err := system.CasbinServiceApp.MigrateInheritance(db); err != nil {
+		return ctx, errors.Wrap(err, "casbin_rule表数据转换失败!")
+	}
 
 	next := context.WithValue(ctx, i.InitializerName(), entities)
 	return next, nil
@@ -44,6 +44,7 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 	if !ok {
 		return ctx, system.ErrMissingDBContext
 	}
+	// 按旧版 [角色, 路径, 方法] 列出 角色初始化后由 CasbinService.MigrateInheritance 转换
 	entities := []adapter.CasbinRule{
 		{Ptype: "p", V0: "888", V1: "/user/admin_register", V2: "POST"},
 
@@ -100,6 +101,12 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 
 		{Ptype: "p", V0: "888", V1: "/casbin/updateCasbin", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/casbin/getPolicyPathByAuthorityId", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/explain", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/getCasbinMatrix", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/createCasbinDeny", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/casbin/updateCasbinDeny", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/casbin/deleteCasbinDeny", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/casbin/getCasbinDenyList", V2: "POST"},
 
 		{Ptype: "p", V0: "888", V1: "/jwt/jsonInBlacklist", V2: "POST"},
 
@@ -122,13 +129,27 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/autoCode/createTemp", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/delSysHistory", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/getSysHistory", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/updatePreview", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/update", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/createPackage", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/getTemplates", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/getPackage", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/delPackage", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/getTemplatePacks", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/uploadTemplatePack", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/delTemplatePack", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/setTemplatePack", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/createPlug", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/installPlugin", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/pubPlug", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/checkPlugin", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/getPlugins", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/autoCode/uninstallPlugin", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/pluginRuntime/getPlugins", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/pluginRuntime/enable", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/pluginRuntime/disable", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/pluginRuntime/uninstall", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/pluginRuntime/health", V2: "GET"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/addFunc", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/mcp", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/autoCode/mcpTest", V2: "POST"},
@@ -155,6 +176,14 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 
 		{Ptype: "p", V0: "888", V1: "/email/emailTest", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/email/sendEmail", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/email/retryEmailMessage", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/email/getEmailMessageList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/email/findEmailMessage", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/email/createEmailTemplate", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/email/updateEmailTemplate", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/email/deleteEmailTemplate", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/email/getEmailTemplateList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/email/previewEmailTemplate", V2: "POST"},
 
 		{Ptype: "p", V0: "888", V1: "/simpleUploader/upload", V2: "POST"},
 		{Ptype: "p", V0: "888", V1: "/simpleUploader/checkFileMd5", V2: "GET"},
@@ -200,6 +229,45 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "888", V1: "/sysVersion/deleteSysVersion", V2: "DELETE"},
 		{Ptype: "p", V0: "888", V1: "/sysVersion/deleteSysVersionByIds", V2: "DELETE"},
 
+		{Ptype: "p", V0: "888", V1: "/notification/createNotification", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/notification/deleteNotification", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/notification/getNotificationList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/notification/getMyNotificationList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/notification/markRead", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/notification/markAllRead", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/notification/archive", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/notification/getUnreadCount", V2: "GET"},
+
+		{Ptype: "p", V0: "888", V1: "/rateLimit/createRateLimitRule", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/rateLimit/deleteRateLimitRule", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/rateLimit/updateRateLimitRule", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/rateLimit/getRateLimitRuleList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/rateLimit/getRateLimitMetrics", V2: "GET"},
+
+		{Ptype: "p", V0: "888", V1: "/alert/getAlertList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/alert/ackAlert", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/alert/resolveAlert", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/alert/createAlertChannel", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/alert/deleteAlertChannel", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/alert/updateAlertChannel", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/alert/getAlertChannelList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/alert/testAlertChannel", V2: "POST"},
+
+		{Ptype: "p", V0: "888", V1: "/webhook/createWebhook", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/webhook/deleteWebhook", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/webhook/updateWebhook", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/webhook/getWebhookList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/webhook/getWebhookEventTypes", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/webhook/pingWebhook", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/webhook/getWebhookDeliveryList", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/webhook/findWebhookDelivery", V2: "GET"},
+		{Ptype: "p", V0: "888", V1: "/webhook/redeliverWebhookDelivery", V2: "POST"},
+
+		{Ptype: "p", V0: "888", V1: "/department/createDepartment", V2: "POST"},
+		{Ptype: "p", V0: "888", V1: "/department/deleteDepartment", V2: "DELETE"},
+		{Ptype: "p", V0: "888", V1: "/department/updateDepartment", V2: "PUT"},
+		{Ptype: "p", V0: "888", V1: "/department/getDepartmentTree", V2: "GET"},
+
 		{Ptype: "p", V0: "8881", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/createApi", V2: "POST"},
 		{Ptype: "p", V0: "8881", V1: "/api/getApiList", V2: "POST"},
@@ -239,6 +307,11 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "8881", V1: "/customer/customer", V2: "GET"},
 		{Ptype: "p", V0: "8881", V1: "/customer/customerList", V2: "GET"},
 		{Ptype: "p", V0: "8881", V1: "/user/getUserInfo", V2: "GET"},
+		{Ptype: "p", V0: "8881", V1: "/notification/getMyNotificationList", V2: "GET"},
+		{Ptype: "p", V0: "8881", V1: "/notification/markRead", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/notification/markAllRead", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/notification/archive", V2: "PUT"},
+		{Ptype: "p", V0: "8881", V1: "/notification/getUnreadCount", V2: "GET"},
 
 		{Ptype: "p", V0: "9528", V1: "/user/admin_register", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/api/createApi", V2: "POST"},
@@ -282,6 +355,11 @@ func (i *initCasbin) InitializeData(ctx context.Context) (context.Context, error
 		{Ptype: "p", V0: "9528", V1: "/customer/customerList", V2: "GET"},
 		{Ptype: "p", V0: "9528", V1: "/autoCode/createTemp", V2: "POST"},
 		{Ptype: "p", V0: "9528", V1: "/user/getUserInfo", V2: "GET"},
+		{Ptype: "p", V0: "9528", V1: "/notification/getMyNotificationList", V2: "GET"},
+		{Ptype: "p", V0: "9528", V1: "/notification/markRead", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/notification/markAllRead", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/notification/archive", V2: "PUT"},
+		{Ptype: "p", V0: "9528", V1: "/notification/getUnreadCount", V2: "GET"},
 	}
 	if err := db.Create(&entities).Error; err != nil {
 		return ctx, errors.Wrap(err, "Casbin 表 ("+i.InitializerName()+") 数据初始化失败!")
@@ -295,7 +373,8 @@ func (i *initCasbin) DataInserted(ctx context.Context) bool {
 	if !ok {
 		return false
 	}
-	if errors.Is(db.Where(adapter.CasbinRule{Ptype: "p", V0: "9528", V1: "/user/getUserInfo", V2: "GET"}).
+	// 初始数据在角色写入后转换为 [角色, 域, 路径, 方法, 效果] 转换前后均可判断
+	if errors.Is(db.Where("ptype = ? AND v0 = ? AND (v1 = ? OR v2 = ?)", "p", "9528", "/user/getUserInfo", "/user/getUserInfo").
 		First(&adapter.CasbinRule{}).Error, gorm.ErrRecordNotFound) { // 判断是否存在数据
 		return false
 	}
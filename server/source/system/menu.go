@@ -85,6 +85,13 @@ func (i *initMenu) InitializeData(ctx context.Context) (next context.Context, er
 		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "dictionary", Name: "dictionary", Component: "view/superAdmin/dictionary/sysDictionary.vue", Sort: 5, Meta: Meta{Title: "字典管理", Icon: "notebook"}},
 		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "operation", Name: "operation", Component: "view/superAdmin/operation/sysOperationRecord.vue", Sort: 6, Meta: Meta{Title: "操作历史", Icon: "pie-chart"}},
 		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "sysParams", Name: "sysParams", Component: "view/superAdmin/params/sysParams.vue", Sort: 7, Meta: Meta{Title: "参数管理", Icon: "compass"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "rateLimit", Name: "rateLimit", Component: "view/superAdmin/rateLimit/rateLimit.vue", Sort: 8, Meta: Meta{Title: "限流规则", Icon: "odometer"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "alert", Name: "alert", Component: "view/superAdmin/alert/alert.vue", Sort: 9, Meta: Meta{Title: "错误告警", Icon: "bell"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "webhook", Name: "webhook", Component: "view/superAdmin/webhook/webhook.vue", Sort: 10, Meta: Meta{Title: "webhook推送", Icon: "connection"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "notification", Name: "notification", Component: "view/superAdmin/notification/notification.vue", Sort: 11, Meta: Meta{Title: "站内通知", Icon: "message"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "department", Name: "department", Component: "view/superAdmin/department/department.vue", Sort: 12, Meta: Meta{Title: "部门管理", Icon: "office-building"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "casbinMatrix", Name: "casbinMatrix", Component: "view/superAdmin/casbin/matrix.vue", Sort: 13, Meta: Meta{Title: "权限矩阵", Icon: "grid"}},
+		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["superAdmin"], Path: "casbinExplain", Name: "casbinExplain", Component: "view/superAdmin/casbin/explain.vue", Sort: 14, Meta: Meta{Title: "权限模拟", Icon: "aim"}},
 
 		// example子菜单
 		{MenuLevel: 1, Hidden: false, ParentId: menuNameMap["example"], Path: "upload", Name: "upload", Component: "view/example/upload/upload.vue", Sort: 5, Meta: Meta{Title: "媒体库（上传下载）", Icon: "upload"}},
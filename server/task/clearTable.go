@@ -26,8 +26,8 @@ func ClearTable(db *gorm.DB) error {
 
 	ClearTableDetail = append(ClearTableDetail, common.ClearDB{
 		TableName:    "jwt_blacklists",
-		CompareField: "created_at",
-		Interval:     "168h",
+		CompareField: "expires_at", // jwt过期后拉黑记录不再需要
+		Interval:     "0s",
 	})
 
 	if db == nil {
@@ -0,0 +1,10 @@
+package task
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/service"
+)
+
+// UserActionLogIndex 滚动用户操作日志的写入索引并按保留期限清理过期索引
+func UserActionLogIndex() error {
+	return service.ServiceGroupApp.SystemServiceGroup.UserActionLogService.ApplyRetention()
+}
@@ -0,0 +1,131 @@
+// Package alert 按接口和错误特征聚合请求错误 由调用方定期取出聚合结果入库并决定是否通知
+package alert
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/jobqueue"
+)
+
+// maxGroups 同时聚合的错误分组上限 超出后丢弃新分组的错误 避免异常请求撑爆内存
+const maxGroups = 1000
+
+// maxMessageLength 记录的错误信息最大字符数
+const maxMessageLength = 1000
+
+// Event 一次请求错误
+type Event struct {
+	Method  string
+	Route   string // 路由模板 如 /user/:id 未匹配路由时为请求路径
+	Status  int
+	Message string
+	IP      string
+	Time    time.Time
+}
+
+// Batch 一个分组自上次取出以来的错误
+type Batch struct {
+	Fingerprint string
+	Method      string
+	Route       string
+	Status      int
+	Message     string // 最近一次的错误信息
+	IP          string // 最近一次的请求IP
+	Count       int64  // 自上次取出以来的次数
+	WindowCount int64  // 当前统计窗口内的次数
+	First       time.Time
+	Last        time.Time
+}
+
+type group struct {
+	batch       Batch
+	windowStart time.Time
+}
+
+// Aggregator 错误聚合器 同一接口、同一特征的错误计入同一分组 并发安全
+type Aggregator struct {
+	mu      sync.Mutex
+	window  time.Duration
+	groups  map[string]*group
+	dropped int64
+}
+
+func New(window time.Duration) *Aggregator {
+	return &Aggregator{window: window, groups: map[string]*group{}}
+}
+
+// Record 记录一次错误
+func (a *Aggregator) Record(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	ev.Message = jobqueue.Truncate(ev.Message, maxMessageLength)
+	fp := Fingerprint(ev.Method, ev.Route, ev.Message)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	g, ok := a.groups[fp]
+	if !ok {
+		if len(a.groups) >= maxGroups {
+			a.dropped++
+			return
+		}
+		g = &group{windowStart: ev.Time}
+		g.batch = Batch{Fingerprint: fp, Method: ev.Method, Route: ev.Route}
+		a.groups[fp] = g
+	}
+	if ev.Time.Sub(g.windowStart) >= a.window {
+		g.windowStart = ev.Time
+		g.batch.WindowCount = 0
+	}
+	b := &g.batch
+	if b.Count == 0 {
+		b.First = ev.Time
+	}
+	b.Count++
+	b.WindowCount++
+	b.Last = ev.Time
+	b.Status = ev.Status
+	b.Message = ev.Message
+	b.IP = ev.IP
+}
+
+// Flush 取出有新错误的分组 并清理窗口已过且没有新错误的分组
+func (a *Aggregator) Flush(now time.Time) []Batch {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var list []Batch
+	for fp, g := range a.groups {
+		if g.batch.Count == 0 {
+			if now.Sub(g.windowStart) >= a.window {
+				delete(a.groups, fp)
+			}
+			continue
+		}
+		list = append(list, g.batch)
+		g.batch.Count = 0
+	}
+	return list
+}
+
+// Dropped 分组数达到上限后丢弃的错误数
+func (a *Aggregator) Dropped() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.dropped
+}
+
+// volatile 错误信息中每次都可能不同的部分 如ID、时间、地址
+var volatile = regexp.MustCompile(`0x[0-9a-fA-F]+|[0-9a-fA-F]{8,}(-[0-9a-fA-F]{4,})*|\d+`)
+
+// Fingerprint 错误特征 将错误信息中的数字和十六进制串替换后与方法、路由一起计算摘要
+// 如 "record 12 not found" 与 "record 34 not found" 为同一特征
+func Fingerprint(method, route, message string) string {
+	h := sha1.New()
+	h.Write([]byte(method + " " + route + "\n"))
+	h.Write([]byte(volatile.ReplaceAllString(message, "#")))
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
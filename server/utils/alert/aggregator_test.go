@@ -0,0 +1,95 @@
+package alert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestAggregatorGroups(t *testing.T) {
+	a := New(time.Minute)
+	start := time.Unix(1700000000, 0)
+	a.Record(Event{Method: "GET", Route: "/user/:id", Status: 500, Message: "record 12 not found", Time: start})
+	a.Record(Event{Method: "GET", Route: "/user/:id", Status: 500, Message: "record 34 not found", IP: "10.0.0.2", Time: start.Add(time.Second)})
+	a.Record(Event{Method: "GET", Route: "/user/:id", Status: 500, Message: "connection refused", Time: start})
+	a.Record(Event{Method: "POST", Route: "/user/:id", Status: 500, Message: "record 56 not found", Time: start})
+
+	batches := a.Flush(start.Add(2 * time.Second))
+	if len(batches) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(batches))
+	}
+	fp := Fingerprint("GET", "/user/:id", "record 99 not found")
+	var found bool
+	for _, b := range batches {
+		if b.Fingerprint != fp {
+			continue
+		}
+		found = true
+		if b.Count != 2 || b.WindowCount != 2 || b.IP != "10.0.0.2" || !b.First.Equal(start) || b.Message != "record 34 not found" {
+			t.Errorf("unexpected batch: %+v", b)
+		}
+	}
+	if !found {
+		t.Fatal("messages differing only in numbers should share a fingerprint")
+	}
+	if b := a.Flush(start.Add(3 * time.Second)); len(b) != 0 {
+		t.Fatalf("flushed groups should be empty until new errors: %+v", b)
+	}
+}
+
+func TestAggregatorWindow(t *testing.T) {
+	a := New(time.Minute)
+	start := time.Unix(1700000000, 0)
+	ev := Event{Method: "GET", Route: "/a", Status: 500, Message: "boom", Time: start}
+	a.Record(ev)
+	a.Flush(start)
+	ev.Time = start.Add(30 * time.Second)
+	a.Record(ev)
+	if b := a.Flush(ev.Time); len(b) != 1 || b[0].Count != 1 || b[0].WindowCount != 2 {
+		t.Fatalf("window count should span flushes: %+v", b)
+	}
+	ev.Time = start.Add(61 * time.Second)
+	a.Record(ev)
+	if b := a.Flush(ev.Time); len(b) != 1 || b[0].WindowCount != 1 {
+		t.Fatalf("window count should restart after the window: %+v", b)
+	}
+	a.Flush(start.Add(3 * time.Minute))
+	if len(a.groups) != 0 {
+		t.Fatal("idle groups should be removed after the window")
+	}
+}
+
+func TestAggregatorTruncatesByRune(t *testing.T) {
+	a := New(time.Minute)
+	start := time.Unix(1700000000, 0)
+	a.Record(Event{Method: "GET", Route: "/db", Status: 500, Message: strings.Repeat("数据库连接失败", 200), Time: start})
+	b := a.Flush(start.Add(time.Second))
+	if len(b) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(b))
+	}
+	if msg := b[0].Message; !utf8.ValidString(msg) || utf8.RuneCountInString(msg) != maxMessageLength {
+		t.Fatalf("message should be truncated to %d runes: %d bytes", maxMessageLength, len(msg))
+	}
+}
+
+func TestWebhookBody(t *testing.T) {
+	n := Notification{Title: "t", Content: "c", Status: 500}
+	cases := map[string]string{
+		FormatSlack:    `{"text":"t\nc"}`,
+		FormatDingTalk: `{"msgtype":"text","text":{"content":"t\nc"}}`,
+		FormatFeishu:   `{"content":{"text":"t\nc"},"msg_type":"text"}`,
+	}
+	for format, want := range cases {
+		got, err := WebhookBody(format, n)
+		if err != nil || string(got) != want {
+			t.Errorf("%s: got %s, %v", format, got, err)
+		}
+	}
+	got, _ := WebhookBody(FormatGeneric, n)
+	var generic Notification
+	if err := json.Unmarshal(got, &generic); err != nil || generic.Status != 500 {
+		t.Errorf("generic body = %s", got)
+	}
+}
@@ -0,0 +1,28 @@
+package alert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// defaultWindow 未配置 alert.window 时的统计窗口
+const defaultWindow = 5 * time.Minute
+
+var (
+	defaultOnce       sync.Once
+	defaultAggregator *Aggregator
+)
+
+// Default 系统使用的错误聚合器 统计窗口取 alert.window
+func Default() *Aggregator {
+	defaultOnce.Do(func() {
+		window := time.Duration(global.GVA_CONFIG.Alert.Window) * time.Second
+		if window <= 0 {
+			window = defaultWindow
+		}
+		defaultAggregator = New(window)
+	})
+	return defaultAggregator
+}
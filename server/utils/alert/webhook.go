@@ -0,0 +1,46 @@
+package alert
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// webhook 消息格式
+const (
+	FormatGeneric  = "generic"  // 通用json 包含告警的全部字段
+	FormatSlack    = "slack"    // Slack Incoming Webhook
+	FormatDingTalk = "dingtalk" // 钉钉群机器人
+	FormatFeishu   = "feishu"   // 飞书群机器人
+	FormatWeCom    = "wecom"    // 企业微信群机器人
+)
+
+// Notification 告警通知
+type Notification struct {
+	AlertID     uint      `json:"alertId"`
+	Fingerprint string    `json:"fingerprint"`
+	Method      string    `json:"method"`
+	Route       string    `json:"route"`
+	Status      int       `json:"status"`
+	Message     string    `json:"message"`
+	Count       int64     `json:"count"` // 自上次通知以来的次数
+	Total       int64     `json:"total"` // 告警累计次数
+	FirstSeen   time.Time `json:"firstSeen"`
+	LastSeen    time.Time `json:"lastSeen"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"` // 纯文本内容
+}
+
+// WebhookBody 按格式生成webhook请求体 未知格式按通用json处理
+func WebhookBody(format string, n Notification) ([]byte, error) {
+	text := n.Title + "\n" + n.Content
+	var body interface{} = n
+	switch format {
+	case FormatSlack:
+		body = map[string]string{"text": text}
+	case FormatDingTalk, FormatWeCom:
+		body = map[string]interface{}{"msgtype": "text", "text": map[string]string{"content": text}}
+	case FormatFeishu:
+		body = map[string]interface{}{"msg_type": "text", "content": map[string]string{"text": text}}
+	}
+	return json.Marshal(body)
+}
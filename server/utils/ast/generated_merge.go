@@ -0,0 +1,321 @@
+package ast
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MergeGenerated 将重新生成的代码合并进已生成的go文件 用于更新已生成的模块
+// before 为按旧结构生成的代码 after 为按新结构生成的代码 current 为磁盘上的文件
+// 新增的声明与导包直接追加 结构体只追加缺少的字段 手写的声明保持不变 手动删除的声明不再追加
+// 与 before 一致(未被手动修改)的声明替换为 after 中的版本 已被修改的声明保持不变并作为冲突返回
+func MergeGenerated(before, after, current []byte) ([]byte, []string, error) {
+	base, err := parseGenerated("before", before)
+	if err != nil {
+		return nil, nil, err
+	}
+	theirs, err := parseGenerated("after", after)
+	if err != nil {
+		return nil, nil, err
+	}
+	mine, err := parseGenerated("current", current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var edits []generatedEdit
+	var conflicts []string
+	var appends []string
+	for _, decl := range theirs.decls {
+		old, ok := mine.index[decl.key]
+		if !ok {
+			prev, existed := base.index[decl.key]
+			switch {
+			case !existed:
+				appends = append(appends, theirs.text(decl))
+			case normalizeDecl(base.text(prev)) != normalizeDecl(theirs.text(decl)):
+				conflicts = append(conflicts, decl.key)
+			} // before 中存在而当前文件中没有 为手动删除 不再追加 重新生成的版本有变化时作为冲突返回
+			continue
+		}
+		afterText := normalizeDecl(theirs.text(decl))
+		currentText := normalizeDecl(mine.text(old))
+		if afterText == currentText {
+			continue
+		}
+		beforeText := ""
+		if prev, ok := base.index[decl.key]; ok {
+			beforeText = normalizeDecl(base.text(prev))
+		}
+		if afterText == beforeText {
+			continue
+		}
+		if currentText == beforeText {
+			edits = append(edits, generatedEdit{start: old.start, end: old.end, text: theirs.text(decl)})
+			continue
+		}
+		if fields := mine.missingFields(old, theirs, decl); fields != nil {
+			edits = append(edits, *fields)
+			continue
+		}
+		conflicts = append(conflicts, decl.key)
+	}
+	if imports := mine.missingImports(theirs); imports != nil {
+		edits = append(edits, *imports)
+	}
+	if len(appends) > 0 {
+		var builder strings.Builder
+		if !bytes.HasSuffix(current, []byte("\n")) {
+			builder.WriteString("\n")
+		}
+		for _, text := range appends {
+			builder.WriteString("\n")
+			builder.WriteString(text)
+			builder.WriteString("\n")
+		}
+		edits = append(edits, generatedEdit{start: len(current), end: len(current), text: builder.String()})
+	}
+	if len(edits) == 0 {
+		return current, conflicts, nil
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var buffer bytes.Buffer
+	offset := 0
+	for _, edit := range edits {
+		buffer.Write(current[offset:edit.start])
+		buffer.WriteString(edit.text)
+		offset = edit.end
+	}
+	buffer.Write(current[offset:])
+	merged := buffer.Bytes()
+	if formatted, err := format.Source(current); err == nil && bytes.Equal(formatted, current) {
+		merged, err = format.Source(merged)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "合并后的代码格式化失败!")
+		}
+	} // 原文件已格式化时 合并结果同样格式化
+	return merged, conflicts, nil
+}
+
+// generatedEdit 对原文件 [start, end) 区间的替换
+type generatedEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// generatedDecl 顶层声明及其在源码中的位置 包含文档注释
+type generatedDecl struct {
+	key   string
+	decl  ast.Decl
+	start int
+	end   int
+}
+
+type generatedFile struct {
+	src     []byte
+	fileSet *token.FileSet
+	file    *ast.File
+	decls   []generatedDecl
+	index   map[string]generatedDecl
+}
+
+func parseGenerated(name string, src []byte) (*generatedFile, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, name, src, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[%s]解析代码失败!", name)
+	}
+	entity := &generatedFile{src: src, fileSet: fileSet, file: file, index: make(map[string]generatedDecl)}
+	for _, decl := range file.Decls {
+		key := declKey(decl)
+		if key == "" {
+			continue
+		}
+		item := generatedDecl{key: key, decl: decl, start: entity.offset(decl.Pos()), end: entity.offset(decl.End())}
+		switch v := decl.(type) {
+		case *ast.FuncDecl:
+			if v.Doc != nil {
+				item.start = entity.offset(v.Doc.Pos())
+			}
+		case *ast.GenDecl:
+			if v.Doc != nil {
+				item.start = entity.offset(v.Doc.Pos())
+			}
+		}
+		entity.decls = append(entity.decls, item)
+		entity.index[key] = item
+	}
+	return entity, nil
+}
+
+func (f *generatedFile) offset(pos token.Pos) int {
+	return f.fileSet.Position(pos).Offset
+}
+
+func (f *generatedFile) text(decl generatedDecl) string {
+	return string(f.src[decl.start:decl.end])
+}
+
+// missingFields 结构体已被手动修改时 只追加 after 中新增的字段
+func (f *generatedFile) missingFields(decl generatedDecl, theirs *generatedFile, other generatedDecl) *generatedEdit {
+	current := structOf(decl.decl)
+	after := structOf(other.decl)
+	if current == nil || after == nil {
+		return nil
+	}
+	exists := make(map[string]bool, len(current.Fields.List))
+	for _, field := range current.Fields.List {
+		for _, name := range fieldNames(f, field) {
+			exists[name] = true
+		}
+	}
+	var builder strings.Builder
+	for _, field := range after.Fields.List {
+		names := fieldNames(theirs, field)
+		if len(names) == 0 || exists[names[0]] {
+			continue
+		}
+		start, end := field.Pos(), field.End()
+		if field.Doc != nil {
+			start = field.Doc.Pos()
+		}
+		if field.Comment != nil {
+			end = field.Comment.End()
+		}
+		builder.WriteString("\t")
+		builder.WriteString(string(theirs.src[theirs.offset(start):theirs.offset(end)]))
+		builder.WriteString("\n")
+	}
+	if builder.Len() == 0 {
+		return nil
+	}
+	closing := f.offset(current.Fields.Closing)
+	lineStart := bytes.LastIndexByte(f.src[:closing], '\n') + 1
+	if lineStart <= f.offset(current.Fields.Opening) {
+		return &generatedEdit{start: closing, end: closing, text: "\n" + builder.String()}
+	} // struct{} 写在同一行
+	return &generatedEdit{start: lineStart, end: lineStart, text: builder.String()}
+}
+
+// missingImports 追加 after 中使用而当前文件缺少的导包
+func (f *generatedFile) missingImports(theirs *generatedFile) *generatedEdit {
+	exists := make(map[string]bool, len(f.file.Imports))
+	for _, spec := range f.file.Imports {
+		exists[spec.Path.Value] = true
+	}
+	var specs []string
+	for _, spec := range theirs.file.Imports {
+		if exists[spec.Path.Value] {
+			continue
+		}
+		exists[spec.Path.Value] = true
+		text := spec.Path.Value
+		if spec.Name != nil {
+			text = spec.Name.Name + " " + text
+		}
+		specs = append(specs, text)
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+	for _, decl := range f.file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		if genDecl.Lparen.IsValid() {
+			rparen := f.offset(genDecl.Rparen)
+			lineStart := bytes.LastIndexByte(f.src[:rparen], '\n') + 1
+			return &generatedEdit{start: lineStart, end: lineStart, text: "\t" + strings.Join(specs, "\n\t") + "\n"}
+		}
+		end := f.offset(genDecl.End())
+		return &generatedEdit{start: end, end: end, text: "\nimport " + strings.Join(specs, "\nimport ")}
+	}
+	end := f.offset(f.file.Name.End())
+	return &generatedEdit{start: end, end: end, text: "\n\nimport (\n\t" + strings.Join(specs, "\n\t") + "\n)"}
+}
+
+// declKey 顶层声明的唯一标识 方法为 接收者.方法名 导包不参与比较
+func declKey(decl ast.Decl) string {
+	switch v := decl.(type) {
+	case *ast.FuncDecl:
+		if v.Recv != nil && len(v.Recv.List) > 0 {
+			return "func " + recvName(v.Recv.List[0].Type) + "." + v.Name.Name
+		}
+		return "func " + v.Name.Name
+	case *ast.GenDecl:
+		if v.Tok == token.IMPORT {
+			return ""
+		}
+		var names []string
+		for _, spec := range v.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					names = append(names, name.Name)
+				}
+			}
+		}
+		return v.Tok.String() + " " + strings.Join(names, ",")
+	}
+	return ""
+}
+
+func recvName(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.StarExpr:
+		return recvName(v.X)
+	case *ast.IndexExpr:
+		return recvName(v.X)
+	case *ast.IndexListExpr:
+		return recvName(v.X)
+	case *ast.Ident:
+		return v.Name
+	}
+	return ""
+}
+
+func structOf(decl ast.Decl) *ast.StructType {
+	genDecl, ok := decl.(*ast.GenDecl)
+	if !ok || genDecl.Tok != token.TYPE || len(genDecl.Specs) != 1 {
+		return nil
+	}
+	spec, ok := genDecl.Specs[0].(*ast.TypeSpec)
+	if !ok {
+		return nil
+	}
+	structType, _ := spec.Type.(*ast.StructType)
+	return structType
+}
+
+// fieldNames 字段名 匿名字段使用类型名
+func fieldNames(f *generatedFile, field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		return []string{string(f.src[f.offset(field.Type.Pos()):f.offset(field.Type.End())])}
+	}
+	names := make([]string, 0, len(field.Names))
+	for _, name := range field.Names {
+		names = append(names, name.Name)
+	}
+	return names
+}
+
+// normalizeDecl 格式化后比较 忽略空白差异
+func normalizeDecl(text string) string {
+	formatted, err := format.Source([]byte(text))
+	if err != nil {
+		return strings.TrimSpace(text)
+	}
+	return strings.TrimSpace(string(formatted))
+}
@@ -0,0 +1,125 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+)
+
+const mergeBefore = `package example
+
+import "github.com/flipped-aurora/gin-vue-admin/server/global"
+
+// Book 图书
+type Book struct {
+	Title *string ` + "`json:\"title\"`" + `
+}
+
+// GetBookInfoList 分页获取图书
+func (s *BookService) GetBookInfoList(title string) {
+	db := global.GVA_DB
+	if title != "" {
+		db = db.Where("title LIKE ?", "%"+title+"%")
+	}
+}
+
+// CreateBook 创建图书
+func (s *BookService) CreateBook(book *Book) error {
+	return global.GVA_DB.Create(book).Error
+}
+`
+
+const mergeAfter = `package example
+
+import (
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"time"
+)
+
+// Book 图书
+type Book struct {
+	Title  *string    ` + "`json:\"title\"`" + `
+	Author *string    ` + "`json:\"author\"`" + ` // 作者
+	Born   *time.Time ` + "`json:\"born\"`" + `
+}
+
+// GetBookInfoList 分页获取图书
+func (s *BookService) GetBookInfoList(title, author string) {
+	db := global.GVA_DB
+	if title != "" {
+		db = db.Where("title LIKE ?", "%"+title+"%")
+	}
+	if author != "" {
+		db = db.Where("author = ?", author)
+	}
+}
+
+// CreateBook 创建图书
+func (s *BookService) CreateBook(book *Book) error {
+	return global.GVA_DB.Create(book).Error
+}
+
+// GetBookDataSource 获取数据源
+func (s *BookService) GetBookDataSource() {}
+`
+
+func TestMergeGenerated(t *testing.T) {
+	current := strings.Replace(mergeBefore, "return global.GVA_DB.Create(book).Error", "book.Title = nil\n\treturn global.GVA_DB.Create(book).Error", 1)
+	current = strings.Replace(current, "type Book struct {\n", "type Book struct {\n\tRemark string // 手写字段\n", 1)
+	current += "\n// CountBook 手写方法\nfunc (s *BookService) CountBook() {}\n"
+
+	merged, conflicts, err := MergeGenerated([]byte(mergeBefore), []byte(mergeAfter), []byte(current))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %v", conflicts)
+	}
+	code := string(merged)
+	for _, want := range []string{
+		`"time"`,
+		"Remark string // 手写字段",
+		"Author *string",
+		"// 作者",
+		`db.Where("author = ?", author)`,
+		"book.Title = nil",
+		"func (s *BookService) CountBook() {}",
+		"func (s *BookService) GetBookDataSource() {}",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("merged code should contain %s\n%s", want, code)
+		}
+	}
+
+	current = strings.Replace(mergeBefore, "db := global.GVA_DB\n", "db := global.GVA_DB.Debug()\n", 1)
+	merged, conflicts, err = MergeGenerated([]byte(mergeBefore), []byte(mergeAfter), []byte(current))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "func BookService.GetBookInfoList" {
+		t.Fatalf("conflicts = %v", conflicts)
+	}
+	if !strings.Contains(string(merged), "global.GVA_DB.Debug()") || strings.Contains(string(merged), "author = ?") {
+		t.Errorf("modified function should be kept\n%s", merged)
+	}
+
+	// 手动删除的生成声明不再追加 重新生成的版本有变化时作为冲突返回
+	current = strings.Replace(mergeBefore, "// CreateBook 创建图书\nfunc (s *BookService) CreateBook(book *Book) error {\n\treturn global.GVA_DB.Create(book).Error\n}\n", "", 1)
+	merged, conflicts, err = MergeGenerated([]byte(mergeBefore), []byte(mergeAfter), []byte(current))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %v", conflicts)
+	}
+	if strings.Contains(string(merged), "CreateBook") || !strings.Contains(string(merged), "GetBookDataSource") {
+		t.Errorf("deleted function should not be re-added\n%s", merged)
+	}
+	changed := strings.Replace(mergeAfter, "return global.GVA_DB.Create(book).Error", "return global.GVA_DB.Omit(\"id\").Create(book).Error", 1)
+	merged, conflicts, err = MergeGenerated([]byte(mergeBefore), []byte(changed), []byte(current))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "func BookService.CreateBook" || strings.Contains(string(merged), "CreateBook") {
+		t.Errorf("conflicts = %v\n%s", conflicts, merged)
+	}
+}
@@ -14,10 +14,13 @@ import (
 	"strings"
 )
 
-type Base struct{}
+type Base struct {
+	fileSet *token.FileSet // 解析时使用的文件集 格式化时沿用以保持注释位置
+}
 
 func (a *Base) Parse(filename string, writer io.Writer) (file *ast.File, err error) {
 	fileSet := token.NewFileSet()
+	a.fileSet = fileSet
 	if writer != nil {
 		file, err = parser.ParseFile(fileSet, filename, nil, parser.ParseComments)
 	} else {
@@ -38,7 +41,10 @@ func (a *Base) Injection(file *ast.File) error {
 }
 
 func (a *Base) Format(filename string, writer io.Writer, file *ast.File) error {
-	fileSet := token.NewFileSet()
+	fileSet := a.fileSet
+	if fileSet == nil {
+		fileSet = token.NewFileSet()
+	}
 	if writer == nil {
 		open, err := os.OpenFile(filename, os.O_WRONLY|os.O_TRUNC, 0666)
 		defer open.Close()
@@ -5,19 +5,21 @@ import (
 	"go/ast"
 	"go/token"
 	"io"
+	"slices"
 )
 
 // PackageInitializeGorm 包初始化gorm
 type PackageInitializeGorm struct {
 	Base
-	Type         Type   // 类型
-	Path         string // 文件路径
-	ImportPath   string // 导包路径
-	Business     string // 业务库 gva => gva, 不要传"gva"
-	StructName   string // 结构体名称
-	PackageName  string // 包名
-	RelativePath string // 相对路径
-	IsNew        bool   // 是否使用new关键字 true: new(PackageName.StructName) false: &PackageName.StructName{}
+	Type         Type     // 类型
+	Path         string   // 文件路径
+	ImportPath   string   // 导包路径
+	Business     string   // 业务库 gva => gva, 不要传"gva"
+	StructName   string   // 结构体名称
+	PackageName  string   // 包名
+	RelativePath string   // 相对路径
+	IsNew        bool     // 是否使用new关键字 true: new(PackageName.StructName) false: &PackageName.StructName{}
+	SubStructs   []string // 子表结构体名称 与主表一同迁移
 }
 
 func (a *PackageInitializeGorm) Parse(filename string, writer io.Writer) (file *ast.File, err error) {
@@ -68,7 +70,7 @@ func (a *PackageInitializeGorm) Rollback(file *ast.File) error {
 					if x, identok := selector.X.(*ast.Ident); identok {
 						if x.Name == a.PackageName {
 							packageNameNum++
-							if selector.Sel.Name == a.StructName {
+							if selector.Sel.Name == a.StructName || slices.Contains(a.SubStructs, selector.Sel.Name) {
 								callExpr.Args = append(callExpr.Args[:i], callExpr.Args[i+1:]...)
 								i--
 							}
@@ -80,7 +82,7 @@ func (a *PackageInitializeGorm) Rollback(file *ast.File) error {
 		return true
 	})
 
-	if packageNameNum == 1 {
+	if packageNameNum == 1+len(a.SubStructs) {
 		_ = NewImport(a.ImportPath).Rollback(file)
 	}
 	return nil
@@ -118,18 +120,38 @@ func (a *PackageInitializeGorm) Injection(file *ast.File) error {
 			return true
 		}
 
-		// 添加结构体参数
-		callExpr.Args = append(callExpr.Args, &ast.CompositeLit{
-			Type: &ast.SelectorExpr{
-				X:   ast.NewIdent(a.PackageName),
-				Sel: ast.NewIdent(a.StructName),
-			},
-		})
+		// 已存在的结构体不重复添加 保证重复生成结果一致
+		for _, name := range append([]string{a.StructName}, a.SubStructs...) {
+			if a.hasArg(callExpr, name) {
+				continue
+			}
+			// 添加结构体参数
+			callExpr.Args = append(callExpr.Args, &ast.CompositeLit{
+				Type: &ast.SelectorExpr{
+					X:   ast.NewIdent(a.PackageName),
+					Sel: ast.NewIdent(name),
+				},
+			})
+		}
 		return true
 	})
 	return nil
 }
 
+// hasArg AutoMigrate 参数中是否已存在结构体
+func (a *PackageInitializeGorm) hasArg(callExpr *ast.CallExpr, structName string) bool {
+	for _, arg := range callExpr.Args {
+		if com, comok := arg.(*ast.CompositeLit); comok {
+			if selector, exprok := com.Type.(*ast.SelectorExpr); exprok {
+				if x, identok := selector.X.(*ast.Ident); identok && x.Name == a.PackageName && selector.Sel.Name == structName {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func (a *PackageInitializeGorm) Format(filename string, writer io.Writer, file *ast.File) error {
 	if filename == "" {
 		filename = a.Path
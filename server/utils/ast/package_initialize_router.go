@@ -109,6 +109,25 @@ func (a *PackageInitializeRouter) Injection(file *ast.File) error {
 			},
 		}
 	}
+	// 已注册过的路由不重复注入 保证重复生成结果一致
+	for _, stmt := range varBlock.List {
+		exprStmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		callExpr, ok := exprStmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := selExpr.X.(*ast.Ident)
+		if ok && ident.Name == a.ModuleName && selExpr.Sel.Name == a.FunctionName {
+			return nil
+		}
+	}
 	routerStmt := CreateStmt(fmt.Sprintf("%s.%s(%s,%s)", a.ModuleName, a.FunctionName, a.LeftRouterGroupName, a.RightRouterGroupName))
 	varBlock.List = append(varBlock.List, routerStmt)
 	if !hasRouter {
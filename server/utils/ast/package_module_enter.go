@@ -83,6 +83,16 @@ func (a *PackageModuleEnter) Injection(file *ast.File) error {
 	_ = NewImport(a.ImportPath).Injection(file)
 	var hasValue bool
 	var hasVariables bool
+	// 变量已存在时不重复注入 保证重复生成结果一致
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.VAR {
+			for _, spec := range genDecl.Specs {
+				if valueSpec, ok := spec.(*ast.ValueSpec); ok && len(valueSpec.Names) == 1 && valueSpec.Names[0].Name == a.ModuleName {
+					hasValue = true
+				}
+			}
+		}
+	}
 	for i := 0; i < len(file.Decls); i++ {
 		v1, o1 := file.Decls[i].(*ast.GenDecl)
 		if o1 {
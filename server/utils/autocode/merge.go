@@ -0,0 +1,101 @@
+package autocode
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// mergeContext 应用差异时用于定位的上下文行数
+const mergeContext = 3
+
+// MergeText 按行合并重新生成的代码 用于更新已生成模块的前端文件
+// 以 before 到 after 的差异为补丁 依据上下文应用到 current 上
+// 上下文已被手动修改而无法定位的差异保持不变 以 before 中的行号作为冲突返回
+func MergeText(before, after, current string) (string, []string) {
+	if before == after {
+		return current, nil
+	}
+	if before == current {
+		return after, nil
+	}
+	a, b, c := splitLines(before), splitLines(after), splitLines(current)
+	opCodes := difflib.NewMatcher(a, b).GetOpCodes()
+	var (
+		out       []string
+		conflicts []string
+		cursor    int // current 中已处理到的行
+		prevEnd   int // 上一处差异在 before 中的结束行
+	)
+	for i, op := range opCodes {
+		if op.Tag == 'e' {
+			continue
+		}
+		nextStart := len(a)
+		if i+2 < len(opCodes) {
+			nextStart = opCodes[i+2].I1
+		}
+		pre := a[max(op.I1-mergeContext, prevEnd):op.I1]
+		post := a[op.I2:min(op.I2+mergeContext, nextStart)]
+		prevEnd = op.I2
+		at, skip := -1, 0
+		for n := mergeContext; n > 0 && at < 0; n-- {
+			p, q := pre[max(len(pre)-n, 0):], post[:min(n, len(post))]
+			pattern := make([]string, 0, len(p)+op.I2-op.I1+len(q))
+			pattern = append(append(append(pattern, p...), a[op.I1:op.I2]...), q...)
+			if len(pattern) == 0 {
+				break
+			}
+			at, skip = indexLines(c, cursor, pattern), len(p)
+		} // 上下文被修改时逐步缩小上下文重试
+		if at < 0 {
+			conflicts = append(conflicts, fmt.Sprintf("第%d行", op.I1+1))
+			continue
+		}
+		start := at + skip
+		out = append(out, c[cursor:start]...)
+		out = append(out, b[op.J1:op.J2]...)
+		cursor = start + op.I2 - op.I1
+	}
+	out = append(out, c[cursor:]...)
+	return strings.Join(out, ""), conflicts
+}
+
+// Diff 生成 unified diff 用于预览文件变更
+func Diff(name, before, after string) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(before),
+		B:        splitLines(after),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  mergeContext,
+	})
+	return diff
+}
+
+// splitLines 按行切分 保留换行符
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// indexLines 从 from 开始查找 pattern 在 lines 中首次出现的位置
+func indexLines(lines []string, from int, pattern []string) int {
+	for i := from; i+len(pattern) <= len(lines); i++ {
+		matched := true
+		for j := range pattern {
+			if lines[i+j] != pattern[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,34 @@
+package autocode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeText(t *testing.T) {
+	before := "<template>\n  <el-form>\n    <el-form-item label=\"标题\" />\n  </el-form>\n</template>\n<script setup>\nconst formData = ref({\n  title: '',\n})\n</script>\n"
+	after := "<template>\n  <el-form>\n    <el-form-item label=\"标题\" />\n    <el-form-item label=\"作者\" />\n  </el-form>\n</template>\n<script setup>\nconst formData = ref({\n  title: '',\n  author: '',\n})\n</script>\n"
+	current := strings.Replace(before, "<script setup>\n", "<script setup>\nimport { hand } from './hand'\n", 1)
+
+	merged, conflicts := MergeText(before, after, current)
+	if len(conflicts) != 0 {
+		t.Fatalf("conflicts = %v", conflicts)
+	}
+	for _, want := range []string{"label=\"作者\"", "author: ''", "import { hand } from './hand'"} {
+		if !strings.Contains(merged, want) {
+			t.Errorf("merged should contain %s\n%s", want, merged)
+		}
+	}
+
+	current = strings.Replace(before, "  title: '',\n", "  title: '未命名',\n", 1)
+	merged, conflicts = MergeText(before, after, current)
+	if len(conflicts) != 1 {
+		t.Fatalf("conflicts = %v", conflicts)
+	}
+	if !strings.Contains(merged, "label=\"作者\"") || strings.Contains(merged, "author: ''") {
+		t.Errorf("only the hunk with intact context should be applied\n%s", merged)
+	}
+	if diff := Diff("a.vue", current, merged); !strings.Contains(diff, "+    <el-form-item label=\"作者\" />") {
+		t.Errorf("diff = %s", diff)
+	}
+}
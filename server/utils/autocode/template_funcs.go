@@ -20,6 +20,10 @@ func GetTemplateFuncMap() template.FuncMap {
 		"GenerateFormItem":         GenerateFormItem,
 		"GenerateDescriptionItem":  GenerateDescriptionItem,
 		"GenerateDefaultFormValue": GenerateDefaultFormValue,
+		"GenerateSubTableCell":     GenerateSubTableCell,
+		"TestRecordNumbers":        TestRecordNumbers,
+		"GenerateTestValue":        GenerateTestValue,
+		"GenerateTestSearchCases":  GenerateTestSearchCases,
 	}
 }
 
@@ -87,22 +91,9 @@ func GenerateField(field systemReq.AutoCodeField) string {
 			field.FieldJson, field.FieldJson, gormTag)
 
 		// 对于int类型，根据DataTypeLong决定具体的Go类型
-		var fieldType string
+		fieldType := field.FieldType
 		if field.FieldType == "int" {
-			switch field.DataTypeLong {
-			case "1", "2", "3":
-				fieldType = "int8"
-			case "4", "5":
-				fieldType = "int16"
-			case "6", "7", "8", "9", "10":
-				fieldType = "int32"
-			case "11", "12", "13", "14", "15", "16", "17", "18", "19", "20":
-				fieldType = "int64"
-			default:
-				fieldType = "int64"
-			}
-		} else {
-			fieldType = field.FieldType
+			fieldType = intFieldType(field.DataTypeLong)
 		}
 
 		result = fmt.Sprintf(`%s  *%s `+"`"+`%s`+"`"+``,
@@ -121,6 +112,20 @@ func GenerateField(field systemReq.AutoCodeField) string {
 	return result
 }
 
+// intFieldType 根据DataTypeLong决定int类型字段在Model中的具体类型
+func intFieldType(dataTypeLong string) string {
+	switch dataTypeLong {
+	case "1", "2", "3":
+		return "int8"
+	case "4", "5":
+		return "int16"
+	case "6", "7", "8", "9", "10":
+		return "int32"
+	default:
+		return "int64"
+	}
+}
+
 // 格式化搜索条件语句
 func GenerateSearchConditions(fields []*systemReq.AutoCodeField) string {
 	var conditions []string
@@ -586,6 +591,34 @@ func GenerateFormItem(field systemReq.AutoCodeField) string {
 	return result
 }
 
+// GenerateSubTableCell 生成子表行内编辑单元格 绑定到表格当前行
+func GenerateSubTableCell(field systemReq.AutoCodeField) string {
+	switch field.FieldType {
+	case "bool":
+		return fmt.Sprintf(`<el-switch v-model="scope.row.%s" active-color="#13ce66" inactive-color="#ff4949" />`, field.FieldJson)
+	case "int":
+		return fmt.Sprintf(`<el-input v-model.number="scope.row.%s" :clearable="%v" placeholder="请输入%s" />`,
+			field.FieldJson, field.Clearable, field.FieldDesc)
+	case "float64":
+		return fmt.Sprintf(`<el-input-number v-model="scope.row.%s" style="width:100%%" :precision="2" controls-position="right" />`, field.FieldJson)
+	case "time.Time":
+		return fmt.Sprintf(`<el-date-picker v-model="scope.row.%s" type="date" style="width:100%%" placeholder="选择日期" :clearable="%v" />`,
+			field.FieldJson, field.Clearable)
+	case "enum":
+		return fmt.Sprintf(`<el-select v-model="scope.row.%s" placeholder="请选择%s" style="width:100%%" filterable :clearable="%v">
+    <el-option v-for="item in [%s]" :key="item" :label="item" :value="item" />
+</el-select>`, field.FieldJson, field.FieldDesc, field.Clearable, field.DataTypeLong)
+	default:
+		if field.DictType != "" {
+			return fmt.Sprintf(`<el-select v-model="scope.row.%s" placeholder="请选择%s" style="width:100%%" filterable :clearable="%v">
+    <el-option v-for="(item,key) in %sOptions" :key="key" :label="item.label" :value="item.value" />
+</el-select>`, field.FieldJson, field.FieldDesc, field.Clearable, field.DictType)
+		}
+		return fmt.Sprintf(`<el-input v-model="scope.row.%s" :clearable="%v" placeholder="请输入%s" />`,
+			field.FieldJson, field.Clearable, field.FieldDesc)
+	}
+}
+
 func GenerateDescriptionItem(field systemReq.AutoCodeField) string {
 	// 开始构建描述项
 	result := fmt.Sprintf(`<el-descriptions-item label="%s">
@@ -0,0 +1,372 @@
+package autocode
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/flipped-aurora/gin-vue-admin/server/utils/ast"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplatePackManifests 模板包描述文件 按顺序查找
+var TemplatePackManifests = []string{"manifest.yaml", "manifest.yml", "manifest.json"}
+
+// templatePackInjections 各内置模板可用的AST注入
+var templatePackInjections = map[string][]string{
+	"package": {
+		ast.TypePackageApiEnter, ast.TypePackageRouterEnter, ast.TypePackageServiceEnter,
+		ast.TypePackageApiModuleEnter, ast.TypePackageRouterModuleEnter, ast.TypePackageServiceModuleEnter,
+		ast.TypePackageInitializeGorm, ast.TypePackageInitializeRouter,
+	},
+	"plugin": {
+		ast.TypePluginGen, ast.TypePluginApiEnter, ast.TypePluginRouterEnter, ast.TypePluginServiceEnter,
+		ast.TypePluginInitializeV2, ast.TypePluginInitializeGorm, ast.TypePluginInitializeRouter,
+	},
+}
+
+var templatePackNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// TemplatePack 代码生成模板包 由目录或zip包根目录下的manifest描述
+// 可以覆盖内置模板、新增生成文件 并声明生成的代码依赖的AST注入
+type TemplatePack struct {
+	Name        string             `json:"name" yaml:"name"`               // 模板包名称 与存放目录名一致
+	Description string             `json:"description" yaml:"description"` // 描述
+	Base        string             `json:"base" yaml:"base"`               // 适用的内置模板 package 或 plugin
+	Files       []TemplatePackFile `json:"files" yaml:"files"`             // 模板文件
+	Injections  []string           `json:"injections" yaml:"injections"`   // 依赖的AST注入 为空时保留内置模板的全部注入
+	Dir         string             `json:"-" yaml:"-"`                     // 模板包所在目录
+}
+
+// TemplatePackFile 模板包中的模板文件
+type TemplatePackFile struct {
+	Template string `json:"template" yaml:"template"` // 模板包内的相对路径 如 server/service/service.go.tpl
+	Target   string `json:"target" yaml:"target"`     // 生成路径 以server/或web/开头 可以使用模板变量 为空时覆盖内置模板中相同路径的模板
+}
+
+// LoadTemplatePack 读取目录下的模板包描述文件 未知字段视为错误
+func LoadTemplatePack(dir string) (*TemplatePack, error) {
+	for _, name := range TemplatePackManifests {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "读取模板包描述文件[%s]失败!", name)
+		}
+		var pack TemplatePack
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(&pack); err != nil {
+			return nil, errors.Wrapf(err, "解析模板包描述文件[%s]失败!", name)
+		}
+		pack.Dir = dir
+		return &pack, nil
+	}
+	return nil, errors.Errorf("模板包缺少描述文件%s!", strings.Join(TemplatePackManifests, "/"))
+}
+
+// Validate 校验模板包 builtinDir 为对应内置模板的目录 返回全部不合法项
+func (p *TemplatePack) Validate(builtinDir string) error {
+	var errs []string
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if !templatePackNameRegexp.MatchString(p.Name) {
+		add("name[%s]只能包含字母、数字、下划线及中划线", p.Name)
+	}
+	injections, ok := templatePackInjections[p.Base]
+	if !ok {
+		add("base只能为package或plugin")
+	}
+	if len(p.Files) == 0 {
+		add("files不能为空")
+	}
+	seen := make(map[string]bool, len(p.Files))
+	for i, file := range p.Files {
+		prefix := fmt.Sprintf("files[%d](%s)", i, file.Template)
+		if !templatePackPath(file.Template) || !strings.HasSuffix(file.Template, ".tpl") {
+			add("%s template必须为模板包内以.tpl结尾的相对路径", prefix)
+			continue
+		}
+		if seen[file.Template] {
+			add("%s template重复", prefix)
+		}
+		seen[file.Template] = true
+		data, err := os.ReadFile(filepath.Join(p.Dir, filepath.FromSlash(file.Template)))
+		if err != nil {
+			add("%s 模板文件不存在", prefix)
+			continue
+		}
+		for _, e := range ValidateTemplateVariables(file.Template, string(data)) {
+			add("%s %s", prefix, e)
+		}
+		if file.Target == "" {
+			if _, err = os.Stat(filepath.Join(builtinDir, filepath.FromSlash(file.Template))); err != nil {
+				add("%s 未指定target时必须覆盖%s模板中已有的模板", prefix, p.Base)
+			}
+			continue
+		}
+		for _, e := range ValidateTemplateVariables(file.Template+"#target", file.Target) {
+			add("%s target %s", prefix, e)
+		}
+		if !strings.HasPrefix(file.Target, "server/") && !strings.HasPrefix(file.Target, "web/") {
+			add("%s target必须以server/或web/开头", prefix)
+		}
+		if strings.Contains(file.Target, "..") || strings.Contains(file.Target, `\`) {
+			add("%s target不能包含..或\\", prefix)
+		}
+	}
+	for _, injection := range p.Injections {
+		found := false
+		for _, name := range injections {
+			if name == injection {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add("injections[%s]不是%s模板可用的AST注入", injection, p.Base)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// Overrides 覆盖内置模板的模板文件 key 为相对内置模板目录的路径 value 为模板包中的绝对路径
+func (p *TemplatePack) Overrides() map[string]string {
+	overrides := make(map[string]string)
+	for _, file := range p.Files {
+		if file.Target == "" {
+			overrides[filepath.FromSlash(file.Template)] = filepath.Join(p.Dir, filepath.FromSlash(file.Template))
+		}
+	}
+	return overrides
+}
+
+// Target 渲染新增文件的生成路径 返回以server/或web/开头的相对路径
+func (p *TemplatePack) Target(file TemplatePackFile, info systemReq.AutoCode) (string, error) {
+	tmpl, err := template.New(file.Template).Funcs(GetTemplateFuncMap()).Parse(file.Target)
+	if err != nil {
+		return "", errors.Wrapf(err, "[%s]解析生成路径失败!", file.Template)
+	}
+	var builder strings.Builder
+	if err = tmpl.Execute(&builder, info); err != nil {
+		return "", errors.Wrapf(err, "[%s]渲染生成路径失败!", file.Template)
+	}
+	target := builder.String()
+	if !templatePackPath(target) {
+		return "", errors.Errorf("[%s]生成路径[%s]不合法!", file.Template, target)
+	}
+	return target, nil
+}
+
+// HasInjection 生成时是否保留该类型的AST注入
+func (p *TemplatePack) HasInjection(typ string) bool {
+	if len(p.Injections) == 0 {
+		return true
+	}
+	for _, injection := range p.Injections {
+		if injection == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// templatePackPath 是否为不越出目录的相对路径
+func templatePackPath(name string) bool {
+	if name == "" || strings.Contains(name, `\`) || path.IsAbs(name) {
+		return false
+	}
+	clean := path.Clean(name)
+	return clean == name && clean != "." && !strings.HasPrefix(clean, "../") && clean != ".."
+}
+
+// ValidateTemplateVariables 校验模板中引用的变量在 AutoCode 中存在 返回不存在的变量
+// 按 range/with 推导当前作用域的类型 无法推导类型时(如函数返回值)不再校验
+func ValidateTemplateVariables(name, text string) []string {
+	tmpl, err := template.New(name).Funcs(GetTemplateFuncMap()).Parse(text)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	root := reflect.TypeOf(systemReq.AutoCode{})
+	var errs []string
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		checker := &templateChecker{tree: t.Tree, root: root}
+		checker.walk(t.Tree.Root, root, map[string]reflect.Type{"$": root})
+		errs = append(errs, checker.errs...)
+	}
+	return errs
+}
+
+// templateChecker 遍历模板语法树校验变量
+type templateChecker struct {
+	tree *parse.Tree
+	root reflect.Type
+	errs []string
+}
+
+func (c *templateChecker) walk(node parse.Node, dot reflect.Type, vars map[string]reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, item := range n.Nodes {
+			c.walk(item, dot, vars)
+		}
+	case *parse.ActionNode:
+		typ := c.pipe(n.Pipe, dot, vars)
+		for _, v := range n.Pipe.Decl {
+			vars[v.Ident[0]] = typ
+		}
+	case *parse.IfNode:
+		c.branch(&n.BranchNode, dot, vars, false)
+	case *parse.WithNode:
+		c.branch(&n.BranchNode, dot, vars, true)
+	case *parse.RangeNode:
+		scope := copyTemplateVars(vars)
+		typ := c.pipe(n.Pipe, dot, scope)
+		key, elem := templateRangeTypes(typ)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			scope[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			scope[n.Pipe.Decl[0].Ident[0]] = key
+			scope[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		c.walk(n.List, elem, scope)
+		if n.ElseList != nil {
+			c.walk(n.ElseList, dot, copyTemplateVars(vars))
+		}
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			c.pipe(n.Pipe, dot, vars)
+		}
+	}
+}
+
+// branch if/with 的两个分支 with 的主分支中 . 为管道的值
+func (c *templateChecker) branch(n *parse.BranchNode, dot reflect.Type, vars map[string]reflect.Type, with bool) {
+	scope := copyTemplateVars(vars)
+	typ := c.pipe(n.Pipe, dot, scope)
+	for _, v := range n.Pipe.Decl {
+		scope[v.Ident[0]] = typ
+	}
+	inner := dot
+	if with {
+		inner = typ
+	}
+	c.walk(n.List, inner, scope)
+	if n.ElseList != nil {
+		c.walk(n.ElseList, dot, copyTemplateVars(vars))
+	}
+}
+
+// pipe 校验管道中的参数 只有单个参数的管道返回其类型
+func (c *templateChecker) pipe(pipe *parse.PipeNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var typ reflect.Type
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			typ = c.arg(arg, dot, vars)
+		}
+	}
+	if len(pipe.Cmds) == 1 && len(pipe.Cmds[0].Args) == 1 {
+		return typ
+	}
+	return nil
+}
+
+func (c *templateChecker) arg(node parse.Node, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return c.field(node, dot, n.Ident)
+	case *parse.VariableNode:
+		return c.field(node, vars[n.Ident[0]], n.Ident[1:])
+	case *parse.ChainNode:
+		return c.field(node, c.arg(n.Node, dot, vars), n.Field)
+	case *parse.PipeNode:
+		return c.pipe(n, dot, vars)
+	}
+	return nil
+}
+
+// field 按字段链推导类型 字段或方法不存在时记录错误
+func (c *templateChecker) field(node parse.Node, typ reflect.Type, names []string) reflect.Type {
+	for _, name := range names {
+		if typ == nil {
+			return nil
+		}
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		if method, ok := reflect.PointerTo(typ).MethodByName(name); ok {
+			if method.Type.NumOut() == 0 {
+				return nil
+			}
+			typ = method.Type.Out(0)
+			continue
+		}
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, ok := typ.FieldByName(name)
+			if !ok || !field.IsExported() {
+				location, _ := c.tree.ErrorContext(node)
+				c.errs = append(c.errs, fmt.Sprintf("%s: 变量.%s在%s中不存在", location, name, typ.Name()))
+				return nil
+			}
+			typ = field.Type
+		case reflect.Map:
+			typ = typ.Elem()
+		default:
+			return nil
+		}
+	}
+	return typ
+}
+
+// templateRangeTypes range 的键及元素类型
+func templateRangeTypes(typ reflect.Type) (key, elem reflect.Type) {
+	if typ == nil {
+		return nil, nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), typ.Elem()
+	case reflect.Map:
+		return typ.Key(), typ.Elem()
+	}
+	return nil, nil
+}
+
+func copyTemplateVars(vars map[string]reflect.Type) map[string]reflect.Type {
+	scope := make(map[string]reflect.Type, len(vars))
+	for k, v := range vars {
+		scope[k] = v
+	}
+	return scope
+}
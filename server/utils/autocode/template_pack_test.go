@@ -0,0 +1,115 @@
+package autocode
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+func writeTemplatePackFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestTemplatePack(t *testing.T) {
+	builtin := t.TempDir()
+	writeTemplatePackFiles(t, builtin, map[string]string{"server/service/service.go.tpl": "package {{.Package}}"})
+
+	dir := t.TempDir()
+	writeTemplatePackFiles(t, dir, map[string]string{
+		"manifest.yaml": `name: audit
+base: package
+files:
+  - template: server/service/service.go.tpl
+  - template: extra/audit.go.tpl
+    target: server/service/{{.Package}}/{{.HumpPackageName}}_audit.go
+injections: [PackageServiceEnter]
+`,
+		"server/service/service.go.tpl": "package {{.Package}}\n{{range .Fields}}// {{.FieldName}}{{end}}",
+		"extra/audit.go.tpl":            "package {{.Package}}",
+	})
+	pack, err := LoadTemplatePack(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = pack.Validate(builtin); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	overrides := pack.Overrides()
+	if got := overrides[filepath.FromSlash("server/service/service.go.tpl")]; got != filepath.Join(dir, "server", "service", "service.go.tpl") {
+		t.Errorf("Overrides() = %v", overrides)
+	}
+	target, err := pack.Target(pack.Files[1], systemReq.AutoCode{Package: "example", HumpPackageName: "book"})
+	if err != nil || target != "server/service/example/book_audit.go" {
+		t.Errorf("Target() = %q, %v", target, err)
+	}
+	if !pack.HasInjection("PackageServiceEnter") || pack.HasInjection("PackageApiEnter") {
+		t.Errorf("HasInjection() should only keep declared injections")
+	}
+
+	writeTemplatePackFiles(t, dir, map[string]string{
+		"manifest.yaml": `name: bad/name
+base: package
+files:
+  - template: server/api/api.go.tpl
+  - template: extra/audit.go.tpl
+    target: ../audit.go
+injections: [PluginGen]
+`,
+		"server/api/api.go.tpl": "{{.Missing}}",
+	})
+	if pack, err = LoadTemplatePack(dir); err != nil {
+		t.Fatal(err)
+	}
+	err = pack.Validate(builtin)
+	if err == nil {
+		t.Fatal("Validate() should fail")
+	}
+	for _, want := range []string{"name[bad/name]", "变量.Missing在AutoCode中不存在", "必须覆盖package模板中已有的模板", "target必须以server/或web/开头", "injections[PluginGen]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, should contain %s", err, want)
+		}
+	}
+
+	writeTemplatePackFiles(t, dir, map[string]string{"manifest.yaml": "name: audit\nunknown: true\n"})
+	if _, err = LoadTemplatePack(dir); err == nil {
+		t.Error("LoadTemplatePack() should reject unknown fields")
+	}
+}
+
+func TestValidateTemplateVariables(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{`{{.StructName}}{{range .Fields}}{{.FieldJson}}{{.DataSource.Table}}{{$.Package}}{{end}}`, nil},
+		{`{{range $i, $f := .SubTables}}{{$f.StructName}}{{range $f.Fields}}{{.FieldName}}{{end}}{{end}}`, nil},
+		{`{{with .PrimaryField}}{{.FieldName}}{{end}}{{$pk := .PrimaryField}}{{$pk.FieldJson}}`, nil},
+		{`{{if .HasPic}}{{.Pkg}}{{end}}`, []string{"变量.Pkg在AutoCode中不存在"}},
+		{`{{range .Fields}}{{.Name}}{{end}}`, []string{"变量.Name在AutoCodeField中不存在"}},
+		{`{{.StructName}`, []string{"bad character"}},
+	}
+	for _, tt := range tests {
+		got := ValidateTemplateVariables("test", tt.text)
+		if len(got) != len(tt.want) {
+			t.Errorf("ValidateTemplateVariables(%s) = %v, want %v", tt.text, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !strings.Contains(got[i], tt.want[i]) {
+				t.Errorf("ValidateTemplateVariables(%s) = %v, want %v", tt.text, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package autocode
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+// testRecordCount 生成的测试写入的记录数 搜索用例以第2条记录的取值作为搜索条件
+const testRecordCount = 3
+
+// testComplexTypes 生成的搜索语句中未实现查询的复杂类型 搜索时返回全部记录
+var testComplexTypes = []string{"pictures", "picture", "video", "json", "richtext", "array"}
+
+// testValue 第n条测试记录中字段的取值
+type testValue struct {
+	model   string  // Model中的赋值表达式
+	search  string  // 搜索结构体中的赋值表达式
+	query   string  // 查询参数
+	text    string  // 字符串类型比较用的值
+	number  float64 // 数值类型比较用的值
+	numeric bool
+}
+
+// testFieldValue 按字段类型生成第n条测试记录的取值 不支持的类型返回false
+func testFieldValue(field *systemReq.AutoCodeField, abbreviation string, n int) (value testValue, ok bool) {
+	ptr := abbreviation + "Ptr"
+	text := fmt.Sprintf("%s%d", field.FieldJson, n)
+	switch field.FieldType {
+	case "string":
+		value.model = fmt.Sprintf("%s(%s)", ptr, strconv.Quote(text))
+		value.search = value.model
+	case "richtext":
+		value.model = fmt.Sprintf("%s(%s)", ptr, strconv.Quote(text))
+		value.search = strconv.Quote(text)
+	case "picture", "video":
+		value.model = strconv.Quote(text)
+		value.search = value.model
+	case "enum":
+		options := enumOptions(field.DataTypeLong)
+		if len(options) == 0 {
+			return value, false
+		}
+		text = options[(n-1)%len(options)]
+		value.model = strconv.Quote(text)
+		value.search = value.model
+	case "int":
+		value.number, value.numeric = float64(n*10), true
+		text = strconv.Itoa(n * 10)
+		value.model = fmt.Sprintf("%s(%s(%s))", ptr, intFieldType(field.DataTypeLong), text)
+		value.search = fmt.Sprintf("%s(%s)", ptr, text)
+	case "float64":
+		value.number, value.numeric = float64(n)+0.5, true
+		text = strconv.FormatFloat(value.number, 'f', -1, 64)
+		value.model = fmt.Sprintf("%s(%s)", ptr, text)
+		value.search = value.model
+	case "bool":
+		text = strconv.FormatBool(n%2 == 1)
+		if n%2 == 1 {
+			value.number = 1
+		}
+		value.numeric = true
+		value.model = fmt.Sprintf("%s(%s)", ptr, text)
+		value.search = value.model
+	case "time.Time":
+		value.number, value.numeric = float64(n), true
+		text = fmt.Sprintf("2024-01-%02dT00:00:00Z", n)
+		value.model = fmt.Sprintf("%s(time.Date(2024, 1, %d, 0, 0, 0, 0, time.UTC))", ptr, n)
+		value.search = value.model
+	case "file", "pictures", "array":
+		value.model = `datatypes.JSON("[]")`
+		value.search = strconv.Quote(text)
+	case "json":
+		value.model = `datatypes.JSON("{}")`
+		value.search = strconv.Quote(text)
+	default:
+		return value, false
+	}
+	value.text, value.query = text, text
+	return value, true
+}
+
+// enumOptions 解析枚举类型的可选值 如 'a','b'
+func enumOptions(dataTypeLong string) []string {
+	var options []string
+	for _, option := range strings.Split(dataTypeLong, ",") {
+		option = strings.Trim(strings.TrimSpace(option), `'"`)
+		if option != "" {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// compare 比较两条记录中字段的取值
+func (v testValue) compare(other testValue) int {
+	if v.numeric {
+		switch {
+		case v.number < other.number:
+			return -1
+		case v.number > other.number:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(v.text, other.text)
+}
+
+// TestRecordNumbers 测试记录的序号 从1开始
+func TestRecordNumbers() []int {
+	numbers := make([]int, 0, testRecordCount)
+	for n := 1; n <= testRecordCount; n++ {
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+// GenerateTestValue 生成第n条测试记录中字段的赋值语句 不支持的类型返回空字符串
+func GenerateTestValue(field *systemReq.AutoCodeField, abbreviation string, n int) string {
+	value, ok := testFieldValue(field, abbreviation, n)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s,", field.FieldName, value.model)
+}
+
+// GenerateTestSearchCases 按字段的搜索条件生成搜索用例 mode 为 service 时生成搜索结构体 为 api 时生成查询参数
+// 期望条数按写入的测试记录计算 复杂类型的搜索语句需自行实现 期望返回全部记录
+func GenerateTestSearchCases(info systemReq.AutoCode, mode string) string {
+	var cases []string
+	for _, field := range info.Fields {
+		if field.FieldSearchType == "" {
+			continue
+		}
+		values := make([]testValue, 0, testRecordCount)
+		for n := 1; n <= testRecordCount; n++ {
+			value, ok := testFieldValue(field, info.Abbreviation, n)
+			if !ok {
+				break
+			}
+			values = append(values, value)
+		}
+		if len(values) != testRecordCount {
+			continue
+		}
+		complex := slices.Contains(testComplexTypes, field.FieldType)
+		between := field.FieldSearchType == "BETWEEN" || field.FieldSearchType == "NOT BETWEEN"
+		if complex && between || field.FieldType == "enum" && between {
+			continue
+		} // 生成的搜索结构体中没有对应的范围字段
+		if field.FieldSearchType == "LIKE" && !complex && field.FieldType != "string" && field.FieldType != "enum" {
+			continue
+		}
+		if field.FieldType == "file" {
+			continue
+		}
+
+		target := values[1]
+		var want int
+		for _, value := range values {
+			if complex || testMatch(field.FieldSearchType, value, values[0], target) {
+				want++
+			}
+		}
+
+		name := strconv.Quote(field.FieldName + " " + field.FieldSearchType)
+		query := url.Values{}
+		var search string
+		switch {
+		case between && field.FieldType == "time.Time":
+			search = fmt.Sprintf("%sRange: []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}", field.FieldName)
+			query[field.FieldJson+"Range[]"] = []string{values[0].query, target.query}
+		case between:
+			search = fmt.Sprintf("Start%s: %s, End%s: %s", field.FieldName, values[0].search, field.FieldName, target.search)
+			query.Set("start"+field.FieldName, values[0].query)
+			query.Set("end"+field.FieldName, target.query)
+		default:
+			search = fmt.Sprintf("%s: %s", field.FieldName, target.search)
+			query.Set(field.FieldJson, target.query)
+		}
+		if mode == "api" {
+			cases = append(cases, fmt.Sprintf("{name: %s, query: %s, want: %d},", name, strconv.Quote(query.Encode()), want))
+			continue
+		}
+		cases = append(cases, fmt.Sprintf("{name: %s, search: %sReq.%sSearch{%s}, want: %d},", name, info.Package, info.StructName, search, want))
+	}
+	return strings.Join(cases, "\n\t\t")
+}
+
+// testMatch 记录是否满足搜索条件 范围搜索以第1条与第2条记录的取值为边界
+func testMatch(searchType string, value, start, target testValue) bool {
+	switch searchType {
+	case "=":
+		return value.compare(target) == 0
+	case "<>":
+		return value.compare(target) != 0
+	case ">":
+		return value.compare(target) > 0
+	case ">=":
+		return value.compare(target) >= 0
+	case "<":
+		return value.compare(target) < 0
+	case "<=":
+		return value.compare(target) <= 0
+	case "LIKE":
+		return strings.Contains(value.text, target.text)
+	case "BETWEEN":
+		return value.compare(start) >= 0 && value.compare(target) <= 0
+	case "NOT BETWEEN":
+		return value.compare(start) < 0 || value.compare(target) > 0
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package autocode
+
+import (
+	"strings"
+	"testing"
+
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+func TestGenerateTestValue(t *testing.T) {
+	tests := []struct {
+		field systemReq.AutoCodeField
+		want  string
+	}{
+		{systemReq.AutoCodeField{FieldName: "Title", FieldType: "string", FieldJson: "title"}, `Title: bookPtr("title2"),`},
+		{systemReq.AutoCodeField{FieldName: "Count", FieldType: "int", FieldJson: "count", DataTypeLong: "5"}, `Count: bookPtr(int16(20)),`},
+		{systemReq.AutoCodeField{FieldName: "OnSale", FieldType: "bool", FieldJson: "onSale"}, `OnSale: bookPtr(false),`},
+		{systemReq.AutoCodeField{FieldName: "Status", FieldType: "enum", FieldJson: "status", DataTypeLong: "'on', 'off'"}, `Status: "off",`},
+		{systemReq.AutoCodeField{FieldName: "Tags", FieldType: "array", FieldJson: "tags"}, `Tags: datatypes.JSON("[]"),`},
+		{systemReq.AutoCodeField{FieldName: "Status", FieldType: "enum", FieldJson: "status"}, ``},
+	}
+	for _, tt := range tests {
+		if got := GenerateTestValue(&tt.field, "book", 2); got != tt.want {
+			t.Errorf("GenerateTestValue(%s) = %q, want %q", tt.field.FieldType, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateTestSearchCases(t *testing.T) {
+	info := systemReq.AutoCode{
+		Package:      "example",
+		StructName:   "Book",
+		Abbreviation: "book",
+		Fields: []*systemReq.AutoCodeField{
+			{FieldName: "Title", FieldType: "string", FieldJson: "title", FieldSearchType: "LIKE"},
+			{FieldName: "Price", FieldType: "float64", FieldJson: "price", FieldSearchType: "NOT BETWEEN"},
+			{FieldName: "Count", FieldType: "int", FieldJson: "count", FieldSearchType: ">"},
+			{FieldName: "OnSale", FieldType: "bool", FieldJson: "onSale", FieldSearchType: "<>"},
+			{FieldName: "PubAt", FieldType: "time.Time", FieldJson: "pubAt", FieldSearchType: "BETWEEN"},
+			{FieldName: "Cover", FieldType: "picture", FieldJson: "cover", FieldSearchType: "="},
+			{FieldName: "Count2", FieldType: "int", FieldJson: "count2", FieldSearchType: "LIKE"},
+			{FieldName: "Remark", FieldType: "string", FieldJson: "remark"},
+		},
+	}
+
+	service := GenerateTestSearchCases(info, "service")
+	for _, want := range []string{
+		`{name: "Title LIKE", search: exampleReq.BookSearch{Title: bookPtr("title2")}, want: 1},`,
+		`{name: "Price NOT BETWEEN", search: exampleReq.BookSearch{StartPrice: bookPtr(1.5), EndPrice: bookPtr(2.5)}, want: 1},`,
+		`{name: "Count >", search: exampleReq.BookSearch{Count: bookPtr(20)}, want: 1},`,
+		`{name: "OnSale <>", search: exampleReq.BookSearch{OnSale: bookPtr(false)}, want: 2},`,
+		`PubAtRange: []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}}, want: 2},`,
+		`{name: "Cover =", search: exampleReq.BookSearch{Cover: "cover2"}, want: 3},`,
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("service cases should contain %s\n%s", want, service)
+		}
+	}
+	if strings.Contains(service, "Count2") || strings.Contains(service, "Remark") {
+		t.Errorf("unsupported or unsearchable fields should be skipped\n%s", service)
+	}
+
+	api := GenerateTestSearchCases(info, "api")
+	for _, want := range []string{
+		`{name: "Price NOT BETWEEN", query: "endPrice=2.5&startPrice=1.5", want: 1},`,
+		`{name: "PubAt BETWEEN", query: "pubAtRange%5B%5D=2024-01-01T00%3A00%3A00Z&pubAtRange%5B%5D=2024-01-02T00%3A00%3A00Z", want: 2},`,
+	} {
+		if !strings.Contains(api, want) {
+			t.Errorf("api cases should contain %s\n%s", want, api)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/json"
 	"sync"
 
 	"github.com/casbin/casbin/v2"
@@ -12,9 +13,35 @@ import (
 
 var (
 	syncedCachedEnforcer *casbin.SyncedCachedEnforcer
+	casbinWatcher        *CasbinWatcher
 	once                 sync.Once
 )
 
+const (
+	CasbinDomain = "api"   // 接口权限的域 角色继承只在域内生效
+	CasbinAllow  = "allow" // 允许
+	CasbinDeny   = "deny"  // 拒绝 优先于允许 用于在继承的权限中排除部分接口
+)
+
+// CasbinModel casbin模型 策略为 [角色, 域, 路径, 方法, 效果] 继承关系为 [子角色, 父角色, 域]
+// 子角色继承父角色(及其祖先)的策略 任一策略拒绝时不允许访问
+const CasbinModel = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act, eft
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow)) && !some(where (p.eft == deny))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && keyMatch2(r.obj,p.obj) && r.act == p.act
+`
+
 // GetCasbin 获取casbin实例
 func GetCasbin() *casbin.SyncedCachedEnforcer {
 	once.Do(func() {
@@ -23,23 +50,7 @@ func GetCasbin() *casbin.SyncedCachedEnforcer {
 			zap.L().Error("适配数据库失败请检查casbin表是否为InnoDB引擎!", zap.Error(err))
 			return
 		}
-		text := `
-		[request_definition]
-		r = sub, obj, act
-		
-		[policy_definition]
-		p = sub, obj, act
-		
-		[role_definition]
-		g = _, _
-		
-		[policy_effect]
-		e = some(where (p.eft == allow))
-		
-		[matchers]
-		m = r.sub == p.sub && keyMatch2(r.obj,p.obj) && r.act == p.act
-		`
-		m, err := model.NewModelFromString(text)
+		m, err := model.NewModelFromString(CasbinModel)
 		if err != nil {
 			zap.L().Error("字符串加载模型失败!", zap.Error(err))
 			return
@@ -47,6 +58,39 @@ func GetCasbin() *casbin.SyncedCachedEnforcer {
 		syncedCachedEnforcer, _ = casbin.NewSyncedCachedEnforcer(m, a)
 		syncedCachedEnforcer.SetExpireTime(60 * 60)
 		_ = syncedCachedEnforcer.LoadPolicy()
+		if global.GVA_REDIS != nil {
+			watchCasbin(syncedCachedEnforcer)
+		}
 	})
 	return syncedCachedEnforcer
 }
+
+// GetCasbinWatcher 获取多实例同步策略的watcher 未使用redis时返回nil
+func GetCasbinWatcher() *CasbinWatcher {
+	GetCasbin()
+	return casbinWatcher
+}
+
+// watchCasbin 订阅其他实例的策略变更 本实例的变更由 enforcer 自动通知
+func watchCasbin(e *casbin.SyncedCachedEnforcer) {
+	w, err := NewCasbinWatcher(global.GVA_REDIS, func(payload string) {
+		var m casbinMessage
+		if err := json.Unmarshal([]byte(payload), &m); err != nil {
+			zap.L().Warn("casbin策略变更消息解析失败", zap.Error(err))
+			return
+		}
+		if err := applyCasbinMessage(e, m); err != nil {
+			zap.L().Error("同步casbin策略失败!", zap.Error(err))
+		}
+	})
+	if err != nil {
+		zap.L().Error("订阅casbin策略变更失败, 多实例部署时策略需手动刷新!", zap.Error(err))
+		return
+	}
+	if err = e.SetWatcher(w); err != nil {
+		zap.L().Error("设置casbin watcher失败!", zap.Error(err))
+		w.Close()
+		return
+	}
+	casbinWatcher = w
+}
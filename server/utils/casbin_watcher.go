@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// casbinChannel 多实例同步casbin策略的redis频道
+const casbinChannel = "gva:casbin:policy"
+
+const (
+	casbinReload         = "reload"
+	casbinAdd            = "add"
+	casbinRemove         = "remove"
+	casbinRemoveFiltered = "removeFiltered"
+	casbinUpdate         = "update"
+)
+
+// casbinMessage 实例间同步的策略变更 其他实例按内容增量更新本地策略 无法增量更新时重新加载
+type casbinMessage struct {
+	Origin      string     `json:"origin"` // 发出消息的实例ID
+	Op          string     `json:"op"`
+	Sec         string     `json:"sec,omitempty"`
+	Ptype       string     `json:"ptype,omitempty"`
+	FieldIndex  int        `json:"fieldIndex,omitempty"`
+	FieldValues []string   `json:"fieldValues,omitempty"`
+	Rules       [][]string `json:"rules,omitempty"`
+	NewRules    [][]string `json:"newRules,omitempty"`
+}
+
+// CasbinWatcher 基于redis发布订阅的casbin watcher
+// 通过 enforcer 的 AddPolicies、RemoveFilteredPolicy 等方法修改策略时自动通知其他实例
+// 直接修改数据库的策略需调用 Update 或 UpdateForXxx 通知
+type CasbinWatcher struct {
+	rdb      redis.UniversalClient
+	id       string
+	mu       sync.RWMutex
+	callback func(string)
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewCasbinWatcher 订阅策略变更频道 收到其他实例的变更时以消息内容调用 callback 订阅失败时返回错误
+func NewCasbinWatcher(rdb redis.UniversalClient, callback func(string)) (*CasbinWatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &CasbinWatcher{rdb: rdb, id: uuid.New().String(), callback: callback, ctx: ctx, cancel: cancel}
+	pubsub := rdb.Subscribe(ctx, casbinChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		_ = pubsub.Close()
+		return nil, err
+	}
+	go w.subscribe(pubsub)
+	return w, nil
+}
+
+// subscribe 接收其他实例的策略变更 忽略本实例发出的消息
+func (w *CasbinWatcher) subscribe(pubsub *redis.PubSub) {
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var m casbinMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+				zap.L().Warn("casbin策略变更消息解析失败", zap.Error(err))
+				continue
+			}
+			if m.Origin == w.id {
+				continue
+			}
+			w.mu.RLock()
+			callback := w.callback
+			w.mu.RUnlock()
+			if callback != nil {
+				callback(msg.Payload)
+			}
+		}
+	}
+}
+
+func (w *CasbinWatcher) publish(m casbinMessage) error {
+	m.Origin = w.id
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err = w.rdb.Publish(w.ctx, casbinChannel, data).Err(); err != nil {
+		zap.L().Error("casbin策略变更通知失败", zap.Error(err))
+	}
+	return err
+}
+
+// SetUpdateCallback 替换收到其他实例的策略变更时的回调 参数为消息内容 回调应在 NewCasbinWatcher 时传入
+func (w *CasbinWatcher) SetUpdateCallback(callback func(string)) error {
+	w.mu.Lock()
+	w.callback = callback
+	w.mu.Unlock()
+	return nil
+}
+
+// Update 通知其他实例重新加载全部策略
+func (w *CasbinWatcher) Update() error {
+	return w.publish(casbinMessage{Op: casbinReload})
+}
+
+// Close 停止订阅
+func (w *CasbinWatcher) Close() {
+	w.cancel()
+}
+
+func (w *CasbinWatcher) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return w.publish(casbinMessage{Op: casbinAdd, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+func (w *CasbinWatcher) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return w.publish(casbinMessage{Op: casbinRemove, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+func (w *CasbinWatcher) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return w.publish(casbinMessage{Op: casbinRemoveFiltered, Sec: sec, Ptype: ptype, FieldIndex: fieldIndex, FieldValues: fieldValues})
+}
+
+func (w *CasbinWatcher) UpdateForSavePolicy(model.Model) error {
+	return w.Update()
+}
+
+func (w *CasbinWatcher) UpdateForAddPolicies(sec string, ptype string, rules ...[]string) error {
+	return w.publish(casbinMessage{Op: casbinAdd, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+func (w *CasbinWatcher) UpdateForRemovePolicies(sec string, ptype string, rules ...[]string) error {
+	return w.publish(casbinMessage{Op: casbinRemove, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+func (w *CasbinWatcher) UpdateForUpdatePolicy(sec string, ptype string, oldRule, newRule []string) error {
+	return w.publish(casbinMessage{Op: casbinUpdate, Sec: sec, Ptype: ptype, Rules: [][]string{oldRule}, NewRules: [][]string{newRule}})
+}
+
+func (w *CasbinWatcher) UpdateForUpdatePolicies(sec string, ptype string, oldRules, newRules [][]string) error {
+	return w.publish(casbinMessage{Op: casbinUpdate, Sec: sec, Ptype: ptype, Rules: oldRules, NewRules: newRules})
+}
+
+// applyCasbinMessage 按其他实例的策略变更增量更新本地策略并清除鉴权缓存
+// 策略已由发出消息的实例写入数据库 更新期间关闭自动保存
+func applyCasbinMessage(e *casbin.SyncedCachedEnforcer, m casbinMessage) error {
+	if m.Op == casbinReload || m.Op == "" {
+		return e.LoadPolicy()
+	}
+	err := func() error {
+		lock := e.GetLock()
+		lock.Lock()
+		defer lock.Unlock()
+		e.EnableAutoSave(false)
+		defer e.EnableAutoSave(true)
+		var err error
+		switch m.Op {
+		case casbinAdd:
+			_, err = e.Enforcer.SelfAddPoliciesEx(m.Sec, m.Ptype, m.Rules)
+		case casbinRemove:
+			_, err = e.Enforcer.SelfRemovePolicies(m.Sec, m.Ptype, m.Rules)
+		case casbinRemoveFiltered:
+			_, err = e.Enforcer.SelfRemoveFilteredPolicy(m.Sec, m.Ptype, m.FieldIndex, m.FieldValues...)
+		case casbinUpdate:
+			var ok bool
+			if ok, err = e.Enforcer.SelfUpdatePolicies(m.Sec, m.Ptype, m.Rules, m.NewRules); err == nil && !ok {
+				err = errors.New("本地不存在要更新的策略")
+			}
+		}
+		return err
+	}()
+	if err != nil {
+		// 本地策略与消息不一致 重新加载全部策略
+		zap.L().Warn("casbin策略增量更新失败, 重新加载", zap.String("op", m.Op), zap.Error(err))
+		return e.LoadPolicy()
+	}
+	return e.InvalidateCache()
+}
+
+// casbinPersisted 策略已直接写入数据库(如在事务中)时 更新本地策略并通知其他实例
+func casbinPersisted(m casbinMessage) error {
+	e := GetCasbin()
+	if e == nil {
+		return errors.New("casbin未初始化")
+	}
+	if err := applyCasbinMessage(e, m); err != nil {
+		return err
+	}
+	if casbinWatcher != nil {
+		return casbinWatcher.publish(m)
+	}
+	return nil
+}
+
+// CasbinPoliciesAdded 已写入数据库的策略 加载到本地并通知其他实例 ptype 为 p 或 g
+func CasbinPoliciesAdded(ptype string, rules [][]string) error {
+	return casbinPersisted(casbinMessage{Op: casbinAdd, Sec: ptype, Ptype: ptype, Rules: rules})
+}
+
+// CasbinSubjectRemoved 已从数据库删除的角色的策略及继承关系 从本地删除并通知其他实例
+func CasbinSubjectRemoved(sub string) error {
+	for _, ptype := range []string{"p", "g"} {
+		m := casbinMessage{Op: casbinRemoveFiltered, Sec: ptype, Ptype: ptype, FieldIndex: 0, FieldValues: []string{sub}}
+		if err := casbinPersisted(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CasbinPoliciesUpdated 已在数据库中修改的策略 更新本地并通知其他实例
+func CasbinPoliciesUpdated(oldRules, newRules [][]string) error {
+	return casbinPersisted(casbinMessage{Op: casbinUpdate, Sec: "p", Ptype: "p", Rules: oldRules, NewRules: newRules})
+}
+
+// CasbinReloaded 本地已重新加载全部策略 通知其他实例重新加载
+func CasbinReloaded() error {
+	if casbinWatcher != nil {
+		return casbinWatcher.Update()
+	}
+	return nil
+}
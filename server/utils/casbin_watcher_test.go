@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryAdapter 记录写入次数的内存适配器 LoadPolicy 时加载 rules
+type memoryAdapter struct {
+	rules  [][]string
+	writes int
+}
+
+func (a *memoryAdapter) LoadPolicy(m model.Model) error {
+	for _, rule := range a.rules {
+		if err := m.AddPolicy("p", "p", rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (a *memoryAdapter) SavePolicy(model.Model) error                    { a.writes++; return nil }
+func (a *memoryAdapter) AddPolicy(string, string, []string) error        { a.writes++; return nil }
+func (a *memoryAdapter) RemovePolicy(string, string, []string) error     { a.writes++; return nil }
+func (a *memoryAdapter) AddPolicies(string, string, [][]string) error    { a.writes++; return nil }
+func (a *memoryAdapter) RemovePolicies(string, string, [][]string) error { a.writes++; return nil }
+func (a *memoryAdapter) RemoveFilteredPolicy(string, string, int, ...string) error {
+	a.writes++
+	return nil
+}
+
+func TestApplyCasbinMessage(t *testing.T) {
+	m, err := model.NewModelFromString(CasbinModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adapter := &memoryAdapter{rules: [][]string{{"888", "api", "/api/list", "GET", "allow"}}}
+	e, err := casbin.NewSyncedCachedEnforcer(m, adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apply := func(msg casbinMessage) {
+		t.Helper()
+		if err := applyCasbinMessage(e, msg); err != nil {
+			t.Fatalf("applyCasbinMessage(%s) = %v", msg.Op, err)
+		}
+	}
+	allowed := func(sub, obj, act string) bool {
+		ok, _ := e.Enforce(sub, CasbinDomain, obj, act)
+		return ok
+	}
+
+	if !allowed("888", "/api/list", "GET") {
+		t.Fatal("initial policy should allow")
+	}
+	apply(casbinMessage{Op: casbinAdd, Sec: "p", Ptype: "p", Rules: [][]string{{"888", "api", "/api/create", "POST", "allow"}, {"9528", "api", "/api/list", "GET", "allow"}}})
+	if !allowed("888", "/api/create", "POST") || !allowed("9528", "/api/list", "GET") {
+		t.Error("added policies should allow")
+	}
+	// 已存在的规则重复添加不报错
+	apply(casbinMessage{Op: casbinAdd, Sec: "p", Ptype: "p", Rules: [][]string{{"888", "api", "/api/list", "GET", "allow"}}})
+
+	apply(casbinMessage{Op: casbinRemoveFiltered, Sec: "p", Ptype: "p", FieldIndex: 0, FieldValues: []string{"888"}})
+	// 鉴权缓存已清除
+	if allowed("888", "/api/list", "GET") || allowed("888", "/api/create", "POST") {
+		t.Error("removed policies should deny")
+	}
+
+	apply(casbinMessage{Op: casbinUpdate, Sec: "p", Ptype: "p", Rules: [][]string{{"9528", "api", "/api/list", "GET", "allow"}}, NewRules: [][]string{{"9528", "api", "/api/list", "POST", "allow"}}})
+	if allowed("9528", "/api/list", "GET") || !allowed("9528", "/api/list", "POST") {
+		t.Error("updated policy should apply")
+	}
+
+	apply(casbinMessage{Op: casbinRemove, Sec: "p", Ptype: "p", Rules: [][]string{{"9528", "api", "/api/list", "POST", "allow"}}})
+	if allowed("9528", "/api/list", "POST") {
+		t.Error("removed policy should deny")
+	}
+	if adapter.writes != 0 {
+		t.Errorf("applyCasbinMessage should not write to adapter, writes = %d", adapter.writes)
+	}
+
+	// 增量更新失败时重新加载 reload 时按数据库(适配器)中的策略加载
+	apply(casbinMessage{Op: casbinUpdate, Sec: "p", Ptype: "p", Rules: [][]string{{"1", "api", "/x", "GET", "allow"}}, NewRules: [][]string{{"1", "api", "/y", "GET", "allow"}}})
+	if !allowed("888", "/api/list", "GET") || allowed("1", "/y", "GET") {
+		t.Error("failed update should reload policies from adapter")
+	}
+	adapter.rules = nil
+	apply(casbinMessage{Op: casbinReload})
+	if allowed("888", "/api/list", "GET") {
+		t.Error("reload should load policies from adapter")
+	}
+}
+
+func TestCasbinWatcherCallback(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	received := make(chan casbinMessage, 1)
+	receiver, err := NewCasbinWatcher(rdb, func(payload string) {
+		var m casbinMessage
+		_ = json.Unmarshal([]byte(payload), &m)
+		received <- m
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+	sender, err := NewCasbinWatcher(rdb, func(string) { t.Error("sender should ignore its own message") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+
+	// 订阅在构造时完成 回调已设置 构造后立即发出的消息不会丢失
+	if err = sender.UpdateForAddPolicy("p", "p", "888", "api", "/api/list", "GET", "allow"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case m := <-received:
+		if m.Op != casbinAdd || m.Origin != sender.id || len(m.Rules) != 1 || m.Rules[0][2] != "/api/list" {
+			t.Errorf("received = %+v", m)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not received")
+	}
+}
@@ -106,6 +106,29 @@ func GetUserAuthorityId(c *gin.Context) uint {
 	}
 }
 
+// GetUserAuthorityIds 从Gin的Context中获取鉴权使用的角色id 第一个为用户当前角色
+func GetUserAuthorityIds(c *gin.Context) []uint {
+	claims := GetUserInfo(c)
+	if claims == nil {
+		return nil
+	}
+	return ClaimsAuthorityIds(claims)
+}
+
+// ClaimsAuthorityIds 鉴权使用的角色id 多角色模式下为用户的全部角色 否则只有当前角色
+func ClaimsAuthorityIds(claims *systemReq.CustomClaims) []uint {
+	ids := []uint{claims.AuthorityId}
+	if !global.GVA_CONFIG.System.UseAllAuthorities {
+		return ids
+	}
+	for _, id := range claims.AuthorityIds {
+		if id != claims.AuthorityId {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // GetUserInfo 从Gin的Context中获取从jwt解析出来的用户角色id
 func GetUserInfo(c *gin.Context) *systemReq.CustomClaims {
 	if claims, exists := c.Get("claims"); !exists {
@@ -135,13 +158,19 @@ func GetUserName(c *gin.Context) string {
 }
 
 func LoginToken(user system.Login) (token string, claims systemReq.CustomClaims, err error) {
+	version, err := GetTokenVersion(user.GetUserId())
+	if err != nil {
+		return
+	}
 	j := NewJWT()
 	claims = j.CreateClaims(systemReq.BaseClaims{
-		UUID:        user.GetUUID(),
-		ID:          user.GetUserId(),
-		NickName:    user.GetNickname(),
-		Username:    user.GetUsername(),
-		AuthorityId: user.GetAuthorityId(),
+		UUID:         user.GetUUID(),
+		ID:           user.GetUserId(),
+		NickName:     user.GetNickname(),
+		Username:     user.GetUsername(),
+		AuthorityId:  user.GetAuthorityId(),
+		AuthorityIds: user.GetAuthorityIds(),
+		TokenVersion: version,
 	})
 	token, err = j.CreateToken(claims)
 	return
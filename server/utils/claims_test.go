@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+)
+
+func TestClaimsAuthorityIds(t *testing.T) {
+	claims := &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{AuthorityId: 9528, AuthorityIds: []uint{888, 9528, 8881}}}
+	defer func(v bool) { global.GVA_CONFIG.System.UseAllAuthorities = v }(global.GVA_CONFIG.System.UseAllAuthorities)
+
+	global.GVA_CONFIG.System.UseAllAuthorities = false
+	if ids := ClaimsAuthorityIds(claims); !reflect.DeepEqual(ids, []uint{9528}) {
+		t.Errorf("single authority mode: ids = %v", ids)
+	}
+	global.GVA_CONFIG.System.UseAllAuthorities = true
+	if ids := ClaimsAuthorityIds(claims); !reflect.DeepEqual(ids, []uint{9528, 888, 8881}) {
+		t.Errorf("all authorities mode: ids = %v, want current authority first", ids)
+	}
+	// 旧版 token 没有 AuthorityIds 时只使用当前角色
+	claims.AuthorityIds = nil
+	if ids := ClaimsAuthorityIds(claims); !reflect.DeepEqual(ids, []uint{9528}) {
+		t.Errorf("legacy token: ids = %v", ids)
+	}
+}
@@ -0,0 +1,243 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"go.uber.org/zap"
+)
+
+const (
+	RolloverDaily   = "daily"
+	RolloverMonthly = "monthly"
+)
+
+// rolloverLayouts 各滚动周期的索引日期格式
+var rolloverLayouts = map[string]string{
+	RolloverDaily:   "2006.01.02",
+	RolloverMonthly: "2006.01",
+}
+
+// rolloverLayout 获取滚动周期对应的索引日期格式 未知周期按天处理
+func rolloverLayout(rollover string) string {
+	if layout, ok := rolloverLayouts[rollover]; ok {
+		return layout
+	}
+	return rolloverLayouts[RolloverDaily]
+}
+
+// parseIndexPeriod 按全部已知的日期格式解析索引后缀 返回索引所在周期的起止时间
+// 修改滚动周期后 之前周期创建的索引同样可以识别
+func parseIndexPeriod(suffix string, loc *time.Location) (start, end time.Time, ok bool) {
+	if t, err := time.ParseInLocation(rolloverLayouts[RolloverDaily], suffix, loc); err == nil {
+		return t, t.AddDate(0, 0, 1), true
+	}
+	if t, err := time.ParseInLocation(rolloverLayouts[RolloverMonthly], suffix, loc); err == nil {
+		return t, t.AddDate(0, 1, 0), true
+	}
+	return start, end, false
+}
+
+// IndexNameFor 获取别名在指定时间所在周期的索引名称 例如 user_action_logs-2024.05.01
+func IndexNameFor(alias, rollover string, t time.Time) string {
+	return alias + "-" + t.Format(rolloverLayout(rollover))
+}
+
+// IndexPattern 获取别名下全部时间索引的通配模式
+func IndexPattern(alias string) string {
+	return alias + "-*"
+}
+
+// ExpiredIndices 从索引列表中筛选整个周期都早于保留期限的时间索引
+// 按全部已知的滚动周期解析日期 无法解析日期的索引不会被选中
+func ExpiredIndices(alias string, indices []string, retention time.Duration, now time.Time) []string {
+	deadline := now.Add(-retention)
+	expired := make([]string, 0)
+	for _, index := range indices {
+		suffix, ok := strings.CutPrefix(index, alias+"-")
+		if !ok {
+			continue
+		}
+		_, end, ok := parseIndexPeriod(suffix, now.Location())
+		if !ok {
+			continue
+		}
+		if !end.After(deadline) {
+			expired = append(expired, index)
+		}
+	}
+	return expired
+}
+
+// ListIndices 获取匹配通配模式的索引名称
+func (e *ESClient) ListIndices(ctx context.Context, pattern string) ([]string, error) {
+	req := esapi.CatIndicesRequest{
+		Index:  []string{pattern},
+		Format: "json",
+		H:      []string{"index"},
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return []string{}, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("获取索引列表错误: %s", res.String())
+	}
+
+	var rows []struct {
+		Index string `json:"index"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil {
+		return nil, err
+	}
+	indices := make([]string, 0, len(rows))
+	for _, row := range rows {
+		indices = append(indices, row.Index)
+	}
+	return indices, nil
+}
+
+// IsAlias 检查名称是否为别名
+func (e *ESClient) IsAlias(ctx context.Context, name string) (bool, error) {
+	req := esapi.IndicesExistsAliasRequest{
+		Name: []string{name},
+	}
+
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode == 200, nil
+}
+
+// EnsureWriteIndex 确保索引存在并成为别名的写入索引
+// 索引已存在时不会修改其设置 别名原有的写入索引保留为只读成员
+func (e *ESClient) EnsureWriteIndex(ctx context.Context, alias, index string, mapping map[string]interface{}) error {
+	exists, err := e.IndexExists(ctx, index)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := e.CreateIndex(ctx, index, mapping); err != nil && !strings.Contains(err.Error(), "resource_already_exists_exception") {
+			return err
+		}
+	}
+
+	req := esapi.IndicesGetAliasRequest{
+		Name: []string{alias},
+	}
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	current := map[string]struct {
+		Aliases map[string]struct {
+			IsWriteIndex *bool `json:"is_write_index"`
+		} `json:"aliases"`
+	}{}
+	if res.StatusCode != 404 {
+		if res.IsError() {
+			return fmt.Errorf("获取别名错误: %s", res.String())
+		}
+		if err := json.NewDecoder(res.Body).Decode(&current); err != nil {
+			return err
+		}
+	}
+
+	actions := make([]map[string]interface{}, 0, len(current)+1)
+	for name, info := range current {
+		if name == index {
+			if a, ok := info.Aliases[alias]; ok && a.IsWriteIndex != nil && *a.IsWriteIndex {
+				return nil
+			}
+			continue
+		}
+		if a, ok := info.Aliases[alias]; ok && a.IsWriteIndex != nil && *a.IsWriteIndex {
+			actions = append(actions, map[string]interface{}{
+				"add": map[string]interface{}{"index": name, "alias": alias, "is_write_index": false},
+			})
+		}
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{"index": index, "alias": alias, "is_write_index": true},
+	})
+
+	if err := e.UpdateAliases(ctx, actions); err != nil {
+		return err
+	}
+	global.GVA_LOG.Info("写入索引已切换", zap.String("alias", alias), zap.String("index", index))
+	return nil
+}
+
+// UpdateAliases 原子地执行一组别名操作
+func (e *ESClient) UpdateAliases(ctx context.Context, actions []map[string]interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	req := esapi.IndicesUpdateAliasesRequest{
+		Body: bytes.NewReader(data),
+	}
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		global.GVA_LOG.Error("更新别名失败", zap.Error(err))
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("更新别名错误: %s", res.String())
+	}
+	return nil
+}
+
+// CreateSnapshot 为指定索引创建快照 并等待快照完成
+func (e *ESClient) CreateSnapshot(ctx context.Context, repository, snapshot string, indices []string) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"indices":              strings.Join(indices, ","),
+		"include_global_state": false,
+	})
+	if err != nil {
+		return err
+	}
+
+	waitForCompletion := true
+	req := esapi.SnapshotCreateRequest{
+		Repository:        repository,
+		Snapshot:          snapshot,
+		Body:              bytes.NewReader(data),
+		WaitForCompletion: &waitForCompletion,
+	}
+	res, err := req.Do(ctx, e.client)
+	if err != nil {
+		global.GVA_LOG.Error("创建快照失败", zap.Error(err))
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("创建快照错误: %s", body)
+	}
+
+	global.GVA_LOG.Info("快照创建成功", zap.String("repository", repository), zap.String("snapshot", snapshot), zap.Strings("indices", indices))
+	return nil
+}
@@ -0,0 +1,45 @@
+package elasticsearch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIndexNameFor(t *testing.T) {
+	at := time.Date(2024, 5, 3, 10, 0, 0, 0, time.Local)
+	if got := IndexNameFor("logs", RolloverDaily, at); got != "logs-2024.05.03" {
+		t.Fatalf("daily index = %s", got)
+	}
+	if got := IndexNameFor("logs", RolloverMonthly, at); got != "logs-2024.05" {
+		t.Fatalf("monthly index = %s", got)
+	}
+	if got := IndexNameFor("logs", "", at); got != "logs-2024.05.03" {
+		t.Fatalf("default index = %s", got)
+	}
+}
+
+func TestExpiredIndices(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	retention := 7 * 24 * time.Hour
+
+	daily := []string{"logs-2024.05.01", "logs-2024.05.02", "logs-2024.05.03", "logs-2024.05.10", "logs-bad", "other-2024.01.01"}
+	got := ExpiredIndices("logs", daily, retention, now)
+	if want := []string{"logs-2024.05.01", "logs-2024.05.02"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("daily expired = %v, want %v", got, want)
+	}
+
+	// 月索引需整个月都早于保留期限才会过期
+	monthly := []string{"logs-2024.03", "logs-2024.04", "logs-2024.05"}
+	got = ExpiredIndices("logs", monthly, 14*24*time.Hour, now)
+	if want := []string{"logs-2024.03"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("monthly expired = %v, want %v", got, want)
+	}
+
+	// 修改滚动周期后 之前周期创建的索引同样会被清理
+	mixed := []string{"logs-2024.03", "logs-2024.04.20", "logs-2024.05.01", "logs-2024.05.09"}
+	got = ExpiredIndices("logs", mixed, retention, now)
+	if want := []string{"logs-2024.03", "logs-2024.04.20", "logs-2024.05.01"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("mixed expired = %v, want %v", got, want)
+	}
+}
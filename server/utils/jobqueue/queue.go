@@ -0,0 +1,221 @@
+// Package jobqueue 基于数据库表的任务队列
+//
+// 任务表需要包含 id status attempts max_attempts next_attempt_at last_error 列
+// 以 attempts 作为版本号做条件更新领取任务 多个协程或多个实例同时领取时只有一个成功
+// 处理中的任务在 next_attempt_at 记录超时时间 进程退出等原因未完成的任务超时后会被重新领取
+// 失败的任务按指数退避重试 超过最大尝试次数或返回 Permanent 错误时转为死信
+package jobqueue
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"go.uber.org/zap"
+)
+
+const (
+	pollInterval    = 5 * time.Second
+	maxRetryBackoff = time.Hour
+	maxErrorLength  = 500
+)
+
+// Status 任务各状态在 status 列中的取值
+type Status struct {
+	Pending string // 等待处理 包括等待重试
+	Running string // 处理中
+	Done    string // 处理成功
+	Dead    string // 死信
+}
+
+// Job 已领取的任务
+type Job struct {
+	ID          uint
+	Attempts    int // 已尝试次数 包括本次
+	MaxAttempts int
+}
+
+// Worker 处理任务 每个协程一个 可以持有连接等协程内的资源
+type Worker interface {
+	// Handle 处理任务 返回需要一并更新到任务表的列 如响应内容
+	Handle(job Job) (values map[string]interface{}, err error)
+	// Idle 一个轮询周期内没有任务时调用 用于释放连接
+	Idle()
+}
+
+// Options 队列配置 数值配置项为函数 每次使用时读取 以便配置修改后立即生效
+type Options struct {
+	Name         string               // 队列名称 用于日志
+	Model        interface{}          // 任务表对应的模型 如 &model.EmailMessage{}
+	Status       Status               // 状态取值
+	DoneAt       string               // 处理成功时记录时间的列 为空时不记录
+	Workers      func() int           // 协程数
+	RetryBackoff func() time.Duration // 首次重试等待时间 之后每次翻倍 最长1小时
+	Lease        func() time.Duration // 领取后的超时时间
+}
+
+// Queue 任务队列
+type Queue struct {
+	Options
+	once sync.Once
+	wake chan struct{}
+}
+
+// New 创建任务队列 调用 Start 后开始处理
+func New(opts Options) *Queue {
+	return &Queue{Options: opts, wake: make(chan struct{}, 1)}
+}
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent 标记重试没有意义的错误 任务直接转为死信
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// Start 启动处理协程 重复调用只启动一次
+func (q *Queue) Start(newWorker func() Worker) {
+	q.once.Do(func() {
+		for i := 0; i < q.Workers(); i++ {
+			go q.run(newWorker())
+		}
+	})
+}
+
+// Notify 唤醒空闲的处理协程 新任务入队或手动重试后调用
+func (q *Queue) Notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *Queue) run(w Worker) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		handled := false
+		for q.Next(w) {
+			handled = true
+		}
+		if !handled {
+			w.Idle()
+		}
+		select {
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Next 领取并处理一条到期的任务 没有可处理的任务时返回false
+func (q *Queue) Next(w Worker) bool {
+	job, err := q.claim()
+	if err != nil {
+		global.GVA_LOG.Error("领取任务失败!", zap.String("queue", q.Name), zap.Error(err))
+		return false
+	}
+	if job == nil {
+		return false
+	}
+	q.Process(w, *job)
+	return true
+}
+
+// claim 领取一条到期的任务 处理中但已超时的任务同样会被重新领取
+func (q *Queue) claim() (*Job, error) {
+	now := time.Now()
+	var list []struct {
+		ID          uint
+		Status      string
+		Attempts    int
+		MaxAttempts int
+	}
+	err := global.GVA_DB.Model(q.Model).Select("id", "status", "attempts", "max_attempts").
+		Where("status IN ? AND next_attempt_at <= ?", []string{q.Status.Pending, q.Status.Running}, now).
+		Order("next_attempt_at").Limit(5).Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		res := global.GVA_DB.Model(q.Model).
+			Where("id = ? AND status = ? AND attempts = ?", item.ID, item.Status, item.Attempts).
+			Updates(map[string]interface{}{
+				"status":          q.Status.Running,
+				"attempts":        item.Attempts + 1,
+				"next_attempt_at": now.Add(q.Lease()),
+			})
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		if res.RowsAffected == 1 {
+			return &Job{ID: item.ID, Attempts: item.Attempts + 1, MaxAttempts: item.MaxAttempts}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Process 处理已领取的任务并记录结果 返回本次处理的错误
+// 任务需为处理中状态 且 attempts 与 job.Attempts 一致
+func (q *Queue) Process(w Worker, job Job) error {
+	values, err := w.Handle(job)
+	if e := q.finish(job, values, err); e != nil {
+		global.GVA_LOG.Error("更新任务状态失败!", zap.String("queue", q.Name), zap.Uint("id", job.ID), zap.Error(e))
+	}
+	return err
+}
+
+// finish 记录处理结果 失败时按指数退避安排重试 超过最大尝试次数或永久失败时标记为死信
+func (q *Queue) finish(job Job, values map[string]interface{}, err error) error {
+	now := time.Now()
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	var permanent permanentError
+	switch {
+	case err == nil:
+		values["status"] = q.Status.Done
+		values["last_error"] = ""
+		if q.DoneAt != "" {
+			values[q.DoneAt] = now
+		}
+	case errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts:
+		values["status"] = q.Status.Dead
+		values["last_error"] = Truncate(err.Error(), maxErrorLength)
+		global.GVA_LOG.Error("任务失败 已转为死信!", zap.String("queue", q.Name), zap.Uint("id", job.ID), zap.Int("attempts", job.Attempts), zap.Error(err))
+	default:
+		values["status"] = q.Status.Pending
+		values["next_attempt_at"] = now.Add(q.Backoff(job.Attempts))
+		values["last_error"] = Truncate(err.Error(), maxErrorLength)
+	}
+	return global.GVA_DB.Model(q.Model).
+		Where("id = ? AND status = ? AND attempts = ?", job.ID, q.Status.Running, job.Attempts).
+		Updates(values).Error
+}
+
+// Backoff 第 attempts 次失败后的等待时间
+func (q *Queue) Backoff(attempts int) time.Duration {
+	backoff := q.RetryBackoff()
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+// Truncate 按字符截断 避免截断多字节字符
+func Truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
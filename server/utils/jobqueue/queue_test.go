@@ -0,0 +1,151 @@
+package jobqueue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type testJob struct {
+	ID            uint
+	Status        string
+	Attempts      int
+	MaxAttempts   int
+	NextAttemptAt time.Time
+	LastError     string
+	DoneAt        *time.Time
+	Result        string
+}
+
+type testWorker struct {
+	errs  []error
+	calls int
+}
+
+func (w *testWorker) Handle(job Job) (map[string]interface{}, error) {
+	w.calls++
+	var err error
+	if len(w.errs) > 0 {
+		err, w.errs = w.errs[0], w.errs[1:]
+	}
+	return map[string]interface{}{"result": "handled"}, err
+}
+
+func (w *testWorker) Idle() {}
+
+func setupQueue(t *testing.T) *Queue {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err = db.AutoMigrate(&testJob{}); err != nil {
+		t.Fatal(err)
+	}
+	global.GVA_DB, global.GVA_LOG = db, zap.NewNop()
+	return New(Options{
+		Name:         "test",
+		Model:        &testJob{},
+		Status:       Status{Pending: "pending", Running: "running", Done: "done", Dead: "dead"},
+		DoneAt:       "done_at",
+		Workers:      func() int { return 1 },
+		RetryBackoff: func() time.Duration { return 10 * time.Second },
+		Lease:        func() time.Duration { return time.Minute },
+	})
+}
+
+func addJob(t *testing.T, maxAttempts int) uint {
+	j := testJob{Status: "pending", MaxAttempts: maxAttempts, NextAttemptAt: time.Now()}
+	if err := global.GVA_DB.Create(&j).Error; err != nil {
+		t.Fatal(err)
+	}
+	return j.ID
+}
+
+func loadJob(t *testing.T, id uint) testJob {
+	var j testJob
+	if err := global.GVA_DB.First(&j, id).Error; err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func makeDue(id uint) {
+	global.GVA_DB.Model(&testJob{}).Where("id = ?", id).Update("next_attempt_at", time.Now())
+}
+
+func TestQueueRetryAndDeadLetter(t *testing.T) {
+	q := setupQueue(t)
+	id := addJob(t, 2)
+	w := &testWorker{errs: []error{errors.New("temporary"), errors.New("again")}}
+
+	start := time.Now()
+	if !q.Next(w) {
+		t.Fatal("job not claimed")
+	}
+	j := loadJob(t, id)
+	if wait := j.NextAttemptAt.Sub(start); j.Status != "pending" || j.Attempts != 1 || j.LastError != "temporary" || wait < 10*time.Second || wait > 11*time.Second {
+		t.Fatalf("failed job should wait for the backoff: %+v", j)
+	}
+	if q.Next(w) {
+		t.Fatal("job claimed before backoff")
+	}
+
+	makeDue(id)
+	q.Next(w)
+	if j = loadJob(t, id); j.Status != "dead" || j.Attempts != 2 || j.Result != "handled" {
+		t.Fatalf("job should be dead after max attempts: %+v", j)
+	}
+}
+
+func TestQueuePermanentAndDone(t *testing.T) {
+	q := setupQueue(t)
+	dead, done := addJob(t, 5), addJob(t, 5)
+	w := &testWorker{errs: []error{Permanent(errors.New("rejected"))}}
+	q.Next(w)
+	q.Next(w)
+	if j := loadJob(t, dead); j.Status != "dead" || j.Attempts != 1 || j.LastError != "rejected" {
+		t.Fatalf("permanent error should not be retried: %+v", j)
+	}
+	if j := loadJob(t, done); j.Status != "done" || j.DoneAt == nil || j.Result != "handled" {
+		t.Fatalf("job should be done: %+v", j)
+	}
+}
+
+func TestQueueReclaimsExpiredLease(t *testing.T) {
+	q := setupQueue(t)
+	id := addJob(t, 5)
+	// 模拟领取后进程退出
+	if job, err := q.claim(); err != nil || job == nil || job.ID != id || job.Attempts != 1 {
+		t.Fatalf("claim = %+v, %v", job, err)
+	}
+	w := &testWorker{}
+	if q.Next(w) {
+		t.Fatal("running job should not be claimed again")
+	}
+	makeDue(id)
+	q.Next(w)
+	if j := loadJob(t, id); j.Status != "done" || j.Attempts != 2 || w.calls != 1 {
+		t.Fatalf("expired job should be reclaimed: %+v", j)
+	}
+}
+
+func TestBackoffAndTruncate(t *testing.T) {
+	q := New(Options{RetryBackoff: func() time.Duration { return 10 * time.Minute }})
+	if b := q.Backoff(3); b != 40*time.Minute {
+		t.Errorf("Backoff(3) = %v", b)
+	}
+	if b := q.Backoff(10); b != maxRetryBackoff {
+		t.Errorf("Backoff(10) = %v", b)
+	}
+	if s := Truncate("数据库连接失败", 3); s != "数据库" {
+		t.Errorf("Truncate = %q", s)
+	}
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -87,6 +89,25 @@ func (j *JWT) ParseToken(tokenString string) (*request.CustomClaims, error) {
 	return nil, TokenValid
 }
 
+// TokenHash jwt的sha256 用作黑名单的键
+func TokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokenRemaining jwt的剩余有效期 已过期时为0 无法解析时为配置的过期时间
+func TokenRemaining(token string) time.Duration {
+	claims, err := NewJWT().ParseToken(token)
+	switch {
+	case errors.Is(err, TokenExpired):
+		return 0
+	case err != nil:
+		dr, _ := ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
+		return dr
+	}
+	return time.Until(claims.ExpiresAt.Time)
+}
+
 //@author: [piexlmax](https://github.com/piexlmax)
 //@function: SetRedisJWT
 //@description: jwt存入redis并设置过期时间
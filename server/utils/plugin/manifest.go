@@ -0,0 +1,212 @@
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestNames 插件描述文件 位于 server/plugin/{插件名}/ 下 随插件源码一起打包 按顺序查找
+var ManifestNames = []string{"plugin.yaml", "plugin.yml", "plugin.json"}
+
+var manifestNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// Manifest 插件描述文件
+type Manifest struct {
+	Name         string             `json:"name" yaml:"name"`                 // 插件名 与 server/plugin 下的目录名一致
+	Version      string             `json:"version" yaml:"version"`           // 插件版本 语义化版本
+	Description  string             `json:"description" yaml:"description"`   // 描述
+	Author       string             `json:"author" yaml:"author"`             // 作者
+	Gva          string             `json:"gva" yaml:"gva"`                   // 兼容的gin-vue-admin版本范围 如 ">=v2.8.0 <v3.0.0" 为空时不限制
+	Dependencies []ModuleDependency `json:"dependencies" yaml:"dependencies"` // 依赖的Go模块
+	Plugins      map[string]string  `json:"plugins" yaml:"plugins"`           // 依赖的其他插件及版本范围
+	Config       []string           `json:"config" yaml:"config"`             // 必需的配置项 如 email.host
+	Menus        []ManifestMenu     `json:"menus" yaml:"menus"`               // 安装时创建的菜单
+	Apis         []ManifestApi      `json:"apis" yaml:"apis"`                 // 安装时创建的api
+}
+
+// ModuleDependency 插件依赖的Go模块
+type ModuleDependency struct {
+	Module  string `json:"module" yaml:"module"`   // 模块路径
+	Version string `json:"version" yaml:"version"` // 最低版本
+}
+
+// ManifestMenu 安装时创建的菜单
+type ManifestMenu struct {
+	Parent    string `json:"parent" yaml:"parent"`       // 父菜单的name 可以是已有菜单或描述文件中在前面声明的菜单 为空时为顶级菜单
+	Name      string `json:"name" yaml:"name"`           // 路由name
+	Path      string `json:"path" yaml:"path"`           // 路由path
+	Component string `json:"component" yaml:"component"` // 前端文件路径
+	Title     string `json:"title" yaml:"title"`         // 菜单名
+	Icon      string `json:"icon" yaml:"icon"`           // 菜单图标
+	Sort      int    `json:"sort" yaml:"sort"`           // 排序
+	Hidden    bool   `json:"hidden" yaml:"hidden"`       // 是否在列表隐藏
+}
+
+// ManifestApi 安装时创建的api
+type ManifestApi struct {
+	Path        string `json:"path" yaml:"path"`               // api路径
+	Method      string `json:"method" yaml:"method"`           // 请求方法
+	Group       string `json:"group" yaml:"group"`             // api组
+	Description string `json:"description" yaml:"description"` // api描述
+}
+
+// LoadManifest 读取目录下的插件描述文件 不存在时返回 os.ErrNotExist 未知字段视为错误
+func LoadManifest(dir string) (*Manifest, error) {
+	for _, name := range ManifestNames {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "读取插件描述文件[%s]失败!", name)
+		}
+		var manifest Manifest
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err = decoder.Decode(&manifest); err != nil {
+			return nil, errors.Wrapf(err, "解析插件描述文件[%s]失败!", name)
+		}
+		return &manifest, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+// Validate 校验描述文件 返回全部不合法项
+func (m *Manifest) Validate() error {
+	var errs []string
+	add := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if !manifestNameRegexp.MatchString(m.Name) {
+		add("name[%s]只能包含字母、数字、下划线及中划线 且以字母开头", m.Name)
+	}
+	if !semver.IsValid(canonicalVersion(m.Version)) {
+		add("version[%s]不是合法的语义化版本", m.Version)
+	}
+	if _, err := ParseVersionRange(m.Gva); err != nil {
+		add("gva %v", err)
+	}
+	for i, dep := range m.Dependencies {
+		if err := module.CheckPath(dep.Module); err != nil {
+			add("dependencies[%d] module[%s]不合法", i, dep.Module)
+		}
+		if !semver.IsValid(dep.Version) {
+			add("dependencies[%d] version[%s]不是合法的Go模块版本", i, dep.Version)
+		}
+	}
+	for name, rng := range m.Plugins {
+		if _, err := ParseVersionRange(rng); err != nil {
+			add("plugins[%s] %v", name, err)
+		}
+	}
+	for i, key := range m.Config {
+		if strings.TrimSpace(key) == "" {
+			add("config[%d]不能为空", i)
+		}
+	}
+	menus := make(map[string]bool, len(m.Menus))
+	for i, menu := range m.Menus {
+		if menu.Name == "" || menu.Path == "" || menu.Component == "" || menu.Title == "" {
+			add("menus[%d] name、path、component、title不能为空", i)
+		}
+		if menus[menu.Name] {
+			add("menus[%d] name[%s]重复", i, menu.Name)
+		}
+		menus[menu.Name] = true
+	}
+	for i, api := range m.Apis {
+		if !strings.HasPrefix(api.Path, "/") {
+			add("apis[%d] path[%s]必须以/开头", i, api.Path)
+		}
+		switch api.Method {
+		case "GET", "POST", "PUT", "DELETE", "PATCH":
+		default:
+			add("apis[%d] method[%s]不合法", i, api.Method)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// VersionRange 版本范围 所有条件均满足时匹配
+type VersionRange []versionConstraint
+
+type versionConstraint struct {
+	op      string
+	version string
+}
+
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseVersionRange 解析以空格或逗号分隔的版本条件 如 ">=v2.8.0 <v3.0.0" 版本号可以省略前缀v 为空或*时不限制
+func ParseVersionRange(expr string) (VersionRange, error) {
+	var rng VersionRange
+	for _, field := range strings.FieldsFunc(expr, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if field == "*" {
+			continue
+		}
+		constraint := versionConstraint{op: "="}
+		for _, op := range versionOperators {
+			if strings.HasPrefix(field, op) {
+				constraint.op, field = op, strings.TrimPrefix(field, op)
+				break
+			}
+		}
+		constraint.version = canonicalVersion(field)
+		if !semver.IsValid(constraint.version) {
+			return nil, errors.Errorf("版本范围[%s]中的版本[%s]不合法", expr, field)
+		}
+		rng = append(rng, constraint)
+	}
+	return rng, nil
+}
+
+// Match 版本是否在范围内
+func (r VersionRange) Match(version string) bool {
+	version = canonicalVersion(version)
+	if !semver.IsValid(version) {
+		return false
+	}
+	for _, c := range r {
+		cmp := semver.Compare(version, c.version)
+		var ok bool
+		switch c.op {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		case "!=":
+			ok = cmp != 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalVersion 补全版本号的前缀v
+func canonicalVersion(version string) string {
+	if version != "" && !strings.HasPrefix(version, "v") {
+		return "v" + version
+	}
+	return version
+}
@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVersionRange(t *testing.T) {
+	tests := []struct {
+		expr    string
+		version string
+		want    bool
+	}{
+		{"", "v2.8.5", true},
+		{"*", "2.8.5", true},
+		{">=v2.8.0 <v3.0.0", "v2.8.5", true},
+		{">=2.8.0, <3.0.0", "v3.0.0", false},
+		{"2.8.5", "v2.8.5", true},
+		{"!=v2.8.5", "v2.8.5", false},
+		{">v2.8.5", "v2.8.5-beta", false},
+		{">=v1.0.0", "latest", false},
+	}
+	for _, tt := range tests {
+		rng, err := ParseVersionRange(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseVersionRange(%q) error = %v", tt.expr, err)
+		}
+		if got := rng.Match(tt.version); got != tt.want {
+			t.Errorf("ParseVersionRange(%q).Match(%q) = %v, want %v", tt.expr, tt.version, got, tt.want)
+		}
+	}
+	if _, err := ParseVersionRange(">=two"); err == nil {
+		t.Error("ParseVersionRange() should reject invalid versions")
+	}
+}
+
+func TestManifest(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadManifest(dir); !os.IsNotExist(err) {
+		t.Fatalf("LoadManifest() of an empty dir = %v, want os.ErrNotExist", err)
+	}
+	manifest := `name: notice
+version: 1.0.0
+gva: ">=v2.8.0"
+dependencies:
+  - module: github.com/robfig/cron/v3
+    version: v3.0.1
+menus:
+  - name: notice
+    path: notice
+    component: view/routerHolder.vue
+    title: 通知
+apis:
+  - path: /notice/list
+    method: GET
+`
+	if err := os.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	m.Name, m.Version, m.Gva = "1notice", "one", ">=x"
+	m.Dependencies[0].Version = "3.0.1"
+	m.Menus = append(m.Menus, ManifestMenu{Name: "notice"})
+	m.Apis[0].Method = "get"
+	err = m.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail")
+	}
+	for _, want := range []string{"name[1notice]", "version[one]", "gva", "dependencies[0] version[3.0.1]", "menus[1] name、path", "menus[1] name[notice]重复", "apis[0] method[get]"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, should contain %s", err, want)
+		}
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte("name: notice\nunknown: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = LoadManifest(dir); err == nil {
+		t.Error("LoadManifest() should reject unknown fields")
+	}
+}
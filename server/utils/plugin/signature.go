@@ -0,0 +1,198 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ChecksumFile  = "checksums.txt"     // 校验文件 位于压缩包根目录 格式与 sha256sum 的输出一致
+	SignatureFile = "checksums.txt.sig" // 校验文件的分离签名 ed25519签名的base64编码
+)
+
+// ErrUnsigned 插件没有签名
+var ErrUnsigned = errors.New("插件未签名")
+
+// Checksums 压缩包内文件的sha256 key为以/分隔的相对路径
+type Checksums map[string]string
+
+// Add 计算并记录文件的sha256
+func (c Checksums) Add(name string, r io.Reader) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+	c[name] = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
+
+// Marshal 按路径排序输出 每行为 "sha256  路径"
+func (c Checksums) Marshal() []byte {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%s  %s\n", c[name], name)
+	}
+	return buf.Bytes()
+}
+
+// ParseChecksums 解析校验文件
+func ParseChecksums(data []byte) (Checksums, error) {
+	c := Checksums{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		sum, name, ok := strings.Cut(text, "  ")
+		if !ok || len(sum) != sha256.Size*2 || name == "" {
+			return nil, errors.Errorf("校验文件第%d行格式不正确", line)
+		}
+		c[name] = sum
+	}
+	return c, scanner.Err()
+}
+
+// GenerateKey 生成签名密钥对 返回base64编码的公钥与私钥
+func GenerateKey() (publicKey, privateKey string, err error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(public), base64.StdEncoding.EncodeToString(private.Seed()), nil
+}
+
+// ParsePublicKey 解析base64编码的ed25519公钥
+func ParsePublicKey(key string) (ed25519.PublicKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil || len(data) != ed25519.PublicKeySize {
+		return nil, errors.New("公钥不是合法的base64编码ed25519公钥")
+	}
+	return data, nil
+}
+
+// ParsePrivateKey 解析base64编码的ed25519私钥 支持32字节的种子或64字节的完整私钥
+func ParsePrivateKey(key string) (ed25519.PrivateKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, errors.New("私钥不是合法的base64编码")
+	}
+	switch len(data) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(data), nil
+	case ed25519.PrivateKeySize:
+		return data, nil
+	}
+	return nil, errors.New("私钥不是合法的ed25519私钥")
+}
+
+// Sign 对校验文件签名 返回签名文件内容
+func Sign(key ed25519.PrivateKey, checksums []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, checksums)))
+}
+
+// VerifyArchive 校验解压目录中的文件与签名 返回验证通过的公钥名
+// 缺少校验文件或签名时返回 ErrUnsigned 存在校验文件时仍会校验文件完整性
+// skip 返回true的文件不参与校验 如 __MACOSX
+func VerifyArchive(dir string, keys map[string]ed25519.PublicKey, skip func(path string) bool) (signer string, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, ChecksumFile))
+	if os.IsNotExist(err) {
+		return "", ErrUnsigned
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "读取校验文件失败!")
+	}
+	checksums, err := ParseChecksums(data)
+	if err != nil {
+		return "", err
+	}
+
+	seen := make(map[string]bool, len(checksums))
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip != nil && skip(path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		name := filepath.ToSlash(rel)
+		if name == ChecksumFile || name == SignatureFile {
+			return nil
+		}
+		want, ok := checksums[name]
+		if !ok {
+			return errors.Errorf("文件%s不在校验文件中", name)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		got := Checksums{}
+		if err = got.Add(name, f); err != nil {
+			return err
+		}
+		if got[name] != want {
+			return errors.Errorf("文件%s校验失败, 插件可能已被篡改", name)
+		}
+		seen[name] = true
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	for name := range checksums {
+		if !seen[name] {
+			return "", errors.Errorf("缺少校验文件中的文件%s", name)
+		}
+	}
+
+	signature, err := os.ReadFile(filepath.Join(dir, SignatureFile))
+	if os.IsNotExist(err) {
+		return "", ErrUnsigned
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "读取签名文件失败!")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return "", errors.New("签名文件格式不正确")
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ed25519.Verify(keys[name], data, sig) {
+			return name, nil
+		}
+	}
+	return "", errors.New("插件签名无法通过受信任的公钥验证")
+}
@@ -0,0 +1,46 @@
+package plugin
+
+import (
+	"context"
+)
+
+// Lifecycle 支持运行时启用停用的插件 路由始终注册 停用后由中间件拦截并隐藏菜单 不需要重启服务
+type Lifecycle interface {
+	Plugin
+	// Name 插件名 与 server/plugin 下的目录名一致
+	Name() string
+	// Install 首次启用时执行 如建表、注册api与菜单 卸载后再次启用会重新执行
+	Install(ctx context.Context) error
+	// Enable 启用时执行 服务启动时已启用的插件也会执行
+	Enable(ctx context.Context) error
+	// Disable 停用时执行 如停止定时任务
+	Disable(ctx context.Context) error
+	// Uninstall 卸载时执行 清理安装时注册的数据 卸载前需先停用
+	Uninstall(ctx context.Context) error
+	// Health 健康检查 返回nil表示正常
+	Health(ctx context.Context) error
+	// ConfigSchema 插件使用的配置项
+	ConfigSchema() []ConfigField
+	// Menus 插件的菜单name 停用后对用户隐藏 组件位于 plugin/{插件名}/ 下的菜单无需声明
+	Menus() []string
+}
+
+// ConfigField 插件配置项说明
+type ConfigField struct {
+	Key         string      `json:"key"`         // 配置项 如 email.host
+	Type        string      `json:"type"`        // 类型 string、number、bool、array、object
+	Description string      `json:"description"` // 描述
+	Required    bool        `json:"required"`    // 是否必填
+	Default     interface{} `json:"default"`     // 默认值
+}
+
+// Base Lifecycle 的默认实现 嵌入后只需实现 Name 与 Register
+type Base struct{}
+
+func (Base) Install(context.Context) error   { return nil }
+func (Base) Enable(context.Context) error    { return nil }
+func (Base) Disable(context.Context) error   { return nil }
+func (Base) Uninstall(context.Context) error { return nil }
+func (Base) Health(context.Context) error    { return nil }
+func (Base) ConfigSchema() []ConfigField     { return nil }
+func (Base) Menus() []string                 { return nil }
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// pluginChannel 多实例同步v2插件启停状态的redis频道
+const pluginChannel = "gva:plugin:state"
+
+// pluginMessage 实例间同步的插件状态变更 状态已写入 sys_plugin_states 其他实例收到后重新加载
+type pluginMessage struct {
+	Origin string `json:"origin"` // 发出消息的实例ID
+	Name   string `json:"name"`   // 插件名
+}
+
+// PluginWatcher 基于redis发布订阅同步v2插件的启用、停用及卸载
+type PluginWatcher struct {
+	rdb      redis.UniversalClient
+	id       string
+	callback func(name string)
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewPluginWatcher 订阅插件状态变更频道 收到其他实例的变更时按插件名调用 callback 订阅失败时返回错误
+func NewPluginWatcher(rdb redis.UniversalClient, callback func(name string)) (*PluginWatcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &PluginWatcher{rdb: rdb, id: uuid.New().String(), callback: callback, ctx: ctx, cancel: cancel}
+	pubsub := rdb.Subscribe(ctx, pluginChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		_ = pubsub.Close()
+		return nil, err
+	}
+	go w.subscribe(pubsub)
+	return w, nil
+}
+
+// subscribe 接收其他实例的插件状态变更 忽略本实例发出的消息 同一实例按收到的顺序依次处理
+func (w *PluginWatcher) subscribe(pubsub *redis.PubSub) {
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var m pluginMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+				zap.L().Warn("插件状态变更消息解析失败", zap.Error(err))
+				continue
+			}
+			if m.Origin == w.id || m.Name == "" {
+				continue
+			}
+			w.callback(m.Name)
+		}
+	}
+}
+
+// Publish 通知其他实例插件状态已变更 需在状态保存到数据库之后调用
+func (w *PluginWatcher) Publish(name string) error {
+	data, err := json.Marshal(pluginMessage{Origin: w.id, Name: name})
+	if err != nil {
+		return err
+	}
+	if err = w.rdb.Publish(w.ctx, pluginChannel, data).Err(); err != nil {
+		zap.L().Error("插件状态变更通知失败", zap.String("plugin", name), zap.Error(err))
+	}
+	return err
+}
+
+// Close 停止订阅
+func (w *PluginWatcher) Close() {
+	w.cancel()
+}
@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"sync"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+)
+
+// redisKeyPrefix redis中限流计数的键前缀
+const redisKeyPrefix = "gva:rate_limit:"
+
+var (
+	defaultOnce    sync.Once
+	defaultLimiter *Limiter
+)
+
+// Default 系统使用的限流器 首次调用时按配置选择存储 需在redis初始化之后调用
+func Default() *Limiter {
+	defaultOnce.Do(func() {
+		var store Store = NewMemoryStore()
+		switch cfg := global.GVA_CONFIG.RateLimit; {
+		case cfg.Store == "memory":
+		case global.GVA_REDIS != nil:
+			store = NewRedisStore(global.GVA_REDIS, redisKeyPrefix)
+		case cfg.Store == "redis":
+			global.GVA_LOG.Warn("限流配置使用redis存储 但未开启redis 已改用内存存储")
+		}
+		defaultLimiter = New(store)
+	})
+	return defaultLimiter
+}
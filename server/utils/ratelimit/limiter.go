@@ -0,0 +1,170 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 规则的计数维度
+const (
+	KeyByIP        = "ip"        // 每个IP分别计数
+	KeyByUser      = "user"      // 每个用户分别计数 未登录的请求不受限
+	KeyByAuthority = "authority" // 同一角色的用户共用计数
+	KeyByRoute     = "route"     // 每个接口分别计数 所有调用方共用
+)
+
+// Rule 限流规则 Method 和 Path 为空时匹配全部接口 Target 不为空时只限制对应的IP/用户ID/角色ID
+type Rule struct {
+	ID        uint
+	Name      string
+	KeyBy     string
+	Target    string
+	Method    string
+	Path      string
+	Algorithm string
+	Limit     int
+	Window    time.Duration
+}
+
+// Request 参与限流判断的请求信息 Path 为不含路由前缀的路由路径 与api管理中的路径一致
+type Request struct {
+	IP          string
+	UserID      uint
+	AuthorityId uint
+	Method      string
+	Path        string
+}
+
+// Decision 限流结果 被拒绝时 Rule 为拒绝请求的规则
+type Decision struct {
+	Allowed    bool
+	Rule       Rule
+	RetryAfter time.Duration
+}
+
+// Metric 单条规则的统计 为当前实例自启动或规则创建以来的数据
+type Metric struct {
+	RuleID         uint
+	Name           string
+	Checked        int64
+	Rejected       int64
+	LastRejectedAt *time.Time
+}
+
+type ruleMetric struct {
+	checked        atomic.Int64
+	rejected       atomic.Int64
+	lastRejectedAt atomic.Int64
+}
+
+// Limiter 按规则顺序依次判断 任一规则拒绝即拒绝请求
+type Limiter struct {
+	store   Store
+	mu      sync.RWMutex
+	rules   []Rule
+	metrics map[uint]*ruleMetric
+}
+
+func New(store Store) *Limiter {
+	return &Limiter{store: store, metrics: make(map[uint]*ruleMetric)}
+}
+
+// SetRules 替换全部规则 保留仍存在的规则的统计
+func (l *Limiter) SetRules(rules []Rule) {
+	metrics := make(map[uint]*ruleMetric, len(rules))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, r := range rules {
+		if m, ok := l.metrics[r.ID]; ok {
+			metrics[r.ID] = m
+		} else {
+			metrics[r.ID] = new(ruleMetric)
+		}
+	}
+	l.rules, l.metrics = rules, metrics
+}
+
+// Rules 当前生效的规则
+func (l *Limiter) Rules() []Rule {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]Rule(nil), l.rules...)
+}
+
+// key 请求在规则下的计数键 返回false表示规则不适用于该请求
+func (r Rule) key(req Request) (string, bool) {
+	if r.Method != "" && r.Method != req.Method {
+		return "", false
+	}
+	if r.Path != "" && r.Path != req.Path {
+		return "", false
+	}
+	var dim string
+	switch r.KeyBy {
+	case KeyByIP:
+		dim = req.IP
+	case KeyByUser:
+		if req.UserID == 0 {
+			return "", false
+		}
+		dim = strconv.FormatUint(uint64(req.UserID), 10)
+	case KeyByAuthority:
+		if req.AuthorityId == 0 {
+			return "", false
+		}
+		dim = strconv.FormatUint(uint64(req.AuthorityId), 10)
+	case KeyByRoute:
+		dim = req.Method + ":" + req.Path
+	default:
+		return "", false
+	}
+	if r.Target != "" && r.Target != dim {
+		return "", false
+	}
+	return strconv.FormatUint(uint64(r.ID), 10) + ":" + r.KeyBy + ":" + dim, true
+}
+
+// Allow 判断请求是否放行 存储出错时返回错误 由调用方决定是否放行
+func (l *Limiter) Allow(ctx context.Context, req Request) (Decision, error) {
+	l.mu.RLock()
+	rules, metrics := l.rules, l.metrics
+	l.mu.RUnlock()
+	for _, r := range rules {
+		key, ok := r.key(req)
+		if !ok || r.Limit <= 0 || r.Window <= 0 {
+			continue
+		}
+		m := metrics[r.ID]
+		m.checked.Add(1)
+		allowed, retryAfter, err := l.store.Take(ctx, key, r.Algorithm, r.Limit, r.Window)
+		if err != nil {
+			return Decision{Allowed: true}, err
+		}
+		if !allowed {
+			m.rejected.Add(1)
+			m.lastRejectedAt.Store(time.Now().UnixNano())
+			return Decision{Rule: r, RetryAfter: retryAfter}, nil
+		}
+	}
+	return Decision{Allowed: true}, nil
+}
+
+// Metrics 各规则的统计
+func (l *Limiter) Metrics() []Metric {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	list := make([]Metric, 0, len(l.rules))
+	for _, r := range l.rules {
+		m := l.metrics[r.ID]
+		item := Metric{RuleID: r.ID, Name: r.Name, Checked: m.checked.Load(), Rejected: m.rejected.Load()}
+		if ns := m.lastRejectedAt.Load(); ns > 0 {
+			t := time.Unix(0, ns)
+			item.LastRejectedAt = &t
+		}
+		list = append(list, item)
+	}
+	return list
+}
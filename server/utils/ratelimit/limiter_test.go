@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestStore() (*MemoryStore, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	s, now := newTestStore()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if ok, _, _ := s.Take(ctx, "k", TokenBucket, 3, 3*time.Second); !ok {
+			t.Fatalf("request %d should pass within the burst", i)
+		}
+	}
+	ok, retry, _ := s.Take(ctx, "k", TokenBucket, 3, 3*time.Second)
+	if ok || retry != time.Second {
+		t.Fatalf("bucket should be empty: ok = %v, retry = %v", ok, retry)
+	}
+	*now = now.Add(time.Second)
+	if ok, _, _ := s.Take(ctx, "k", TokenBucket, 3, 3*time.Second); !ok {
+		t.Fatal("one token should be refilled after a second")
+	}
+	if ok, _, _ := s.Take(ctx, "k", TokenBucket, 3, 3*time.Second); ok {
+		t.Fatal("only one token should be refilled")
+	}
+}
+
+func TestMemoryStoreSlidingWindow(t *testing.T) {
+	s, now := newTestStore()
+	ctx := context.Background()
+	s.Take(ctx, "k", SlidingWindow, 2, 10*time.Second)
+	*now = now.Add(4 * time.Second)
+	s.Take(ctx, "k", SlidingWindow, 2, 10*time.Second)
+	ok, retry, _ := s.Take(ctx, "k", SlidingWindow, 2, 10*time.Second)
+	if ok || retry != 6*time.Second {
+		t.Fatalf("window should be full until the first hit expires: ok = %v, retry = %v", ok, retry)
+	}
+	*now = now.Add(6 * time.Second)
+	if ok, _, _ := s.Take(ctx, "k", SlidingWindow, 2, 10*time.Second); !ok {
+		t.Fatal("first hit should have left the window")
+	}
+	if ok, _, _ := s.Take(ctx, "other", SlidingWindow, 2, 10*time.Second); !ok {
+		t.Fatal("keys should be counted separately")
+	}
+}
+
+func TestLimiterRules(t *testing.T) {
+	s, _ := newTestStore()
+	l := New(s)
+	l.SetRules([]Rule{
+		{ID: 1, Name: "login", KeyBy: KeyByIP, Method: "POST", Path: "/base/login", Limit: 1, Window: time.Minute},
+		{ID: 2, Name: "vip", KeyBy: KeyByUser, Target: "7", Limit: 2, Window: time.Minute},
+		{ID: 3, Name: "guest", KeyBy: KeyByAuthority, Target: "9528", Algorithm: SlidingWindow, Limit: 1, Window: time.Minute},
+	})
+	ctx := context.Background()
+	login := Request{IP: "10.0.0.1", Method: "POST", Path: "/base/login"}
+	if d, _ := l.Allow(ctx, login); !d.Allowed {
+		t.Fatal("first login should pass")
+	}
+	d, _ := l.Allow(ctx, login)
+	if d.Allowed || d.Rule.ID != 1 || d.RetryAfter <= 0 {
+		t.Fatalf("second login should be rejected by rule 1: %+v", d)
+	}
+	login.IP = "10.0.0.2"
+	if d, _ := l.Allow(ctx, login); !d.Allowed {
+		t.Fatal("another IP should have its own counter")
+	}
+	if d, _ := l.Allow(ctx, Request{IP: "10.0.0.1", Method: "GET", Path: "/base/login"}); !d.Allowed {
+		t.Fatal("rule 1 should only match POST")
+	}
+
+	user := Request{IP: "10.0.0.3", UserID: 7, AuthorityId: 888, Method: "GET", Path: "/user/getUserInfo"}
+	l.Allow(ctx, user)
+	l.Allow(ctx, user)
+	if d, _ := l.Allow(ctx, user); d.Allowed || d.Rule.ID != 2 {
+		t.Fatalf("user 7 should be limited by rule 2: %+v", d)
+	}
+	user.UserID = 8
+	if d, _ := l.Allow(ctx, user); !d.Allowed {
+		t.Fatal("rule 2 should only limit user 7")
+	}
+
+	guest := Request{IP: "10.0.0.4", UserID: 10, AuthorityId: 9528, Method: "GET", Path: "/menu/getMenu"}
+	l.Allow(ctx, guest)
+	guest.UserID = 11
+	if d, _ := l.Allow(ctx, guest); d.Allowed || d.Rule.ID != 3 {
+		t.Fatalf("users of authority 9528 should share a counter: %+v", d)
+	}
+
+	metrics := map[uint]Metric{}
+	for _, m := range l.Metrics() {
+		metrics[m.RuleID] = m
+	}
+	if m := metrics[1]; m.Checked != 3 || m.Rejected != 1 || m.LastRejectedAt == nil {
+		t.Errorf("rule 1 metrics = %+v", m)
+	}
+	if m := metrics[3]; m.Checked != 2 || m.Rejected != 1 {
+		t.Errorf("rule 3 metrics = %+v", m)
+	}
+
+	l.SetRules(l.Rules()[:1])
+	if m := l.Metrics(); len(m) != 1 || m[0].Rejected != 1 {
+		t.Errorf("metrics of kept rules should survive a reload: %+v", m)
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 脚本使用 redis 的时间 避免实例间时钟不一致
+
+// tokenBucketScript 令牌桶 哈希中保存剩余令牌及上次补充的毫秒时间
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local b = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(b[1])
+local ts = tonumber(b[2])
+if tokens == nil or ts == nil then
+  tokens = limit
+  ts = now
+end
+local rate = limit / window
+tokens = math.min(limit, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], window)
+return {allowed, wait}
+`)
+
+// slidingWindowScript 滑动窗口 有序集合中保存窗口内每次请求的微秒时间
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2]) * 1000
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+if redis.call('ZCARD', KEYS[1]) < limit then
+  redis.call('ZADD', KEYS[1], now, now .. '-' .. ARGV[3])
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return {1, 0}
+end
+local first = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {0, math.ceil((tonumber(first[2]) + window - now) / 1000)}
+`)
+
+// RedisStore redis存储 多个实例共享计数
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key, algorithm string, limit int, window time.Duration) (bool, time.Duration, error) {
+	ms := window.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+	var res []int64
+	var err error
+	if algorithm == SlidingWindow {
+		res, err = slidingWindowScript.Run(ctx, s.client, []string{s.prefix + key}, limit, ms, strconv.FormatUint(rand.Uint64(), 36)).Int64Slice()
+	} else {
+		res, err = tokenBucketScript.Run(ctx, s.client, []string{s.prefix + key}, limit, ms).Int64Slice()
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// 限流算法
+const (
+	TokenBucket   = "token_bucket"   // 令牌桶 窗口内最多 limit 个令牌 按 limit/window 的速率补充 允许短时突发
+	SlidingWindow = "sliding_window" // 滑动窗口 任意 window 时长内最多 limit 次
+)
+
+// Store 限流计数的存储
+type Store interface {
+	// Take 按算法消耗一次配额 被拒绝时返回需要等待的时间
+	Take(ctx context.Context, key, algorithm string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// memorySweepInterval 内存存储清理过期计数的间隔
+const memorySweepInterval = time.Minute
+
+type memoryEntry struct {
+	tokens  float64
+	last    time.Time
+	hits    []time.Time
+	expires time.Time
+}
+
+// MemoryStore 单实例的内存存储 多实例部署时各实例分别计数
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry), now: time.Now}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key, algorithm string, limit int, window time.Duration) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.lastSweep) > memorySweepInterval {
+		s.sweep(now)
+	}
+	e, ok := s.entries[key]
+	if !ok || now.After(e.expires) {
+		e = &memoryEntry{tokens: float64(limit), last: now}
+		s.entries[key] = e
+	}
+	e.expires = now.Add(window)
+
+	if algorithm == SlidingWindow {
+		start := now.Add(-window)
+		i := 0
+		for i < len(e.hits) && !e.hits[i].After(start) {
+			i++
+		}
+		e.hits = e.hits[i:]
+		if len(e.hits) < limit {
+			e.hits = append(e.hits, now)
+			return true, 0, nil
+		}
+		return false, e.hits[0].Add(window).Sub(now), nil
+	}
+
+	rate := float64(limit) / float64(window)
+	e.tokens = math.Min(float64(limit), e.tokens+float64(now.Sub(e.last))*rate)
+	e.last = now
+	if e.tokens >= 1 {
+		e.tokens--
+		return true, 0, nil
+	}
+	return false, time.Duration(math.Ceil((1 - e.tokens) / rate)), nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for k, e := range s.entries {
+		if now.After(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.lastSweep = now
+}
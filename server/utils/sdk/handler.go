@@ -0,0 +1,406 @@
+package sdk
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// namedType 命名空间中的类型引用 如 System.SysApi
+var namedType = regexp.MustCompile(`^[A-Z]\w*\.\w+$`)
+
+// commonResponse 统一返回结构所在的包 用于识别 response.OkWithData 等调用
+const commonResponse = "/model/common/response"
+
+// typeRef 类型引用 expr 为Go类型表达式 basic 为 swag 注释及查询参数中的基础类型
+type typeRef struct {
+	file     *goFile
+	expr     ast.Expr
+	basic    string              // string integer number boolean unknown array map
+	elem     *typeRef            // array、map 的元素类型
+	override map[string]*typeRef // swag 注释中 PageResult{list=[]T} 覆盖的字段
+}
+
+// handler 路由处理函数的请求及返回类型
+type handler struct {
+	recv    string // 接收者类型名
+	name    string // 方法名
+	summary string // swag 注释中的 @Summary
+	body    *typeRef
+	form    bool     // 请求体为 multipart/form-data
+	query   *typeRef // 绑定的查询参数结构体
+	keys    map[string]*typeRef
+	data    *typeRef // 返回的 data 为nil表示未知
+}
+
+// handler 分析 gin 路由的处理函数 name 为 gin.RouteInfo.Handler
+// 请求类型取自 ShouldBindJSON 等绑定调用及 c.Query 读取的参数 未找到时使用 swag 注释中的 @Param
+// 返回类型取自 swag 注释中 @Success 的 data 未声明时取自 response.OkWithData 等调用的参数
+func (t *Types) handler(name string) (*handler, bool) {
+	pkgPath, recv, method, ok := splitHandler(name)
+	if !ok {
+		return nil, false
+	}
+	pkg := t.load(pkgPath)
+	if pkg == nil {
+		return nil, false
+	}
+	fn, ok := pkg.funcs[recv+"."+method]
+	if !ok || fn.decl.Body == nil {
+		return nil, false
+	}
+	a := &handlerAnalysis{
+		types:   t,
+		file:    fn.file,
+		ctx:     contextParam(fn.decl),
+		locals:  map[string]ast.Expr{},
+		keys:    map[string]*typeRef{},
+		docKeys: map[string]*typeRef{},
+	}
+	a.annotations(fn.decl.Doc)
+	ast.Inspect(fn.decl.Body, a.visit)
+
+	h := &handler{recv: recv, name: method, summary: a.summary, body: a.body, form: a.form, query: a.query, keys: a.keys, data: a.docData}
+	// 未绑定请求体而是读取查询参数时 swag 注释中的 body 通常是复制来的
+	if h.body == nil && !a.bound && len(a.keys) == 0 {
+		h.body = a.docBody
+	}
+	if !a.bound {
+		if h.query == nil {
+			h.query = a.docQuery
+		}
+		for key, ref := range a.docKeys {
+			if _, ok := h.keys[key]; !ok {
+				h.keys[key] = ref
+			}
+		}
+	}
+	if h.data == nil {
+		h.data = a.data
+	}
+	return h, true
+}
+
+// sortedKeys 按名称排序的查询参数
+func (h *handler) sortedKeys() []string {
+	keys := make([]string, 0, len(h.keys))
+	for key := range h.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ts 类型引用对应的TypeScript类型
+func (t *Types) ts(ref *typeRef) string {
+	if ref == nil {
+		return "unknown"
+	}
+	switch ref.basic {
+	case "":
+	case "integer", "number":
+		return "number"
+	case "array":
+		return arrayOf(t.ts(ref.elem))
+	case "map":
+		return "Record<string, " + t.ts(ref.elem) + ">"
+	default:
+		return ref.basic
+	}
+	ts := t.Expr(ref.file, ref.expr)
+	if len(ref.override) == 0 || ts == "unknown" {
+		return ts
+	}
+	keys := make([]string, 0, len(ref.override))
+	for key := range ref.override {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var omit, props []string
+	for _, key := range keys {
+		omit = append(omit, strconv.Quote(key))
+		props = append(props, propName(key)+": "+t.ts(ref.override[key]))
+	}
+	return "Omit<" + ts + ", " + strings.Join(omit, " | ") + "> & { " + strings.Join(props, "; ") + " }"
+}
+
+// input 请求参数中的结构体字段均为可选 未传的字段按零值处理
+func input(ts string) string {
+	if namedType.MatchString(ts) {
+		return "Partial<" + ts + ">"
+	}
+	return ts
+}
+
+// splitHandler 拆分 gin 记录的处理函数名 如 .../api/v1/system.(*BaseApi).Login-fm
+func splitHandler(name string) (pkgPath, recv, method string, ok bool) {
+	name = strings.TrimSuffix(name, "-fm")
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", "", "", false
+	}
+	pkgPath, name = name[:slash+1+dot], name[slash+2+dot:]
+	recv, method, ok = strings.Cut(name, ".")
+	if !ok || strings.Contains(method, ".") {
+		return "", "", "", false
+	}
+	recv = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(recv, "("), "*"), ")")
+	return pkgPath, recv, method, recv != "" && ast.IsExported(method)
+}
+
+// contextParam 处理函数中 *gin.Context 参数的名称
+func contextParam(fn *ast.FuncDecl) string {
+	for _, field := range fn.Type.Params.List {
+		if star, ok := field.Type.(*ast.StarExpr); ok {
+			if sel, ok := star.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "Context" && len(field.Names) > 0 {
+				return field.Names[0].Name
+			}
+		}
+	}
+	return "c"
+}
+
+type handlerAnalysis struct {
+	types    *Types
+	file     *goFile
+	ctx      string
+	locals   map[string]ast.Expr // 局部变量声明的类型
+	summary  string
+	docBody  *typeRef
+	docQuery *typeRef
+	docKeys  map[string]*typeRef
+	docData  *typeRef
+	bound    bool // 处理函数中有绑定调用时不使用 @Param
+	body     *typeRef
+	query    *typeRef
+	keys     map[string]*typeRef
+	form     bool
+	data     *typeRef
+}
+
+func (a *handlerAnalysis) visit(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.ValueSpec:
+		for _, name := range n.Names {
+			if n.Type != nil {
+				a.locals[name.Name] = n.Type
+			}
+		}
+	case *ast.AssignStmt:
+		if n.Tok == token.DEFINE && len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					if typ := literalType(n.Rhs[i]); typ != nil {
+						a.locals[ident.Name] = typ
+					}
+				}
+			}
+		}
+	case *ast.CallExpr:
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if x.Name == a.ctx {
+			a.contextCall(sel.Sel.Name, n.Args)
+		} else if len(n.Args) > 0 && strings.HasSuffix(a.types.resolve(a.file, x.Name, ""), commonResponse) {
+			switch sel.Sel.Name {
+			case "OkWithData", "OkWithDetailed":
+				if typ := a.typeOf(n.Args[0]); typ != nil && a.data == nil {
+					a.data = &typeRef{file: a.file, expr: typ}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// contextCall 识别 gin.Context 上的参数绑定及读取
+func (a *handlerAnalysis) contextCall(method string, args []ast.Expr) {
+	switch method {
+	case "ShouldBindJSON", "BindJSON", "ShouldBindQuery", "BindQuery", "ShouldBind", "Bind", "ShouldBindWith":
+		if len(args) == 0 {
+			return
+		}
+		a.bound = true
+		typ := a.typeOf(args[0])
+		if typ == nil {
+			return
+		}
+		query := strings.Contains(method, "Query")
+		if method == "ShouldBindWith" && len(args) > 1 {
+			if sel, ok := args[1].(*ast.SelectorExpr); ok {
+				query = sel.Sel.Name == "Query" || sel.Sel.Name == "Form"
+			}
+		}
+		if query {
+			a.query = &typeRef{file: a.file, expr: typ}
+		} else {
+			a.body = &typeRef{file: a.file, expr: typ}
+		}
+	case "Query", "DefaultQuery", "GetQuery", "QueryArray":
+		if len(args) == 0 {
+			return
+		}
+		if key, err := strconv.Unquote(literal(args[0])); err == nil {
+			if method == "QueryArray" {
+				// axios 序列化数组参数时会加上 []
+				a.keys[strings.TrimSuffix(key, "[]")] = &typeRef{basic: "array", elem: &typeRef{basic: "string"}}
+			} else {
+				a.keys[key] = &typeRef{basic: "string"}
+			}
+		}
+	case "FormFile", "MultipartForm", "PostForm":
+		a.form = true
+	}
+}
+
+func literal(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		return lit.Value
+	}
+	return ""
+}
+
+// typeOf 绑定或返回的参数的类型 支持 &x、x 及复合字面量
+func (a *handlerAnalysis) typeOf(expr ast.Expr) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return a.locals[ident.Name]
+	}
+	return literalType(expr)
+}
+
+// literalType 复合字面量及 new(T) 的类型
+func literalType(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return literalType(e.X)
+	case *ast.CompositeLit:
+		return e.Type
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+			return e.Args[0]
+		}
+	}
+	return nil
+}
+
+// annotations 读取 swag 注释中的 @Summary、@Param 及 @Success
+func (a *handlerAnalysis) annotations(doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch strings.ToLower(fields[0]) {
+		case "@summary":
+			a.summary = strings.Join(fields[1:], " ")
+		case "@param":
+			if len(fields) < 4 {
+				continue
+			}
+			switch fields[2] {
+			case "body":
+				a.docBody = a.swagType(fields[3])
+			case "query":
+				if strings.Contains(fields[3], ".") {
+					a.docQuery = a.swagType(fields[3])
+				} else {
+					a.docKeys[fields[1]] = a.swagType(fields[3])
+				}
+			case "formData":
+				a.form = true
+			}
+		case "@success":
+			if len(fields) < 4 {
+				continue
+			}
+			if i := strings.Index(fields[3], "{"); i > 0 {
+				if data, ok := swagFields(fields[3][i+1 : len(fields[3])-1])["data"]; ok {
+					a.docData = a.swagType(data)
+				}
+			}
+		}
+	}
+}
+
+// swagType 解析 swag 注释中的类型 如 response.PageResult{list=[]system.SysApi}
+func (a *handlerAnalysis) swagType(expr string) *typeRef {
+	switch {
+	case strings.HasPrefix(expr, "[]"):
+		return &typeRef{basic: "array", elem: a.swagType(expr[2:])}
+	case strings.HasPrefix(expr, "map["):
+		if end := strings.Index(expr, "]"); end > 0 {
+			return &typeRef{basic: "map", elem: a.swagType(expr[end+1:])}
+		}
+	}
+	switch expr {
+	case "string", "file":
+		return &typeRef{basic: "string"}
+	case "int", "integer", "uint", "int64":
+		return &typeRef{basic: "integer"}
+	case "number", "float64":
+		return &typeRef{basic: "number"}
+	case "bool", "boolean":
+		return &typeRef{basic: "boolean"}
+	case "object", "interface{}", "any":
+		return &typeRef{basic: "unknown"}
+	}
+	name, override, _ := strings.Cut(expr, "{")
+	typ, err := parser.ParseExpr(name)
+	if err != nil {
+		return &typeRef{basic: "unknown"}
+	}
+	ref := &typeRef{file: a.file, expr: typ}
+	if override != "" && strings.HasSuffix(override, "}") {
+		ref.override = map[string]*typeRef{}
+		for key, value := range swagFields(override[:len(override)-1]) {
+			ref.override[key] = a.swagType(value)
+		}
+	}
+	return ref
+}
+
+// swagFields 拆分 swag 注释中 {k=v,k2=v2} 的字段 忽略嵌套括号中的逗号
+func swagFields(s string) map[string]string {
+	fields := map[string]string{}
+	depth, start := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			switch s[i] {
+			case '{', '[':
+				depth++
+				continue
+			case '}', ']':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if key, value, ok := strings.Cut(s[start:i], "="); ok {
+			fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		start = i + 1
+	}
+	return fields
+}
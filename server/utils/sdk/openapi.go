@@ -0,0 +1,357 @@
+package sdk
+
+import (
+	"go/ast"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Schema OpenAPI 3.1 中的 JSON Schema
+type Schema = map[string]any
+
+// Info OpenAPI 文档的基本信息
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// externalSchemas 模块外类型对应的 JSON Schema 未列出的类型为任意值
+var externalSchemas = map[string]Schema{
+	"time.Time":                             {"type": "string", "format": "date-time"},
+	"time.Duration":                         {"type": "integer", "format": "int64"},
+	"encoding/json.RawMessage":              {},
+	"mime/multipart.FileHeader":             {"type": "string", "format": "binary"},
+	"gorm.io/gorm.DeletedAt":                {"type": []string{"string", "null"}, "format": "date-time"},
+	"gorm.io/datatypes.JSON":                {},
+	"gorm.io/datatypes.JSONMap":             {"type": "object"},
+	"gorm.io/datatypes.Date":                {"type": "string", "format": "date"},
+	"gorm.io/datatypes.Time":                {"type": "string"},
+	"github.com/google/uuid.UUID":           {"type": "string", "format": "uuid"},
+	"github.com/gin-gonic/gin.H":            {"type": "object"},
+	"github.com/gofrs/uuid/v5.UUID":         {"type": "string", "format": "uuid"},
+	"github.com/shopspring/decimal.Decimal": {"type": "string"},
+}
+
+// basicSchemas Go内置类型对应的 JSON Schema
+var basicSchemas = map[string]Schema{
+	"bool": {"type": "boolean"}, "string": {"type": "string"},
+	"int": {"type": "integer"}, "int8": {"type": "integer"}, "int16": {"type": "integer"},
+	"int32": {"type": "integer", "format": "int32"}, "int64": {"type": "integer", "format": "int64"},
+	"uint": {"type": "integer", "minimum": 0}, "uint8": {"type": "integer", "minimum": 0},
+	"uint16": {"type": "integer", "minimum": 0}, "uint32": {"type": "integer", "minimum": 0},
+	"uint64": {"type": "integer", "minimum": 0}, "byte": {"type": "integer", "minimum": 0},
+	"rune": {"type": "integer"}, "float32": {"type": "number"}, "float64": {"type": "number"},
+	"any": {},
+}
+
+// Document 生成 OpenAPI 3.1 文档
+// 找不到处理函数的路由(如匿名函数注册或运行环境中没有源码)只有在 sys_apis 中登记过(Group 不为空)时才生成
+func (t *Types) Document(info Info, routes []Route) Schema {
+	s := &schemas{types: t, components: map[string]Schema{}}
+	paths := map[string]Schema{}
+	tags := map[string]bool{}
+	functions, _ := t.functions(routes, true)
+	for _, fn := range functions {
+		route, h := fn.route, fn.handler
+		if !methods[route.Method] {
+			continue
+		}
+		op := Schema{"operationId": fn.name, "responses": Schema{}}
+		summary := route.Description
+		if summary == "" && h != nil {
+			summary = h.summary
+		}
+		if summary != "" {
+			op["summary"] = summary
+		}
+		tag := route.Group
+		if tag == "" {
+			tag = strings.Split(strings.Trim(route.Path, "/"), "/")[0]
+		}
+		op["tags"], tags[tag] = []string{tag}, true
+		if route.Auth {
+			op["security"] = []Schema{{"ApiKeyAuth": []string{}}}
+		}
+		if route.Casbin {
+			op["x-casbin"] = true
+		}
+		if route.Plugin != "" {
+			op["x-plugin"] = Schema{"name": route.Plugin, "enabled": !route.Disabled}
+		}
+
+		var params []Schema
+		for _, param := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+			params = append(params, Schema{"name": param[1], "in": "path", "required": true, "schema": Schema{"type": "string"}})
+		}
+		data := Schema{}
+		if h != nil {
+			params = append(params, s.queryParams(h)...)
+			if body := s.requestBody(h); body != nil {
+				op["requestBody"] = body
+			}
+			if h.data != nil {
+				data = s.ref(h.data)
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		op["responses"] = Schema{
+			"200": Schema{
+				"description": "code 为0时成功 否则 msg 为错误信息",
+				"content": Schema{"application/json": Schema{"schema": Schema{
+					"type": "object",
+					"properties": Schema{
+						"code": Schema{"type": "integer"},
+						"data": data,
+						"msg":  Schema{"type": "string"},
+					},
+				}}},
+			},
+		}
+		if route.Auth {
+			op["responses"].(Schema)["401"] = Schema{"description": "未登录或登录已过期"}
+		}
+
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		if paths[path] == nil {
+			paths[path] = Schema{}
+		}
+		paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	tagList := make([]string, 0, len(tags))
+	for tag := range tags {
+		tagList = append(tagList, tag)
+	}
+	sort.Strings(tagList)
+	tagSchemas := make([]Schema, 0, len(tagList))
+	for _, tag := range tagList {
+		tagSchemas = append(tagSchemas, Schema{"name": tag})
+	}
+	return Schema{
+		"openapi": "3.1.0",
+		"info":    Schema{"title": info.Title, "version": info.Version, "description": info.Description},
+		"paths":   paths,
+		"tags":    tagSchemas,
+		"components": Schema{
+			"schemas": s.components,
+			"securitySchemes": Schema{
+				"ApiKeyAuth": Schema{"type": "apiKey", "in": "header", "name": "x-token"},
+			},
+		},
+	}
+}
+
+// schemas 生成文档时用到的结构体 生成为 components 中的 schema
+type schemas struct {
+	types      *Types
+	components map[string]Schema
+}
+
+// requestBody 请求体 读取上传文件的接口为 multipart/form-data
+func (s *schemas) requestBody(h *handler) Schema {
+	switch {
+	case h.form:
+		return Schema{"content": Schema{"multipart/form-data": Schema{"schema": Schema{"type": "object"}}}}
+	case h.body != nil:
+		return Schema{"required": true, "content": Schema{"application/json": Schema{"schema": s.ref(h.body)}}}
+	}
+	return nil
+}
+
+// queryParams 绑定的结构体按 form 标签展开为查询参数 与单独读取的参数合并
+func (s *schemas) queryParams(h *handler) []Schema {
+	var params []Schema
+	seen := map[string]bool{}
+	if h.query != nil && h.query.basic == "" {
+		if decl := s.types.lookup(h.query.file, h.query.expr); decl != nil {
+			if st, ok := decl.spec.Type.(*ast.StructType); ok {
+				for _, f := range s.types.props(decl.file, st, "form") {
+					param := Schema{"name": f.name, "in": "query", "schema": s.field(f)}
+					if f.required {
+						param["required"] = true
+					}
+					if f.doc != "" {
+						param["description"] = f.doc
+					}
+					params, seen[f.name] = append(params, param), true
+				}
+			}
+		}
+	}
+	for _, key := range h.sortedKeys() {
+		if seen[key] {
+			continue
+		}
+		schema := s.ref(h.keys[key])
+		name := key
+		if h.keys[key].basic == "array" {
+			name += "[]"
+		}
+		params = append(params, Schema{"name": name, "in": "query", "schema": schema})
+	}
+	return params
+}
+
+// ref 类型引用对应的 JSON Schema
+func (s *schemas) ref(ref *typeRef) Schema {
+	if ref == nil {
+		return Schema{}
+	}
+	switch ref.basic {
+	case "":
+	case "array":
+		return Schema{"type": "array", "items": s.ref(ref.elem)}
+	case "map":
+		return Schema{"type": "object", "additionalProperties": s.ref(ref.elem)}
+	case "unknown":
+		return Schema{}
+	default:
+		return Schema{"type": ref.basic}
+	}
+	schema := s.expr(ref.file, ref.expr)
+	if len(ref.override) == 0 {
+		return schema
+	}
+	properties := Schema{}
+	for key, value := range ref.override {
+		properties[key] = s.ref(value)
+	}
+	return Schema{"allOf": []Schema{schema, {"type": "object", "properties": properties}}}
+}
+
+// expr 文件中的类型表达式对应的 JSON Schema
+func (s *schemas) expr(file *goFile, expr ast.Expr) Schema {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if schema, ok := basicSchemas[e.Name]; ok {
+			return clone(schema)
+		}
+		return s.named(file.pkg.path, e.Name)
+	case *ast.SelectorExpr:
+		alias, ok := e.X.(*ast.Ident)
+		if !ok {
+			return Schema{}
+		}
+		return s.named(s.types.resolve(file, alias.Name, e.Sel.Name), e.Sel.Name)
+	case *ast.StarExpr:
+		return nullable(s.expr(file, e.X))
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return Schema{"type": "string", "format": "byte"}
+		}
+		return Schema{"type": "array", "items": s.expr(file, e.Elt)}
+	case *ast.MapType:
+		return Schema{"type": "object", "additionalProperties": s.expr(file, e.Value)}
+	case *ast.StructType:
+		return s.object(file, e)
+	}
+	return Schema{}
+}
+
+// named 模块内的类型生成为 components 中的 schema 返回引用
+func (s *schemas) named(pkgPath, name string) Schema {
+	if schema, ok := externalSchemas[pkgPath+"."+name]; ok {
+		return clone(schema)
+	}
+	pkg := s.types.load(pkgPath)
+	if pkg == nil {
+		return Schema{}
+	}
+	decl, ok := pkg.types[name]
+	if !ok || decl.spec.TypeParams != nil {
+		return Schema{}
+	}
+	key := s.types.Namespace(pkgPath) + "." + name
+	if _, ok = s.components[key]; !ok {
+		s.components[key] = Schema{} // 先占位 支持递归引用
+		var schema Schema
+		if st, ok := decl.spec.Type.(*ast.StructType); ok {
+			schema = s.object(decl.file, st)
+		} else {
+			schema = s.expr(decl.file, decl.spec.Type)
+		}
+		if doc := comment(decl.spec.Doc, decl.spec.Comment); doc != "" {
+			schema["description"] = doc
+		}
+		s.components[key] = schema
+	}
+	return Schema{"$ref": "#/components/schemas/" + key}
+}
+
+// object 结构体按 json 标签生成 binding:"required" 的字段为必填
+func (s *schemas) object(file *goFile, st *ast.StructType) Schema {
+	properties := Schema{}
+	var required []string
+	for _, f := range s.types.props(file, st, "json") {
+		schema := s.field(f)
+		if f.doc != "" {
+			if _, ok := schema["$ref"]; ok {
+				schema = Schema{"allOf": []Schema{schema}}
+			}
+			schema["description"] = f.doc
+		}
+		properties[f.name] = schema
+		if f.required {
+			required = append(required, f.name)
+		}
+	}
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// field 字段的 JSON Schema swaggertype 标签优先
+func (s *schemas) field(f structField) Schema {
+	switch {
+	case f.swagger != "":
+		parts := strings.Split(f.swagger, ",")
+		item := Schema{}
+		switch last := parts[len(parts)-1]; last {
+		case "string", "integer", "number", "boolean", "object":
+			item["type"] = last
+		}
+		if parts[0] == "array" {
+			return Schema{"type": "array", "items": item}
+		}
+		return item
+	case f.asString:
+		return Schema{"type": "string"}
+	}
+	return s.expr(f.file, f.expr)
+}
+
+// nullable 允许为 null 的类型 引用使用 anyOf
+func nullable(schema Schema) Schema {
+	switch typ := schema["type"].(type) {
+	case string:
+		schema["type"] = []string{typ, "null"}
+		return schema
+	case nil:
+		if len(schema) == 0 {
+			return schema
+		}
+	default:
+		return schema
+	}
+	return Schema{"anyOf": []Schema{schema, {"type": "null"}}}
+}
+
+func clone(schema Schema) Schema {
+	c := make(Schema, len(schema))
+	for k, v := range schema {
+		c[k] = v
+	}
+	return c
+}
+
+// methods 文档中包含的请求方法 忽略 gin 自动注册的 HEAD 等
+var methods = map[string]bool{
+	http.MethodGet: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodDelete: true, http.MethodPatch: true,
+}
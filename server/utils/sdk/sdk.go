@@ -0,0 +1,272 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Header 生成文件的头部注释 用于识别生成的文件
+const Header = "// Code generated by gva sdk. DO NOT EDIT."
+
+// Route 需要生成请求函数的路由 Description 为 sys_apis 中的描述
+// Group 及以下字段只用于生成 OpenAPI 文档
+type Route struct {
+	Method      string
+	Path        string
+	Handler     string
+	Description string
+	Group       string // sys_apis 中的分组
+	Auth        bool   // 需要登录
+	Casbin      bool   // 需要接口权限
+	Plugin      string // 注册路由的v2插件
+	Disabled    bool   // 插件已停用
+}
+
+// Files 生成的文件名及内容
+type Files map[string]string
+
+// reserved TypeScript保留字 不能作为函数名
+var reserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+	"request": true, "service": true,
+}
+
+var pathParam = regexp.MustCompile(`[:*](\w+)`)
+
+// Generate 生成前端SDK 每个路由生成一个请求函数 处理函数不是模块内的方法的路由被跳过
+// webSrc 为输出目录到前端 src 目录的相对路径 用于 tsconfig.json 中 @/ 的映射
+func (t *Types) Generate(routes []Route, webSrc string) (Files, []Route) {
+	functions, skipped := t.functions(routes, false)
+
+	var api strings.Builder
+	for _, fn := range functions {
+		route, h := fn.route, fn.handler
+		var args, config []string
+		url := "'" + route.Path + "'"
+		if params := pathParam.FindAllStringSubmatch(route.Path, -1); len(params) > 0 {
+			url = "`" + pathParam.ReplaceAllString(route.Path, "$${$1}") + "`"
+			for _, param := range params {
+				args = append(args, param[1]+": string | number")
+			}
+		}
+		config = append(config, "url: "+url, "method: '"+strings.ToLower(route.Method)+"'")
+		if h.form {
+			args, config = append(args, "data: FormData"), append(config, "data")
+		} else if h.body != nil {
+			body := input(t.ts(h.body))
+			arg := "data: " + body
+			if t.empty(body) {
+				arg += " = {}"
+			}
+			args, config = append(args, arg), append(config, "data")
+		}
+		if query := t.queryTS(h); query != "" {
+			args, config = append(args, "params: "+query), append(config, "params")
+		}
+
+		description := route.Description
+		if description == "" {
+			description = h.summary
+		}
+		api.WriteString("\n/**\n")
+		if description != "" {
+			api.WriteString(" * " + strings.ReplaceAll(description, "*/", "* /") + "\n")
+		}
+		fmt.Fprintf(&api, " * %s %s\n */\n", route.Method, route.Path)
+		fmt.Fprintf(&api, "export const %s = (%s) =>\n  request<%s>({ %s })\n",
+			fn.name, strings.Join(args, ", "), t.ts(h.data), strings.Join(config, ", "))
+	}
+
+	types := t.Declarations()
+	var imports []string
+	for namespace := range t.namespaces() {
+		if regexp.MustCompile(`\b` + namespace + `\.`).MatchString(api.String()) {
+			imports = append(imports, namespace)
+		}
+	}
+	sort.Strings(imports)
+
+	var head strings.Builder
+	head.WriteString(Header + "\n")
+	head.WriteString("import service from '@/utils/request'\n")
+	if len(imports) > 0 {
+		head.WriteString("import type { " + strings.Join(imports, ", ") + " } from './types'\n")
+	}
+	head.WriteString(`
+// ApiResponse 统一返回结构 code 为0时表示成功
+export interface ApiResponse<T = unknown> {
+  code: number
+  data: T
+  msg: string
+}
+
+interface RequestConfig {
+  url: string
+  method: string
+  data?: unknown
+  params?: unknown
+}
+
+// request 响应拦截器返回的是 response.data 即 ApiResponse
+const request = <T>(config: RequestConfig): Promise<ApiResponse<T>> =>
+  service.request<unknown, ApiResponse<T>>(config)
+`)
+
+	files := Files{
+		"api.ts":   head.String() + api.String(),
+		"types.ts": Header + "\n\n" + types,
+		"tsconfig.json": fmt.Sprintf(`{
+  "compilerOptions": {
+    "target": "ES2020",
+    "module": "ESNext",
+    "moduleResolution": "Bundler",
+    "strict": true,
+    "noEmit": true,
+    "allowJs": true,
+    "skipLibCheck": true,
+    "baseUrl": ".",
+    "paths": {
+      "@/*": ["%s/*"]
+    }
+  },
+  "include": ["./*.ts"]
+}
+`, webSrc),
+	}
+	if types == "" {
+		files["types.ts"] = Header + "\n\nexport {}\n"
+	}
+	return files, skipped
+}
+
+// function 路由对应的请求函数 handler 为空时只用于生成文档
+type function struct {
+	route   Route
+	handler *handler
+	name    string
+}
+
+// functions 按路径排序分析路由的处理函数并命名 described 时保留有接口描述但找不到处理函数的路由
+func (t *Types) functions(routes []Route, described bool) ([]*function, []Route) {
+	routes = append([]Route(nil), routes...)
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	var (
+		functions []*function
+		skipped   []Route
+		names     = map[string][]*function{}
+	)
+	for _, route := range routes {
+		h, ok := t.handler(route.Handler)
+		if !ok {
+			if !described || route.Group == "" {
+				skipped = append(skipped, route)
+				continue
+			}
+			fn := &function{route: route, name: pathName(route.Method, route.Path)}
+			functions = append(functions, fn)
+			continue
+		}
+		fn := &function{route: route, handler: h, name: lowerFirst(h.name)}
+		functions = append(functions, fn)
+		names[fn.name] = append(names[fn.name], fn)
+	}
+	// 不同处理函数的方法同名时加上接收者类型 同一处理函数注册到多个路由时加上请求方法
+	for name, list := range names {
+		if len(list) == 1 && !reserved[name] {
+			continue
+		}
+		for _, fn := range list {
+			fn.name = lowerFirst(strings.TrimSuffix(fn.handler.recv, "Api")) + fn.handler.name
+		}
+	}
+	seen := map[string]int{}
+	for _, fn := range functions {
+		seen[fn.name]++
+	}
+	for _, fn := range functions {
+		if seen[fn.name] > 1 {
+			fn.name += upperFirst(strings.ToLower(fn.route.Method))
+		}
+	}
+	return functions, skipped
+}
+
+// pathName 按请求方法及路径命名 如 GET /health 为 getHealth
+func pathName(method, path string) string {
+	name := strings.ToLower(method)
+	for _, part := range strings.FieldsFunc(path, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		name += upperFirst(part)
+	}
+	return name
+}
+
+// queryTS 查询参数的TypeScript类型 绑定的结构体与单独读取的参数合并
+func (t *Types) queryTS(h *handler) string {
+	var query string
+	if h.query != nil {
+		query = input(t.ts(h.query))
+	}
+	if len(h.keys) == 0 {
+		return query
+	}
+	var b strings.Builder
+	b.WriteString("{ ")
+	for _, key := range h.sortedKeys() {
+		// 查询参数读取为字符串 数字也可以直接传入
+		ts, ref := t.ts(h.keys[key]), h.keys[key]
+		switch {
+		case ref.basic == "string":
+			ts = "string | number"
+		case ref.basic == "array" && ref.elem.basic == "string":
+			ts = "(string | number)[]"
+		}
+		b.WriteString(propName(key) + "?: " + ts + "; ")
+	}
+	b.WriteString("}")
+	if query == "" {
+		return b.String() + " = {}"
+	}
+	return query + " & " + b.String()
+}
+
+func (t *Types) namespaces() map[string]bool {
+	namespaces := map[string]bool{}
+	for _, decl := range t.decls {
+		namespaces[decl.namespace] = true
+	}
+	return namespaces
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
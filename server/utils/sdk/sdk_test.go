@@ -0,0 +1,284 @@
+package sdk
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testModule = "example.com/app"
+
+var testFiles = map[string]string{
+	"model/common/model.go": `package common
+
+import "time"
+
+// Model 公共字段
+type Model struct {
+	ID        uint      ` + "`json:\"ID\"`" + ` // 主键ID
+	CreatedAt time.Time
+	secret    string
+}
+`,
+	"model/common/response/response.go": `package response
+
+type Response struct {
+	Code int         ` + "`json:\"code\"`" + `
+	Data interface{} ` + "`json:\"data\"`" + `
+	Msg  string      ` + "`json:\"msg\"`" + `
+}
+
+type PageResult struct {
+	List  interface{} ` + "`json:\"list\"`" + `
+	Total int64       ` + "`json:\"total\"`" + `
+}
+
+func OkWithData(data interface{}, c interface{}) {}
+`,
+	"model/system/user.go": `package system
+
+import (
+	"example.com/app/model/common"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+type User struct {
+	common.Model
+	UUID     uuid.UUID ` + "`json:\"uuid\"`" + `
+	Name     string    ` + "`json:\"userName\"`" + ` // 用户名
+	Password string    ` + "`json:\"-\"`" + `
+	Nick     *string   ` + "`json:\"nickName,omitempty\"`" + `
+	Status   Status    ` + "`json:\"status\"`" + `
+	Tags     []byte    ` + "`json:\"tags\" swaggertype:\"array,integer\"`" + `
+	Parent   *User     ` + "`json:\"parent\"`" + `
+	Roles    []Role    ` + "`json:\"roles\"`" + `
+	Extra    map[string]int
+}
+
+type Role struct {
+	ID uint ` + "`json:\"id,string\"`" + `
+}
+`,
+	"model/system/request/user.go": `package request
+
+type Search struct {
+	Page int    ` + "`json:\"page\" form:\"page\"`" + `
+	Name string ` + "`json:\"name\" form:\"name\"`" + `
+}
+
+type Empty struct{}
+`,
+	"api/user.go": `package api
+
+import (
+	"example.com/app/model/common/response"
+	"example.com/app/model/system"
+	systemReq "example.com/app/model/system/request"
+	"github.com/gin-gonic/gin"
+)
+
+type UserApi struct{}
+
+// CreateUser 创建用户
+// @Summary 创建用户
+// @Param data body system.User true "用户"
+// @Success 200 {object} response.Response{msg=string}
+func (a *UserApi) CreateUser(c *gin.Context) {
+	var user system.User
+	_ = c.ShouldBindJSON(&user)
+	response.OkWithData(user, c)
+}
+
+// GetUserList 分页获取用户
+// @Summary 分页获取用户
+// @Success 200 {object} response.Response{data=response.PageResult{list=[]system.User},msg=string}
+func (a *UserApi) GetUserList(ctx *gin.Context) {
+	search := systemReq.Search{}
+	_ = ctx.ShouldBindQuery(&search)
+	_ = ctx.Query("sort")
+}
+
+// DeleteUser 删除用户
+// @Summary 删除用户
+// @Param data body system.User true "复制来的注释"
+func (a *UserApi) DeleteUser(c *gin.Context) {
+	_ = c.Query("id")
+	_ = c.QueryArray("ids[]")
+}
+
+// Menus 菜单 注释中使用本文件未导入的包
+// @Summary 获取菜单
+// @Success 200 {object} response.Response{data=[]systemRes.Menu}
+func (a *UserApi) Menus(c *gin.Context) {
+	var empty systemReq.Empty
+	_ = c.ShouldBindJSON(&empty)
+}
+
+func (a *UserApi) Upload(c *gin.Context) {
+	_, _ = c.FormFile("file")
+	response.OkWithData(&system.Role{}, c)
+}
+`,
+	"api/other.go": `package api
+
+import systemRes "example.com/app/model/system/response"
+
+var _ systemRes.Menu
+`,
+	"api/role.go": `package api
+
+import "github.com/gin-gonic/gin"
+
+type RoleApi struct{}
+
+func (a *RoleApi) CreateUser(c *gin.Context) {}
+
+func (a *RoleApi) Delete(c *gin.Context) {}
+`,
+	"model/system/response/menu.go": `package response
+
+type Menu struct {
+	Path string ` + "`json:\"path\"`" + `
+}
+`,
+}
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range testFiles {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := func(recv, method string) string {
+		return testModule + "/api.(*" + recv + ")." + method + "-fm"
+	}
+	routes := []Route{
+		{Method: "POST", Path: "/user/createUser", Handler: handler("UserApi", "CreateUser"), Description: "新增用户"},
+		{Method: "GET", Path: "/user/getUserList", Handler: handler("UserApi", "GetUserList")},
+		{Method: "DELETE", Path: "/user/:id", Handler: handler("UserApi", "DeleteUser")},
+		{Method: "POST", Path: "/user/menus", Handler: handler("UserApi", "Menus")},
+		{Method: "POST", Path: "/user/upload", Handler: handler("UserApi", "Upload")},
+		{Method: "POST", Path: "/role/createUser", Handler: handler("RoleApi", "CreateUser")},
+		{Method: "DELETE", Path: "/role/delete", Handler: handler("RoleApi", "Delete")},
+		{Method: "GET", Path: "/health", Handler: testModule + "/initialize.Routers.func1"},
+	}
+	files, skipped := NewTypes(root, testModule).Generate(routes, "..")
+	if len(skipped) != 1 || skipped[0].Path != "/health" {
+		t.Errorf("Generate() skipped = %+v", skipped)
+	}
+
+	types := files["types.ts"]
+	for _, want := range []string{
+		"export namespace System {\n  export interface Role {\n    id: string\n  }\n\n  export type Status = string\n",
+		"export interface User {\n    /** 主键ID */\n    ID: number\n    CreatedAt: string\n    uuid: string\n",
+		"    /** 用户名 */\n    userName: string\n    nickName?: string | null\n    status: System.Status\n    tags: number[]\n    parent: System.User | null\n    roles: System.Role[]\n    Extra: Record<string, number>\n  }",
+		"export namespace SystemRequest {\n  export type Empty = Record<string, never>\n",
+		"export namespace SystemResponse {\n  export interface Menu {",
+	} {
+		if !strings.Contains(types, want) {
+			t.Errorf("types.ts should contain %q\n%s", want, types)
+		}
+	}
+	for _, unwanted := range []string{"Password", "secret", "Common {"} {
+		if strings.Contains(types, unwanted) {
+			t.Errorf("types.ts should not contain %q", unwanted)
+		}
+	}
+
+	api := files["api.ts"]
+	for _, want := range []string{
+		"import type { CommonResponse, System, SystemRequest, SystemResponse } from './types'",
+		" * 新增用户\n * POST /user/createUser\n */\nexport const userCreateUser = (data: Partial<System.User>) =>\n  request<System.User>({ url: '/user/createUser', method: 'post', data })",
+		"export const getUserList = (params: Partial<SystemRequest.Search> & { sort?: string | number; }) =>\n  request<Omit<CommonResponse.PageResult, \"list\"> & { list: System.User[] }>(",
+		"export const deleteUser = (id: string | number, params: { id?: string | number; ids?: (string | number)[]; } = {}) =>\n  request<unknown>({ url: `/user/${id}`, method: 'delete', params })",
+		"export const menus = (data: Partial<SystemRequest.Empty> = {}) =>\n  request<SystemResponse.Menu[]>(",
+		"export const upload = (data: FormData) =>\n  request<System.Role>(",
+		"export const roleCreateUser = () =>",
+		"export const roleDelete = () =>",
+	} {
+		if !strings.Contains(api, want) {
+			t.Errorf("api.ts should contain %q\n%s", want, api)
+		}
+	}
+	if !strings.Contains(files["tsconfig.json"], `"@/*": ["../*"]`) {
+		t.Errorf("tsconfig.json = %s", files["tsconfig.json"])
+	}
+}
+
+func TestSplitHandler(t *testing.T) {
+	tests := []struct {
+		name                string
+		pkgPath, recv, meth string
+		ok                  bool
+	}{
+		{"github.com/a/server/api/v1/system.(*BaseApi).Login-fm", "github.com/a/server/api/v1/system", "BaseApi", "Login", true},
+		{"github.com/a/server/plugin/fans-club/api.clubApi.List-fm", "github.com/a/server/plugin/fans-club/api", "clubApi", "List", true},
+		{"github.com/a/server/initialize.Routers.func1", "", "", "", false},
+		{"main.handler", "", "", "", false},
+	}
+	for _, tt := range tests {
+		pkgPath, recv, method, ok := splitHandler(tt.name)
+		if ok != tt.ok || ok && (pkgPath != tt.pkgPath || recv != tt.recv || method != tt.meth) {
+			t.Errorf("splitHandler(%q) = %s %s %s %v", tt.name, pkgPath, recv, method, ok)
+		}
+	}
+}
+
+func TestDocument(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range testFiles {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := func(recv, method string) string {
+		return testModule + "/api.(*" + recv + ")." + method + "-fm"
+	}
+	routes := []Route{
+		{Method: "POST", Path: "/user/createUser", Handler: handler("UserApi", "CreateUser"), Description: "新增用户", Group: "用户", Auth: true, Casbin: true},
+		{Method: "GET", Path: "/user/getUserList", Handler: handler("UserApi", "GetUserList"), Auth: true},
+		{Method: "DELETE", Path: "/user/:id", Handler: handler("UserApi", "DeleteUser"), Plugin: "user", Disabled: true},
+		{Method: "GET", Path: "/health", Handler: testModule + "/initialize.Routers.func1", Group: "系统"},
+		{Method: "GET", Path: "/mcp", Handler: testModule + "/initialize.Routers.func2"},
+	}
+	doc := NewTypes(root, testModule).Document(Info{Title: "test", Version: "v1"}, routes)
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{
+		`"openapi":"3.1.0"`,
+		`"/user/createUser":{"post":{"operationId":"createUser","requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/System.User"}}},"required":true}`,
+		`"security":[{"ApiKeyAuth":[]}],"summary":"新增用户","tags":["用户"],"x-casbin":true`,
+		`"parameters":[{"in":"query","name":"page","schema":{"type":"integer"}},{"in":"query","name":"name","schema":{"type":"string"}},{"in":"query","name":"sort","schema":{"type":"string"}}]`,
+		`"/user/{id}":{"delete":{"operationId":"deleteUser","parameters":[{"in":"path","name":"id","required":true,"schema":{"type":"string"}}`,
+		`"x-plugin":{"enabled":false,"name":"user"}`,
+		`"/health":{"get":{"operationId":"getHealth"`,
+		`"parent":{"anyOf":[{"$ref":"#/components/schemas/System.User"},{"type":"null"}]}`,
+		`"nickName":{"type":["string","null"]}`,
+		`"tags":{"items":{"type":"integer"},"type":"array"}`,
+		`"ID":{"description":"主键ID","minimum":0,"type":"integer"}`,
+		`"ApiKeyAuth":{"in":"header","name":"x-token","type":"apiKey"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("document should contain %s\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, `"/mcp"`) {
+		t.Errorf("document should not contain routes without handler and description")
+	}
+}
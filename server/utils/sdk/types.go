@@ -0,0 +1,544 @@
+package sdk
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// externalTypes 模块外类型对应的TypeScript类型 未列出的类型使用 unknown
+var externalTypes = map[string]string{
+	"time.Time":                             "string",
+	"time.Duration":                         "number",
+	"encoding/json.RawMessage":              "unknown",
+	"mime/multipart.FileHeader":             "File",
+	"gorm.io/gorm.DeletedAt":                "string | null",
+	"gorm.io/datatypes.JSON":                "unknown",
+	"gorm.io/datatypes.JSONMap":             "Record<string, unknown>",
+	"gorm.io/datatypes.Date":                "string",
+	"gorm.io/datatypes.Time":                "string",
+	"github.com/google/uuid.UUID":           "string",
+	"github.com/gin-gonic/gin.H":            "Record<string, unknown>",
+	"github.com/gofrs/uuid/v5.UUID":         "string",
+	"github.com/shopspring/decimal.Decimal": "string",
+}
+
+// basicTypes Go内置类型对应的TypeScript类型
+var basicTypes = map[string]string{
+	"bool": "boolean", "string": "string", "byte": "number", "rune": "number",
+	"int": "number", "int8": "number", "int16": "number", "int32": "number", "int64": "number",
+	"uint": "number", "uint8": "number", "uint16": "number", "uint32": "number", "uint64": "number",
+	"float32": "number", "float64": "number", "any": "unknown",
+}
+
+// goPackage 解析后的模块内的包 只保留类型及方法声明
+type goPackage struct {
+	path  string
+	name  string
+	types map[string]*typeDecl
+	funcs map[string]*funcDecl // "接收者类型.方法名"
+	alias map[string]string    // 包内各文件使用的导入别名
+}
+
+type goFile struct {
+	pkg     *goPackage
+	imports map[string]string // 显式别名 -> 包路径
+	paths   []string
+}
+
+type typeDecl struct {
+	file *goFile
+	spec *ast.TypeSpec
+}
+
+type funcDecl struct {
+	file *goFile
+	decl *ast.FuncDecl
+}
+
+// tsDecl 已生成的TypeScript声明
+type tsDecl struct {
+	namespace string
+	name      string
+	body      string
+}
+
+// Types 按需加载模块内的Go包 将类型转换为TypeScript声明
+type Types struct {
+	root   string // 模块根目录
+	module string // 模块路径
+	fset   *token.FileSet
+	pkgs   map[string]*goPackage
+	decls  map[string]*tsDecl // "包路径.类型名"
+	queue  []string
+}
+
+// NewTypes root 为 go.mod 所在目录 module 为模块路径
+func NewTypes(root, module string) *Types {
+	return &Types{
+		root:   root,
+		module: module,
+		fset:   token.NewFileSet(),
+		pkgs:   map[string]*goPackage{},
+		decls:  map[string]*tsDecl{},
+	}
+}
+
+// load 加载模块内的包 不在模块内或目录不存在时返回nil
+func (t *Types) load(pkgPath string) *goPackage {
+	if pkg, ok := t.pkgs[pkgPath]; ok {
+		return pkg
+	}
+	t.pkgs[pkgPath] = nil
+	if pkgPath != t.module && !strings.HasPrefix(pkgPath, t.module+"/") {
+		return nil
+	}
+	dir := filepath.Join(t.root, filepath.FromSlash(strings.TrimPrefix(pkgPath, t.module)))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	pkg := &goPackage{path: pkgPath, types: map[string]*typeDecl{}, funcs: map[string]*funcDecl{}, alias: map[string]string{}}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(t.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil || f.Name.Name == "main" {
+			continue
+		}
+		pkg.name = f.Name.Name
+		file := &goFile{pkg: pkg, imports: map[string]string{}}
+		for _, spec := range f.Imports {
+			p, _ := strconv.Unquote(spec.Path.Value)
+			if spec.Name != nil {
+				file.imports[spec.Name.Name] = p
+				pkg.alias[spec.Name.Name] = p
+			} else {
+				file.paths = append(file.paths, p)
+			}
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						if ts.Doc == nil && len(d.Specs) == 1 {
+							ts.Doc = d.Doc
+						}
+						pkg.types[ts.Name.Name] = &typeDecl{file: file, spec: ts}
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) == 1 {
+					pkg.funcs[receiverName(d.Recv.List[0].Type)+"."+d.Name.Name] = &funcDecl{file: file, decl: d}
+				}
+			}
+		}
+	}
+	if pkg.name == "" {
+		return nil
+	}
+	t.pkgs[pkgPath] = pkg
+	return pkg
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// resolve 按文件的导入查找包名对应的包路径 有多个候选时选择声明了该类型的包
+// swag 注释中的类型可能使用本文件未导入的包 依次按同包其他文件的别名及已加载的包名查找
+func (t *Types) resolve(file *goFile, alias, name string) string {
+	if p, ok := file.imports[alias]; ok {
+		return p
+	}
+	var candidates []string
+	for _, p := range file.paths {
+		if pkg := t.load(p); (pkg != nil && pkg.name == alias) || (pkg == nil && externalName(p) == alias) {
+			candidates = append(candidates, p)
+		}
+	}
+	if p, ok := file.pkg.alias[alias]; ok {
+		candidates = append(candidates, p)
+	}
+	var loaded []string
+	for p, pkg := range t.pkgs {
+		if pkg != nil && pkg.name == alias {
+			loaded = append(loaded, p)
+		}
+	}
+	sort.Strings(loaded)
+	candidates = append(candidates, loaded...)
+	if len(candidates) == 0 {
+		return ""
+	}
+	for _, p := range candidates {
+		if pkg := t.load(p); pkg != nil {
+			if _, ok := pkg.types[name]; ok {
+				return p
+			}
+		}
+	}
+	return candidates[0]
+}
+
+// externalName 模块外包的默认包名 忽略主版本后缀及 gopkg.in 的版本
+func externalName(p string) string {
+	name := path.Base(p)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(p))
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// Namespace 包对应的TypeScript命名空间 由包路径去掉开头的 model 后转换为大驼峰
+func (t *Types) Namespace(pkgPath string) string {
+	rel := strings.TrimPrefix(strings.TrimPrefix(pkgPath, t.module), "/")
+	rel = strings.TrimPrefix(rel, "model/")
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(rel, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	if b.Len() == 0 {
+		return "Root"
+	}
+	return b.String()
+}
+
+// Named 返回包中类型的TypeScript引用 首次引用时加入待生成队列
+func (t *Types) Named(pkgPath, name string) string {
+	if ts, ok := externalTypes[pkgPath+"."+name]; ok {
+		return ts
+	}
+	pkg := t.load(pkgPath)
+	if pkg == nil {
+		return "unknown"
+	}
+	decl, ok := pkg.types[name]
+	if !ok || decl.spec.TypeParams != nil {
+		return "unknown"
+	}
+	key := pkgPath + "." + name
+	if _, ok = t.decls[key]; !ok {
+		t.decls[key] = &tsDecl{namespace: t.Namespace(pkgPath), name: name}
+		t.queue = append(t.queue, key)
+	}
+	return t.decls[key].namespace + "." + name
+}
+
+// Expr 将文件中的类型表达式转换为TypeScript类型
+func (t *Types) Expr(file *goFile, expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ts, ok := basicTypes[e.Name]; ok {
+			return ts
+		}
+		return t.Named(file.pkg.path, e.Name)
+	case *ast.SelectorExpr:
+		alias, ok := e.X.(*ast.Ident)
+		if !ok {
+			return "unknown"
+		}
+		pkgPath := t.resolve(file, alias.Name, e.Sel.Name)
+		if pkgPath == "" {
+			return "unknown"
+		}
+		return t.Named(pkgPath, e.Sel.Name)
+	case *ast.StarExpr:
+		return t.Expr(file, e.X) + " | null"
+	case *ast.ArrayType:
+		if ident, ok := e.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return "string" // []byte 按base64编码
+		}
+		return arrayOf(t.Expr(file, e.Elt))
+	case *ast.MapType:
+		return "Record<string, " + t.Expr(file, e.Value) + ">"
+	case *ast.StructType:
+		return t.inline(file, e, "")
+	}
+	return "unknown"
+}
+
+func arrayOf(ts string) string {
+	if strings.ContainsAny(ts, " |&") {
+		return "(" + ts + ")[]"
+	}
+	return ts + "[]"
+}
+
+// structField 展开后的结构体字段
+type structField struct {
+	name     string
+	file     *goFile
+	expr     ast.Expr
+	optional bool   // omitempty
+	asString bool   // json 标签的 string 选项
+	swagger  string // swaggertype 标签
+	required bool   // binding:"required"
+	doc      string
+	depth    int
+}
+
+// fields 按 encoding/json 的规则展开结构体字段 未指定名称的嵌入结构体展开到外层
+// key 为字段名所用的标签 请求体及返回为 json 查询参数为 form
+func (t *Types) fields(file *goFile, st *ast.StructType, key string, depth int, out *[]structField) {
+	for _, field := range st.Fields.List {
+		tag := reflect.StructTag("")
+		if field.Tag != nil {
+			value, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		value, hasTag := tag.Lookup(key)
+		name, opts, _ := strings.Cut(value, ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		f := structField{file: file, expr: field.Type, swagger: tag.Get("swaggertype"), doc: comment(field.Doc, field.Comment), depth: depth}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty", "omitzero":
+				f.optional = true
+			case "string":
+				f.asString = true
+			}
+		}
+		for _, rule := range strings.Split(tag.Get("binding"), ",") {
+			f.required = f.required || rule == "required"
+		}
+		if len(field.Names) == 0 {
+			if name == "" {
+				if decl := t.lookup(file, field.Type); decl != nil {
+					if embedded, ok := decl.spec.Type.(*ast.StructType); ok {
+						t.fields(decl.file, embedded, key, depth+1, out)
+						continue
+					}
+				}
+			}
+			goName := receiverName(field.Type)
+			if sel, ok := field.Type.(*ast.SelectorExpr); ok {
+				goName = sel.Sel.Name
+			} else if star, ok := field.Type.(*ast.StarExpr); ok {
+				if sel, ok := star.X.(*ast.SelectorExpr); ok {
+					goName = sel.Sel.Name
+				}
+			}
+			if !ast.IsExported(goName) {
+				continue
+			}
+			if !hasTag || name == "" {
+				name = goName
+			}
+			f.name = name
+			*out = append(*out, f)
+			continue
+		}
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			f.name = name
+			if f.name == "" {
+				f.name = ident.Name
+			}
+			*out = append(*out, f)
+		}
+	}
+}
+
+// props 结构体展开后的字段 同名字段只保留嵌入层级最浅的
+func (t *Types) props(file *goFile, st *ast.StructType, key string) []structField {
+	var list []structField
+	t.fields(file, st, key, 0, &list)
+	index := map[string]int{}
+	var props []structField
+	for _, f := range list {
+		if i, ok := index[f.name]; ok {
+			if f.depth < props[i].depth {
+				props[i] = f
+			}
+			continue
+		}
+		index[f.name] = len(props)
+		props = append(props, f)
+	}
+	return props
+}
+
+// fieldTS 字段的TypeScript类型 swaggertype 标签优先
+func (t *Types) fieldTS(f structField) string {
+	switch {
+	case f.swagger != "":
+		return swaggerType(f.swagger)
+	case f.asString:
+		return "string"
+	}
+	return t.Expr(f.file, f.expr)
+}
+
+// swaggerType 按字段的 swaggertype 标签确定类型 如 array,integer
+func swaggerType(tag string) string {
+	parts := strings.Split(tag, ",")
+	ts := "unknown"
+	switch parts[len(parts)-1] {
+	case "string":
+		ts = "string"
+	case "integer", "number":
+		ts = "number"
+	case "boolean":
+		ts = "boolean"
+	case "object":
+		ts = "Record<string, unknown>"
+	}
+	if parts[0] == "array" {
+		return arrayOf(ts)
+	}
+	return ts
+}
+
+// empty 类型引用是否为没有字段的结构体
+func (t *Types) empty(ts string) bool {
+	ts = strings.TrimSuffix(strings.TrimPrefix(ts, "Partial<"), ">")
+	for key, decl := range t.decls {
+		if decl.namespace+"."+decl.name != ts {
+			continue
+		}
+		i := strings.LastIndex(key, ".")
+		st, ok := t.pkgs[key[:i]].types[key[i+1:]].spec.Type.(*ast.StructType)
+		return ok && len(st.Fields.List) == 0
+	}
+	return false
+}
+
+// lookup 查找类型表达式对应的模块内类型声明 忽略指针
+func (t *Types) lookup(file *goFile, expr ast.Expr) *typeDecl {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return file.pkg.types[e.Name]
+	case *ast.SelectorExpr:
+		if alias, ok := e.X.(*ast.Ident); ok {
+			if pkg := t.load(t.resolve(file, alias.Name, e.Sel.Name)); pkg != nil {
+				return pkg.types[e.Sel.Name]
+			}
+		}
+	}
+	return nil
+}
+
+// inline 生成结构体的TypeScript对象类型 indent 为属性的缩进
+func (t *Types) inline(file *goFile, st *ast.StructType, indent string) string {
+	props := t.props(file, st, "json")
+	if len(props) == 0 {
+		return "Record<string, never>"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, p := range props {
+		if p.doc != "" {
+			b.WriteString(indent + "  /** " + p.doc + " */\n")
+		}
+		b.WriteString(indent + "  " + propName(p.name))
+		if p.optional {
+			b.WriteString("?")
+		}
+		b.WriteString(": " + t.fieldTS(p) + "\n")
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// propName 不是合法标识符的属性名加引号
+func propName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	return name
+}
+
+func comment(groups ...*ast.CommentGroup) string {
+	var parts []string
+	for _, group := range groups {
+		if text := strings.TrimSpace(group.Text()); text != "" {
+			parts = append(parts, strings.Join(strings.Fields(text), " "))
+		}
+	}
+	return strings.ReplaceAll(strings.Join(parts, " "), "*/", "* /")
+}
+
+// Declarations 生成所有被引用类型的声明 按命名空间分组
+func (t *Types) Declarations() string {
+	for len(t.queue) > 0 {
+		key := t.queue[0]
+		t.queue = t.queue[1:]
+		i := strings.LastIndex(key, ".")
+		decl := t.pkgs[key[:i]].types[key[i+1:]]
+		doc := comment(decl.spec.Doc, decl.spec.Comment)
+		var body string
+		if st, ok := decl.spec.Type.(*ast.StructType); ok && len(st.Fields.List) > 0 {
+			body = "export interface " + decl.spec.Name.Name + " " + t.inline(decl.file, st, "  ")
+		} else {
+			body = "export type " + decl.spec.Name.Name + " = " + t.Expr(decl.file, decl.spec.Type)
+		}
+		if doc != "" {
+			body = "/** " + doc + " */\n  " + body
+		}
+		t.decls[key].body = body
+	}
+
+	keys := make([]string, 0, len(t.decls))
+	for key := range t.decls {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := t.decls[keys[i]], t.decls[keys[j]]
+		if a.namespace != b.namespace {
+			return a.namespace < b.namespace
+		}
+		return a.name < b.name
+	})
+	var b strings.Builder
+	namespace := ""
+	for _, key := range keys {
+		decl := t.decls[key]
+		if decl.namespace != namespace {
+			if namespace != "" {
+				b.WriteString("}\n\n")
+			}
+			namespace = decl.namespace
+			fmt.Fprintf(&b, "export namespace %s {\n", namespace)
+		} else {
+			b.WriteString("\n")
+		}
+		b.WriteString("  " + decl.body + "\n")
+	}
+	if namespace != "" {
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+type Client struct {
+	hub    *Hub
+	conn   *websocket.Conn
+	send   chan []byte
+	user   *User
+	userID string
+	check  func() bool // 连接期间定期检查鉴权是否仍然有效 为nil时不检查
+}
+
+// Serve 升级为WebSocket连接并注册到hub 调用方负责鉴权
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, user *User) {
+	h.ServeChecked(w, r, user, nil)
+}
+
+// ServeChecked 同 Serve 连接期间每次心跳时调用 check 返回false时(如令牌已注销)断开连接
+func (h *Hub) ServeChecked(w http.ResponseWriter, r *http.Request, user *User, check func() bool) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		zap.L().Error("WebSocket 升级失败", zap.Error(err))
+		return
+	}
+
+	client := &Client{
+		hub:    h,
+		conn:   conn,
+		send:   make(chan []byte, 256),
+		user:   user,
+		userID: user.ID,
+		check:  check,
+	}
+
+	client.hub.register <- client
+
+	go client.writePump()
+	go client.readPump()
+}
+
+func (c *Client) readPump() {
+	defer func() {
+		c.hub.unregister <- c
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(512)
+	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		return nil
+	})
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				zap.L().Warn("websocket读取错误", zap.String("userID", c.userID), zap.Error(err))
+			}
+			break
+		}
+
+		// 解析消息
+		var msg Message
+		if err := json.Unmarshal(message, &msg); err != nil {
+			zap.L().Warn("websocket消息解析错误", zap.Error(err))
+			continue
+		}
+
+		// 设置发送者信息
+		msg.From = *c.user
+		msg.Time = time.Now().Unix()
+
+		// 处理不同类型的消息
+		switch msg.Type {
+		case "private_message":
+			// 私聊消息
+			content, ok := msg.Content.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if targetUserID, ok := content["target"].(string); ok {
+				privateMsg := Message{
+					Type: "private_message",
+					From: *c.user,
+					Content: map[string]interface{}{
+						"message": content["message"],
+						"from":    c.user.Username,
+					},
+					Time: time.Now().Unix(),
+				}
+				c.hub.SendToUser(targetUserID, privateMsg)
+
+				// 同时给自己也发一份
+				c.hub.SendToUser(c.userID, privateMsg)
+			}
+
+		default:
+			// 聊天及其他类型消息直接广播
+			c.hub.BroadcastMessage(msg)
+		}
+	}
+}
+
+func (c *Client) writePump() {
+	ticker := time.NewTicker(54 * time.Second)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+
+			w, err := c.conn.NextWriter(websocket.TextMessage)
+			if err != nil {
+				return
+			}
+			w.Write(message)
+
+			n := len(c.send)
+			for i := 0; i < n; i++ {
+				w.Write(<-c.send)
+			}
+
+			if err := w.Close(); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			if c.check != nil && !c.check() {
+				zap.L().Info("websocket鉴权已失效 断开连接", zap.String("userID", c.userID))
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "token revoked"))
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
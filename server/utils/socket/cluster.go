@@ -0,0 +1,362 @@
+package socket
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	envelopeBroadcast = "broadcast"
+	envelopeUser      = "user"
+)
+
+// ClusterOptions 集群模式配置
+type ClusterOptions struct {
+	Prefix            string        // redis key及频道前缀
+	HeartbeatInterval time.Duration // 实例心跳间隔
+	InstanceTTL       time.Duration // 实例心跳超时时间
+}
+
+// envelope 实例间转发的消息
+type envelope struct {
+	Origin  string          `json:"origin"`           // 发出消息的实例ID
+	Kind    string          `json:"kind"`             // broadcast | user
+	UserID  string          `json:"userId,omitempty"` // 定向消息的目标用户
+	Payload json.RawMessage `json:"payload"`
+}
+
+// presence 待登记到集群的上下线事件
+type presence struct {
+	join bool // true为上线 false为下线
+	user User
+}
+
+// cluster 基于redis发布订阅的实例间消息转发及在线用户登记
+//
+// 每个实例在 {prefix}:instances 有序集合中以心跳时间为分值登记自身
+// 并把连接到本实例的用户写入 {prefix}:instance:{id}:users 哈希
+// 心跳超时的实例由存活实例回收 回收者负责广播其用户的下线消息
+// 上下线的登记及广播由单独的协程按顺序处理 redis响应慢或不可用时不会阻塞hub的注册及注销
+type cluster struct {
+	hub       *Hub
+	rdb       redis.UniversalClient
+	id        string
+	prefix    string
+	heartbeat time.Duration
+	ttl       time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	mu      sync.Mutex
+	pending []presence
+	notify  chan struct{}
+}
+
+// EnableCluster 开启集群模式 需在Run之前调用
+func (h *Hub) EnableCluster(rdb redis.UniversalClient, opts ClusterOptions) error {
+	if opts.Prefix == "" {
+		opts.Prefix = "gva:ws"
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = 10 * time.Second
+	}
+	if opts.InstanceTTL <= opts.HeartbeatInterval {
+		opts.InstanceTTL = 3 * opts.HeartbeatInterval
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &cluster{
+		hub:       h,
+		rdb:       rdb,
+		id:        uuid.New().String(),
+		prefix:    opts.Prefix,
+		heartbeat: opts.HeartbeatInterval,
+		ttl:       opts.InstanceTTL,
+		ctx:       ctx,
+		cancel:    cancel,
+		notify:    make(chan struct{}, 1),
+	}
+	if err := c.beat(); err != nil {
+		cancel()
+		return err
+	}
+	pubsub := rdb.Subscribe(ctx, c.channel())
+	if _, err := pubsub.Receive(ctx); err != nil {
+		cancel()
+		_ = pubsub.Close()
+		return err
+	}
+	h.cluster = c
+	go c.subscribe(pubsub)
+	go c.keepalive()
+	go c.presenceLoop()
+	zap.L().Info("websocket集群模式已开启", zap.String("instance", c.id))
+	return nil
+}
+
+func (c *cluster) channel() string {
+	return c.prefix + ":channel"
+}
+
+func (c *cluster) instancesKey() string {
+	return c.prefix + ":instances"
+}
+
+func (c *cluster) usersKey(instance string) string {
+	return c.prefix + ":instance:" + instance + ":users"
+}
+
+// publish 向其他实例转发消息
+func (c *cluster) publish(kind, userID string, payload []byte) {
+	data, _ := json.Marshal(envelope{Origin: c.id, Kind: kind, UserID: userID, Payload: payload})
+	if err := c.rdb.Publish(c.ctx, c.channel(), data).Err(); err != nil {
+		zap.L().Error("websocket集群转发消息失败", zap.Error(err))
+	}
+}
+
+// subscribe 接收其他实例转发的消息并投递给本实例的客户端
+func (c *cluster) subscribe(pubsub *redis.PubSub) {
+	defer pubsub.Close()
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var env envelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil {
+				zap.L().Warn("websocket集群消息解析失败", zap.Error(err))
+				continue
+			}
+			if env.Origin == c.id {
+				continue
+			}
+			switch env.Kind {
+			case envelopeBroadcast:
+				c.hub.broadcastLocal(env.Payload)
+			case envelopeUser:
+				c.hub.sendLocal(env.UserID, env.Payload)
+			}
+		}
+	}
+}
+
+// keepalive 定时续期本实例心跳 并回收心跳超时的实例
+func (c *cluster) keepalive() {
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.beat(); err != nil {
+				zap.L().Error("websocket集群心跳失败", zap.Error(err))
+				continue
+			}
+			c.reap()
+		}
+	}
+}
+
+func (c *cluster) beat() error {
+	now := time.Now()
+	pipe := c.rdb.TxPipeline()
+	pipe.ZAdd(c.ctx, c.instancesKey(), redis.Z{Score: float64(now.Unix()), Member: c.id})
+	pipe.Expire(c.ctx, c.usersKey(c.id), 2*c.ttl)
+	_, err := pipe.Exec(c.ctx)
+	return err
+}
+
+// reap 回收心跳超时的实例 只有成功从有序集合中移除实例的一方负责广播下线
+func (c *cluster) reap() {
+	deadline := time.Now().Add(-c.ttl).Unix()
+	dead, err := c.rdb.ZRangeByScore(c.ctx, c.instancesKey(), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(deadline, 10),
+	}).Result()
+	if err != nil {
+		zap.L().Error("websocket集群获取超时实例失败", zap.Error(err))
+		return
+	}
+	for _, instance := range dead {
+		removed, err := c.rdb.ZRem(c.ctx, c.instancesKey(), instance).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+		users, err := c.rdb.HGetAll(c.ctx, c.usersKey(instance)).Result()
+		if err != nil {
+			zap.L().Error("websocket集群读取超时实例用户失败", zap.String("instance", instance), zap.Error(err))
+			continue
+		}
+		c.rdb.Del(c.ctx, c.usersKey(instance))
+		zap.L().Warn("websocket集群回收超时实例", zap.String("instance", instance), zap.Int("users", len(users)))
+		for userID, raw := range users {
+			if online, _ := c.isOnline(userID); online {
+				continue
+			}
+			var user User
+			if err := json.Unmarshal([]byte(raw), &user); err != nil {
+				user = User{ID: userID}
+			}
+			c.hub.broadcastPresence("user_leave", user)
+		}
+	}
+}
+
+// enqueue 提交上下线事件 不阻塞
+func (c *cluster) enqueue(p presence) {
+	c.mu.Lock()
+	c.pending = append(c.pending, p)
+	c.mu.Unlock()
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// presenceLoop 按提交顺序登记上下线事件 并在用户首次上线或在集群中完全离线时广播
+func (c *cluster) presenceLoop() {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-c.notify:
+		}
+		c.mu.Lock()
+		events := c.pending
+		c.pending = nil
+		c.mu.Unlock()
+		for _, p := range events {
+			c.handlePresence(p)
+		}
+	}
+}
+
+func (c *cluster) handlePresence(p presence) {
+	if p.join {
+		first, err := c.join(&p.user)
+		if err != nil {
+			zap.L().Error("websocket集群登记在线用户失败", zap.Error(err))
+		}
+		if first {
+			c.hub.broadcastPresence("user_join", p.user)
+		}
+		return
+	}
+	if c.hub.IsLocalOnline(p.user.ID) {
+		// 处理前用户已重新连接到本实例
+		return
+	}
+	last, err := c.leave(p.user.ID)
+	if err != nil {
+		zap.L().Error("websocket集群移除在线用户失败", zap.Error(err))
+	}
+	if last {
+		c.hub.broadcastPresence("user_leave", p.user)
+	}
+}
+
+// liveInstances 获取心跳未超时的实例
+func (c *cluster) liveInstances() ([]string, error) {
+	deadline := time.Now().Add(-c.ttl).Unix()
+	return c.rdb.ZRangeByScore(c.ctx, c.instancesKey(), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(deadline, 10),
+		Max: "+inf",
+	}).Result()
+}
+
+// join 登记本实例的在线用户 返回该用户此前是否在集群中离线
+func (c *cluster) join(user *User) (bool, error) {
+	online, err := c.isOnline(user.ID)
+	if err != nil {
+		return true, err
+	}
+	data, _ := json.Marshal(user)
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(c.ctx, c.usersKey(c.id), user.ID, data)
+	pipe.Expire(c.ctx, c.usersKey(c.id), 2*c.ttl)
+	_, err = pipe.Exec(c.ctx)
+	return !online, err
+}
+
+// leave 移除本实例的在线用户 返回该用户是否已在整个集群中离线
+func (c *cluster) leave(userID string) (bool, error) {
+	if err := c.rdb.HDel(c.ctx, c.usersKey(c.id), userID).Err(); err != nil {
+		return true, err
+	}
+	online, err := c.isOnline(userID)
+	return !online, err
+}
+
+func (c *cluster) isOnline(userID string) (bool, error) {
+	instances, err := c.liveInstances()
+	if err != nil {
+		return false, err
+	}
+	for _, instance := range instances {
+		exists, err := c.rdb.HExists(c.ctx, c.usersKey(instance), userID).Result()
+		if err != nil {
+			return false, err
+		}
+		if exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// onlineUsers 汇总所有存活实例的在线用户
+func (c *cluster) onlineUsers() ([]User, error) {
+	instances, err := c.liveInstances()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	users := make([]User, 0)
+	for _, instance := range instances {
+		entries, err := c.rdb.HGetAll(c.ctx, c.usersKey(instance)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for userID, raw := range entries {
+			if seen[userID] {
+				continue
+			}
+			seen[userID] = true
+			var user User
+			if err := json.Unmarshal([]byte(raw), &user); err != nil {
+				user = User{ID: userID}
+			}
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+// close 注销本实例 并广播仅连接在本实例的用户下线
+func (c *cluster) close() {
+	users, _ := c.rdb.HGetAll(c.ctx, c.usersKey(c.id)).Result()
+	c.rdb.ZRem(c.ctx, c.instancesKey(), c.id)
+	c.rdb.Del(c.ctx, c.usersKey(c.id))
+	for userID, raw := range users {
+		if online, _ := c.isOnline(userID); online {
+			continue
+		}
+		var user User
+		if err := json.Unmarshal([]byte(raw), &user); err != nil {
+			user = User{ID: userID}
+		}
+		c.hub.broadcastPresence("user_leave", user)
+	}
+	c.cancel()
+}
@@ -0,0 +1,125 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func startClusterHub(t *testing.T, mr *miniredis.Miniredis) (*Hub, *httptest.Server) {
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	hub := NewHub()
+	if err := hub.EnableCluster(rdb, ClusterOptions{Prefix: "test:ws"}); err != nil {
+		t.Fatalf("enable cluster failed: %v", err)
+	}
+	go hub.Run()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		hub.Serve(w, r, &User{ID: id, Username: id})
+	}))
+	t.Cleanup(srv.Close)
+	return hub, srv
+}
+
+// waitFor 集群登记由单独的协程异步处理
+func waitFor(t *testing.T, cond func() bool, msg string) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClusterFanOutAndPresence(t *testing.T) {
+	mr := miniredis.RunT(t)
+	hub1, srv1 := startClusterHub(t, mr)
+	hub2, srv2 := startClusterHub(t, mr)
+
+	a := dial(t, srv1, "a")
+	defer a.Close()
+	readUntil(t, a, "user_join")
+	b := dial(t, srv2, "b")
+	if join := readUntil(t, a, "user_join"); join.From.ID != "b" {
+		t.Fatalf("expected join of b forwarded from the other instance, got %s", join.From.ID)
+	}
+
+	if users := hub1.GetOnlineUsers(); len(users) != 2 {
+		t.Fatalf("expected 2 online users across instances, got %d", len(users))
+	}
+	if !hub1.IsOnline("b") || hub1.IsLocalOnline("b") || hub1.IsOnline("c") {
+		t.Fatalf("unexpected online state")
+	}
+
+	hub2.SendToUser("a", Message{Type: "system_message", From: SystemUser, Content: "hello"})
+	if msg := readUntil(t, a, "system_message"); msg.Content != "hello" {
+		t.Fatalf("unexpected content %v", msg.Content)
+	}
+
+	// 同一用户连接到另一个实例 断开其中一个连接时不应视为下线
+	b2 := dial(t, srv1, "b")
+	defer b2.Close()
+	c1 := hub1.cluster
+	waitFor(t, func() bool { return mr.HGet(c1.usersKey(c1.id), "b") != "" }, "b should be registered by hub1")
+	b.Close()
+	waitFor(t, func() bool { return !hub2.IsLocalOnline("b") }, "b should be disconnected from hub2")
+	// 上下线事件按顺序处理 标记用户上线时b的下线事件已处理完毕
+	hub2.cluster.enqueue(presence{join: true, user: User{ID: "m"}})
+	a.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var msg Message
+		if err := a.ReadJSON(&msg); err != nil {
+			t.Fatalf("read join of m failed: %v", err)
+		}
+		if msg.Type == "user_leave" {
+			t.Fatalf("b is still connected to hub1 and should not leave")
+		}
+		if msg.Type == "user_join" && msg.From.ID == "m" {
+			break
+		}
+	}
+	if !hub2.IsOnline("b") {
+		t.Fatalf("b is still connected to hub1")
+	}
+
+	b2.Close()
+	if leave := readUntil(t, a, "user_leave"); leave.From.ID != "b" {
+		t.Fatalf("expected leave of b, got %s", leave.From.ID)
+	}
+	waitFor(t, func() bool { return !hub2.IsOnline("b") }, "b should be offline")
+}
+
+func TestClusterReap(t *testing.T) {
+	mr := miniredis.RunT(t)
+	hub, srv := startClusterHub(t, mr)
+	a := dial(t, srv, "a")
+	defer a.Close()
+	readUntil(t, a, "user_join")
+
+	// 模拟心跳超时的实例
+	c := hub.cluster
+	data, _ := json.Marshal(User{ID: "z", Username: "z"})
+	mr.ZAdd(c.instancesKey(), float64(time.Now().Add(-time.Hour).Unix()), "dead")
+	mr.HSet(c.usersKey("dead"), "z", string(data))
+	if online, _ := c.isOnline("z"); online {
+		t.Fatalf("users of a dead instance should be offline")
+	}
+
+	c.reap()
+	if leave := readUntil(t, a, "user_leave"); leave.From.ID != "z" {
+		t.Fatalf("expected leave of z, got %s", leave.From.ID)
+	}
+	if members, _ := mr.ZMembers(c.instancesKey()); len(members) != 1 || members[0] != c.id {
+		t.Fatalf("dead instance should be removed, got %v", members)
+	}
+	if mr.Exists(c.usersKey("dead")) {
+		t.Fatalf("users of the dead instance should be removed")
+	}
+}
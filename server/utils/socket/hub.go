@@ -0,0 +1,209 @@
+package socket
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// User 用户信息结构
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// Message WebSocket 消息结构
+type Message struct {
+	Type    string      `json:"type"`    // message, join, leave, etc.
+	From    User        `json:"from"`    // 发送者信息
+	Content interface{} `json:"content"` // 消息内容
+	Time    int64       `json:"time"`    // 时间戳
+}
+
+// SystemUser 系统消息的发送者
+var SystemUser = User{ID: "system", Username: "系统", Role: "system"}
+
+type Hub struct {
+	mu         sync.RWMutex
+	clients    map[*Client]bool
+	users      map[string]*Client // 用户ID到客户端的映射
+	register   chan *Client
+	unregister chan *Client
+	cluster    *cluster // 未开启集群模式时为nil
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		clients:    make(map[*Client]bool),
+		users:      make(map[string]*Client),
+	}
+}
+
+func (h *Hub) Run() {
+	for {
+		select {
+		case client := <-h.register:
+			h.mu.Lock()
+			h.clients[client] = true
+			if client.userID != "" {
+				h.users[client.userID] = client
+			}
+			count := len(h.clients)
+			h.mu.Unlock()
+			zap.L().Info("websocket用户连接", zap.String("userID", client.userID), zap.Int("connections", count))
+
+			if h.cluster != nil {
+				// 集群登记需要访问redis 交给集群协程处理 避免阻塞注册及注销
+				h.cluster.enqueue(presence{join: true, user: *client.user})
+				continue
+			}
+			h.broadcastPresence("user_join", *client.user)
+
+		case client := <-h.unregister:
+			// 每个客户端只会注销一次 发送缓冲区满时客户端可能已被提前移除 仍需处理下线
+			h.mu.Lock()
+			if _, ok := h.clients[client]; ok {
+				h.removeLocked(client)
+			}
+			count := len(h.clients)
+			h.mu.Unlock()
+			zap.L().Info("websocket用户断开", zap.String("userID", client.userID), zap.Int("connections", count))
+
+			if h.IsLocalOnline(client.userID) {
+				// 同一用户的新连接已替换旧连接
+				continue
+			}
+			if h.cluster != nil {
+				h.cluster.enqueue(presence{user: *client.user})
+				continue
+			}
+			h.broadcastPresence("user_leave", *client.user)
+		}
+	}
+}
+
+// removeLocked 移除客户端 调用方需持有写锁
+func (h *Hub) removeLocked(client *Client) {
+	delete(h.clients, client)
+	if client.userID != "" && h.users[client.userID] == client {
+		delete(h.users, client.userID)
+	}
+	close(client.send)
+}
+
+// broadcastPresence 广播用户上下线消息
+func (h *Hub) broadcastPresence(typ string, user User) {
+	users := h.GetOnlineUsers()
+	h.BroadcastMessage(Message{
+		Type: typ,
+		From: user,
+		Content: map[string]interface{}{
+			"userCount":   len(users),
+			"onlineUsers": users,
+		},
+		Time: time.Now().Unix(),
+	})
+}
+
+// GetOnlineUsers 获取在线用户列表 集群模式下返回所有实例的在线用户
+func (h *Hub) GetOnlineUsers() []User {
+	if h.cluster != nil {
+		users, err := h.cluster.onlineUsers()
+		if err == nil {
+			return users
+		}
+		zap.L().Error("websocket获取集群在线用户失败 降级为本实例在线用户", zap.Error(err))
+	}
+	return h.localUsers()
+}
+
+func (h *Hub) localUsers() []User {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	users := make([]User, 0, len(h.users))
+	for _, client := range h.users {
+		if client.user != nil {
+			users = append(users, *client.user)
+		}
+	}
+	return users
+}
+
+// IsLocalOnline 用户是否连接在本实例
+func (h *Hub) IsLocalOnline(userID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	_, ok := h.users[userID]
+	return ok
+}
+
+// IsOnline 用户是否在线 集群模式下检查所有实例
+func (h *Hub) IsOnline(userID string) bool {
+	if h.IsLocalOnline(userID) {
+		return true
+	}
+	if h.cluster != nil {
+		online, err := h.cluster.isOnline(userID)
+		if err != nil {
+			zap.L().Error("websocket查询集群在线状态失败", zap.Error(err))
+		}
+		return online
+	}
+	return false
+}
+
+// SendToUser 向特定用户发送消息 集群模式下用户连接在其他实例时同样可以送达
+func (h *Hub) SendToUser(userID string, message Message) {
+	msgBytes, _ := json.Marshal(message)
+	h.sendLocal(userID, msgBytes)
+	if h.cluster != nil {
+		h.cluster.publish(envelopeUser, userID, msgBytes)
+	}
+}
+
+// BroadcastMessage 广播消息 集群模式下广播到所有实例
+func (h *Hub) BroadcastMessage(message Message) {
+	msgBytes, _ := json.Marshal(message)
+	h.broadcastLocal(msgBytes)
+	if h.cluster != nil {
+		h.cluster.publish(envelopeBroadcast, "", msgBytes)
+	}
+}
+
+// sendLocal 投递给连接在本实例的用户
+func (h *Hub) sendLocal(userID string, msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if client, exists := h.users[userID]; exists {
+		select {
+		case client.send <- msg:
+		default:
+			h.removeLocked(client)
+		}
+	}
+}
+
+// broadcastLocal 投递给连接在本实例的所有客户端
+func (h *Hub) broadcastLocal(msg []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for client := range h.clients {
+		select {
+		case client.send <- msg:
+		default:
+			h.removeLocked(client)
+		}
+	}
+}
+
+// Close 关闭hub 集群模式下注销本实例并广播其用户下线
+func (h *Hub) Close() {
+	if h.cluster != nil {
+		h.cluster.close()
+	}
+}
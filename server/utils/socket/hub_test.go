@@ -0,0 +1,75 @@
+package socket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dial(t *testing.T, srv *httptest.Server, id string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?id=" + id
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	return conn
+}
+
+func readUntil(t *testing.T, conn *websocket.Conn, typ string) Message {
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read %s failed: %v", typ, err)
+		}
+		var msg Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		if msg.Type == typ {
+			return msg
+		}
+	}
+}
+
+func TestHubLocal(t *testing.T) {
+	hub := NewHub()
+	go hub.Run()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id")
+		hub.Serve(w, r, &User{ID: id, Username: id})
+	}))
+	defer srv.Close()
+
+	a := dial(t, srv, "a")
+	defer a.Close()
+	readUntil(t, a, "user_join")
+	b := dial(t, srv, "b")
+	readUntil(t, a, "user_join")
+
+	if users := hub.GetOnlineUsers(); len(users) != 2 {
+		t.Fatalf("expected 2 online users, got %d", len(users))
+	}
+	if !hub.IsOnline("b") || hub.IsOnline("c") {
+		t.Fatalf("unexpected online state")
+	}
+
+	hub.SendToUser("a", Message{Type: "system_message", From: SystemUser, Content: "hello"})
+	if msg := readUntil(t, a, "system_message"); msg.Content != "hello" {
+		t.Fatalf("unexpected content %v", msg.Content)
+	}
+
+	b.Close()
+	leave := readUntil(t, a, "user_leave")
+	if leave.From.ID != "b" {
+		t.Fatalf("expected leave of b, got %s", leave.From.ID)
+	}
+	if hub.IsOnline("b") {
+		t.Fatalf("b should be offline")
+	}
+}
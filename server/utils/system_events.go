@@ -2,11 +2,64 @@ package utils
 
 import (
 	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// 业务事件类型
+const (
+	EventUserCreated            = "user.created"             // 用户创建
+	EventUserDisabled           = "user.disabled"            // 用户冻结
+	EventUserEnabled            = "user.enabled"             // 用户解冻
+	EventUserDeleted            = "user.deleted"             // 用户删除
+	EventUserAuthoritiesChanged = "user.authorities_changed" // 用户角色变更
+	EventAuthorityCreated       = "authority.created"        // 角色创建 包括拷贝
+	EventAuthorityUpdated       = "authority.updated"        // 角色更新
+	EventAuthorityDeleted       = "authority.deleted"        // 角色删除
+	EventAutoCodePackageCreated = "autocode.package.created" // 自动化代码包创建
+	EventAutoCodePackageDeleted = "autocode.package.deleted" // 自动化代码包删除
+	EventAutoCodeModuleCreated  = "autocode.module.created"  // 自动化代码模块生成
+	EventAutoCodeModuleRollback = "autocode.module.rollback" // 自动化代码模块回滚
+	EventAutoCodeModuleDeleted  = "autocode.module.deleted"  // 自动化代码模块历史删除
+	EventExportFinished         = "export.finished"          // 导出完成
 )
 
+// EventTypes 全部业务事件类型及说明
+var EventTypes = []struct {
+	Type        string
+	Description string
+}{
+	{EventUserCreated, "用户创建"},
+	{EventUserDisabled, "用户冻结"},
+	{EventUserEnabled, "用户解冻"},
+	{EventUserDeleted, "用户删除"},
+	{EventUserAuthoritiesChanged, "用户角色变更"},
+	{EventAuthorityCreated, "角色创建"},
+	{EventAuthorityUpdated, "角色更新"},
+	{EventAuthorityDeleted, "角色删除"},
+	{EventAutoCodePackageCreated, "自动化代码包创建"},
+	{EventAutoCodePackageDeleted, "自动化代码包删除"},
+	{EventAutoCodeModuleCreated, "自动化代码模块生成"},
+	{EventAutoCodeModuleRollback, "自动化代码模块回滚"},
+	{EventAutoCodeModuleDeleted, "自动化代码模块历史删除"},
+	{EventExportFinished, "导出完成"},
+}
+
+// Event 业务事件
+type Event struct {
+	ID   string      `json:"id"`
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
 // SystemEvents 定义系统级事件处理
 type SystemEvents struct {
 	reloadHandlers []func() error
+	eventHandlers  []func(Event)
 	mu             sync.RWMutex
 }
 
@@ -32,3 +85,31 @@ func (e *SystemEvents) TriggerReload() error {
 	}
 	return nil
 }
+
+// Subscribe 订阅业务事件
+func (e *SystemEvents) Subscribe(handler func(Event)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.eventHandlers = append(e.eventHandlers, handler)
+}
+
+// Publish 发布业务事件 在当前协程中依次调用订阅者 订阅者应尽快返回 其panic不会影响发布方
+func (e *SystemEvents) Publish(eventType string, data interface{}) {
+	e.mu.RLock()
+	handlers := e.eventHandlers
+	e.mu.RUnlock()
+	if len(handlers) == 0 {
+		return
+	}
+	ev := Event{ID: uuid.NewString(), Type: eventType, Time: time.Now(), Data: data}
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil && global.GVA_LOG != nil {
+					global.GVA_LOG.Error("处理业务事件失败!", zap.String("type", eventType), zap.Any("panic", r))
+				}
+			}()
+			handler(ev)
+		}()
+	}
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"go.uber.org/zap"
+)
+
+func TestSystemEventsPublish(t *testing.T) {
+	global.GVA_LOG = zap.NewNop()
+	e := &SystemEvents{}
+	e.Publish(EventUserCreated, nil) // 没有订阅者
+
+	var got []Event
+	e.Subscribe(func(ev Event) { panic("boom") })
+	e.Subscribe(func(ev Event) { got = append(got, ev) })
+	e.Publish(EventUserCreated, map[string]interface{}{"userId": 1})
+	e.Publish(EventUserDeleted, nil)
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if got[0].Type != EventUserCreated || got[0].ID == "" || got[0].Time.IsZero() || got[0].ID == got[1].ID {
+		t.Errorf("unexpected events: %+v", got)
+	}
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 用户的令牌版本 登录时写入 jwt 用户被禁用、删除、修改角色或重置密码时更新 之前签发的 jwt 随即失效
+// 版本为更新时的纳秒时间戳 且只增不减
+// 使用redis时保存在redis中 过期时间为jwt有效期 过期后读到0时已签发的令牌也已过期
+// 不使用redis时保存在 sys_users.token_version 通过本地缓存读取 重启后不丢失
+const tokenVersionPrefix = "gva:token_version:"
+
+// tokenVersionCacheTTL 不使用redis时数据库中的版本在本地缓存的时间 多个实例时其他实例最多延迟此时间生效
+const tokenVersionCacheTTL = time.Minute
+
+// bumpTokenVersionScript 新版本不大于当前版本时取当前版本+1 避免实例间时钟不一致导致版本回退
+var bumpTokenVersionScript = redis.NewScript(`
+local v = tonumber(redis.call('GET', KEYS[1]) or '0')
+local n = tonumber(ARGV[1])
+if n <= v then n = v + 1 end
+redis.call('SET', KEYS[1], n, 'PX', ARGV[2])
+return n
+`)
+
+var tokenVersionMu sync.Mutex
+
+func tokenVersionKey(userID uint) string {
+	return tokenVersionPrefix + strconv.FormatUint(uint64(userID), 10)
+}
+
+// tokenVersionTTL 版本的缓存时间 过期后读到0不影响已签发令牌的判断
+func tokenVersionTTL() time.Duration {
+	dr, err := ParseDuration(global.GVA_CONFIG.JWT.ExpiresTime)
+	if err != nil || dr <= 0 {
+		return 7 * 24 * time.Hour
+	}
+	return dr
+}
+
+// GetTokenVersion 获取用户当前的令牌版本 使用redis时多个实例共享
+func GetTokenVersion(userID uint) (int64, error) {
+	key := tokenVersionKey(userID)
+	if global.GVA_REDIS != nil {
+		v, err := global.GVA_REDIS.Get(context.Background(), key).Int64()
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return v, err
+	}
+	if v, ok := global.BlackCache.Get(key); ok {
+		return v.(int64), nil
+	}
+	if global.GVA_DB == nil {
+		return 0, nil
+	}
+	var versions []int64
+	err := global.GVA_DB.Model(&system.SysUser{}).Unscoped().Where("id = ?", userID).Pluck("token_version", &versions).Error
+	if err != nil {
+		return 0, err
+	}
+	var v int64
+	if len(versions) > 0 {
+		v = versions[0]
+	}
+	global.BlackCache.Set(key, v, tokenVersionCacheTTL)
+	return v, nil
+}
+
+// BumpTokenVersion 更新用户的令牌版本 使用户已签发的令牌全部失效
+func BumpTokenVersion(userIDs ...uint) error {
+	ttl := tokenVersionTTL()
+	for _, id := range userIDs {
+		now := time.Now().UnixNano()
+		key := tokenVersionKey(id)
+		if global.GVA_REDIS != nil {
+			if err := bumpTokenVersionScript.Run(context.Background(), global.GVA_REDIS, []string{key}, now, ttl.Milliseconds()).Err(); err != nil {
+				return err
+			}
+			continue
+		}
+		if global.GVA_DB != nil {
+			if err := bumpPersistedTokenVersion(id, now); err != nil {
+				return err
+			}
+			continue
+		}
+		tokenVersionMu.Lock()
+		if v, ok := global.BlackCache.Get(key); ok && now <= v.(int64) {
+			now = v.(int64) + 1
+		}
+		global.BlackCache.Set(key, now, ttl)
+		tokenVersionMu.Unlock()
+	}
+	return nil
+}
+
+// bumpPersistedTokenVersion 更新数据库中的令牌版本 新版本不大于当前版本时取当前版本+1
+func bumpPersistedTokenVersion(userID uint, now int64) error {
+	err := global.GVA_DB.Model(&system.SysUser{}).Unscoped().Where("id = ?", userID).
+		UpdateColumn("token_version", gorm.Expr("CASE WHEN token_version >= ? THEN token_version + 1 ELSE ? END", now, now)).Error
+	if err != nil {
+		return err
+	}
+	global.BlackCache.Delete(tokenVersionKey(userID))
+	_, err = GetTokenVersion(userID)
+	return err
+}
+
+// TokenRevoked 令牌签发后用户的令牌版本已更新 读取版本失败时不拦截
+func TokenRevoked(claims *systemReq.CustomClaims) bool {
+	version, err := GetTokenVersion(claims.BaseClaims.ID)
+	if err != nil {
+		global.GVA_LOG.Error("获取令牌版本失败!", zap.Error(err))
+		return false
+	}
+	return claims.TokenVersion < version
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flipped-aurora/gin-vue-admin/server/global"
+	"github.com/flipped-aurora/gin-vue-admin/server/model/system"
+	systemReq "github.com/flipped-aurora/gin-vue-admin/server/model/system/request"
+	"github.com/glebarez/sqlite"
+	"github.com/songzhibin97/gkit/cache/local_cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+func TestTokenRevoked(t *testing.T) {
+	global.BlackCache = local_cache.NewCache()
+	global.GVA_LOG = zap.NewNop()
+	global.GVA_DB, global.GVA_REDIS = nil, nil
+
+	version, err := GetTokenVersion(1)
+	if err != nil || version != 0 {
+		t.Fatalf("GetTokenVersion = %d, %v", version, err)
+	}
+	claims := &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1, TokenVersion: version}}
+	if TokenRevoked(claims) {
+		t.Fatal("token should be valid before bump")
+	}
+
+	if err = BumpTokenVersion(1); err != nil {
+		t.Fatal(err)
+	}
+	if !TokenRevoked(claims) {
+		t.Error("token issued before bump should be revoked")
+	}
+	// 其他用户不受影响
+	if TokenRevoked(&systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 2}}) {
+		t.Error("other users should not be revoked")
+	}
+
+	// 重新登录后的令牌有效 连续更新版本只增不减
+	version, _ = GetTokenVersion(1)
+	claims.TokenVersion = version
+	if TokenRevoked(claims) {
+		t.Error("token issued after bump should be valid")
+	}
+	_ = BumpTokenVersion(1)
+	_ = BumpTokenVersion(1)
+	if next, _ := GetTokenVersion(1); next <= version || !TokenRevoked(claims) {
+		t.Errorf("version should increase: %d -> %d", version, next)
+	}
+}
+
+func TestTokenVersionPersisted(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close(); global.GVA_DB = nil })
+	if err = db.AutoMigrate(&system.SysUser{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&system.SysUser{Username: "a"})
+	global.GVA_DB, global.GVA_REDIS, global.GVA_LOG = db, nil, zap.NewNop()
+	global.BlackCache = local_cache.NewCache()
+
+	claims := &systemReq.CustomClaims{BaseClaims: systemReq.BaseClaims{ID: 1}}
+	if err = BumpTokenVersion(1); err != nil {
+		t.Fatal(err)
+	}
+	// 重启后本地缓存为空 从数据库读取版本
+	global.BlackCache = local_cache.NewCache()
+	if !TokenRevoked(claims) {
+		t.Fatal("revocation should survive restart")
+	}
+	version, _ := GetTokenVersion(1)
+	claims.TokenVersion = version
+	if TokenRevoked(claims) {
+		t.Fatal("token issued after bump should be valid")
+	}
+
+	// 数据库中的版本大于当前时间时 只增不减
+	db.Model(&system.SysUser{}).Where("id = ?", 1).Update("token_version", version+int64(time.Hour))
+	_ = BumpTokenVersion(1)
+	if next, _ := GetTokenVersion(1); next != version+int64(time.Hour)+1 {
+		t.Errorf("version should increase from the stored value, got %d", next)
+	}
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress 推送地址解析到回环、链路本地或内网地址
+var ErrForbiddenAddress = errors.New("不允许推送到回环、链路本地或内网地址")
+
+// ForbiddenIP 判断是否为不允许推送的地址 包括回环、链路本地(含云服务器元数据地址 169.254.169.254)、内网及未指定地址
+func ForbiddenIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// CheckURL 校验推送地址 只允许http(s) 主机为IP时同时校验IP 域名在连接时校验解析后的地址
+func CheckURL(raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("地址必须为http或https地址")
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && !allowPrivate && ForbiddenIP(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// NewClient 推送使用的http客户端
+// 在建立连接时校验实际连接的IP 防止域名解析到内网地址 不跟随重定向 重定向响应按非2xx处理
+// allowPrivate 在每次连接时调用 返回true时不校验 用于明确需要推送到内网服务的部署
+func NewClient(timeout time.Duration, allowPrivate func() bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if allowPrivate != nil && allowPrivate() {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || ForbiddenIP(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // 经过代理时校验的是代理地址 推送请求直接连接
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
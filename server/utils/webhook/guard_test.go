@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckURL(t *testing.T) {
+	cases := []struct {
+		url          string
+		allowPrivate bool
+		ok           bool
+	}{
+		{"https://example.com/hook", false, true},
+		{"ftp://example.com", false, false},
+		{"http://127.0.0.1:8080/hook", false, false},
+		{"http://169.254.169.254/latest/meta-data", false, false},
+		{"http://10.0.0.1/hook", false, false},
+		{"http://[::1]/hook", false, false},
+		{"http://10.0.0.1/hook", true, true},
+	}
+	for _, c := range cases {
+		if err := CheckURL(c.url, c.allowPrivate); (err == nil) != c.ok {
+			t.Errorf("CheckURL(%s, %v) err = %v", c.url, c.allowPrivate, err)
+		}
+	}
+}
+
+func TestClientGuard(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	// 域名或地址在连接时才能确定 测试服务器监听在回环地址
+	_, err := NewClient(time.Second, nil).Get(target.URL)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("loopback should be rejected, got %v", err)
+	}
+
+	allow := NewClient(time.Second, func() bool { return true })
+	resp, err := allow.Get(redirect.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("redirect should not be followed, got status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,51 @@
+// Package webhook webhook事件推送的签名与校验
+//
+// 签名为 "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+// 接收方用相同的密钥计算后与 X-Gva-Signature 比较 并检查 X-Gva-Timestamp 防止重放
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// 推送请求头
+const (
+	HeaderEvent     = "X-Gva-Event"     // 事件类型
+	HeaderDelivery  = "X-Gva-Delivery"  // 推送记录ID
+	HeaderTimestamp = "X-Gva-Timestamp" // 签名时间 unix秒
+	HeaderSignature = "X-Gva-Signature" // 签名
+)
+
+const signaturePrefix = "sha256="
+
+// Sign 计算签名
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验签名 并要求签名时间与当前时间相差不超过 tolerance tolerance为0时不检查时间
+func Verify(secret string, timestamp int64, body []byte, signature string, tolerance time.Duration) bool {
+	if tolerance > 0 {
+		d := time.Since(time.Unix(timestamp, 0))
+		if d > tolerance || d < -tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// NewSecret 生成随机密钥
+func NewSecret() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"type":"user.created"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if got := Sign("secret", 1700000000, body); got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"user.created"}`)
+	now := time.Now().Unix()
+	sig := Sign("secret", now, body)
+	if !Verify("secret", now, body, sig, time.Minute) {
+		t.Fatal("valid signature rejected")
+	}
+	if Verify("other", now, body, sig, time.Minute) {
+		t.Error("signature with wrong secret accepted")
+	}
+	if Verify("secret", now, []byte(`{"type":"user.deleted"}`), sig, time.Minute) {
+		t.Error("signature of modified body accepted")
+	}
+	old := now - 600
+	if Verify("secret", old, body, Sign("secret", old, body), time.Minute) {
+		t.Error("expired signature accepted")
+	}
+	if !Verify("secret", old, body, Sign("secret", old, body), 0) {
+		t.Error("signature rejected without tolerance")
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	a, b := NewSecret(), NewSecret()
+	if a == b || !strings.HasPrefix(a, "whsec_") || len(a) != len("whsec_")+48 {
+		t.Fatalf("unexpected secrets %q %q", a, b)
+	}
+}
@@ -6,48 +6,99 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
-// 解压
+// UnzipLimit 解压限制 为0时不限制
+type UnzipLimit struct {
+	MaxFileSize  int64 // 单个文件解压后的最大字节数
+	MaxTotalSize int64 // 解压后的总字节数
+	MaxFiles     int   // 最多包含的文件数
+}
+
+// DefaultUnzipLimit Unzip 使用的默认限制
+var DefaultUnzipLimit = UnzipLimit{MaxFileSize: 100 << 20, MaxTotalSize: 500 << 20, MaxFiles: 10000}
+
+// Unzip 按默认限制解压
 func Unzip(zipFile string, destDir string) ([]string, error) {
+	return UnzipWithLimit(zipFile, destDir, DefaultUnzipLimit)
+}
+
+// UnzipWithLimit 解压到目标目录 拒绝越出目标目录的路径、符号链接等特殊文件及超出限制的文件
+// 文件大小以实际解压的字节数为准 不信任压缩包中记录的大小
+func UnzipWithLimit(zipFile string, destDir string, limit UnzipLimit) ([]string, error) {
 	zipReader, err := zip.OpenReader(zipFile)
-	var paths []string
 	if err != nil {
 		return []string{}, err
 	}
 	defer zipReader.Close()
 
+	if limit.MaxFiles > 0 && len(zipReader.File) > limit.MaxFiles {
+		return []string{}, fmt.Errorf("压缩包包含%d个文件, 超过限制%d", len(zipReader.File), limit.MaxFiles)
+	}
+	var paths []string
+	var total int64
 	for _, f := range zipReader.File {
-		if strings.Contains(f.Name, "..") {
+		if !filepath.IsLocal(filepath.FromSlash(f.Name)) {
 			return []string{}, fmt.Errorf("%s 文件名不合法", f.Name)
 		}
-		fpath := filepath.Join(destDir, f.Name)
+		mode := f.Mode()
+		if !mode.IsDir() && !mode.IsRegular() {
+			return []string{}, fmt.Errorf("%s 是符号链接或特殊文件, 不允许解压", f.Name)
+		}
+		fpath := filepath.Join(destDir, filepath.FromSlash(f.Name))
 		paths = append(paths, fpath)
-		if f.FileInfo().IsDir() {
-			os.MkdirAll(fpath, os.ModePerm)
-		} else {
-			if err = os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+		if mode.IsDir() {
+			if err = os.MkdirAll(fpath, os.ModePerm); err != nil {
 				return []string{}, err
 			}
+			continue
+		}
+		if limit.MaxFileSize > 0 && f.UncompressedSize64 > uint64(limit.MaxFileSize) {
+			return []string{}, fmt.Errorf("%s 超过单个文件大小限制%d字节", f.Name, limit.MaxFileSize)
+		}
+		max := int64(-1)
+		if limit.MaxFileSize > 0 {
+			max = limit.MaxFileSize
+		}
+		if limit.MaxTotalSize > 0 && (max < 0 || limit.MaxTotalSize-total < max) {
+			max = limit.MaxTotalSize - total
+		}
+		n, err := unzipFile(f, fpath, max)
+		if err != nil {
+			return []string{}, err
+		}
+		total += n
+	}
+	return paths, nil
+}
 
-			inFile, err := f.Open()
-			if err != nil {
-				return []string{}, err
-			}
-			defer inFile.Close()
+// unzipFile 解压单个文件 max 不小于0时最多写入max字节 超出时返回错误
+func unzipFile(f *zip.File, fpath string, max int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+		return 0, err
+	}
+	inFile, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer inFile.Close()
 
-			outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode())
-			if err != nil {
-				return []string{}, err
-			}
-			defer outFile.Close()
+	outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode().Perm())
+	if err != nil {
+		return 0, err
+	}
+	defer outFile.Close()
 
-			_, err = io.Copy(outFile, inFile)
-			if err != nil {
-				return []string{}, err
-			}
-		}
+	var reader io.Reader = inFile
+	if max >= 0 {
+		reader = io.LimitReader(inFile, max+1)
 	}
-	return paths, nil
+	n, err := io.Copy(outFile, reader)
+	if err != nil {
+		return n, err
+	}
+	if max >= 0 && n > max {
+		return n, fmt.Errorf("%s 解压后超过大小限制", f.Name)
+	}
+	return n, nil
 }
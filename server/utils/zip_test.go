@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeZip 生成测试用的压缩包 content为nil的条目为符号链接
+func writeZip(t *testing.T, entries map[string][]byte) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "test.zip")
+	out, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(out)
+	for path, content := range entries {
+		header := &zip.FileHeader{Name: path, Method: zip.Deflate}
+		header.SetMode(0o644)
+		if content == nil {
+			header.SetMode(os.ModeSymlink | 0o777)
+			content = []byte("/etc/passwd")
+		}
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write(content)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	_ = out.Close()
+	return name
+}
+
+func TestUnzipWithLimit(t *testing.T) {
+	limit := UnzipLimit{MaxFileSize: 16, MaxTotalSize: 24, MaxFiles: 3}
+	tests := []struct {
+		name    string
+		entries map[string][]byte
+		wantErr string
+	}{
+		{name: "ok", entries: map[string][]byte{"a/b.txt": []byte("hello"), "a/c.txt": []byte("world")}},
+		{name: "traversal", entries: map[string][]byte{"../evil.txt": []byte("x")}, wantErr: "文件名不合法"},
+		{name: "nested traversal", entries: map[string][]byte{"a/../../evil.txt": []byte("x")}, wantErr: "文件名不合法"},
+		{name: "absolute", entries: map[string][]byte{"/etc/evil.txt": []byte("x")}, wantErr: "文件名不合法"},
+		{name: "symlink", entries: map[string][]byte{"a/link": nil}, wantErr: "符号链接"},
+		{name: "oversized file", entries: map[string][]byte{"big.txt": []byte(strings.Repeat("x", 17))}, wantErr: "单个文件大小限制"},
+		{name: "oversized total", entries: map[string][]byte{"a.txt": []byte(strings.Repeat("x", 16)), "b.txt": []byte(strings.Repeat("y", 16))}, wantErr: "超过大小限制"},
+		{name: "too many files", entries: map[string][]byte{"a": {}, "b": {}, "c": {}, "d": {}}, wantErr: "超过限制"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest := t.TempDir()
+			_, err := UnzipWithLimit(writeZip(t, tt.entries), dest, limit)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("UnzipWithLimit() error = %v", err)
+				}
+				if data, _ := os.ReadFile(filepath.Join(dest, "a", "b.txt")); string(data) != "hello" {
+					t.Errorf("UnzipWithLimit() a/b.txt = %q", data)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("UnzipWithLimit() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+import service from '@/utils/request'
+
+// @Tags Alert
+// @Summary 分页获取告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.AlertSearch true "分页获取告警"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /alert/getAlertList [get]
+export const getAlertList = (params) => {
+  return service({
+    url: '/alert/getAlertList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Alert
+// @Summary 确认告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "告警ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"确认成功"}"
+// @Router /alert/ackAlert [post]
+export const ackAlert = (data) => {
+  return service({
+    url: '/alert/ackAlert',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Alert
+// @Summary 解决告警
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "告警ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"解决成功"}"
+// @Router /alert/resolveAlert [post]
+export const resolveAlert = (data) => {
+  return service({
+    url: '/alert/resolveAlert',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Alert
+// @Summary 创建告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysAlertChannel true "创建告警渠道"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /alert/createAlertChannel [post]
+export const createAlertChannel = (data) => {
+  return service({
+    url: '/alert/createAlertChannel',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Alert
+// @Summary 删除告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "渠道ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /alert/deleteAlertChannel [delete]
+export const deleteAlertChannel = (data) => {
+  return service({
+    url: '/alert/deleteAlertChannel',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags Alert
+// @Summary 更新告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysAlertChannel true "更新告警渠道"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /alert/updateAlertChannel [put]
+export const updateAlertChannel = (data) => {
+  return service({
+    url: '/alert/updateAlertChannel',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags Alert
+// @Summary 分页获取告警渠道
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.AlertChannelSearch true "分页获取告警渠道"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /alert/getAlertChannelList [get]
+export const getAlertChannelList = (params) => {
+  return service({
+    url: '/alert/getAlertChannelList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Alert
+// @Summary 发送测试通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "渠道ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"发送成功"}"
+// @Router /alert/testAlertChannel [post]
+export const testAlertChannel = (data) => {
+  return service({
+    url: '/alert/testAlertChannel',
+    method: 'post',
+    data
+  })
+}
@@ -85,6 +85,22 @@ export const getMeta = (data) => {
   })
 }
 
+export const updatePreview = (data) => {
+  return service({
+    url: '/autoCode/updatePreview',
+    method: 'post',
+    data
+  })
+}
+
+export const updateModule = (data) => {
+  return service({
+    url: '/autoCode/update',
+    method: 'post',
+    data
+  })
+}
+
 export const delSysHistory = (data) => {
   return service({
     url: '/autoCode/delSysHistory',
@@ -123,6 +139,29 @@ export const getTemplatesApi = () => {
   })
 }
 
+export const getTemplatePacksApi = () => {
+  return service({
+    url: '/autoCode/getTemplatePacks',
+    method: 'get'
+  })
+}
+
+export const deleteTemplatePackApi = (data) => {
+  return service({
+    url: '/autoCode/delTemplatePack',
+    method: 'post',
+    data
+  })
+}
+
+export const setTemplatePackApi = (data) => {
+  return service({
+    url: '/autoCode/setTemplatePack',
+    method: 'post',
+    data
+  })
+}
+
 export const installPlug = (data) => {
   return service({
     url: '/autoCode/installPlug',
@@ -131,6 +170,21 @@ export const installPlug = (data) => {
   })
 }
 
+export const getPluginsApi = () => {
+  return service({
+    url: '/autoCode/getPlugins',
+    method: 'get'
+  })
+}
+
+export const uninstallPluginApi = (data) => {
+  return service({
+    url: '/autoCode/uninstallPlugin',
+    method: 'post',
+    data
+  })
+}
+
 export const pubPlug = (params) => {
   return service({
     url: '/autoCode/pubPlug',
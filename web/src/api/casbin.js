@@ -30,3 +30,99 @@ export const getPolicyPathByAuthorityId = (data) => {
     data
   })
 }
+
+// @Tags casbin
+// @Summary 权限模拟 判断用户或角色能否访问接口并说明原因
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CasbinExplain true "用户id或角色id, 路径, 方法"
+// @Success 200 {string} json "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /casbin/explain [post]
+export const explainCasbin = (data) => {
+  return service({
+    url: '/casbin/explain',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 获取角色与接口的权限矩阵
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CasbinMatrix true "角色id列表, api组"
+// @Success 200 {string} json "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /casbin/getCasbinMatrix [post]
+export const getCasbinMatrix = (data) => {
+  return service({
+    url: '/casbin/getCasbinMatrix',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 创建显式拒绝规则 角色及其子角色不能访问该接口
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysCasbinDeny true "角色id, 路径, 方法, 备注"
+// @Success 200 {string} json "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /casbin/createCasbinDeny [post]
+export const createCasbinDeny = (data) => {
+  return service({
+    url: '/casbin/createCasbinDeny',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 更新显式拒绝规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysCasbinDeny true "规则id, 路径, 方法, 备注"
+// @Success 200 {string} json "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /casbin/updateCasbinDeny [put]
+export const updateCasbinDeny = (data) => {
+  return service({
+    url: '/casbin/updateCasbinDeny',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 删除显式拒绝规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "规则id"
+// @Success 200 {string} json "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /casbin/deleteCasbinDeny [delete]
+export const deleteCasbinDeny = (data) => {
+  return service({
+    url: '/casbin/deleteCasbinDeny',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags casbin
+// @Summary 获取角色的显式拒绝规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.CasbinInReceive true "权限id"
+// @Success 200 {string} json "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /casbin/getCasbinDenyList [post]
+export const getCasbinDenyList = (data) => {
+  return service({
+    url: '/casbin/getCasbinDenyList',
+    method: 'post',
+    data
+  })
+}
@@ -0,0 +1,62 @@
+import service from '@/utils/request'
+
+// @Tags Department
+// @Summary 创建部门
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysDepartment true "上级部门ID, 部门名称, 排序"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /department/createDepartment [post]
+export const createDepartment = (data) => {
+  return service({
+    url: '/department/createDepartment',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Department
+// @Summary 删除部门
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "部门ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /department/deleteDepartment [delete]
+export const deleteDepartment = (data) => {
+  return service({
+    url: '/department/deleteDepartment',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags Department
+// @Summary 更新部门
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysDepartment true "部门ID, 上级部门ID, 部门名称, 排序"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /department/updateDepartment [put]
+export const updateDepartment = (data) => {
+  return service({
+    url: '/department/updateDepartment',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags Department
+// @Summary 获取部门树
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /department/getDepartmentTree [get]
+export const getDepartmentTree = () => {
+  return service({
+    url: '/department/getDepartmentTree',
+    method: 'get'
+  })
+}
@@ -0,0 +1,125 @@
+import service from '@/utils/request'
+
+// @Tags Notification
+// @Summary 发送通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.NotificationCreate true "发送通知"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"发送成功"}"
+// @Router /notification/createNotification [post]
+export const createNotification = (data) => {
+  return service({
+    url: '/notification/createNotification',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Notification
+// @Summary 删除通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "通知ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /notification/deleteNotification [delete]
+export const deleteNotification = (data) => {
+  return service({
+    url: '/notification/deleteNotification',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags Notification
+// @Summary 分页获取已发送的通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.NotificationSearch true "分页获取已发送的通知"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /notification/getNotificationList [get]
+export const getNotificationList = (params) => {
+  return service({
+    url: '/notification/getNotificationList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Notification
+// @Summary 分页获取当前用户的通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.MyNotificationSearch true "分页获取当前用户的通知"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /notification/getMyNotificationList [get]
+export const getMyNotificationList = (params) => {
+  return service({
+    url: '/notification/getMyNotificationList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Notification
+// @Summary 标记通知为已读
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.NotificationIds true "接收记录ID列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"操作成功"}"
+// @Router /notification/markRead [put]
+export const markRead = (data) => {
+  return service({
+    url: '/notification/markRead',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags Notification
+// @Summary 标记全部通知为已读
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"操作成功"}"
+// @Router /notification/markAllRead [put]
+export const markAllRead = () => {
+  return service({
+    url: '/notification/markAllRead',
+    method: 'put'
+  })
+}
+
+// @Tags Notification
+// @Summary 归档通知
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body systemReq.NotificationIds true "接收记录ID列表"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"归档成功"}"
+// @Router /notification/archive [put]
+export const archiveNotification = (data) => {
+  return service({
+    url: '/notification/archive',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags Notification
+// @Summary 获取未读通知数量
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /notification/getUnreadCount [get]
+export const getUnreadCount = () => {
+  return service({
+    url: '/notification/getUnreadCount',
+    method: 'get'
+  })
+}
@@ -0,0 +1,61 @@
+import service from '@/utils/request'
+
+// @Tags PluginRuntime
+// @Summary 获取v2插件的运行状态
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Router /pluginRuntime/getPlugins [get]
+export const getRuntimePlugins = () => {
+  return service({
+    url: '/pluginRuntime/getPlugins',
+    method: 'get'
+  })
+}
+
+// @Tags PluginRuntime
+// @Summary 启用插件
+// @Security ApiKeyAuth
+// @Router /pluginRuntime/enable [post]
+export const enablePlugin = (data) => {
+  return service({
+    url: '/pluginRuntime/enable',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags PluginRuntime
+// @Summary 停用插件
+// @Security ApiKeyAuth
+// @Router /pluginRuntime/disable [post]
+export const disablePlugin = (data) => {
+  return service({
+    url: '/pluginRuntime/disable',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags PluginRuntime
+// @Summary 卸载插件数据
+// @Security ApiKeyAuth
+// @Router /pluginRuntime/uninstall [post]
+export const uninstallRuntimePlugin = (data) => {
+  return service({
+    url: '/pluginRuntime/uninstall',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags PluginRuntime
+// @Summary 插件健康检查
+// @Security ApiKeyAuth
+// @Router /pluginRuntime/health [get]
+export const pluginHealth = (params) => {
+  return service({
+    url: '/pluginRuntime/health',
+    method: 'get',
+    params
+  })
+}
@@ -0,0 +1,79 @@
+import service from '@/utils/request'
+
+// @Tags RateLimit
+// @Summary 创建限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysRateLimitRule true "创建限流规则"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /rateLimit/createRateLimitRule [post]
+export const createRateLimitRule = (data) => {
+  return service({
+    url: '/rateLimit/createRateLimitRule',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags RateLimit
+// @Summary 删除限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "规则ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /rateLimit/deleteRateLimitRule [delete]
+export const deleteRateLimitRule = (data) => {
+  return service({
+    url: '/rateLimit/deleteRateLimitRule',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags RateLimit
+// @Summary 更新限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysRateLimitRule true "更新限流规则"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /rateLimit/updateRateLimitRule [put]
+export const updateRateLimitRule = (data) => {
+  return service({
+    url: '/rateLimit/updateRateLimitRule',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags RateLimit
+// @Summary 分页获取限流规则
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.RateLimitRuleSearch true "分页获取限流规则"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /rateLimit/getRateLimitRuleList [get]
+export const getRateLimitRuleList = (params) => {
+  return service({
+    url: '/rateLimit/getRateLimitRuleList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags RateLimit
+// @Summary 获取当前实例各限流规则的判断及拒绝次数
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /rateLimit/getRateLimitMetrics [get]
+export const getRateLimitMetrics = () => {
+  return service({
+    url: '/rateLimit/getRateLimitMetrics',
+    method: 'get'
+  })
+}
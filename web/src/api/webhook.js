@@ -0,0 +1,142 @@
+import service from '@/utils/request'
+
+// @Tags Webhook
+// @Summary 创建webhook订阅
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysWebhook true "创建webhook订阅"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"创建成功"}"
+// @Router /webhook/createWebhook [post]
+export const createWebhook = (data) => {
+  return service({
+    url: '/webhook/createWebhook',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Webhook
+// @Summary 删除webhook订阅
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "webhook ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"删除成功"}"
+// @Router /webhook/deleteWebhook [delete]
+export const deleteWebhook = (data) => {
+  return service({
+    url: '/webhook/deleteWebhook',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags Webhook
+// @Summary 更新webhook订阅
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body system.SysWebhook true "更新webhook订阅"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"更新成功"}"
+// @Router /webhook/updateWebhook [put]
+export const updateWebhook = (data) => {
+  return service({
+    url: '/webhook/updateWebhook',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags Webhook
+// @Summary 分页获取webhook订阅
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.WebhookSearch true "分页获取webhook订阅"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /webhook/getWebhookList [get]
+export const getWebhookList = (params) => {
+  return service({
+    url: '/webhook/getWebhookList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Webhook
+// @Summary 获取可订阅的事件类型
+// @Security ApiKeyAuth
+// @Produce application/json
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /webhook/getWebhookEventTypes [get]
+export const getWebhookEventTypes = () => {
+  return service({
+    url: '/webhook/getWebhookEventTypes',
+    method: 'get'
+  })
+}
+
+// @Tags Webhook
+// @Summary 发送测试推送
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "webhook ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"推送成功"}"
+// @Router /webhook/pingWebhook [post]
+export const pingWebhook = (data) => {
+  return service({
+    url: '/webhook/pingWebhook',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags Webhook
+// @Summary 分页获取推送记录
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query systemReq.WebhookDeliverySearch true "分页获取推送记录"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /webhook/getWebhookDeliveryList [get]
+export const getWebhookDeliveryList = (params) => {
+  return service({
+    url: '/webhook/getWebhookDeliveryList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Webhook
+// @Summary 获取推送记录详情
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data query request.GetById true "推送记录ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"获取成功"}"
+// @Router /webhook/findWebhookDelivery [get]
+export const findWebhookDelivery = (params) => {
+  return service({
+    url: '/webhook/findWebhookDelivery',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags Webhook
+// @Summary 重新推送
+// @Security ApiKeyAuth
+// @accept application/json
+// @Produce application/json
+// @Param data body request.GetById true "推送记录ID"
+// @Success 200 {string} string "{"success":true,"data":{},"msg":"已加入推送队列"}"
+// @Router /webhook/redeliverWebhookDelivery [post]
+export const redeliverWebhookDelivery = (data) => {
+  return service({
+    url: '/webhook/redeliverWebhookDelivery',
+    method: 'post',
+    data
+  })
+}
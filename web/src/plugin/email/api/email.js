@@ -17,8 +17,8 @@ export const emailTest = (data) => {
 // @Summary 发送邮件
 // @Security ApiKeyAuth
 // @Produce  application/json
-// @Param data body email_response.Email true "发送邮件必须的参数"
-// @Success 200 {string} string "{"success":true,"data":{},"msg":"发送成功"}"
+// @Param data body emailReq.EmailSend true "收件人 标题正文或模板及变量 附件"
+// @Success 200 {object} response.Response{data=email_response.EmailSendResult,msg=string} "已加入发送队列"
 // @Router /email/sendEmail [post]
 export const sendEmail = (data) => {
   return service({
@@ -27,3 +27,115 @@ export const sendEmail = (data) => {
     data
   })
 }
+
+// @Tags System
+// @Summary 分页获取邮件发送记录
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data query emailReq.EmailMessageSearch true "页码, 每页大小, 搜索条件"
+// @Router /email/getEmailMessageList [get]
+export const getEmailMessageList = (params) => {
+  return service({
+    url: '/email/getEmailMessageList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags System
+// @Summary 获取邮件发送记录详情
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data query request.GetById true "发送记录ID"
+// @Router /email/findEmailMessage [get]
+export const findEmailMessage = (params) => {
+  return service({
+    url: '/email/findEmailMessage',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags System
+// @Summary 重新发送死信邮件
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body request.GetById true "发送记录ID"
+// @Router /email/retryEmailMessage [post]
+export const retryEmailMessage = (data) => {
+  return service({
+    url: '/email/retryEmailMessage',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags System
+// @Summary 创建邮件模板
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body model.EmailTemplate true "模板标识, 语言, 标题模板, 正文模板"
+// @Router /email/createEmailTemplate [post]
+export const createEmailTemplate = (data) => {
+  return service({
+    url: '/email/createEmailTemplate',
+    method: 'post',
+    data
+  })
+}
+
+// @Tags System
+// @Summary 更新邮件模板
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body model.EmailTemplate true "模板标识, 语言, 标题模板, 正文模板"
+// @Router /email/updateEmailTemplate [put]
+export const updateEmailTemplate = (data) => {
+  return service({
+    url: '/email/updateEmailTemplate',
+    method: 'put',
+    data
+  })
+}
+
+// @Tags System
+// @Summary 删除邮件模板
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body request.GetById true "模板ID"
+// @Router /email/deleteEmailTemplate [delete]
+export const deleteEmailTemplate = (data) => {
+  return service({
+    url: '/email/deleteEmailTemplate',
+    method: 'delete',
+    data
+  })
+}
+
+// @Tags System
+// @Summary 分页获取邮件模板
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data query emailReq.EmailTemplateSearch true "页码, 每页大小, 搜索条件"
+// @Router /email/getEmailTemplateList [get]
+export const getEmailTemplateList = (params) => {
+  return service({
+    url: '/email/getEmailTemplateList',
+    method: 'get',
+    params
+  })
+}
+
+// @Tags System
+// @Summary 预览邮件模板
+// @Security ApiKeyAuth
+// @Produce  application/json
+// @Param data body emailReq.EmailTemplatePreview true "模板ID或标题正文, 模板变量"
+// @Router /email/previewEmailTemplate [post]
+export const previewEmailTemplate = (data) => {
+  return service({
+    url: '/email/previewEmailTemplate',
+    method: 'post',
+    data
+  })
+}
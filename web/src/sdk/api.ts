@@ -0,0 +1,1621 @@
+// Code generated by gva sdk. DO NOT EDIT.
+import service from '@/utils/request'
+import type { Common, CommonRequest, CommonResponse, Example, ExampleRequest, ExampleResponse, PluginAnnouncementModel, PluginAnnouncementModelRequest, PluginEmailModel, PluginEmailModelRequest, PluginEmailModelResponse, PluginFansClubModelRequest, System, SystemRequest, SystemResponse } from './types'
+
+// ApiResponse 统一返回结构 code 为0时表示成功
+export interface ApiResponse<T = unknown> {
+  code: number
+  data: T
+  msg: string
+}
+
+interface RequestConfig {
+  url: string
+  method: string
+  data?: unknown
+  params?: unknown
+}
+
+// request 响应拦截器返回的是 response.data 即 ApiResponse
+const request = <T>(config: RequestConfig): Promise<ApiResponse<T>> =>
+  service.request<unknown, ApiResponse<T>>(config)
+
+/**
+ * 确认告警
+ * POST /alert/ackAlert
+ */
+export const ackAlert = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/alert/ackAlert', method: 'post', data })
+
+/**
+ * 创建告警渠道
+ * POST /alert/createAlertChannel
+ */
+export const createAlertChannel = (data: Partial<System.SysAlertChannel>) =>
+  request<unknown>({ url: '/alert/createAlertChannel', method: 'post', data })
+
+/**
+ * 删除告警渠道
+ * DELETE /alert/deleteAlertChannel
+ */
+export const deleteAlertChannel = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/alert/deleteAlertChannel', method: 'delete', data })
+
+/**
+ * 分页获取告警渠道
+ * GET /alert/getAlertChannelList
+ */
+export const getAlertChannelList = (params: Partial<SystemRequest.AlertChannelSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/alert/getAlertChannelList', method: 'get', params })
+
+/**
+ * 分页获取告警
+ * GET /alert/getAlertList
+ */
+export const getAlertList = (params: Partial<SystemRequest.AlertSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/alert/getAlertList', method: 'get', params })
+
+/**
+ * 解决告警
+ * POST /alert/resolveAlert
+ */
+export const resolveAlert = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/alert/resolveAlert', method: 'post', data })
+
+/**
+ * 发送测试告警通知
+ * POST /alert/testAlertChannel
+ */
+export const testAlertChannel = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/alert/testAlertChannel', method: 'post', data })
+
+/**
+ * 更新告警渠道
+ * PUT /alert/updateAlertChannel
+ */
+export const updateAlertChannel = (data: Partial<System.SysAlertChannel>) =>
+  request<unknown>({ url: '/alert/updateAlertChannel', method: 'put', data })
+
+/**
+ * 创建api
+ * POST /api/createApi
+ */
+export const createApi = (data: Partial<System.SysApi>) =>
+  request<unknown>({ url: '/api/createApi', method: 'post', data })
+
+/**
+ * 删除Api
+ * POST /api/deleteApi
+ */
+export const deleteApi = (data: Partial<System.SysApi>) =>
+  request<unknown>({ url: '/api/deleteApi', method: 'post', data })
+
+/**
+ * 批量删除api
+ * DELETE /api/deleteApisByIds
+ */
+export const deleteApisByIds = (data: Partial<CommonRequest.IdsReq>) =>
+  request<unknown>({ url: '/api/deleteApisByIds', method: 'delete', data })
+
+/**
+ * 确认同步API
+ * POST /api/enterSyncApi
+ */
+export const enterSyncApi = (data: Partial<SystemResponse.SysSyncApis>) =>
+  request<unknown>({ url: '/api/enterSyncApi', method: 'post', data })
+
+/**
+ * 刷新casbin缓存
+ * GET /api/freshCasbin
+ */
+export const freshCasbin = () =>
+  request<unknown>({ url: '/api/freshCasbin', method: 'get' })
+
+/**
+ * 获取所有api
+ * POST /api/getAllApis
+ */
+export const getAllApis = () =>
+  request<SystemResponse.SysAPIListResponse>({ url: '/api/getAllApis', method: 'post' })
+
+/**
+ * 获取api详细信息
+ * POST /api/getApiById
+ */
+export const getApiById = (data: Partial<CommonRequest.GetById>) =>
+  request<SystemResponse.SysAPIResponse>({ url: '/api/getApiById', method: 'post', data })
+
+/**
+ * 获取路由组
+ * GET /api/getApiGroups
+ */
+export const getApiGroups = () =>
+  request<Record<string, unknown>>({ url: '/api/getApiGroups', method: 'get' })
+
+/**
+ * 获取api列表
+ * POST /api/getApiList
+ */
+export const getApiList = (data: Partial<SystemRequest.SearchApiParams>) =>
+  request<CommonResponse.PageResult>({ url: '/api/getApiList', method: 'post', data })
+
+/**
+ * 忽略API
+ * POST /api/ignoreApi
+ */
+export const ignoreApi = (data: Partial<System.SysIgnoreApi>) =>
+  request<unknown>({ url: '/api/ignoreApi', method: 'post', data })
+
+/**
+ * 获取待同步API
+ * GET /api/syncApi
+ */
+export const syncApi = () =>
+  request<Record<string, unknown>>({ url: '/api/syncApi', method: 'get' })
+
+/**
+ * 更新Api
+ * POST /api/updateApi
+ */
+export const updateApi = (data: Partial<System.SysApi>) =>
+  request<unknown>({ url: '/api/updateApi', method: 'post', data })
+
+/**
+ * 添加/编辑分类
+ * POST /attachmentCategory/addCategory
+ */
+export const addCategory = (data: Partial<Example.ExaAttachmentCategory>) =>
+  request<unknown>({ url: '/attachmentCategory/addCategory', method: 'post', data })
+
+/**
+ * 删除分类
+ * POST /attachmentCategory/deleteCategory
+ */
+export const deleteCategory = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/attachmentCategory/deleteCategory', method: 'post', data })
+
+/**
+ * 分类列表
+ * GET /attachmentCategory/getCategoryList
+ */
+export const getCategoryList = () =>
+  request<Example.ExaAttachmentCategory>({ url: '/attachmentCategory/getCategoryList', method: 'get' })
+
+/**
+ * 拷贝角色
+ * POST /authority/copyAuthority
+ */
+export const copyAuthority = (data: Partial<SystemResponse.SysAuthorityCopyResponse>) =>
+  request<SystemResponse.SysAuthorityResponse>({ url: '/authority/copyAuthority', method: 'post', data })
+
+/**
+ * 创建角色
+ * POST /authority/createAuthority
+ */
+export const createAuthority = (data: Partial<System.SysAuthority>) =>
+  request<SystemResponse.SysAuthorityResponse>({ url: '/authority/createAuthority', method: 'post', data })
+
+/**
+ * 删除角色
+ * POST /authority/deleteAuthority
+ */
+export const deleteAuthority = (data: Partial<System.SysAuthority>) =>
+  request<unknown>({ url: '/authority/deleteAuthority', method: 'post', data })
+
+/**
+ * 获取角色列表
+ * POST /authority/getAuthorityList
+ */
+export const getAuthorityList = (data: Partial<CommonRequest.PageInfo>) =>
+  request<CommonResponse.PageResult>({ url: '/authority/getAuthorityList', method: 'post', data })
+
+/**
+ * 设置角色资源权限
+ * POST /authority/setDataAuthority
+ */
+export const setDataAuthority = (data: Partial<System.SysAuthority>) =>
+  request<unknown>({ url: '/authority/setDataAuthority', method: 'post', data })
+
+/**
+ * 更新角色信息
+ * PUT /authority/updateAuthority
+ */
+export const updateAuthority = (data: Partial<System.SysAuthority>) =>
+  request<SystemResponse.SysAuthorityResponse>({ url: '/authority/updateAuthority', method: 'put', data })
+
+/**
+ * 删除按钮
+ * POST /authorityBtn/canRemoveAuthorityBtn
+ */
+export const canRemoveAuthorityBtn = (params: { id?: string | number; } = {}) =>
+  request<unknown>({ url: '/authorityBtn/canRemoveAuthorityBtn', method: 'post', params })
+
+/**
+ * 获取已有按钮权限
+ * POST /authorityBtn/getAuthorityBtn
+ */
+export const getAuthorityBtn = (data: Partial<SystemRequest.SysAuthorityBtnReq>) =>
+  request<SystemResponse.SysAuthorityBtnRes>({ url: '/authorityBtn/getAuthorityBtn', method: 'post', data })
+
+/**
+ * 设置按钮权限
+ * POST /authorityBtn/setAuthorityBtn
+ */
+export const setAuthorityBtn = (data: Partial<SystemRequest.SysAuthorityBtnReq>) =>
+  request<unknown>({ url: '/authorityBtn/setAuthorityBtn', method: 'post', data })
+
+/**
+ * 增加模板方法
+ * POST /autoCode/addFunc
+ */
+export const addFunc = (data: Partial<SystemRequest.AutoFunc>) =>
+  request<Record<string, string>>({ url: '/autoCode/addFunc', method: 'post', data })
+
+/**
+ * 检查插件
+ * POST /autoCode/checkPlugin
+ */
+export const check = (data: FormData) =>
+  request<SystemResponse.AutoCodePluginCheck>({ url: '/autoCode/checkPlugin', method: 'post', data })
+
+/**
+ * 配置模板
+ * POST /autoCode/createPackage
+ */
+export const autoCodePackageCreate = (data: Partial<SystemRequest.SysAutoCodePackageCreate>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/createPackage', method: 'post', data })
+
+/**
+ * 自动化代码
+ * POST /autoCode/createTemp
+ */
+export const autoCodeTemplateCreate = (data: Partial<SystemRequest.AutoCode>) =>
+  request<unknown>({ url: '/autoCode/createTemp', method: 'post', data })
+
+/**
+ * 删除模板
+ * POST /autoCode/delPackage
+ */
+export const autoCodePackageDelete = (data: Partial<CommonRequest.GetById>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/delPackage', method: 'post', data })
+
+/**
+ * 删除回滚记录
+ * POST /autoCode/delSysHistory
+ */
+export const autoCodeHistoryDelete = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/autoCode/delSysHistory', method: 'post', data })
+
+/**
+ * 删除模板包
+ * POST /autoCode/delTemplatePack
+ */
+export const deleteTemplatePack = (data: Partial<SystemRequest.SysAutoCodeTemplatePack>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/delTemplatePack', method: 'post', data })
+
+/**
+ * 获取所选table的所有字段
+ * GET /autoCode/getColumn
+ */
+export const getColumn = (params: { businessDB?: string | number; dbName?: string | number; tableName?: string | number; } = {}) =>
+  request<Record<string, unknown>>({ url: '/autoCode/getColumn', method: 'get', params })
+
+/**
+ * 获取所有数据库
+ * GET /autoCode/getDB
+ */
+export const getDB = (params: { businessDB?: string | number; } = {}) =>
+  request<Record<string, unknown>>({ url: '/autoCode/getDB', method: 'get', params })
+
+/**
+ * 获取meta信息
+ * POST /autoCode/getMeta
+ */
+export const first = (data: Partial<CommonRequest.GetById>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/getMeta', method: 'post', data })
+
+/**
+ * 获取所有模板
+ * POST /autoCode/getPackage
+ */
+export const all = () =>
+  request<Record<string, unknown>>({ url: '/autoCode/getPackage', method: 'post' })
+
+/**
+ * 获取已安装的插件
+ * GET /autoCode/getPlugins
+ */
+export const plugins = () =>
+  request<System.SysPlugin[]>({ url: '/autoCode/getPlugins', method: 'get' })
+
+/**
+ * 查询回滚记录
+ * POST /autoCode/getSysHistory
+ */
+export const getList = (data: Partial<CommonRequest.PageInfo>) =>
+  request<CommonResponse.PageResult>({ url: '/autoCode/getSysHistory', method: 'post', data })
+
+/**
+ * 获取数据库表
+ * GET /autoCode/getTables
+ */
+export const getTables = (params: { businessDB?: string | number; dbName?: string | number; } = {}) =>
+  request<Record<string, unknown>>({ url: '/autoCode/getTables', method: 'get', params })
+
+/**
+ * 获取模板包
+ * GET /autoCode/getTemplatePacks
+ */
+export const templatePacks = () =>
+  request<SystemResponse.AutoCodeTemplatePack[]>({ url: '/autoCode/getTemplatePacks', method: 'get' })
+
+/**
+ * 获取模板文件
+ * GET /autoCode/getTemplates
+ */
+export const templates = () =>
+  request<Record<string, unknown>>({ url: '/autoCode/getTemplates', method: 'get' })
+
+/**
+ * 打包插件
+ * POST /autoCode/initAPI
+ */
+export const initAPI = (data: Partial<SystemRequest.InitApi>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/initAPI', method: 'post', data })
+
+/**
+ * 打包插件
+ * POST /autoCode/initMenu
+ */
+export const initMenu = (data: Partial<SystemRequest.InitMenu>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/initMenu', method: 'post', data })
+
+/**
+ * 安装插件
+ * POST /autoCode/installPlugin
+ */
+export const install = (data: FormData) =>
+  request<SystemResponse.AutoCodePluginCheck>({ url: '/autoCode/installPlugin', method: 'post', data })
+
+/**
+ * POST /autoCode/llmAuto
+ */
+export const lLMAuto = (data: Partial<Common.JSONMap>) =>
+  request<unknown>({ url: '/autoCode/llmAuto', method: 'post', data })
+
+/**
+ * 自动生成 MCP Tool 模板
+ * POST /autoCode/mcp
+ */
+export const mCP = (data: Partial<SystemRequest.AutoMcpTool>) =>
+  request<unknown>({ url: '/autoCode/mcp', method: 'post', data })
+
+/**
+ * 获取 MCP ToolList
+ * POST /autoCode/mcpList
+ */
+export const mCPList = (data: Partial<SystemRequest.AutoMcpTool>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/mcpList', method: 'post', data })
+
+/**
+ * MCP Tool 测试
+ * POST /autoCode/mcpTest
+ */
+export const mCPTest = (data: {
+  /** 工具名称 */
+  name: string
+  /** 工具参数 */
+  arguments: Record<string, unknown>
+}) =>
+  request<unknown>({ url: '/autoCode/mcpTest', method: 'post', data })
+
+/**
+ * 预览自动化代码
+ * POST /autoCode/preview
+ */
+export const preview = (data: Partial<SystemRequest.AutoCode>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/preview', method: 'post', data })
+
+/**
+ * 打包插件
+ * POST /autoCode/pubPlug
+ */
+export const packaged = (params: { plugName?: string | number; } = {}) =>
+  request<Record<string, unknown>>({ url: '/autoCode/pubPlug', method: 'post', params })
+
+/**
+ * 回滚自动生成代码
+ * POST /autoCode/rollback
+ */
+export const rollBack = (data: Partial<SystemRequest.SysAutoHistoryRollBack>) =>
+  request<unknown>({ url: '/autoCode/rollback', method: 'post', data })
+
+/**
+ * 设置模板包
+ * POST /autoCode/setTemplatePack
+ */
+export const setTemplatePack = (data: Partial<SystemRequest.SysAutoCodePackageTemplatePack>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/setTemplatePack', method: 'post', data })
+
+/**
+ * 卸载插件
+ * POST /autoCode/uninstallPlugin
+ */
+export const uninstall = (data: Partial<SystemRequest.UninstallPlugin>) =>
+  request<Record<string, unknown>>({ url: '/autoCode/uninstallPlugin', method: 'post', data })
+
+/**
+ * 更新已生成的模块
+ * POST /autoCode/update
+ */
+export const update = (data: Partial<SystemRequest.SysAutoHistoryUpdate>) =>
+  request<SystemResponse.AutoCodeUpdate>({ url: '/autoCode/update', method: 'post', data })
+
+/**
+ * 预览模块更新
+ * POST /autoCode/updatePreview
+ */
+export const updatePreview = (data: Partial<SystemRequest.SysAutoHistoryUpdate>) =>
+  request<SystemResponse.AutoCodeUpdate>({ url: '/autoCode/updatePreview', method: 'post', data })
+
+/**
+ * 上传模板包
+ * POST /autoCode/uploadTemplatePack
+ */
+export const uploadTemplatePack = (data: FormData) =>
+  request<Record<string, unknown>>({ url: '/autoCode/uploadTemplatePack', method: 'post', data })
+
+/**
+ * 生成验证码
+ * POST /base/captcha
+ */
+export const captcha = () =>
+  request<SystemResponse.SysCaptchaResponse>({ url: '/base/captcha', method: 'post' })
+
+/**
+ * 用户登录
+ * POST /base/login
+ */
+export const login = (data: Partial<SystemRequest.Login>) =>
+  request<SystemResponse.LoginResponse>({ url: '/base/login', method: 'post', data })
+
+/**
+ * 创建显式拒绝规则
+ * POST /casbin/createCasbinDeny
+ */
+export const createCasbinDeny = (data: Partial<System.SysCasbinDeny>) =>
+  request<unknown>({ url: '/casbin/createCasbinDeny', method: 'post', data })
+
+/**
+ * 删除显式拒绝规则
+ * DELETE /casbin/deleteCasbinDeny
+ */
+export const deleteCasbinDeny = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/casbin/deleteCasbinDeny', method: 'delete', data })
+
+/**
+ * 权限模拟
+ * POST /casbin/explain
+ */
+export const explain = (data: Partial<SystemRequest.CasbinExplain>) =>
+  request<SystemResponse.CasbinExplainResponse>({ url: '/casbin/explain', method: 'post', data })
+
+/**
+ * 获取显式拒绝规则
+ * POST /casbin/getCasbinDenyList
+ */
+export const getCasbinDenyList = (data: Partial<SystemRequest.CasbinInReceive>) =>
+  request<System.SysCasbinDeny[]>({ url: '/casbin/getCasbinDenyList', method: 'post', data })
+
+/**
+ * 获取角色接口权限矩阵
+ * POST /casbin/getCasbinMatrix
+ */
+export const getCasbinMatrix = (data: Partial<SystemRequest.CasbinMatrix>) =>
+  request<SystemResponse.CasbinMatrixResponse>({ url: '/casbin/getCasbinMatrix', method: 'post', data })
+
+/**
+ * 获取权限列表
+ * POST /casbin/getPolicyPathByAuthorityId
+ */
+export const getPolicyPathByAuthorityId = (data: Partial<SystemRequest.CasbinInReceive>) =>
+  request<SystemResponse.PolicyPathResponse>({ url: '/casbin/getPolicyPathByAuthorityId', method: 'post', data })
+
+/**
+ * 更改角色api权限
+ * POST /casbin/updateCasbin
+ */
+export const updateCasbin = (data: Partial<SystemRequest.CasbinInReceive>) =>
+  request<unknown>({ url: '/casbin/updateCasbin', method: 'post', data })
+
+/**
+ * 更新显式拒绝规则
+ * PUT /casbin/updateCasbinDeny
+ */
+export const updateCasbinDeny = (data: Partial<System.SysCasbinDeny>) =>
+  request<unknown>({ url: '/casbin/updateCasbinDeny', method: 'put', data })
+
+/**
+ * 删除客户
+ * DELETE /customer/customer
+ */
+export const deleteExaCustomer = (data: Partial<Example.ExaCustomer>) =>
+  request<unknown>({ url: '/customer/customer', method: 'delete', data })
+
+/**
+ * 获取单一客户
+ * GET /customer/customer
+ */
+export const getExaCustomer = (params: Partial<Example.ExaCustomer>) =>
+  request<ExampleResponse.ExaCustomerResponse>({ url: '/customer/customer', method: 'get', params })
+
+/**
+ * 创建客户
+ * POST /customer/customer
+ */
+export const createExaCustomer = (data: Partial<Example.ExaCustomer>) =>
+  request<unknown>({ url: '/customer/customer', method: 'post', data })
+
+/**
+ * 更新客户
+ * PUT /customer/customer
+ */
+export const updateExaCustomer = (data: Partial<Example.ExaCustomer>) =>
+  request<unknown>({ url: '/customer/customer', method: 'put', data })
+
+/**
+ * 获取客户列表
+ * GET /customer/customerList
+ */
+export const getExaCustomerList = (params: Partial<CommonRequest.PageInfo>) =>
+  request<CommonResponse.PageResult>({ url: '/customer/customerList', method: 'get', params })
+
+/**
+ * 创建部门
+ * POST /department/createDepartment
+ */
+export const createDepartment = (data: Partial<System.SysDepartment>) =>
+  request<unknown>({ url: '/department/createDepartment', method: 'post', data })
+
+/**
+ * 删除部门
+ * DELETE /department/deleteDepartment
+ */
+export const deleteDepartment = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/department/deleteDepartment', method: 'delete', data })
+
+/**
+ * 获取部门树
+ * GET /department/getDepartmentTree
+ */
+export const getDepartmentTree = () =>
+  request<System.SysDepartment[]>({ url: '/department/getDepartmentTree', method: 'get' })
+
+/**
+ * 更新部门
+ * PUT /department/updateDepartment
+ */
+export const updateDepartment = (data: Partial<System.SysDepartment>) =>
+  request<unknown>({ url: '/department/updateDepartment', method: 'put', data })
+
+/**
+ * 创建邮件模板
+ * POST /email/createEmailTemplate
+ */
+export const createEmailTemplate = (data: Partial<PluginEmailModel.EmailTemplate>) =>
+  request<unknown>({ url: '/email/createEmailTemplate', method: 'post', data })
+
+/**
+ * 删除邮件模板
+ * DELETE /email/deleteEmailTemplate
+ */
+export const deleteEmailTemplate = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/email/deleteEmailTemplate', method: 'delete', data })
+
+/**
+ * 发送测试邮件
+ * POST /email/emailTest
+ */
+export const emailTest = () =>
+  request<unknown>({ url: '/email/emailTest', method: 'post' })
+
+/**
+ * 获取邮件发送记录详情
+ * GET /email/findEmailMessage
+ */
+export const findEmailMessage = (params: Partial<CommonRequest.GetById>) =>
+  request<PluginEmailModel.EmailMessage>({ url: '/email/findEmailMessage', method: 'get', params })
+
+/**
+ * 分页获取邮件发送记录
+ * GET /email/getEmailMessageList
+ */
+export const getEmailMessageList = (params: Partial<PluginEmailModelRequest.EmailMessageSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/email/getEmailMessageList', method: 'get', params })
+
+/**
+ * 分页获取邮件模板
+ * GET /email/getEmailTemplateList
+ */
+export const getEmailTemplateList = (params: Partial<PluginEmailModelRequest.EmailTemplateSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/email/getEmailTemplateList', method: 'get', params })
+
+/**
+ * 预览邮件模板
+ * POST /email/previewEmailTemplate
+ */
+export const previewEmailTemplate = (data: Partial<PluginEmailModelRequest.EmailTemplatePreview>) =>
+  request<PluginEmailModelResponse.EmailPreview>({ url: '/email/previewEmailTemplate', method: 'post', data })
+
+/**
+ * 重新发送死信邮件
+ * POST /email/retryEmailMessage
+ */
+export const retryEmailMessage = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/email/retryEmailMessage', method: 'post', data })
+
+/**
+ * 发送邮件
+ * POST /email/sendEmail
+ */
+export const sendEmail = (data: Partial<PluginEmailModelRequest.EmailSend>) =>
+  request<PluginEmailModelResponse.EmailSendResult>({ url: '/email/sendEmail', method: 'post', data })
+
+/**
+ * 更新邮件模板
+ * PUT /email/updateEmailTemplate
+ */
+export const updateEmailTemplate = (data: Partial<PluginEmailModel.EmailTemplate>) =>
+  request<unknown>({ url: '/email/updateEmailTemplate', method: 'put', data })
+
+/**
+ * 创建粉丝团
+ * POST /fansClub/createFansClub
+ */
+export const createFansClub = (data: Partial<PluginFansClubModelRequest.CreateFansClubReq>) =>
+  request<unknown>({ url: '/fansClub/createFansClub', method: 'post', data })
+
+/**
+ * 删除粉丝团
+ * DELETE /fansClub/deleteFansClub
+ */
+export const deleteFansClub = (data: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClub/deleteFansClub', method: 'delete', data })
+
+/**
+ * 获取粉丝团详情
+ * GET /fansClub/getFansClub
+ */
+export const getFansClub = (params: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClub/getFansClub', method: 'get', params })
+
+/**
+ * 获取粉丝团列表
+ * GET /fansClub/getFansClubList
+ */
+export const getFansClubList = (params: Partial<PluginFansClubModelRequest.FansClubSearch>) =>
+  request<unknown>({ url: '/fansClub/getFansClubList', method: 'get', params })
+
+/**
+ * 获取我的粉丝团
+ * GET /fansClub/getMyClubs
+ */
+export const getMyClubs = (params: Partial<PluginFansClubModelRequest.PageInfo>) =>
+  request<unknown>({ url: '/fansClub/getMyClubs', method: 'get', params })
+
+/**
+ * 更新粉丝团
+ * PUT /fansClub/updateFansClub
+ */
+export const updateFansClub = (data: Partial<PluginFansClubModelRequest.UpdateFansClubReq>) =>
+  request<unknown>({ url: '/fansClub/updateFansClub', method: 'put', data })
+
+/**
+ * 获取成员列表
+ * GET /fansClubMember/getMemberList
+ */
+export const getMemberList = (params: Partial<PluginFansClubModelRequest.MemberSearch>) =>
+  request<unknown>({ url: '/fansClubMember/getMemberList', method: 'get', params })
+
+/**
+ * 加入粉丝团
+ * POST /fansClubMember/joinClub
+ */
+export const joinClub = (data: Partial<PluginFansClubModelRequest.JoinClubReq>) =>
+  request<unknown>({ url: '/fansClubMember/joinClub', method: 'post', data })
+
+/**
+ * 退出粉丝团
+ * POST /fansClubMember/quitClub
+ */
+export const quitClub = (data: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClubMember/quitClub', method: 'post', data })
+
+/**
+ * 移除成员
+ * DELETE /fansClubMember/removeMember
+ */
+export const removeMember = (data: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClubMember/removeMember', method: 'delete', data })
+
+/**
+ * 更新成员角色
+ * PUT /fansClubMember/updateMemberRole
+ */
+export const updateMemberRole = (data: Partial<PluginFansClubModelRequest.UpdateMemberRoleReq>) =>
+  request<unknown>({ url: '/fansClubMember/updateMemberRole', method: 'put', data })
+
+/**
+ * 创建动态
+ * POST /fansClubPost/createPost
+ */
+export const createPost = (data: Partial<PluginFansClubModelRequest.CreatePostReq>) =>
+  request<unknown>({ url: '/fansClubPost/createPost', method: 'post', data })
+
+/**
+ * 删除动态
+ * DELETE /fansClubPost/deletePost
+ */
+export const deletePost = (data: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClubPost/deletePost', method: 'delete', data })
+
+/**
+ * 获取动态详情
+ * GET /fansClubPost/getPost
+ */
+export const getPost = (params: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClubPost/getPost', method: 'get', params })
+
+/**
+ * 获取动态列表
+ * GET /fansClubPost/getPostList
+ */
+export const getPostList = (params: Partial<PluginFansClubModelRequest.PostSearch>) =>
+  request<unknown>({ url: '/fansClubPost/getPostList', method: 'get', params })
+
+/**
+ * 点赞动态
+ * POST /fansClubPost/likePost
+ */
+export const likePost = (data: Partial<PluginFansClubModelRequest.GetById>) =>
+  request<unknown>({ url: '/fansClubPost/likePost', method: 'post', data })
+
+/**
+ * 更新动态
+ * PUT /fansClubPost/updatePost
+ */
+export const updatePost = (data: Partial<PluginFansClubModelRequest.UpdatePostReq>) =>
+  request<unknown>({ url: '/fansClubPost/updatePost', method: 'put', data })
+
+/**
+ * 断点续传
+ * POST /fileUploadAndDownload/breakpointContinue
+ */
+export const breakpointContinue = (data: FormData) =>
+  request<unknown>({ url: '/fileUploadAndDownload/breakpointContinue', method: 'post', data })
+
+/**
+ * 断点续传完成
+ * POST /fileUploadAndDownload/breakpointContinueFinish
+ */
+export const breakpointContinueFinish = (data: FormData, params: { fileMd5?: string | number; fileName?: string | number; } = {}) =>
+  request<ExampleResponse.FilePathResponse>({ url: '/fileUploadAndDownload/breakpointContinueFinish', method: 'post', data, params })
+
+/**
+ * 删除文件
+ * POST /fileUploadAndDownload/deleteFile
+ */
+export const deleteFile = (data: Partial<Example.ExaFileUploadAndDownload>) =>
+  request<unknown>({ url: '/fileUploadAndDownload/deleteFile', method: 'post', data })
+
+/**
+ * 文件名或者备注编辑
+ * POST /fileUploadAndDownload/editFileName
+ */
+export const editFileName = (data: Partial<Example.ExaFileUploadAndDownload>) =>
+  request<unknown>({ url: '/fileUploadAndDownload/editFileName', method: 'post', data })
+
+/**
+ * 寻找目标文件（秒传）
+ * GET /fileUploadAndDownload/findFile
+ */
+export const findFile = (data: FormData, params: { chunkTotal?: string | number; fileMd5?: string | number; fileName?: string | number; } = {}) =>
+  request<ExampleResponse.FileResponse>({ url: '/fileUploadAndDownload/findFile', method: 'get', data, params })
+
+/**
+ * 获取上传文件列表
+ * POST /fileUploadAndDownload/getFileList
+ */
+export const getFileList = (data: Partial<ExampleRequest.ExaAttachmentCategorySearch>) =>
+  request<CommonResponse.PageResult>({ url: '/fileUploadAndDownload/getFileList', method: 'post', data })
+
+/**
+ * 导入URL
+ * POST /fileUploadAndDownload/importURL
+ */
+export const importURL = (data: Example.ExaFileUploadAndDownload[]) =>
+  request<unknown>({ url: '/fileUploadAndDownload/importURL', method: 'post', data })
+
+/**
+ * 上传完成移除文件
+ * POST /fileUploadAndDownload/removeChunk
+ */
+export const removeChunk = (data: FormData) =>
+  request<unknown>({ url: '/fileUploadAndDownload/removeChunk', method: 'post', data })
+
+/**
+ * 文件上传（建议选择）
+ * POST /fileUploadAndDownload/upload
+ */
+export const uploadFile = (data: FormData, params: { noSave?: string | number; } = {}) =>
+  request<ExampleResponse.ExaFileResponse>({ url: '/fileUploadAndDownload/upload', method: 'post', data, params })
+
+/**
+ * 新建公告
+ * POST /info/createInfo
+ */
+export const createInfo = (data: Partial<PluginAnnouncementModel.Info>) =>
+  request<unknown>({ url: '/info/createInfo', method: 'post', data })
+
+/**
+ * 删除公告
+ * DELETE /info/deleteInfo
+ */
+export const deleteInfo = (params: { ID?: string | number; } = {}) =>
+  request<unknown>({ url: '/info/deleteInfo', method: 'delete', params })
+
+/**
+ * 批量删除公告
+ * DELETE /info/deleteInfoByIds
+ */
+export const deleteInfoByIds = (params: { IDs?: (string | number)[]; } = {}) =>
+  request<unknown>({ url: '/info/deleteInfoByIds', method: 'delete', params })
+
+/**
+ * 根据ID获取公告
+ * GET /info/findInfo
+ */
+export const findInfo = (params: Partial<PluginAnnouncementModel.Info> & { ID?: string | number; }) =>
+  request<PluginAnnouncementModel.Info>({ url: '/info/findInfo', method: 'get', params })
+
+/**
+ * 获取Info的数据源
+ * GET /info/getInfoDataSource
+ */
+export const getInfoDataSource = () =>
+  request<unknown>({ url: '/info/getInfoDataSource', method: 'get' })
+
+/**
+ * 获取公告列表
+ * GET /info/getInfoList
+ */
+export const getInfoList = (params: Partial<PluginAnnouncementModelRequest.InfoSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/info/getInfoList', method: 'get', params })
+
+/**
+ * 不需要鉴权的公告接口
+ * GET /info/getInfoPublic
+ */
+export const getInfoPublic = (params: Partial<PluginAnnouncementModelRequest.InfoSearch>) =>
+  request<unknown>({ url: '/info/getInfoPublic', method: 'get', params })
+
+/**
+ * 更新公告
+ * PUT /info/updateInfo
+ */
+export const updateInfo = (data: Partial<PluginAnnouncementModel.Info>) =>
+  request<unknown>({ url: '/info/updateInfo', method: 'put', data })
+
+/**
+ * 初始化用户数据库
+ * POST /init/checkdb
+ */
+export const checkDB = () =>
+  request<Record<string, unknown>>({ url: '/init/checkdb', method: 'post' })
+
+/**
+ * 初始化用户数据库
+ * POST /init/initdb
+ */
+export const initDB = (data: Partial<SystemRequest.InitDB>) =>
+  request<string>({ url: '/init/initdb', method: 'post', data })
+
+/**
+ * jwt加入黑名单(退出，必选)
+ * POST /jwt/jsonInBlacklist
+ */
+export const jsonInBlacklist = () =>
+  request<unknown>({ url: '/jwt/jsonInBlacklist', method: 'post' })
+
+/**
+ * 新增菜单
+ * POST /menu/addBaseMenu
+ */
+export const addBaseMenu = (data: Partial<System.SysBaseMenu>) =>
+  request<unknown>({ url: '/menu/addBaseMenu', method: 'post', data })
+
+/**
+ * 增加menu和角色关联关系
+ * POST /menu/addMenuAuthority
+ */
+export const addMenuAuthority = (data: Partial<SystemRequest.AddMenuAuthorityInfo>) =>
+  request<unknown>({ url: '/menu/addMenuAuthority', method: 'post', data })
+
+/**
+ * 删除菜单
+ * POST /menu/deleteBaseMenu
+ */
+export const deleteBaseMenu = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/menu/deleteBaseMenu', method: 'post', data })
+
+/**
+ * 根据id获取菜单
+ * POST /menu/getBaseMenuById
+ */
+export const getBaseMenuById = (data: Partial<CommonRequest.GetById>) =>
+  request<SystemResponse.SysBaseMenuResponse>({ url: '/menu/getBaseMenuById', method: 'post', data })
+
+/**
+ * 获取用户动态路由
+ * POST /menu/getBaseMenuTree
+ */
+export const getBaseMenuTree = (data: Partial<CommonRequest.Empty> = {}) =>
+  request<SystemResponse.SysBaseMenusResponse>({ url: '/menu/getBaseMenuTree', method: 'post', data })
+
+/**
+ * 获取菜单树(必选)
+ * POST /menu/getMenu
+ */
+export const getMenu = (data: Partial<CommonRequest.Empty> = {}) =>
+  request<SystemResponse.SysMenusResponse>({ url: '/menu/getMenu', method: 'post', data })
+
+/**
+ * 获取指定角色menu
+ * POST /menu/getMenuAuthority
+ */
+export const getMenuAuthority = (data: Partial<CommonRequest.GetAuthorityId>) =>
+  request<Record<string, unknown>>({ url: '/menu/getMenuAuthority', method: 'post', data })
+
+/**
+ * 分页获取基础menu列表
+ * POST /menu/getMenuList
+ */
+export const getMenuList = (data: Partial<CommonRequest.PageInfo>) =>
+  request<CommonResponse.PageResult>({ url: '/menu/getMenuList', method: 'post', data })
+
+/**
+ * 更新菜单
+ * POST /menu/updateBaseMenu
+ */
+export const updateBaseMenu = (data: Partial<System.SysBaseMenu>) =>
+  request<unknown>({ url: '/menu/updateBaseMenu', method: 'post', data })
+
+/**
+ * 归档通知(必选)
+ * PUT /notification/archive
+ */
+export const archive = (data: Partial<SystemRequest.NotificationIds>) =>
+  request<unknown>({ url: '/notification/archive', method: 'put', data })
+
+/**
+ * 发送通知
+ * POST /notification/createNotification
+ */
+export const createNotification = (data: Partial<SystemRequest.NotificationCreate>) =>
+  request<System.SysNotification>({ url: '/notification/createNotification', method: 'post', data })
+
+/**
+ * 删除通知
+ * DELETE /notification/deleteNotification
+ */
+export const deleteNotification = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/notification/deleteNotification', method: 'delete', data })
+
+/**
+ * 获取我的通知(必选)
+ * GET /notification/getMyNotificationList
+ */
+export const getMyNotificationList = (params: Partial<SystemRequest.MyNotificationSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/notification/getMyNotificationList', method: 'get', params })
+
+/**
+ * 获取已发送的通知
+ * GET /notification/getNotificationList
+ */
+export const getNotificationList = (params: Partial<SystemRequest.NotificationSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/notification/getNotificationList', method: 'get', params })
+
+/**
+ * 获取未读通知数量(必选)
+ * GET /notification/getUnreadCount
+ */
+export const getUnreadCount = () =>
+  request<SystemResponse.NotificationUnreadCount>({ url: '/notification/getUnreadCount', method: 'get' })
+
+/**
+ * 通知全部标记已读(必选)
+ * PUT /notification/markAllRead
+ */
+export const markAllRead = () =>
+  request<unknown>({ url: '/notification/markAllRead', method: 'put' })
+
+/**
+ * 通知标记已读(必选)
+ * PUT /notification/markRead
+ */
+export const markRead = (data: Partial<SystemRequest.NotificationIds>) =>
+  request<unknown>({ url: '/notification/markRead', method: 'put', data })
+
+/**
+ * 按当前路由表生成 OpenAPI 3.1 文档 包括自动化代码及v2插件注册的路由
+ * GET /openapi.json
+ */
+export const getDocument = () =>
+  request<unknown>({ url: '/openapi.json', method: 'get' })
+
+/**
+ * 停用插件
+ * POST /pluginRuntime/disable
+ */
+export const disablePlugin = (data: Partial<SystemRequest.PluginRuntime>) =>
+  request<unknown>({ url: '/pluginRuntime/disable', method: 'post', data })
+
+/**
+ * 启用插件
+ * POST /pluginRuntime/enable
+ */
+export const enablePlugin = (data: Partial<SystemRequest.PluginRuntime>) =>
+  request<unknown>({ url: '/pluginRuntime/enable', method: 'post', data })
+
+/**
+ * 获取插件运行状态
+ * GET /pluginRuntime/getPlugins
+ */
+export const getPlugins = () =>
+  request<SystemResponse.PluginRuntime[]>({ url: '/pluginRuntime/getPlugins', method: 'get' })
+
+/**
+ * 插件健康检查
+ * GET /pluginRuntime/health
+ */
+export const pluginHealth = (params: Partial<SystemRequest.PluginRuntime>) =>
+  request<unknown>({ url: '/pluginRuntime/health', method: 'get', params })
+
+/**
+ * 卸载插件数据
+ * POST /pluginRuntime/uninstall
+ */
+export const uninstallPlugin = (data: Partial<SystemRequest.PluginRuntime>) =>
+  request<unknown>({ url: '/pluginRuntime/uninstall', method: 'post', data })
+
+/**
+ * 创建限流规则
+ * POST /rateLimit/createRateLimitRule
+ */
+export const createRateLimitRule = (data: Partial<System.SysRateLimitRule>) =>
+  request<unknown>({ url: '/rateLimit/createRateLimitRule', method: 'post', data })
+
+/**
+ * 删除限流规则
+ * DELETE /rateLimit/deleteRateLimitRule
+ */
+export const deleteRateLimitRule = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/rateLimit/deleteRateLimitRule', method: 'delete', data })
+
+/**
+ * 获取限流统计
+ * GET /rateLimit/getRateLimitMetrics
+ */
+export const getRateLimitMetrics = () =>
+  request<SystemResponse.RateLimitMetrics>({ url: '/rateLimit/getRateLimitMetrics', method: 'get' })
+
+/**
+ * 获取限流规则列表
+ * GET /rateLimit/getRateLimitRuleList
+ */
+export const getRateLimitRuleList = (params: Partial<SystemRequest.RateLimitRuleSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/rateLimit/getRateLimitRuleList', method: 'get', params })
+
+/**
+ * 更新限流规则
+ * PUT /rateLimit/updateRateLimitRule
+ */
+export const updateRateLimitRule = (data: Partial<System.SysRateLimitRule>) =>
+  request<unknown>({ url: '/rateLimit/updateRateLimitRule', method: 'put', data })
+
+/**
+ * 新增字典
+ * POST /sysDictionary/createSysDictionary
+ */
+export const createSysDictionary = (data: Partial<System.SysDictionary>) =>
+  request<unknown>({ url: '/sysDictionary/createSysDictionary', method: 'post', data })
+
+/**
+ * 删除字典
+ * DELETE /sysDictionary/deleteSysDictionary
+ */
+export const deleteSysDictionary = (data: Partial<System.SysDictionary>) =>
+  request<unknown>({ url: '/sysDictionary/deleteSysDictionary', method: 'delete', data })
+
+/**
+ * 根据ID获取字典（建议选择）
+ * GET /sysDictionary/findSysDictionary
+ */
+export const findSysDictionary = (params: Partial<System.SysDictionary>) =>
+  request<Record<string, unknown>>({ url: '/sysDictionary/findSysDictionary', method: 'get', params })
+
+/**
+ * 获取字典列表
+ * GET /sysDictionary/getSysDictionaryList
+ */
+export const getSysDictionaryList = () =>
+  request<CommonResponse.PageResult>({ url: '/sysDictionary/getSysDictionaryList', method: 'get' })
+
+/**
+ * 更新字典
+ * PUT /sysDictionary/updateSysDictionary
+ */
+export const updateSysDictionary = (data: Partial<System.SysDictionary>) =>
+  request<unknown>({ url: '/sysDictionary/updateSysDictionary', method: 'put', data })
+
+/**
+ * 新增字典内容
+ * POST /sysDictionaryDetail/createSysDictionaryDetail
+ */
+export const createSysDictionaryDetail = (data: Partial<System.SysDictionaryDetail>) =>
+  request<unknown>({ url: '/sysDictionaryDetail/createSysDictionaryDetail', method: 'post', data })
+
+/**
+ * 删除字典内容
+ * DELETE /sysDictionaryDetail/deleteSysDictionaryDetail
+ */
+export const deleteSysDictionaryDetail = (data: Partial<System.SysDictionaryDetail>) =>
+  request<unknown>({ url: '/sysDictionaryDetail/deleteSysDictionaryDetail', method: 'delete', data })
+
+/**
+ * 根据ID获取字典内容
+ * GET /sysDictionaryDetail/findSysDictionaryDetail
+ */
+export const findSysDictionaryDetail = (params: Partial<System.SysDictionaryDetail>) =>
+  request<Record<string, unknown>>({ url: '/sysDictionaryDetail/findSysDictionaryDetail', method: 'get', params })
+
+/**
+ * 获取字典内容列表
+ * GET /sysDictionaryDetail/getSysDictionaryDetailList
+ */
+export const getSysDictionaryDetailList = (params: Partial<SystemRequest.SysDictionaryDetailSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/sysDictionaryDetail/getSysDictionaryDetailList', method: 'get', params })
+
+/**
+ * 更新字典内容
+ * PUT /sysDictionaryDetail/updateSysDictionaryDetail
+ */
+export const updateSysDictionaryDetail = (data: Partial<System.SysDictionaryDetail>) =>
+  request<unknown>({ url: '/sysDictionaryDetail/updateSysDictionaryDetail', method: 'put', data })
+
+/**
+ * 新增导出模板
+ * POST /sysExportTemplate/createSysExportTemplate
+ */
+export const createSysExportTemplate = (data: Partial<System.SysExportTemplate>) =>
+  request<unknown>({ url: '/sysExportTemplate/createSysExportTemplate', method: 'post', data })
+
+/**
+ * 删除导出模板
+ * DELETE /sysExportTemplate/deleteSysExportTemplate
+ */
+export const deleteSysExportTemplate = (data: Partial<System.SysExportTemplate>) =>
+  request<unknown>({ url: '/sysExportTemplate/deleteSysExportTemplate', method: 'delete', data })
+
+/**
+ * 批量删除导出模板
+ * DELETE /sysExportTemplate/deleteSysExportTemplateByIds
+ */
+export const deleteSysExportTemplateByIds = (data: Partial<CommonRequest.IdsReq>) =>
+  request<unknown>({ url: '/sysExportTemplate/deleteSysExportTemplateByIds', method: 'delete', data })
+
+/**
+ * 导出Excel
+ * GET /sysExportTemplate/exportExcel
+ */
+export const exportExcel = (params: { templateID?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysExportTemplate/exportExcel', method: 'get', params })
+
+/**
+ * 导出表格
+ * GET /sysExportTemplate/exportExcelByToken
+ */
+export const exportExcelByToken = (params: { token?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysExportTemplate/exportExcelByToken', method: 'get', params })
+
+/**
+ * 下载模板
+ * GET /sysExportTemplate/exportTemplate
+ */
+export const exportTemplate = (params: { templateID?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysExportTemplate/exportTemplate', method: 'get', params })
+
+/**
+ * 通过token导出表格模板
+ * GET /sysExportTemplate/exportTemplateByToken
+ */
+export const exportTemplateByToken = (params: { token?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysExportTemplate/exportTemplateByToken', method: 'get', params })
+
+/**
+ * 根据ID获取导出模板
+ * GET /sysExportTemplate/findSysExportTemplate
+ */
+export const findSysExportTemplate = (params: Partial<System.SysExportTemplate>) =>
+  request<Record<string, unknown>>({ url: '/sysExportTemplate/findSysExportTemplate', method: 'get', params })
+
+/**
+ * 获取导出模板列表
+ * GET /sysExportTemplate/getSysExportTemplateList
+ */
+export const getSysExportTemplateList = (params: Partial<SystemRequest.SysExportTemplateSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/sysExportTemplate/getSysExportTemplateList', method: 'get', params })
+
+/**
+ * 导入Excel
+ * POST /sysExportTemplate/importExcel
+ */
+export const importExcel = (data: FormData, params: { templateID?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysExportTemplate/importExcel', method: 'post', data, params })
+
+/**
+ * 更新导出模板
+ * PUT /sysExportTemplate/updateSysExportTemplate
+ */
+export const updateSysExportTemplate = (data: Partial<System.SysExportTemplate>) =>
+  request<unknown>({ url: '/sysExportTemplate/updateSysExportTemplate', method: 'put', data })
+
+/**
+ * 删除操作记录
+ * DELETE /sysOperationRecord/deleteSysOperationRecord
+ */
+export const deleteSysOperationRecord = (data: Partial<System.SysOperationRecord>) =>
+  request<unknown>({ url: '/sysOperationRecord/deleteSysOperationRecord', method: 'delete', data })
+
+/**
+ * 批量删除操作历史
+ * DELETE /sysOperationRecord/deleteSysOperationRecordByIds
+ */
+export const deleteSysOperationRecordByIds = (data: Partial<CommonRequest.IdsReq>) =>
+  request<unknown>({ url: '/sysOperationRecord/deleteSysOperationRecordByIds', method: 'delete', data })
+
+/**
+ * 根据ID获取操作记录
+ * GET /sysOperationRecord/findSysOperationRecord
+ */
+export const findSysOperationRecord = (params: Partial<System.SysOperationRecord>) =>
+  request<Record<string, unknown>>({ url: '/sysOperationRecord/findSysOperationRecord', method: 'get', params })
+
+/**
+ * 获取操作记录列表
+ * GET /sysOperationRecord/getSysOperationRecordList
+ */
+export const getSysOperationRecordList = (params: Partial<SystemRequest.SysOperationRecordSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/sysOperationRecord/getSysOperationRecordList', method: 'get', params })
+
+/**
+ * 新建参数
+ * POST /sysParams/createSysParams
+ */
+export const createSysParams = (data: Partial<System.SysParams>) =>
+  request<unknown>({ url: '/sysParams/createSysParams', method: 'post', data })
+
+/**
+ * 删除参数
+ * DELETE /sysParams/deleteSysParams
+ */
+export const deleteSysParams = (params: { ID?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysParams/deleteSysParams', method: 'delete', params })
+
+/**
+ * 批量删除参数
+ * DELETE /sysParams/deleteSysParamsByIds
+ */
+export const deleteSysParamsByIds = (params: { IDs?: (string | number)[]; } = {}) =>
+  request<unknown>({ url: '/sysParams/deleteSysParamsByIds', method: 'delete', params })
+
+/**
+ * 根据ID获取参数
+ * GET /sysParams/findSysParams
+ */
+export const findSysParams = (params: Partial<System.SysParams> & { ID?: string | number; }) =>
+  request<System.SysParams>({ url: '/sysParams/findSysParams', method: 'get', params })
+
+/**
+ * 获取参数列表
+ * GET /sysParams/getSysParam
+ */
+export const getSysParam = (params: { key?: string | number; } = {}) =>
+  request<System.SysParams>({ url: '/sysParams/getSysParam', method: 'get', params })
+
+/**
+ * 获取参数列表
+ * GET /sysParams/getSysParamsList
+ */
+export const getSysParamsList = (params: Partial<SystemRequest.SysParamsSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/sysParams/getSysParamsList', method: 'get', params })
+
+/**
+ * 更新参数
+ * PUT /sysParams/updateSysParams
+ */
+export const updateSysParams = (data: Partial<System.SysParams>) =>
+  request<unknown>({ url: '/sysParams/updateSysParams', method: 'put', data })
+
+/**
+ * 删除版本
+ * DELETE /sysVersion/deleteSysVersion
+ */
+export const deleteSysVersion = (params: { ID?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysVersion/deleteSysVersion', method: 'delete', params })
+
+/**
+ * 批量删除版本
+ * DELETE /sysVersion/deleteSysVersionByIds
+ */
+export const deleteSysVersionByIds = (params: { IDs?: (string | number)[]; } = {}) =>
+  request<unknown>({ url: '/sysVersion/deleteSysVersionByIds', method: 'delete', params })
+
+/**
+ * 下载版本json
+ * GET /sysVersion/downloadVersionJson
+ */
+export const downloadVersionJson = (params: { ID?: string | number; } = {}) =>
+  request<unknown>({ url: '/sysVersion/downloadVersionJson', method: 'get', params })
+
+/**
+ * 创建版本
+ * POST /sysVersion/exportVersion
+ */
+export const exportVersion = (data: Partial<SystemRequest.ExportVersionRequest>) =>
+  request<unknown>({ url: '/sysVersion/exportVersion', method: 'post', data })
+
+/**
+ * 获取单一版本
+ * GET /sysVersion/findSysVersion
+ */
+export const findSysVersion = (params: { ID?: string | number; } = {}) =>
+  request<System.SysVersion>({ url: '/sysVersion/findSysVersion', method: 'get', params })
+
+/**
+ * 获取版本列表
+ * GET /sysVersion/getSysVersionList
+ */
+export const getSysVersionList = (params: Partial<SystemRequest.SysVersionSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/sysVersion/getSysVersionList', method: 'get', params })
+
+/**
+ * 同步版本
+ * POST /sysVersion/importVersion
+ */
+export const importVersion = (data: Partial<SystemRequest.ImportVersionRequest>) =>
+  request<unknown>({ url: '/sysVersion/importVersion', method: 'post', data })
+
+/**
+ * 获取服务器信息
+ * POST /system/getServerInfo
+ */
+export const getServerInfo = () =>
+  request<Record<string, unknown>>({ url: '/system/getServerInfo', method: 'post' })
+
+/**
+ * 获取配置文件内容
+ * POST /system/getSystemConfig
+ */
+export const getSystemConfig = () =>
+  request<SystemResponse.SysConfigResponse>({ url: '/system/getSystemConfig', method: 'post' })
+
+/**
+ * 重载系统
+ * POST /system/reloadSystem
+ */
+export const reloadSystem = () =>
+  request<unknown>({ url: '/system/reloadSystem', method: 'post' })
+
+/**
+ * 设置配置文件内容
+ * POST /system/setSystemConfig
+ */
+export const setSystemConfig = (data: Partial<System.System>) =>
+  request<string>({ url: '/system/setSystemConfig', method: 'post', data })
+
+/**
+ * 用户注册
+ * POST /user/admin_register
+ */
+export const register = (data: Partial<SystemRequest.Register>) =>
+  request<SystemResponse.SysUserResponse>({ url: '/user/admin_register', method: 'post', data })
+
+/**
+ * 修改密码（建议选择)
+ * POST /user/changePassword
+ */
+export const changePassword = (data: Partial<SystemRequest.ChangePasswordReq>) =>
+  request<unknown>({ url: '/user/changePassword', method: 'post', data })
+
+/**
+ * 删除用户
+ * DELETE /user/deleteUser
+ */
+export const deleteUser = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/user/deleteUser', method: 'delete', data })
+
+/**
+ * 获取自身信息(必选)
+ * GET /user/getUserInfo
+ */
+export const getUserInfo = () =>
+  request<Record<string, unknown>>({ url: '/user/getUserInfo', method: 'get' })
+
+/**
+ * 获取用户列表
+ * POST /user/getUserList
+ */
+export const getUserList = (data: Partial<SystemRequest.GetUserList>) =>
+  request<CommonResponse.PageResult>({ url: '/user/getUserList', method: 'post', data })
+
+/**
+ * 重置用户密码
+ * POST /user/resetPassword
+ */
+export const resetPassword = (data: Partial<SystemRequest.ResetPassword>) =>
+  request<unknown>({ url: '/user/resetPassword', method: 'post', data })
+
+/**
+ * 设置自身信息(必选)
+ * PUT /user/setSelfInfo
+ */
+export const setSelfInfo = (data: Partial<SystemRequest.ChangeUserInfo>) =>
+  request<Record<string, unknown>>({ url: '/user/setSelfInfo', method: 'put', data })
+
+/**
+ * 用户界面配置
+ * PUT /user/setSelfSetting
+ */
+export const setSelfSetting = (data: Partial<Common.JSONMap>) =>
+  request<Record<string, unknown>>({ url: '/user/setSelfSetting', method: 'put', data })
+
+/**
+ * 设置权限组
+ * POST /user/setUserAuthorities
+ */
+export const setUserAuthorities = (data: Partial<SystemRequest.SetUserAuthorities>) =>
+  request<unknown>({ url: '/user/setUserAuthorities', method: 'post', data })
+
+/**
+ * 修改用户角色(必选)
+ * POST /user/setUserAuthority
+ */
+export const setUserAuthority = (data: Partial<SystemRequest.SetUserAuth>) =>
+  request<unknown>({ url: '/user/setUserAuthority', method: 'post', data })
+
+/**
+ * 设置用户信息
+ * PUT /user/setUserInfo
+ */
+export const setUserInfo = (data: Partial<SystemRequest.ChangeUserInfo>) =>
+  request<Record<string, unknown>>({ url: '/user/setUserInfo', method: 'put', data })
+
+/**
+ * 批量创建测试数据
+ * POST /userActionLog/batchCreateTestData
+ */
+export const batchCreateTestData = (params: { count?: string | number; } = {}) =>
+  request<unknown>({ url: '/userActionLog/batchCreateTestData', method: 'post', params })
+
+/**
+ * 创建用户操作日志
+ * POST /userActionLog/createLog
+ */
+export const createLog = (data: Partial<SystemRequest.UserActionLogCreate>) =>
+  request<unknown>({ url: '/userActionLog/createLog', method: 'post', data })
+
+/**
+ * 删除索引（危险操作）
+ * DELETE /userActionLog/deleteIndex
+ */
+export const deleteIndex = () =>
+  request<unknown>({ url: '/userActionLog/deleteIndex', method: 'delete' })
+
+/**
+ * 删除日志
+ * DELETE /userActionLog/deleteLog/:id
+ */
+export const deleteLog = (id: string | number) =>
+  request<unknown>({ url: `/userActionLog/deleteLog/${id}`, method: 'delete' })
+
+/**
+ * 获取单条日志
+ * GET /userActionLog/getLog/:id
+ */
+export const getLog = (id: string | number) =>
+  request<System.UserActionLog>({ url: `/userActionLog/getLog/${id}`, method: 'get' })
+
+/**
+ * 获取日志统计
+ * POST /userActionLog/getStats
+ */
+export const getStats = (data: Partial<SystemRequest.UserActionLogStats>) =>
+  request<SystemResponse.UserActionLogStatsResponse>({ url: '/userActionLog/getStats', method: 'post', data })
+
+/**
+ * 初始化用户操作日志索引
+ * POST /userActionLog/initIndex
+ */
+export const initIndex = () =>
+  request<unknown>({ url: '/userActionLog/initIndex', method: 'post' })
+
+/**
+ * 搜索用户操作日志
+ * POST /userActionLog/searchLogs
+ */
+export const searchLogs = (data: Partial<SystemRequest.UserActionLogSearch>) =>
+  request<SystemResponse.UserActionLogListResponse>({ url: '/userActionLog/searchLogs', method: 'post', data })
+
+/**
+ * 创建webhook订阅
+ * POST /webhook/createWebhook
+ */
+export const createWebhook = (data: Partial<System.SysWebhook>) =>
+  request<System.SysWebhook>({ url: '/webhook/createWebhook', method: 'post', data })
+
+/**
+ * 删除webhook订阅
+ * DELETE /webhook/deleteWebhook
+ */
+export const deleteWebhook = (data: Partial<CommonRequest.GetById>) =>
+  request<unknown>({ url: '/webhook/deleteWebhook', method: 'delete', data })
+
+/**
+ * 获取webhook推送记录详情
+ * GET /webhook/findWebhookDelivery
+ */
+export const findWebhookDelivery = (params: Partial<CommonRequest.GetById>) =>
+  request<System.SysWebhookDelivery>({ url: '/webhook/findWebhookDelivery', method: 'get', params })
+
+/**
+ * 分页获取webhook推送记录
+ * GET /webhook/getWebhookDeliveryList
+ */
+export const getWebhookDeliveryList = (params: Partial<SystemRequest.WebhookDeliverySearch>) =>
+  request<CommonResponse.PageResult>({ url: '/webhook/getWebhookDeliveryList', method: 'get', params })
+
+/**
+ * 获取可订阅的事件类型
+ * GET /webhook/getWebhookEventTypes
+ */
+export const getWebhookEventTypes = () =>
+  request<SystemResponse.WebhookEventType[]>({ url: '/webhook/getWebhookEventTypes', method: 'get' })
+
+/**
+ * 分页获取webhook订阅
+ * GET /webhook/getWebhookList
+ */
+export const getWebhookList = (params: Partial<SystemRequest.WebhookSearch>) =>
+  request<CommonResponse.PageResult>({ url: '/webhook/getWebhookList', method: 'get', params })
+
+/**
+ * 发送webhook测试推送
+ * POST /webhook/pingWebhook
+ */
+export const pingWebhook = (data: Partial<CommonRequest.GetById>) =>
+  request<System.SysWebhookDelivery>({ url: '/webhook/pingWebhook', method: 'post', data })
+
+/**
+ * webhook重新推送
+ * POST /webhook/redeliverWebhookDelivery
+ */
+export const redeliverWebhookDelivery = (data: Partial<CommonRequest.GetById>) =>
+  request<System.SysWebhookDelivery>({ url: '/webhook/redeliverWebhookDelivery', method: 'post', data })
+
+/**
+ * 更新webhook订阅
+ * PUT /webhook/updateWebhook
+ */
+export const updateWebhook = (data: Partial<System.SysWebhook>) =>
+  request<unknown>({ url: '/webhook/updateWebhook', method: 'put', data })
@@ -0,0 +1,2717 @@
+// Code generated by gva sdk. DO NOT EDIT.
+
+export namespace Common {
+  export type JSONMap = Record<string, unknown>
+}
+
+export namespace CommonRequest {
+  export type Empty = Record<string, never>
+
+  /** GetAuthorityId Get role by id structure */
+  export interface GetAuthorityId {
+    /** 角色ID */
+    authorityId: number
+  }
+
+  /** GetById Find by id structure */
+  export interface GetById {
+    /** 主键ID */
+    id: number
+  }
+
+  export interface IdsReq {
+    ids: number[]
+  }
+
+  /** PageInfo Paging common input parameter structure */
+  export interface PageInfo {
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+}
+
+export namespace CommonResponse {
+  export interface PageResult {
+    list: unknown
+    total: number
+    page: number
+    pageSize: number
+  }
+}
+
+export namespace Config {
+  export interface ActionLog {
+    /** 是否自动记录用户操作日志 */
+    enable: boolean
+    /** 存储方式 elasticsearch | gorm | mongo 为空时开启ES则使用ES 否则使用数据库 */
+    store: string
+    /** 是否记录GET请求 */
+    "log-get": boolean
+    /** 异步队列长度 队列满时写入本地暂存 */
+    "queue-size": number
+    /** 单次批量写入条数 */
+    "batch-size": number
+    /** 批量写入间隔 (秒) */
+    "flush-interval": number
+    /** 写入失败时写入本地暂存 补写连续失败时等待时间翻倍 最多翻倍的次数 */
+    "max-retries": number
+    /** 本地暂存目录 存储恢复后自动补写 */
+    "spool-dir": string
+  }
+
+  export interface Alert {
+    /** 是否开启错误告警 */
+    enable: boolean
+    /** 响应状态码大于等于此值时记为错误 默认500 发生panic或 c.Error 记录了错误时同样记为错误 */
+    "min-status": number
+    /** 统计窗口(秒) 默认300 */
+    window: number
+    /** 同一错误在窗口内达到此次数时发送通知 默认1 */
+    threshold: number
+    /** 同一告警两次通知的最小间隔(秒) 默认600 */
+    cooldown: number
+    /** 每小时最多发送的通知数 超出后只记录不通知 默认30 */
+    "max-per-hour": number
+    /** webhook渠道是否允许推送到回环、链路本地及内网地址 默认不允许 */
+    "allow-private": boolean
+  }
+
+  export interface AliyunOSS {
+    endpoint: string
+    "access-key-id": string
+    "access-key-secret": string
+    "bucket-name": string
+    "bucket-url": string
+    "base-path": string
+  }
+
+  export interface Autocode {
+    web: string
+    root: string
+    server: string
+    module: string
+    "ai-path": string
+  }
+
+  export interface AwsS3 {
+    bucket: string
+    region: string
+    endpoint: string
+    "secret-id": string
+    "secret-key": string
+    "base-url": string
+    "path-prefix": string
+    "s3-force-path-style": boolean
+    "disable-ssl": boolean
+  }
+
+  export interface CORS {
+    mode: string
+    whitelist: Config.CORSWhitelist[]
+  }
+
+  export interface CORSWhitelist {
+    "allow-origin": string
+    "allow-methods": string
+    "allow-headers": string
+    "expose-headers": string
+    "allow-credentials": boolean
+  }
+
+  export interface Captcha {
+    /** 验证码长度 */
+    "key-long": number
+    /** 验证码宽度 */
+    "img-width": number
+    /** 验证码高度 */
+    "img-height": number
+    /** 防爆破验证码开启此数，0代表每次登录都需要验证码，其他数字代表错误密码次数，如3代表错误三次后出现验证码 */
+    "open-captcha": number
+    /** 防爆破验证码超时时间，单位：s(秒) */
+    "open-captcha-timeout": number
+  }
+
+  export interface CloudflareR2 {
+    bucket: string
+    "base-url": string
+    path: string
+    "account-id": string
+    "access-key-id": string
+    "secret-access-key": string
+  }
+
+  export interface DiskList {
+    "mount-point": string
+  }
+
+  export interface Elasticsearch {
+    /** Elasticsearch 集群地址 */
+    addresses: string[]
+    /** 用户名 */
+    username: string
+    /** 密码 */
+    password: string
+    /** 默认索引 */
+    index: string
+    /** 最大重试次数 */
+    "max-retries": number
+    /** 请求超时时间 (秒) */
+    timeout: number
+    /** gzip压缩 */
+    compression: string
+    /** 证书文件路径 */
+    "cert-file": string
+    /** 时间索引滚动周期 daily | monthly */
+    "index-rollover": string
+    /** 时间索引主分片数 */
+    "number-of-shards": number
+    /** 时间索引副本数 */
+    "number-of-replicas": number
+    /** 时间索引保留天数 0为不清理 */
+    "retention-days": number
+    /** 过期索引处理方式 delete | snapshot */
+    "retention-action": string
+    /** 快照仓库名称 retention-action为snapshot时必填 */
+    "snapshot-repository": string
+  }
+
+  export interface Email {
+    /** 收件人:多个以英文逗号分隔 例：a@qq.com b@qq.com 正式开发中请把此项目作为参数使用 */
+    to: string
+    /** 发件人 你自己要发邮件的邮箱 */
+    from: string
+    /** 服务器地址 例如 smtp.qq.com 请前往QQ或者你要发邮件的邮箱查看其smtp协议 */
+    host: string
+    /** 密钥 用于登录的密钥 最好不要用邮箱密码 去邮箱smtp申请一个用于登录的密钥 */
+    secret: string
+    /** 昵称 发件人昵称 通常为自己的邮箱 */
+    nickname: string
+    /** 端口 请前往QQ或者你要发邮件的邮箱查看其smtp协议 大多为 465 */
+    port: number
+    /** 是否SSL 是否开启SSL */
+    "is-ssl": boolean
+    /** 是否LoginAuth 是否使用LoginAuth认证方式（适用于IBM、微软邮箱服务器等） */
+    "is-loginauth": boolean
+    /** 发送队列 并发发送数 每个发送者复用一个smtp连接 默认2 */
+    workers: number
+    /** 最大尝试次数 超过后标记为死信 默认5 */
+    "max-attempts": number
+    /** 首次重试间隔(秒) 之后每次翻倍 最长1小时 默认30 */
+    "retry-backoff": number
+    /** 未指定语言时使用的模板语言 默认zh-CN */
+    "default-locale": string
+  }
+
+  export interface Excel {
+    dir: string
+  }
+
+  export interface HuaWeiObs {
+    path: string
+    bucket: string
+    endpoint: string
+    "access-key": string
+    "secret-key": string
+  }
+
+  export interface JWT {
+    /** jwt签名 */
+    "signing-key": string
+    /** 过期时间 */
+    "expires-time": string
+    /** 缓冲时间 */
+    "buffer-time": string
+    /** 签发者 */
+    issuer: string
+  }
+
+  export interface Local {
+    /** 本地文件访问路径 */
+    path: string
+    /** 本地文件存储路径 */
+    "store-path": string
+  }
+
+  export interface MCP {
+    /** MCP名称 */
+    name: string
+    /** MCP版本 */
+    version: string
+    /** SSE路径 */
+    sse_path: string
+    /** 消息路径 */
+    message_path: string
+    /** URL前缀 */
+    url_prefix: string
+  }
+
+  export interface Minio {
+    endpoint: string
+    "access-key-id": string
+    "access-key-secret": string
+    "bucket-name": string
+    "use-ssl": boolean
+    "base-path": string
+    "bucket-url": string
+  }
+
+  export interface Mongo {
+    /** collection name */
+    coll: string
+    /** mongodb options */
+    options: string
+    /** database name */
+    database: string
+    /** 用户名 */
+    username: string
+    /** 密码 */
+    password: string
+    /** 验证数据库 */
+    "auth-source": string
+    /** 最小连接池 */
+    "min-pool-size": number
+    /** 最大连接池 */
+    "max-pool-size": number
+    /** socket超时时间 */
+    "socket-timeout-ms": number
+    /** 连接超时时间 */
+    "connect-timeout-ms": number
+    /** 是否开启zap日志 */
+    "is-zap": boolean
+    /** 主机列表 */
+    hosts: (Config.MongoHost | null)[]
+  }
+
+  export interface MongoHost {
+    /** ip地址 */
+    host: string
+    /** 端口 */
+    port: string
+  }
+
+  export interface Mssql {
+    /** 数据库前缀 */
+    prefix: string
+    /** 数据库端口 */
+    port: string
+    /** 高级配置 */
+    config: string
+    /** 数据库名 */
+    "db-name": string
+    /** 数据库账号 */
+    username: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库地址 */
+    path: string
+    /** 数据库引擎，默认InnoDB */
+    engine: string
+    /** 是否开启Gorm全局日志 */
+    "log-mode": string
+    /** 空闲中的最大连接数 */
+    "max-idle-conns": number
+    /** 打开到数据库的最大连接数 */
+    "max-open-conns": number
+    /** 是否开启全局禁用复数，true表示开启 */
+    singular: boolean
+    /** 是否通过zap写入日志文件 */
+    "log-zap": boolean
+  }
+
+  export interface Mysql {
+    /** 数据库前缀 */
+    prefix: string
+    /** 数据库端口 */
+    port: string
+    /** 高级配置 */
+    config: string
+    /** 数据库名 */
+    "db-name": string
+    /** 数据库账号 */
+    username: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库地址 */
+    path: string
+    /** 数据库引擎，默认InnoDB */
+    engine: string
+    /** 是否开启Gorm全局日志 */
+    "log-mode": string
+    /** 空闲中的最大连接数 */
+    "max-idle-conns": number
+    /** 打开到数据库的最大连接数 */
+    "max-open-conns": number
+    /** 是否开启全局禁用复数，true表示开启 */
+    singular: boolean
+    /** 是否通过zap写入日志文件 */
+    "log-zap": boolean
+  }
+
+  export interface Oracle {
+    /** 数据库前缀 */
+    prefix: string
+    /** 数据库端口 */
+    port: string
+    /** 高级配置 */
+    config: string
+    /** 数据库名 */
+    "db-name": string
+    /** 数据库账号 */
+    username: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库地址 */
+    path: string
+    /** 数据库引擎，默认InnoDB */
+    engine: string
+    /** 是否开启Gorm全局日志 */
+    "log-mode": string
+    /** 空闲中的最大连接数 */
+    "max-idle-conns": number
+    /** 打开到数据库的最大连接数 */
+    "max-open-conns": number
+    /** 是否开启全局禁用复数，true表示开启 */
+    singular: boolean
+    /** 是否通过zap写入日志文件 */
+    "log-zap": boolean
+  }
+
+  export interface Pgsql {
+    /** 数据库前缀 */
+    prefix: string
+    /** 数据库端口 */
+    port: string
+    /** 高级配置 */
+    config: string
+    /** 数据库名 */
+    "db-name": string
+    /** 数据库账号 */
+    username: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库地址 */
+    path: string
+    /** 数据库引擎，默认InnoDB */
+    engine: string
+    /** 是否开启Gorm全局日志 */
+    "log-mode": string
+    /** 空闲中的最大连接数 */
+    "max-idle-conns": number
+    /** 打开到数据库的最大连接数 */
+    "max-open-conns": number
+    /** 是否开启全局禁用复数，true表示开启 */
+    singular: boolean
+    /** 是否通过zap写入日志文件 */
+    "log-zap": boolean
+  }
+
+  /** Plugin 插件安装与打包配置 */
+  export interface Plugin {
+    /** 为true时只允许安装已签名的插件 */
+    "require-signature": boolean
+    /** 打包插件时使用的ed25519私钥(base64) 为空时只生成校验文件不签名 */
+    "sign-key": string
+    /** 受信任的签名公钥 */
+    "trusted-keys": Config.PluginKey[]
+    /** 解压时单个文件的最大体积 单位MB 为0时使用默认值 */
+    "max-file-size": number
+    /** 解压后的最大总体积 单位MB 为0时使用默认值 */
+    "max-total-size": number
+    /** 压缩包最多包含的文件数 为0时使用默认值 */
+    "max-files": number
+  }
+
+  /** PluginKey 受信任的签名公钥 */
+  export interface PluginKey {
+    /** 公钥名 安装时展示为签名者 */
+    name: string
+    /** base64编码的ed25519公钥 */
+    key: string
+  }
+
+  export interface Qiniu {
+    /** 存储区域 */
+    zone: string
+    /** 空间名称 */
+    bucket: string
+    /** CDN加速域名 */
+    "img-path": string
+    /** 秘钥AK */
+    "access-key": string
+    /** 秘钥SK */
+    "secret-key": string
+    /** 是否使用https */
+    "use-https": boolean
+    /** 上传是否使用CDN上传加速 */
+    "use-cdn-domains": boolean
+  }
+
+  export interface RateLimit {
+    /** 是否开启限流 */
+    enable: boolean
+    /** 计数存储 memory | redis 为空时开启redis则使用redis 否则使用内存 */
+    store: string
+    /** 默认IP限流使用的算法 token_bucket | sliding_window */
+    algorithm: string
+  }
+
+  export interface Redis {
+    /** 代表当前实例的名字 */
+    name: string
+    /** 服务器地址:端口 */
+    addr: string
+    /** 密码 */
+    password: string
+    /** 单实例模式下redis的哪个数据库 */
+    db: number
+    /** 是否使用集群模式 */
+    useCluster: boolean
+    /** 集群模式下的节点地址列表 */
+    clusterAddrs: string[]
+  }
+
+  export interface Server {
+    jwt: Config.JWT
+    zap: Config.Zap
+    redis: Config.Redis
+    "redis-list": Config.Redis[]
+    mongo: Config.Mongo
+    email: Config.Email
+    system: Config.System
+    captcha: Config.Captcha
+    /** auto */
+    autocode: Config.Autocode
+    /** 插件 */
+    plugin: Config.Plugin
+    /** gorm */
+    mysql: Config.Mysql
+    mssql: Config.Mssql
+    pgsql: Config.Pgsql
+    oracle: Config.Oracle
+    sqlite: Config.Sqlite
+    "db-list": Config.SpecializedDB[]
+    /** oss */
+    local: Config.Local
+    qiniu: Config.Qiniu
+    "aliyun-oss": Config.AliyunOSS
+    "hua-wei-obs": Config.HuaWeiObs
+    "tencent-cos": Config.TencentCOS
+    "aws-s3": Config.AwsS3
+    "cloudflare-r2": Config.CloudflareR2
+    minio: Config.Minio
+    excel: Config.Excel
+    "disk-list": Config.DiskList[]
+    /** 跨域配置 */
+    cors: Config.CORS
+    /** MCP配置 */
+    mcp: Config.MCP
+    /** Elasticsearch配置 */
+    elasticsearch: Config.Elasticsearch
+    /** WebSocket配置 */
+    websocket: Config.Websocket
+    /** 用户操作日志配置 */
+    "action-log": Config.ActionLog
+    /** 限流配置 */
+    "rate-limit": Config.RateLimit
+    /** 错误告警配置 */
+    alert: Config.Alert
+    /** webhook事件推送配置 */
+    webhook: Config.Webhook
+  }
+
+  export interface SpecializedDB {
+    type: string
+    "alias-name": string
+    /** 数据库前缀 */
+    prefix: string
+    /** 数据库端口 */
+    port: string
+    /** 高级配置 */
+    config: string
+    /** 数据库名 */
+    "db-name": string
+    /** 数据库账号 */
+    username: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库地址 */
+    path: string
+    /** 数据库引擎，默认InnoDB */
+    engine: string
+    /** 是否开启Gorm全局日志 */
+    "log-mode": string
+    /** 空闲中的最大连接数 */
+    "max-idle-conns": number
+    /** 打开到数据库的最大连接数 */
+    "max-open-conns": number
+    /** 是否开启全局禁用复数，true表示开启 */
+    singular: boolean
+    /** 是否通过zap写入日志文件 */
+    "log-zap": boolean
+    disable: boolean
+  }
+
+  export interface Sqlite {
+    /** 数据库前缀 */
+    prefix: string
+    /** 数据库端口 */
+    port: string
+    /** 高级配置 */
+    config: string
+    /** 数据库名 */
+    "db-name": string
+    /** 数据库账号 */
+    username: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库地址 */
+    path: string
+    /** 数据库引擎，默认InnoDB */
+    engine: string
+    /** 是否开启Gorm全局日志 */
+    "log-mode": string
+    /** 空闲中的最大连接数 */
+    "max-idle-conns": number
+    /** 打开到数据库的最大连接数 */
+    "max-open-conns": number
+    /** 是否开启全局禁用复数，true表示开启 */
+    singular: boolean
+    /** 是否通过zap写入日志文件 */
+    "log-zap": boolean
+  }
+
+  export interface System {
+    /** 数据库类型:mysql(默认)|sqlite|sqlserver|postgresql */
+    "db-type": string
+    /** Oss类型 */
+    "oss-type": string
+    "router-prefix": string
+    /** 端口值 */
+    addr: number
+    "iplimit-count": number
+    "iplimit-time": number
+    /** 多点登录拦截 */
+    "use-multipoint": boolean
+    /** 使用redis */
+    "use-redis": boolean
+    /** 使用mongo */
+    "use-mongo": boolean
+    /** 使用elasticsearch */
+    "use-elasticsearch": boolean
+    /** 使用树形角色分配模式 */
+    "use-strict-auth": boolean
+    /** 合并用户全部角色的权限 关闭时只使用当前角色 */
+    "use-all-authorities": boolean
+  }
+
+  export interface TencentCOS {
+    bucket: string
+    region: string
+    "secret-id": string
+    "secret-key": string
+    "base-url": string
+    "path-prefix": string
+  }
+
+  export interface Webhook {
+    /** 是否开启webhook事件推送 */
+    enable: boolean
+    /** 推送协程数 默认2 */
+    workers: number
+    /** 每次推送的最大尝试次数 默认6 */
+    "max-attempts": number
+    /** 首次重试等待时间(秒) 之后每次翻倍 默认10 */
+    "retry-backoff": number
+    /** 单次请求超时时间(秒) 默认10 */
+    timeout: number
+    /** 是否允许推送到回环、链路本地及内网地址 默认不允许 */
+    "allow-private": boolean
+  }
+
+  export interface Websocket {
+    /** 端口值 */
+    addr: number
+    /** 多实例部署时通过redis发布订阅转发消息 */
+    "use-cluster": boolean
+    /** redis key及频道前缀 */
+    "channel-prefix": string
+    /** 实例心跳间隔 (秒) */
+    "heartbeat-interval": number
+    /** 实例心跳超时时间 (秒) 超时后视为实例已下线 */
+    "instance-ttl": number
+  }
+
+  export interface Zap {
+    /** 级别 */
+    level: string
+    /** 日志前缀 */
+    prefix: string
+    /** 输出 */
+    format: string
+    /** 日志文件夹 */
+    director: string
+    /** 编码级 */
+    "encode-level": string
+    /** 栈名 */
+    "stacktrace-key": string
+    /** 显示行 */
+    "show-line": boolean
+    /** 输出控制台 */
+    "log-in-console": boolean
+    /** 日志保留天数 */
+    "retention-day": number
+  }
+}
+
+export namespace Example {
+  export interface ExaAttachmentCategory {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    name: string
+    pid: number
+    children: (Example.ExaAttachmentCategory | null)[]
+  }
+
+  export interface ExaCustomer {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 客户名 */
+    customerName: string
+    /** 客户手机号 */
+    customerPhoneData: string
+    /** 管理ID */
+    sysUserId: number
+    /** 管理角色ID */
+    sysUserAuthorityID: number
+    /** 管理详情 */
+    sysUser: System.SysUser
+  }
+
+  /** file struct, 文件结构体 */
+  export interface ExaFile {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    FileName: string
+    FileMd5: string
+    FilePath: string
+    ExaFileChunk: Example.ExaFileChunk[]
+    ChunkTotal: number
+    IsFinish: boolean
+  }
+
+  /** file chunk struct, 切片结构体 */
+  export interface ExaFileChunk {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    ExaFileID: number
+    FileChunkNumber: number
+    FileChunkPath: string
+  }
+
+  export interface ExaFileUploadAndDownload {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 文件名 */
+    name: string
+    /** 分类id */
+    classId: number
+    /** 文件地址 */
+    url: string
+    /** 文件标签 */
+    tag: string
+    /** 编号 */
+    key: string
+  }
+}
+
+export namespace ExampleRequest {
+  export interface ExaAttachmentCategorySearch {
+    classId: number
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+}
+
+export namespace ExampleResponse {
+  export interface ExaCustomerResponse {
+    customer: Example.ExaCustomer
+  }
+
+  export interface ExaFileResponse {
+    file: Example.ExaFileUploadAndDownload
+  }
+
+  export interface FilePathResponse {
+    filePath: string
+  }
+
+  export interface FileResponse {
+    file: Example.ExaFile
+  }
+}
+
+export namespace PluginAnnouncementModel {
+  /** Info 公告 结构体 */
+  export interface Info {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 标题 */
+    title: string
+    /** 内容 */
+    content: string
+    /** 作者 */
+    userID: number | null
+    /** 附件 */
+    attachments: (Record<string, unknown>)[]
+  }
+}
+
+export namespace PluginAnnouncementModelRequest {
+  export interface InfoSearch {
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+}
+
+export namespace PluginEmailModel {
+  /** EmailAttachment 邮件附件 Content 在json中为base64 */
+  export interface EmailAttachment {
+    filename: string
+    contentType: string
+    content?: string
+  }
+
+  /** EmailMessage 发送队列中的邮件 发送完成后保留作为发送记录 */
+  export interface EmailMessage {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 收件人 */
+    to: string
+    /** 抄送 */
+    cc: string
+    /** 标题 */
+    subject: string
+    /** 正文 */
+    body?: string
+    /** 使用的模板 */
+    template: string
+    /** 模板语言 */
+    locale: string
+    /** 附件 */
+    attachments?: (Record<string, unknown>)[]
+    /** 状态 */
+    status: string
+    /** 已尝试次数 */
+    attempts: number
+    /** 最大尝试次数 */
+    maxAttempts: number
+    /** 下次尝试时间 */
+    nextAttemptAt: string
+    /** 最近一次失败原因 */
+    lastError: string
+    /** 发送成功时间 */
+    sentAt: string | null
+  }
+
+  /** EmailTemplate 邮件模板 同名模板可按语言提供多个版本 标题使用 text/template 正文使用 html/template */
+  export interface EmailTemplate {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 模板标识 */
+    name: string
+    /** 语言 如 zh-CN en */
+    locale: string
+    /** 标题模板 */
+    subject: string
+    /** 正文模板 */
+    body: string
+    /** 说明 */
+    description: string
+  }
+}
+
+export namespace PluginEmailModelRequest {
+  /** EmailMessageSearch 发送记录查询条件 */
+  export interface EmailMessageSearch {
+    to: string
+    status: string
+    template: string
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** EmailSend 发送邮件 指定 Template 时使用模板渲染标题和正文 否则直接使用 Subject 和 Body */
+  export interface EmailSend {
+    /** 收件人 */
+    to: string[]
+    /** 抄送 */
+    cc: string[]
+    /** 标题 */
+    subject: string
+    /** 正文 html */
+    body: string
+    /** 模板标识 */
+    template: string
+    /** 模板语言 为空时使用配置的默认语言 */
+    locale: string
+    /** 模板变量 */
+    data: Record<string, unknown>
+    /** 附件 content 为base64 */
+    attachments: PluginEmailModel.EmailAttachment[]
+  }
+
+  /** EmailTemplatePreview 预览模板 ID 为0时直接使用请求中的标题和正文 */
+  export interface EmailTemplatePreview {
+    ID: number
+    subject: string
+    body: string
+    data: Record<string, unknown>
+  }
+
+  /** EmailTemplateSearch 邮件模板查询条件 */
+  export interface EmailTemplateSearch {
+    name: string
+    locale: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+}
+
+export namespace PluginEmailModelResponse {
+  /** EmailPreview 模板渲染结果 */
+  export interface EmailPreview {
+    subject: string
+    body: string
+  }
+
+  /** EmailSendResult 加入发送队列的邮件 */
+  export interface EmailSendResult {
+    /** 发送记录ID 可用于查询发送结果 */
+    ID: number
+  }
+}
+
+export namespace PluginFansClubModelRequest {
+  /** CreateFansClubReq 创建粉丝团请求 */
+  export interface CreateFansClubReq {
+    name: string
+    description: string
+    avatar: string
+  }
+
+  /** CreatePostReq 创建动态请求 */
+  export interface CreatePostReq {
+    clubId: number
+    content: string
+    images: string[]
+  }
+
+  /** FansClubSearch 查询条件 */
+  export interface FansClubSearch {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    name: string
+    description: string
+    avatar: string
+    ownerId: number
+    memberCount: number
+    level: number
+    status: number
+    page: number
+    pageSize: number
+    keyword: string
+  }
+
+  /** GetById 通过ID获取 */
+  export interface GetById {
+    id: number
+  }
+
+  /** JoinClubReq 加入粉丝团请求 */
+  export interface JoinClubReq {
+    clubId: number
+  }
+
+  /** MemberSearch 成员查询条件 */
+  export interface MemberSearch {
+    clubId: number
+    userId: number
+    role: string
+    page: number
+    pageSize: number
+    keyword: string
+  }
+
+  /** PageInfo 分页参数 */
+  export interface PageInfo {
+    page: number
+    pageSize: number
+    keyword: string
+  }
+
+  /** PostSearch 动态查询条件 */
+  export interface PostSearch {
+    clubId: number
+    userId: number
+    page: number
+    pageSize: number
+    keyword: string
+  }
+
+  /** UpdateFansClubReq 更新粉丝团请求 */
+  export interface UpdateFansClubReq {
+    id: number
+    name: string
+    description: string
+    avatar: string
+    status: number | null
+  }
+
+  /** UpdateMemberRoleReq 更新成员角色 */
+  export interface UpdateMemberRoleReq {
+    id: number
+    role: string
+  }
+
+  /** UpdatePostReq 更新动态请求 */
+  export interface UpdatePostReq {
+    id: number
+    content: string
+    images: string[]
+  }
+}
+
+export namespace System {
+  export interface Condition {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    templateID: string
+    from: string
+    column: string
+    operator: string
+  }
+
+  export interface JoinTemplate {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    templateID: string
+    joins: string
+    table: string
+    on: string
+  }
+
+  export interface Meta {
+    activeName: string
+    /** 是否缓存 */
+    keepAlive: boolean
+    /** 是否是基础路由（开发中） */
+    defaultMenu: boolean
+    /** 菜单名 */
+    title: string
+    /** 菜单图标 */
+    icon: string
+    /** 自动关闭tab */
+    closeTab: boolean
+    /** 路由切换动画 */
+    transitionType: string
+  }
+
+  /** SysAlertChannel 告警通知渠道 */
+  export interface SysAlertChannel {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 渠道名称 */
+    name: string
+    /** 类型 */
+    type: string
+    /** 收件人或webhook地址 */
+    target: string
+    /** webhook消息格式 */
+    format: string
+    /** 是否启用 */
+    enable: boolean
+  }
+
+  export interface SysApi {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** api路径 */
+    path: string
+    /** api中文描述 */
+    description: string
+    /** api组 */
+    apiGroup: string
+    /** 方法:创建POST(默认)|查看GET|更新PUT|删除DELETE */
+    method: string
+  }
+
+  export interface SysAuthority {
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    DeletedAt: string | null
+    /** 角色ID */
+    authorityId: number
+    /** 角色名 */
+    authorityName: string
+    /** 父角色ID */
+    parentId: number | null
+    dataAuthorityId: (System.SysAuthority | null)[]
+    children: System.SysAuthority[]
+    menus: System.SysBaseMenu[]
+    /** 默认菜单(默认dashboard) */
+    defaultRouter: string
+  }
+
+  export interface SysBaseMenu {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 父菜单ID */
+    parentId: number
+    /** 路由path */
+    path: string
+    /** 路由name */
+    name: string
+    /** 是否在列表隐藏 */
+    hidden: boolean
+    /** 对应前端文件路径 */
+    component: string
+    /** 排序标记 */
+    sort: number
+    /** 附加属性 */
+    meta: System.Meta
+    authoritys: System.SysAuthority[]
+    children: System.SysBaseMenu[]
+    parameters: System.SysBaseMenuParameter[]
+    menuBtn: System.SysBaseMenuBtn[]
+  }
+
+  export interface SysBaseMenuBtn {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    name: string
+    desc: string
+    sysBaseMenuID: number
+  }
+
+  export interface SysBaseMenuParameter {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    SysBaseMenuID: number
+    /** 地址栏携带参数为params还是query */
+    type: string
+    /** 地址栏携带参数的key */
+    key: string
+    /** 地址栏携带参数的值 */
+    value: string
+  }
+
+  /** SysCasbinDeny 角色的显式拒绝规则 对应 casbin 中 eft 为 deny 的策略 角色及其子角色均不能访问该接口 与编辑角色接口权限时为未勾选的继承接口自动生成的 deny 策略不同 只能在此处增删 */
+  export interface SysCasbinDeny {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 角色ID */
+    authorityId: number
+    /** 路径 */
+    path: string
+    /** 方法 */
+    method: string
+    /** 备注 */
+    remark: string
+  }
+
+  /** SysDepartment 部门 用户通过 SysUser.DepartmentID 归属部门 用于按部门发送通知等 */
+  export interface SysDepartment {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 上级部门ID */
+    parentId: number
+    /** 部门名称 */
+    name: string
+    /** 排序 */
+    sort: number
+    children: System.SysDepartment[]
+  }
+
+  /** 如果含有time.Time 请自行import time包 */
+  export interface SysDictionary {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 字典名（中） */
+    name: string
+    /** 字典名（英） */
+    type: string
+    /** 状态 */
+    status: boolean | null
+    /** 描述 */
+    desc: string
+    sysDictionaryDetails: System.SysDictionaryDetail[]
+  }
+
+  /** 如果含有time.Time 请自行import time包 */
+  export interface SysDictionaryDetail {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 展示值 */
+    label: string
+    /** 字典值 */
+    value: string
+    /** 扩展值 */
+    extend: string
+    /** 启用状态 */
+    status: boolean | null
+    /** 排序标记 */
+    sort: number
+    /** 关联标记 */
+    sysDictionaryID: number
+  }
+
+  /** 导出模板 结构体 SysExportTemplate */
+  export interface SysExportTemplate {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 数据库名称 */
+    dbName: string
+    /** 模板名称 */
+    name: string
+    /** 表名称 */
+    tableName: string
+    /** 模板标识 */
+    templateID: string
+    /** 模板信息 */
+    templateInfo: string
+    limit: number | null
+    order: string
+    conditions: System.Condition[]
+    joinTemplate: System.JoinTemplate[]
+  }
+
+  export interface SysIgnoreApi {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** api路径 */
+    path: string
+    /** 方法:创建POST(默认)|查看GET|更新PUT|删除DELETE */
+    method: string
+    /** 是否忽略 */
+    flag: boolean
+  }
+
+  export interface SysMenu {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 父菜单ID */
+    parentId: number
+    /** 路由path */
+    path: string
+    /** 路由name */
+    name: string
+    /** 是否在列表隐藏 */
+    hidden: boolean
+    /** 对应前端文件路径 */
+    component: string
+    /** 排序标记 */
+    sort: number
+    /** 附加属性 */
+    meta: System.Meta
+    authoritys: System.SysAuthority[]
+    children: System.SysMenu[]
+    parameters: System.SysBaseMenuParameter[]
+    menuBtn: System.SysBaseMenuBtn[]
+    menuId: number
+    btns: Record<string, number>
+  }
+
+  /** SysNotification 站内通知 */
+  export interface SysNotification {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 通知标题 */
+    title: string
+    /** 通知内容 */
+    content: string
+    /** 通知类型 */
+    type: string
+    /** 通知级别 */
+    level: string
+    /** 跳转地址 */
+    link: string
+    /** 目标类型 */
+    targetType: string
+    /** 目标ID列表 */
+    targetIDs: number[]
+    /** 发送者ID */
+    senderID: number
+    /** 接收人数 */
+    receivers: number
+  }
+
+  /** 如果含有time.Time 请自行import time包 */
+  export interface SysOperationRecord {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 请求ip */
+    ip: string
+    /** 请求方法 */
+    method: string
+    /** 请求路径 */
+    path: string
+    /** 请求状态 */
+    status: number
+    /** 延迟 */
+    latency: string
+    /** 代理 */
+    agent: string
+    /** 错误信息 */
+    error_message: string
+    /** 请求Body */
+    body: string
+    /** 响应Body */
+    resp: string
+    /** 用户id */
+    user_id: number
+    user: System.SysUser
+  }
+
+  /** 参数 结构体 SysParams */
+  export interface SysParams {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 参数名称 */
+    name: string
+    /** 参数键 */
+    key: string
+    /** 参数值 */
+    value: string
+    /** 参数说明 */
+    desc: string
+  }
+
+  /** SysPlugin 已安装的插件 */
+  export interface SysPlugin {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    name: string
+    version: string
+    description: string
+    author: string
+    gva: string
+    manifest: string
+    files: string[]
+    menuIDs: number[]
+    apiIDs: number[]
+  }
+
+  /** SysRateLimitRule 限流规则 按 Sort 从小到大依次判断 任一规则拒绝即返回429 */
+  export interface SysRateLimitRule {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 规则名称 */
+    name: string
+    /** 计数维度 */
+    keyBy: string
+    /** 限定对象 */
+    target: string
+    /** 接口方法 */
+    method: string
+    /** 接口路径 */
+    path: string
+    /** 限流算法 */
+    algorithm: string
+    /** 请求次数 */
+    limit: number
+    /** 时间窗口(秒) */
+    window: number
+    /** 是否启用 */
+    enable: boolean
+    /** 判断顺序 */
+    sort: number
+  }
+
+  export interface SysUser {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 用户UUID */
+    uuid: string
+    /** 用户登录名 */
+    userName: string
+    /** 用户昵称 */
+    nickName: string
+    /** 用户头像 */
+    headerImg: string
+    /** 用户角色ID */
+    authorityId: number
+    /** 用户角色 */
+    authority: System.SysAuthority
+    /** 多用户角色 */
+    authorities: System.SysAuthority[]
+    /** 所属部门ID */
+    departmentId: number
+    /** 用户手机号 */
+    phone: string
+    /** 用户邮箱 */
+    email: string
+    /** 用户是否被冻结 1正常 2冻结 */
+    enable: number
+    /** 配置 */
+    originSetting: Common.JSONMap
+  }
+
+  /** 版本管理 结构体 SysVersion */
+  export interface SysVersion {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 版本名称 */
+    versionName: string | null
+    /** 版本号 */
+    versionCode: string | null
+    /** 版本描述 */
+    description: string | null
+    /** 版本数据 */
+    versionData: string | null
+  }
+
+  /** SysWebhook webhook订阅 订阅的事件发生时向地址推送签名后的事件 */
+  export interface SysWebhook {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 名称 */
+    name: string
+    /** 推送地址 */
+    url: string
+    /** 签名密钥 为空时自动生成 只在创建时返回 */
+    secret?: string
+    /** 脱敏后的签名密钥 用于列表展示 */
+    secretHint?: string
+    /** 订阅的事件类型 */
+    events: string[]
+    /** 是否启用 */
+    enable: boolean
+    /** 描述 */
+    description: string
+  }
+
+  /** SysWebhookDelivery 一次事件推送 推送完成后保留作为推送记录 */
+  export interface SysWebhookDelivery {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** webhook订阅ID */
+    webhookId: number
+    /** 事件ID 重新推送时不变 接收方可据此去重 */
+    eventId: string
+    /** 事件类型 */
+    eventType: string
+    /** 推送内容 */
+    payload?: string
+    /** 状态 */
+    status: string
+    /** 已尝试次数 */
+    attempts: number
+    /** 最大尝试次数 */
+    maxAttempts: number
+    /** 下次尝试时间 */
+    nextAttemptAt: string
+    /** 最近一次响应状态码 */
+    responseStatus: number
+    /** 最近一次响应内容 */
+    responseBody?: string
+    /** 最近一次失败原因 */
+    lastError: string
+    /** 最近一次请求耗时(毫秒) */
+    duration: number
+    /** 推送成功时间 */
+    deliveredAt: string | null
+    /** 重新推送自哪条记录 */
+    redeliveryOf: number
+  }
+
+  /** 配置文件结构体 */
+  export interface System {
+    config: Config.Server
+  }
+
+  /** UserActionLog 用户操作日志（按配置存储在 ES、数据库或 MongoDB 中） */
+  export interface UserActionLog {
+    /** 日志ID */
+    id: string
+    /** 用户ID */
+    user_id: number
+    /** 用户名 */
+    username: string
+    /** 操作动作（login, logout, create, update, delete等） */
+    action: string
+    /** 操作模块（user, role, menu等） */
+    module: string
+    /** 请求方法（GET, POST, PUT, DELETE） */
+    method: string
+    /** 请求路径 */
+    path: string
+    /** IP地址 */
+    ip: string
+    /** 用户代理 */
+    user_agent: string
+    /** 响应状态码 */
+    status: number
+    /** 响应时间（毫秒） */
+    latency: number
+    /** 请求参数 */
+    request?: string
+    /** 响应数据 */
+    response?: string
+    /** 错误信息 */
+    error_msg?: string
+    /** 创建时间 */
+    create_time: string
+  }
+}
+
+export namespace SystemRequest {
+  /** AddMenuAuthorityInfo Add menu authority info structure */
+  export interface AddMenuAuthorityInfo {
+    menus: System.SysBaseMenu[]
+    /** 角色ID */
+    authorityId: number
+  }
+
+  /** AlertChannelSearch 告警渠道列表 */
+  export interface AlertChannelSearch {
+    name: string
+    type: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** AlertSearch 告警列表 */
+  export interface AlertSearch {
+    state: string
+    route: string
+    status: number
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  export interface AutoCode {
+    package: string
+    /** 表名 */
+    tableName: string
+    /** 业务数据库 */
+    businessDB: string
+    /** Struct名称 */
+    structName: string
+    /** 文件名称 */
+    packageName: string
+    /** Struct中文名称 */
+    description: string
+    /** Struct简称 */
+    abbreviation: string
+    /** go文件名称 */
+    humpPackageName: string
+    /** 是否使用gva默认Model */
+    gvaModel: boolean
+    /** 是否自动迁移表结构 */
+    autoMigrate: boolean
+    /** 是否自动创建资源标识 */
+    autoCreateResource: boolean
+    /** 是否自动创建api */
+    autoCreateApiToSql: boolean
+    /** 是否自动创建menu */
+    autoCreateMenuToSql: boolean
+    /** 是否自动创建按钮权限 */
+    autoCreateBtnAuth: boolean
+    /** 是否只生成模板 */
+    onlyTemplate: boolean
+    /** 是否树形结构 */
+    isTree: boolean
+    /** 展示的树json字段 */
+    treeJson: string
+    /** 是否新增 */
+    isAdd: boolean
+    fields: (SystemRequest.AutoCodeField | null)[]
+    /** 子表 与主表一对多 */
+    subTables: (SystemRequest.AutoCodeSubTable | null)[]
+    /** 是否生成web */
+    generateWeb: boolean
+    /** 是否生成server */
+    generateServer: boolean
+    /** 是否生成测试 */
+    generateTest: boolean
+    primaryField: SystemRequest.AutoCodeField | null
+  }
+
+  export interface AutoCodeField {
+    /** Field名 */
+    fieldName: string
+    /** 中文名 */
+    fieldDesc: string
+    /** Field数据类型 */
+    fieldType: string
+    /** FieldJson */
+    fieldJson: string
+    /** 数据库字段长度 */
+    dataTypeLong: string
+    /** 数据库字段描述 */
+    comment: string
+    /** 数据库字段 */
+    columnName: string
+    /** 搜索条件 */
+    fieldSearchType: string
+    /** 是否隐藏查询条件 */
+    fieldSearchHide: boolean
+    /** 字典 */
+    dictType: string
+    /** Front bool `json:"front"` // 是否前端可见 是否前端新建/编辑 */
+    form: boolean
+    /** 是否前端表格列 */
+    table: boolean
+    /** 是否前端详情 */
+    desc: boolean
+    /** 是否导入/导出 */
+    excel: boolean
+    /** 是否必填 */
+    require: boolean
+    /** 是否必填 */
+    defaultValue: string
+    /** 校验失败文字 */
+    errorText: string
+    /** 是否可清空 */
+    clearable: boolean
+    /** 是否增加排序 */
+    sort: boolean
+    /** 是否主键 */
+    primaryKey: boolean
+    /** 数据源 */
+    dataSource: SystemRequest.DataSource | null
+    /** 是否检查数据源 */
+    checkDataSource: boolean
+    /** 索引类型 */
+    fieldIndexType: string
+  }
+
+  /** AutoCodeSubTable 主子表中的子表 子表通过外键关联主表ID 与主表在同一事务中保存 */
+  export interface AutoCodeSubTable {
+    /** 子表Struct名称 */
+    structName: string
+    /** 子表表名 */
+    tableName: string
+    /** 子表中文名称 */
+    description: string
+    /** 主表中子表集合的字段名 默认为子表Struct名称+List */
+    fieldName: string
+    /** 主表中子表集合的json名称 */
+    fieldJson: string
+    /** 外键字段名 默认为主表Struct名称+ID */
+    foreignKey: string
+    /** 外键列名 默认为外键字段名的下划线形式 */
+    foreignKeyColumn: string
+    fields: (SystemRequest.AutoCodeField | null)[]
+  }
+
+  export interface AutoFunc {
+    package: string
+    /** 方法名称 */
+    funcName: string
+    /** 路由名称 */
+    router: string
+    /** 方法介绍 */
+    funcDesc: string
+    /** 业务库 */
+    businessDB: string
+    /** Struct名称 */
+    structName: string
+    /** 文件名称 */
+    packageName: string
+    /** Struct中文名称 */
+    description: string
+    /** Struct简称 */
+    abbreviation: string
+    /** go文件名称 */
+    humpPackageName: string
+    /** 方法 */
+    method: string
+    /** 是否插件 */
+    isPlugin: boolean
+    /** 是否鉴权 */
+    isAuth: boolean
+    /** 是否预览 */
+    isPreview: boolean
+    /** 是否AI */
+    isAi: boolean
+    /** API方法 */
+    apiFunc: string
+    /** 服务方法 */
+    serverFunc: string
+    /** JS方法 */
+    jsFunc: string
+  }
+
+  export interface AutoMcpTool {
+    name: string
+    description: string
+    params: ({
+  name: string
+  description: string
+  /** string, number, boolean, object, array */
+  type: string
+  required: boolean
+  default: string
+})[]
+    response: ({
+  /** text, image */
+  type: string
+})[]
+  }
+
+  /** CasbinExplain 权限模拟 按用户或角色判断能否访问接口 */
+  export interface CasbinExplain {
+    /** 用户id 不为0时判断用户的全部角色 */
+    userId: number
+    /** 角色id 未指定用户时使用 */
+    authorityId: number
+    /** 路径 可带路由前缀 */
+    path: string
+    /** 方法 */
+    method: string
+  }
+
+  /** CasbinInReceive Casbin structure for input parameters */
+  export interface CasbinInReceive {
+    /** 权限id */
+    authorityId: number
+    casbinInfos: SystemRequest.CasbinInfo[]
+  }
+
+  /** CasbinInfo Casbin info structure */
+  export interface CasbinInfo {
+    /** 路径 */
+    path: string
+    /** 方法 */
+    method: string
+  }
+
+  /** CasbinMatrix 角色接口权限矩阵的查询条件 */
+  export interface CasbinMatrix {
+    /** 角色id 为空时为全部角色 */
+    authorityIds: number[]
+    /** api组 */
+    apiGroup: string
+  }
+
+  /** ChangePasswordReq Modify password structure */
+  export interface ChangePasswordReq {
+    /** 密码 */
+    password: string
+    /** 新密码 */
+    newPassword: string
+  }
+
+  export interface ChangeUserInfo {
+    /** 主键ID */
+    ID: number
+    /** 用户昵称 */
+    nickName: string
+    /** 用户手机号 */
+    phone: string
+    /** 角色ID */
+    authorityIds: number[]
+    /** 用户邮箱 */
+    email: string
+    /** 用户头像 */
+    headerImg: string
+    /** 冻结用户 */
+    enable: number
+    /** 所属部门ID */
+    departmentId: number
+  }
+
+  export interface DataSource {
+    dbName: string
+    table: string
+    label: string
+    value: string
+    /** 关联关系 1 一对一 2 一对多 */
+    association: number
+    hasDeletedAt: boolean
+  }
+
+  /** ExportVersionRequest 导出版本请求结构体 */
+  export interface ExportVersionRequest {
+    /** 版本名称 */
+    versionName: string
+    /** 版本号 */
+    versionCode: string
+    /** 版本描述 */
+    description: string
+    /** 选中的菜单ID列表 */
+    menuIds: number[]
+    /** 选中的API ID列表 */
+    apiIds: number[]
+    /** 选中的字典ID列表 */
+    dictIds: number[]
+  }
+
+  export interface GetUserList {
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+    username: string
+    nickName: string
+    phone: string
+    email: string
+  }
+
+  /** ImportVersionRequest 导入版本请求结构体 */
+  export interface ImportVersionRequest {
+    /** 版本信息 */
+    version: SystemRequest.VersionInfo
+    /** 菜单数据，直接复用SysBaseMenu */
+    menus: System.SysBaseMenu[]
+    /** API数据，直接复用SysApi */
+    apis: System.SysApi[]
+    /** 字典数据，直接复用SysDictionary */
+    dictionaries: System.SysDictionary[]
+  }
+
+  export interface InitApi {
+    plugName: string
+    apis: number[]
+  }
+
+  export interface InitDB {
+    adminPassword: string
+    /** 数据库类型 */
+    dbType: string
+    /** 服务器地址 */
+    host: string
+    /** 数据库连接端口 */
+    port: string
+    /** 数据库用户名 */
+    userName: string
+    /** 数据库密码 */
+    password: string
+    /** 数据库名 */
+    dbName: string
+    /** sqlite数据库文件路径 */
+    dbPath: string
+    /** postgresql指定template */
+    template: string
+  }
+
+  export interface InitMenu {
+    plugName: string
+    parentMenu: string
+    menus: number[]
+  }
+
+  /** Login User login structure */
+  export interface Login {
+    /** 用户名 */
+    username: string
+    /** 密码 */
+    password: string
+    /** 验证码 */
+    captcha: string
+    /** 验证码ID */
+    captchaId: string
+  }
+
+  /** MyNotificationSearch 当前用户的通知列表 */
+  export interface MyNotificationSearch {
+    /** unread|read|archived 为空时返回未归档的全部通知 */
+    status: string
+    type: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** NotificationCreate 发送通知 */
+  export interface NotificationCreate {
+    /** 通知标题 */
+    title: string
+    /** 通知内容 */
+    content: string
+    /** 通知类型 */
+    type: string
+    /** 通知级别 info|warning|error */
+    level: string
+    /** 跳转地址 */
+    link: string
+    /** 目标类型 user|authority|department|all */
+    targetType: string
+    /** 目标ID列表 targetType为all时忽略 */
+    targetIDs: number[]
+  }
+
+  /** NotificationIds 通知ID列表 */
+  export interface NotificationIds {
+    ids: number[]
+  }
+
+  /** NotificationSearch 管理端通知列表 */
+  export interface NotificationSearch {
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    title: string
+    type: string
+    targetType: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** PluginRuntime 启停、卸载v2插件 */
+  export interface PluginRuntime {
+    /** 插件名 */
+    name: string
+  }
+
+  /** RateLimitRuleSearch 限流规则列表 */
+  export interface RateLimitRuleSearch {
+    name: string
+    keyBy: string
+    path: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** Register User register structure */
+  export interface Register {
+    userName: string
+    passWord: string
+    nickName: string
+    headerImg: string
+    authorityId: string
+    enable: string
+    authorityIds: string
+    phone: string
+    email: string
+    departmentId: string
+  }
+
+  export interface ResetPassword {
+    ID: number
+    /** 用户登录密码 */
+    password: string
+  }
+
+  /** api分页条件查询及排序结构体 */
+  export interface SearchApiParams {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** api路径 */
+    path: string
+    /** api中文描述 */
+    description: string
+    /** api组 */
+    apiGroup: string
+    /** 方法:创建POST(默认)|查看GET|更新PUT|删除DELETE */
+    method: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+    /** 排序 */
+    orderKey: string
+    /** 排序方式:升序false(默认)|降序true */
+    desc: boolean
+  }
+
+  /** SetUserAuth Modify user's auth structure */
+  export interface SetUserAuth {
+    /** 角色ID */
+    authorityId: number
+  }
+
+  /** SetUserAuthorities Modify user's auth structure */
+  export interface SetUserAuthorities {
+    ID: number
+    /** 角色ID */
+    authorityIds: number[]
+  }
+
+  export interface SysAuthorityBtnReq {
+    menuID: number
+    authorityId: number
+    selected: number[]
+  }
+
+  export interface SysAutoCodePackageCreate {
+    desc: string
+    label: string
+    template: string
+    packageName: string
+    templatePack: string
+  }
+
+  /** SysAutoCodePackageTemplatePack 设置package使用的模板包 为空时使用内置模板 */
+  export interface SysAutoCodePackageTemplatePack {
+    /** 主键ID */
+    id: number
+    templatePack: string
+  }
+
+  /** SysAutoCodeTemplatePack 指定模板包 */
+  export interface SysAutoCodeTemplatePack {
+    name: string
+  }
+
+  export interface SysAutoHistoryRollBack {
+    /** 主键ID */
+    id: number
+    /** 是否删除接口 */
+    deleteApi: boolean
+    /** 是否删除菜单 */
+    deleteMenu: boolean
+    /** 是否删除表 */
+    deleteTable: boolean
+  }
+
+  /** SysAutoHistoryUpdate 按新的结构化信息更新已生成的模块 */
+  export interface SysAutoHistoryUpdate {
+    /** 主键ID */
+    id: number
+    /** 更新后的结构化信息 */
+    autoCode: SystemRequest.AutoCode
+  }
+
+  export interface SysDictionaryDetailSearch {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 展示值 */
+    label: string
+    /** 字典值 */
+    value: string
+    /** 扩展值 */
+    extend: string
+    /** 启用状态 */
+    status: boolean | null
+    /** 排序标记 */
+    sort: number
+    /** 关联标记 */
+    sysDictionaryID: number
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  export interface SysExportTemplateSearch {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 数据库名称 */
+    dbName: string
+    /** 模板名称 */
+    name: string
+    /** 表名称 */
+    tableName: string
+    /** 模板标识 */
+    templateID: string
+    /** 模板信息 */
+    templateInfo: string
+    limit: number | null
+    order: string
+    conditions: System.Condition[]
+    joinTemplate: System.JoinTemplate[]
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  export interface SysOperationRecordSearch {
+    /** 主键ID */
+    ID: number
+    /** 创建时间 */
+    CreatedAt: string
+    /** 更新时间 */
+    UpdatedAt: string
+    /** 请求ip */
+    ip: string
+    /** 请求方法 */
+    method: string
+    /** 请求路径 */
+    path: string
+    /** 请求状态 */
+    status: number
+    /** 延迟 */
+    latency: string
+    /** 代理 */
+    agent: string
+    /** 错误信息 */
+    error_message: string
+    /** 请求Body */
+    body: string
+    /** 响应Body */
+    resp: string
+    /** 用户id */
+    user_id: number
+    user: System.SysUser
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  export interface SysParamsSearch {
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    name: string
+    key: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  export interface SysVersionSearch {
+    createdAtRange: string[]
+    versionName: string | null
+    versionCode: string | null
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** UninstallPlugin 卸载插件 */
+  export interface UninstallPlugin {
+    name: string
+  }
+
+  /** UserActionLogCreate 创建用户操作日志请求 */
+  export interface UserActionLogCreate {
+    user_id: number
+    username: string
+    action: string
+    module: string
+    method: string
+    path: string
+    ip: string
+    user_agent: string
+    status: number
+    latency: number
+    request: string
+    response: string
+    error_msg: string
+  }
+
+  /** UserActionLogSearch 搜索用户操作日志请求 */
+  export interface UserActionLogSearch {
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键词（搜索path和error_msg） */
+    keyword: string
+    /** 用户ID */
+    user_id: number | null
+    /** 用户名（模糊搜索） */
+    username: string
+    /** 操作动作 */
+    action: string
+    /** 操作模块 */
+    module: string
+    /** 请求方法 */
+    method: string
+    /** IP地址 */
+    ip: string
+    /** 响应状态码 */
+    status: number | null
+    /** 开始时间 */
+    start_time: string
+    /** 结束时间 */
+    end_time: string
+    /** 排序字段 */
+    order_field: string
+    /** 排序类型 asc/desc */
+    order_type: string
+  }
+
+  /** UserActionLogStats 用户操作日志统计请求 */
+  export interface UserActionLogStats {
+    /** 开始时间 */
+    start_time: string
+    /** 结束时间 */
+    end_time: string
+    /** 分组字段 action, module, user等 */
+    group_by: string
+  }
+
+  /** VersionInfo 版本信息结构体 */
+  export interface VersionInfo {
+    /** 版本名称 */
+    name: string
+    /** 版本号 */
+    code: string
+    /** 版本描述 */
+    description: string
+    /** 导出时间 */
+    exportTime: string
+  }
+
+  /** WebhookDeliverySearch 推送记录列表 */
+  export interface WebhookDeliverySearch {
+    webhookId: number
+    eventId: string
+    eventType: string
+    status: string
+    startCreatedAt: string | null
+    endCreatedAt: string | null
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+
+  /** WebhookSearch webhook订阅列表 */
+  export interface WebhookSearch {
+    name: string
+    /** 订阅了此事件类型的webhook */
+    event: string
+    /** 页码 */
+    page: number
+    /** 每页大小 */
+    pageSize: number
+    /** 关键字 */
+    keyword: string
+  }
+}
+
+export namespace SystemResponse {
+  /** AutoCodePluginCheck 插件安装前的检查结果 */
+  export interface AutoCodePluginCheck {
+    /** 插件名 */
+    name: string
+    /** 插件版本 缺少描述文件时为空 */
+    version: string
+    /** 插件描述文件 */
+    manifest: UtilsPlugin.Manifest | null
+    /** 是否包含server端插件 */
+    server: boolean
+    /** 是否包含web端插件 */
+    web: boolean
+    /** 不满足的安装条件 存在时不能安装 */
+    errors: string[]
+    /** 不影响安装的提示 */
+    warnings: string[]
+    /** 已存在的文件 相对项目根目录 存在时不能安装 */
+    conflicts: string[]
+    /** 验证通过的签名公钥名 未签名时为空 */
+    signer: string
+    /** 是否已安装 */
+    installed: boolean
+  }
+
+  /** AutoCodeTemplatePack 已注册的模板包 */
+  export interface AutoCodeTemplatePack {
+    /** 模板包名称 */
+    name: string
+    /** 描述 */
+    description: string
+    /** 适用的内置模板 */
+    base: string
+    /** 模板文件 */
+    files: string[]
+    /** 依赖的AST注入 */
+    injections: string[]
+    /** 使用该模板包的package */
+    packages: string[]
+    /** 校验失败的原因 不为空时不可使用 */
+    error: string
+  }
+
+  /** AutoCodeUpdate 更新已生成模块的结果 */
+  export interface AutoCodeUpdate {
+    /** 本次更新的内容 */
+    changes: string[]
+    /** 发生变更的文件 */
+    files: SystemResponse.AutoCodeUpdateFile[]
+  }
+
+  export interface AutoCodeUpdateFile {
+    /** 相对项目根目录的路径 */
+    path: string
+    /** unified diff */
+    diff: string
+    /** 已被手动修改而未能自动合并的内容 需手动处理 */
+    conflicts: string[]
+  }
+
+  /** CasbinDecision 单个角色的鉴权结果 */
+  export interface CasbinDecision {
+    /** 角色id */
+    authorityId: number
+    /** 角色名 */
+    authorityName: string
+    /** 是否允许访问 */
+    allowed: boolean
+    /** 权限来源 allow inherit deny 或空 */
+    effect: string
+    /** 决定结果的策略 [角色, 域, 路径, 方法, 效果] */
+    policy: string[]
+    /** 参与判断的角色 包括继承的父角色 */
+    roles: string[]
+    /** 说明 */
+    reason: string
+  }
+
+  /** CasbinExplainResponse 权限模拟结果 */
+  export interface CasbinExplainResponse {
+    /** 鉴权使用的路径 已去掉路由前缀 */
+    path: string
+    /** 方法 */
+    method: string
+    /** 匹配的api 未登记时为空 */
+    api: System.SysApi | null
+    /** 用户当前角色 */
+    authorityId: number
+    /** 是否允许访问 多角色模式下任一角色允许即可 */
+    allowed: boolean
+    /** 每个角色的鉴权结果 */
+    decisions: SystemResponse.CasbinDecision[]
+  }
+
+  /** CasbinMatrixAuthority 权限矩阵中的角色 */
+  export interface CasbinMatrixAuthority {
+    /** 角色id */
+    authorityId: number
+    /** 角色名 */
+    authorityName: string
+    /** 父角色id */
+    parentId: number
+  }
+
+  /** CasbinMatrixResponse 角色接口权限矩阵 Cells[i][j] 为第 i 个接口对第 j 个角色的权限来源 */
+  export interface CasbinMatrixResponse {
+    authorities: SystemResponse.CasbinMatrixAuthority[]
+    apis: System.SysApi[]
+    cells: string[][]
+  }
+
+  export interface LoginResponse {
+    user: System.SysUser
+    token: string
+    expiresAt: number
+  }
+
+  /** NotificationUnreadCount 未读通知数量 */
+  export interface NotificationUnreadCount {
+    count: number
+  }
+
+  /** PluginConfigField 插件配置项及是否已配置 */
+  export interface PluginConfigField {
+    /** 配置项 如 email.host */
+    key: string
+    /** 类型 string、number、bool、array、object */
+    type: string
+    /** 描述 */
+    description: string
+    /** 是否必填 */
+    required: boolean
+    /** 默认值 */
+    default: unknown
+    /** 配置文件中是否存在该项 */
+    configured: boolean
+  }
+
+  /** PluginRuntime v2插件的运行时状态 */
+  export interface PluginRuntime {
+    /** 插件名 */
+    name: string
+    /** 是否实现了生命周期接口 未实现时只能启停路由 */
+    lifecycle: boolean
+    /** 是否启用 */
+    enabled: boolean
+    /** 是否已执行安装 */
+    installed: boolean
+    /** 健康检查是否通过 停用的插件不检查 */
+    healthy: boolean
+    /** 健康检查失败的原因 */
+    health: string
+    /** 最近一次启用失败的原因 */
+    message: string
+    /** 插件注册的路由 */
+    routes: string[]
+    /** 插件声明的菜单 */
+    menus: string[]
+    /** 插件的配置项 */
+    config: SystemResponse.PluginConfigField[]
+  }
+
+  export interface PolicyPathResponse {
+    paths: SystemRequest.CasbinInfo[]
+  }
+
+  /** RateLimitMetric 单条限流规则的统计 */
+  export interface RateLimitMetric {
+    /** 规则ID 0为默认IP限流 */
+    ruleId: number
+    /** 规则名称 */
+    name: string
+    /** 判断次数 */
+    checked: number
+    /** 拒绝次数 */
+    rejected: number
+    /** 最近一次拒绝的时间 */
+    lastRejectedAt: string | null
+  }
+
+  /** RateLimitMetrics 限流统计 为当前实例自启动以来的数据 */
+  export interface RateLimitMetrics {
+    /** 是否开启限流 */
+    enable: boolean
+    /** 拒绝总数 */
+    rejected: number
+    /** 各规则的统计 */
+    rules: SystemResponse.RateLimitMetric[]
+  }
+
+  export interface SysAPIListResponse {
+    apis: System.SysApi[]
+  }
+
+  export interface SysAPIResponse {
+    api: System.SysApi
+  }
+
+  export interface SysAuthorityBtnRes {
+    selected: number[]
+  }
+
+  export interface SysAuthorityCopyResponse {
+    authority: System.SysAuthority
+    /** 旧角色ID */
+    oldAuthorityId: number
+  }
+
+  export interface SysAuthorityResponse {
+    authority: System.SysAuthority
+  }
+
+  export interface SysBaseMenuResponse {
+    menu: System.SysBaseMenu
+  }
+
+  export interface SysBaseMenusResponse {
+    menus: System.SysBaseMenu[]
+  }
+
+  export interface SysCaptchaResponse {
+    captchaId: string
+    picPath: string
+    captchaLength: number
+    openCaptcha: boolean
+  }
+
+  export interface SysConfigResponse {
+    config: Config.Server
+  }
+
+  export interface SysMenusResponse {
+    menus: System.SysMenu[]
+  }
+
+  export interface SysSyncApis {
+    newApis: System.SysApi[]
+    deleteApis: System.SysApi[]
+  }
+
+  export interface SysUserResponse {
+    user: System.SysUser
+  }
+
+  /** UserActionLogListResponse 用户操作日志列表响应 */
+  export interface UserActionLogListResponse {
+    list: System.UserActionLog[]
+    total: number
+    page: number
+    pageSize: number
+  }
+
+  /** UserActionLogStatsResponse 用户操作日志统计响应 */
+  export interface UserActionLogStatsResponse {
+    /** 总数 */
+    total: number
+    /** 统计数据 */
+    stats: (Record<string, unknown>)[]
+  }
+
+  /** WebhookEventType 可订阅的事件类型 */
+  export interface WebhookEventType {
+    /** 事件类型 */
+    type: string
+    /** 说明 */
+    description: string
+  }
+}
+
+export namespace UtilsPlugin {
+  /** Manifest 插件描述文件 */
+  export interface Manifest {
+    /** 插件名 与 server/plugin 下的目录名一致 */
+    name: string
+    /** 插件版本 语义化版本 */
+    version: string
+    /** 描述 */
+    description: string
+    /** 作者 */
+    author: string
+    /** 兼容的gin-vue-admin版本范围 如 ">=v2.8.0 <v3.0.0" 为空时不限制 */
+    gva: string
+    /** 依赖的Go模块 */
+    dependencies: UtilsPlugin.ModuleDependency[]
+    /** 依赖的其他插件及版本范围 */
+    plugins: Record<string, string>
+    /** 必需的配置项 如 email.host */
+    config: string[]
+    /** 安装时创建的菜单 */
+    menus: UtilsPlugin.ManifestMenu[]
+    /** 安装时创建的api */
+    apis: UtilsPlugin.ManifestApi[]
+  }
+
+  /** ManifestApi 安装时创建的api */
+  export interface ManifestApi {
+    /** api路径 */
+    path: string
+    /** 请求方法 */
+    method: string
+    /** api组 */
+    group: string
+    /** api描述 */
+    description: string
+  }
+
+  /** ManifestMenu 安装时创建的菜单 */
+  export interface ManifestMenu {
+    /** 父菜单的name 可以是已有菜单或描述文件中在前面声明的菜单 为空时为顶级菜单 */
+    parent: string
+    /** 路由name */
+    name: string
+    /** 路由path */
+    path: string
+    /** 前端文件路径 */
+    component: string
+    /** 菜单名 */
+    title: string
+    /** 菜单图标 */
+    icon: string
+    /** 排序 */
+    sort: number
+    /** 是否在列表隐藏 */
+    hidden: boolean
+  }
+
+  /** ModuleDependency 插件依赖的Go模块 */
+  export interface ModuleDependency {
+    /** 模块路径 */
+    module: string
+    /** 最低版本 */
+    version: string
+  }
+}
@@ -183,6 +183,19 @@ service.interceptors.response.use(
       return Promise.reject(error)
     }
 
+    // 触发限流 提示稍后重试 不弹出错误页
+    if (error.response.status === 429) {
+      const retryAfter = error.response.headers['retry-after']
+      ElMessage({
+        showClose: true,
+        message:
+          error.response.data?.msg ||
+          `请求过于频繁, 请${retryAfter || '稍'}秒后再试`,
+        type: 'warning'
+      })
+      return Promise.reject(error)
+    }
+
     emitter.emit('show-error', {
       code: error.response.status,
       message: getErrorMessage(error)